@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Program configschemagen generates the descriptions of the configuration settings
+// used by the configschema package from the doc comments of the struct fields of
+// the collector module, so that they are available without its source code.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// modulePath is the path of the collector module.
+const modulePath = "go.opentelemetry.io/collector"
+
+func main() {
+	flag.Parse()
+	if err := run(flag.Arg(0), flag.Arg(1)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(moduleDir, outputFile string) error {
+	if moduleDir == "" || outputFile == "" {
+		return errors.New("usage: configschemagen <module dir> <output file>")
+	}
+	descriptions, err := readModule(moduleDir)
+	if err != nil {
+		return err
+	}
+	src, err := generate(descriptions)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(outputFile, src, 0600); err != nil {
+		return fmt.Errorf("failed writing %q: %v", outputFile, err)
+	}
+	return nil
+}
+
+// readModule returns the doc comments of the configuration struct fields of all the
+// packages of the module, keyed by "<package path>.<type>.<field>".
+func readModule(moduleDir string) (map[string]string, error) {
+	descriptions := make(map[string]string)
+	err := filepath.Walk(moduleDir, func(dir string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		if dir != moduleDir && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+		if dir != moduleDir {
+			if _, err = os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+				// Nested modules are not part of the collector module.
+				return filepath.SkipDir
+			}
+		}
+		rel, err := filepath.Rel(moduleDir, dir)
+		if err != nil {
+			return err
+		}
+		return readPackage(dir, path.Join(modulePath, filepath.ToSlash(rel)), descriptions)
+	})
+	return descriptions, err
+}
+
+func readPackage(dir, pkgPath string, descriptions map[string]string) error {
+	notTest := func(info os.FileInfo) bool { return !strings.HasSuffix(info.Name(), "_test.go") }
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, notTest, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	for _, astPkg := range pkgs {
+		for _, file := range astPkg.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				spec, ok := n.(*ast.TypeSpec)
+				if !ok {
+					return true
+				}
+				st, ok := spec.Type.(*ast.StructType)
+				if !ok {
+					return true
+				}
+				for _, field := range st.Fields.List {
+					if !isSetting(field) {
+						continue
+					}
+					doc := field.Doc
+					if doc == nil {
+						doc = field.Comment
+					}
+					text := strings.Join(strings.Fields(doc.Text()), " ")
+					if text == "" {
+						continue
+					}
+					for _, name := range fieldNames(field) {
+						descriptions[pkgPath+"."+spec.Name.Name+"."+name] = text
+					}
+				}
+				return true
+			})
+		}
+	}
+	return nil
+}
+
+// isSetting returns true if the field is decoded from a configuration setting: it has
+// a mapstructure tag, and is neither ignored nor squashed.
+func isSetting(field *ast.Field) bool {
+	if field.Tag == nil {
+		return false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return false
+	}
+	value, ok := reflect.StructTag(tag).Lookup("mapstructure")
+	if !ok || value == "-" {
+		return false
+	}
+	for _, option := range strings.Split(value, ",")[1:] {
+		if option == "squash" {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldNames returns the names of the field, embedded fields are named after their type.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) != 0 {
+		names := make([]string, len(field.Names))
+		for i, name := range field.Names {
+			names[i] = name.Name
+		}
+		return names
+	}
+
+	t := field.Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	switch id := t.(type) {
+	case *ast.Ident:
+		return []string{id.Name}
+	case *ast.SelectorExpr:
+		return []string{id.Sel.Name}
+	}
+	return nil
+}
+
+// generate returns the source of the Go file declaring the descriptions.
+func generate(descriptions map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(descriptions))
+	for key := range descriptions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("// Code generated by configschemagen. DO NOT EDIT.\n\n")
+	buf.WriteString("package configschema\n\n")
+	buf.WriteString("// fieldDescriptions are the doc comments of the configuration struct fields of the\n")
+	buf.WriteString("// collector module, keyed by \"<package path>.<type>.<field>\".\n")
+	buf.WriteString("var fieldDescriptions = map[string]string{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", key, descriptions[key])
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+const header = `// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+`
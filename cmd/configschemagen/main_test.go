@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPackage(t *testing.T) {
+	descriptions := make(map[string]string)
+	require.NoError(t, readPackage(filepath.Join("testdata", "example"), "example", descriptions))
+	assert.Equal(t, map[string]string{
+		"example.Settings.Endpoint": "Endpoint to listen on.",
+		"example.Config.Enabled":    "Enabled turns the feature on.",
+		"example.Config.Min":        "Min and Max are the limits of the values.",
+		"example.Config.Max":        "Min and Max are the limits of the values.",
+	}, descriptions)
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := generate(map[string]string{"b.T.F": `"quoted"`, "a.T.F": "A field."})
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "// Code generated by configschemagen. DO NOT EDIT.")
+	assert.Regexp(t, `(?s)"a\.T\.F": +"A field\.",\n\t"b\.T\.F": +"\\"quoted\\"",`, string(src))
+}
+
+func TestDescriptionsUpToDate(t *testing.T) {
+	moduleDir := filepath.Join("..", "..")
+	descriptions, err := readModule(moduleDir)
+	require.NoError(t, err)
+	src, err := generate(descriptions)
+	require.NoError(t, err)
+
+	current, err := ioutil.ReadFile(filepath.Join(moduleDir, "config", "configschema", "descriptions.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(src), string(current), "run go generate ./config/configschema")
+}
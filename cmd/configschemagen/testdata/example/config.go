@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package example
+
+type Settings struct {
+	// Endpoint to listen on.
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+type Config struct {
+	Settings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	Enabled bool `mapstructure:"enabled"` // Enabled turns the feature on.
+	// Min and Max are the
+	// limits of the values.
+	Min, Max int `mapstructure:"min"`
+	// Ignored is not decoded.
+	Ignored string `mapstructure:"-"`
+	// NoTag is not a setting.
+	NoTag        string
+	Undocumented string `mapstructure:"undocumented"`
+}
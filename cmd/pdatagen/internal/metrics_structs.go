@@ -17,6 +17,7 @@ package internal
 var metricsFile = &File{
 	Name: "metrics",
 	imports: []string{
+		`otlpcommon "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/common/v1"`,
 		`otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/metrics/v1"`,
 	},
 	testImports: []string{
@@ -39,6 +40,7 @@ var metricsFile = &File{
 		doubleSum,
 		intHistogram,
 		doubleHistogram,
+		exponentialHistogram,
 		doubleSummary,
 		intDataPointSlice,
 		intDataPoint,
@@ -48,6 +50,9 @@ var metricsFile = &File{
 		intHistogramDataPoint,
 		doubleHistogramDataPointSlice,
 		doubleHistogramDataPoint,
+		exponentialHistogramDataPointSlice,
+		exponentialHistogramDataPoint,
+		exponentialHistogramBuckets,
 		doubleSummaryDataPointSlice,
 		doubleSummaryDataPoint,
 		quantileValuesSlice,
@@ -211,6 +216,21 @@ var doubleHistogram = &messagePtrStruct{
 	},
 }
 
+var exponentialHistogram = &messagePtrStruct{
+	structName: "ExponentialHistogram",
+	description: "// ExponentialHistogram represents the type of a metric that is calculated by aggregating as an\n" +
+		"// ExponentialHistogram of all reported double measurements over a time interval.",
+	originFullName: "otlpmetrics.ExponentialHistogram",
+	fields: []baseField{
+		aggregationTemporalityField,
+		&sliceField{
+			fieldName:       "DataPoints",
+			originFieldName: "DataPoints",
+			returnSlice:     exponentialHistogramDataPointSlice,
+		},
+	},
+}
+
 var doubleSummary = &messagePtrStruct{
 	structName:     "DoubleSummary",
 	description:    "// DoubleSummary represents the type of a metric that is calculated by aggregating as a Summary of all reported double measurements over a time interval.",
@@ -302,6 +322,68 @@ var doubleHistogramDataPoint = &messagePtrStruct{
 	},
 }
 
+var exponentialHistogramDataPointSlice = &sliceStruct{
+	structName: "ExponentialHistogramDataPointSlice",
+	element:    exponentialHistogramDataPoint,
+}
+
+var exponentialHistogramDataPoint = &messagePtrStruct{
+	structName: "ExponentialHistogramDataPoint",
+	description: "// ExponentialHistogramDataPoint is a single data point in a timeseries that describes the\n" +
+		"// time-varying values of a ExponentialHistogram of double values. For a scale s the\n" +
+		"// buckets have boundaries at powers of base = 2^(2^-s), the bucket with index i covers\n" +
+		"// the values in (base^i, base^(i+1)].",
+	originFullName: "otlpmetrics.ExponentialHistogramDataPoint",
+	fields: []baseField{
+		labelsField,
+		startTimeField,
+		timeField,
+		countField,
+		doubleSumField,
+		&primitiveField{
+			fieldName:       "Scale",
+			originFieldName: "Scale",
+			returnType:      "int32",
+			defaultVal:      "int32(0)",
+			testVal:         "int32(4)",
+		},
+		&primitiveField{
+			fieldName:       "ZeroCount",
+			originFieldName: "ZeroCount",
+			returnType:      "uint64",
+			defaultVal:      "uint64(0)",
+			testVal:         "uint64(201)",
+		},
+		&messageValueField{
+			fieldName:       "Positive",
+			originFieldName: "Positive",
+			returnMessage:   exponentialHistogramBuckets,
+		},
+		&messageValueField{
+			fieldName:       "Negative",
+			originFieldName: "Negative",
+			returnMessage:   exponentialHistogramBuckets,
+		},
+		doubleExemplarsField,
+	},
+}
+
+var exponentialHistogramBuckets = &messageValueStruct{
+	structName:     "ExponentialHistogramBuckets",
+	description:    "// ExponentialHistogramBuckets are a set of bucket counts, encoded in a contiguous array of counts.",
+	originFullName: "otlpmetrics.ExponentialHistogramDataPoint_Buckets",
+	fields: []baseField{
+		&primitiveField{
+			fieldName:       "Offset",
+			originFieldName: "Offset",
+			returnType:      "int32",
+			defaultVal:      "int32(0)",
+			testVal:         "int32(909)",
+		},
+		bucketCountsField,
+	},
+}
+
 var doubleSummaryDataPointSlice = &sliceStruct{
 	structName: "DoubleSummaryDataPointSlice",
 	element:    doubleSummaryDataPoint,
@@ -360,6 +442,8 @@ var intExemplar = &messagePtrStruct{
 			originFieldName: "FilteredLabels",
 			returnSlice:     stringMap,
 		},
+		traceIDField,
+		spanIDField,
 	},
 }
 
@@ -383,6 +467,8 @@ var doubleExemplar = &messagePtrStruct{
 			originFieldName: "FilteredLabels",
 			returnSlice:     stringMap,
 		},
+		traceIDField,
+		spanIDField,
 	},
 }
 
@@ -20,6 +20,7 @@ import (
 	"github.com/spf13/viper"
 
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
 )
 
 // Component is either a receiver, exporter, processor or extension.
@@ -45,7 +46,7 @@ type Component interface {
 	Shutdown(ctx context.Context) error
 }
 
-// Kind specified one of the 4 components kinds, see consts below.
+// Kind specified one of the 5 components kinds, see consts below.
 type Kind int
 
 const (
@@ -54,8 +55,27 @@ const (
 	KindProcessor
 	KindExporter
 	KindExtension
+	KindConnector
 )
 
+// String returns the name of the kind as used in the configuration and in the
+// status of the components, e.g. "receiver".
+func (k Kind) String() string {
+	switch k {
+	case KindReceiver:
+		return "receiver"
+	case KindProcessor:
+		return "processor"
+	case KindExporter:
+		return "exporter"
+	case KindExtension:
+		return "extension"
+	case KindConnector:
+		return "connector"
+	}
+	return "unknown"
+}
+
 // Host represents the entity that is hosting a Component. It is used to allow communication
 // between the Component and its host (normally the service.Application is the host).
 type Host interface {
@@ -64,6 +84,12 @@ type Host interface {
 	// from) after its start function had already returned.
 	ReportFatalError(err error)
 
+	// ReportStatus is used by a component to report a change of its status, e.g. an
+	// exporter that starts or stops failing to send data. The host forwards the event
+	// to the extensions implementing StatusWatcher. It can be called anytime after
+	// Start() begins and must not block.
+	ReportStatus(event StatusEvent)
+
 	// GetFactory of the specified kind. Returns the factory for a component type.
 	// This allows components to create other components. For example:
 	//   func (r MyReceiver) Start(host component.Host) error {
@@ -93,6 +119,33 @@ type Host interface {
 	GetExporters() map[configmodels.DataType]map[configmodels.Exporter]Exporter
 }
 
+// ConfigManager is an extra interface implemented by the Host of the service that lets
+// extensions, e.g. a remote management agent, read and replace the configuration.
+// Extensions find it with a type assertion on the Host given to Start.
+type ConfigManager interface {
+	// EffectiveConfig returns the configuration in effect, with the values of the
+	// settings that may contain secrets redacted.
+	EffectiveConfig() (map[string]interface{}, error)
+
+	// ApplyConfig validates the YAML configuration and applies it like a reload: only
+	// the affected receivers, pipelines and exporters are rebuilt and the previous
+	// configuration stays in effect if this fails. The applied configuration replaces
+	// the configuration files until the service restarts.
+	ApplyConfig(ctx context.Context, yamlConfig []byte) error
+}
+
+// PipelineConsumers is an extra interface implemented by the Host of the service that lets
+// extensions send data into the pipelines, e.g. the output of a process they run.
+// Extensions find it with a type assertion on the Host given to Start.
+type PipelineConsumers interface {
+	// GetLogsConsumer returns the consumer the receivers of the logs pipeline with the
+	// given name send to, nil if there is no such pipeline or the pipelines are not
+	// running. The pipelines are built after the extensions are started and may be
+	// replaced when the configuration is reloaded, so the consumer must be looked up
+	// each time data is sent.
+	GetLogsConsumer(pipelineName string) consumer.LogsConsumer
+}
+
 // Factory interface must be implemented by all component factories.
 type Factory interface {
 	// Type gets the type of the component created by this factory.
@@ -13,3 +13,18 @@
 // limitations under the License.
 
 package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindString(t *testing.T) {
+	assert.Equal(t, "receiver", KindReceiver.String())
+	assert.Equal(t, "processor", KindProcessor.String())
+	assert.Equal(t, "exporter", KindExporter.String())
+	assert.Equal(t, "extension", KindExtension.String())
+	assert.Equal(t, "connector", KindConnector.String())
+	assert.Equal(t, "unknown", Kind(0).String())
+}
@@ -56,6 +56,11 @@ func (ews *ErrorWaitingHost) WaitForFatalError(timeout time.Duration) (receivedE
 	return
 }
 
+// ReportStatus is used to report a change of the status of a component.
+func (ews *ErrorWaitingHost) ReportStatus(_ component.StatusEvent) {
+	// Do nothing for now.
+}
+
 // GetFactory of the specified kind. Returns the factory for a component type.
 func (ews *ErrorWaitingHost) GetFactory(_ component.Kind, _ configmodels.Type) component.Factory {
 	return nil
@@ -17,6 +17,7 @@ package componenttest
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/spf13/viper"
 
@@ -433,6 +434,109 @@ func (ep *ExampleProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) erro
 	return ep.nextLogs.ConsumeLogs(ctx, ld)
 }
 
+// ExampleConnectorCfg is for testing purposes. We are defining an example config and factory
+// for "exampleconnector" connector type.
+type ExampleConnectorCfg struct {
+	configmodels.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	ExtraSetting                   string                   `mapstructure:"extra"`
+}
+
+// ExampleConnectorFactory is factory for ExampleConnector.
+type ExampleConnectorFactory struct {
+}
+
+var _ component.ConnectorFactory = (*ExampleConnectorFactory)(nil)
+
+// Type gets the type of the Connector config created by this factory.
+func (f *ExampleConnectorFactory) Type() configmodels.Type {
+	return "exampleconnector"
+}
+
+// CreateDefaultConfig creates the default configuration for the Connector.
+func (f *ExampleConnectorFactory) CreateDefaultConfig() configmodels.Connector {
+	return &ExampleConnectorCfg{
+		ConnectorSettings: configmodels.ConnectorSettings{
+			TypeVal: f.Type(),
+			NameVal: string(f.Type()),
+		},
+		ExtraSetting: "some connector string",
+	}
+}
+
+// CreateTracesConnector creates a connector that forwards the traces to the traces
+// pipelines and sends their number of spans to the metrics pipelines.
+func (f *ExampleConnectorFactory) CreateTracesConnector(
+	_ context.Context,
+	_ component.ConnectorCreateParams,
+	_ configmodels.Connector,
+	nextConsumers component.ConnectorConsumers,
+) (component.TracesConnector, error) {
+	if nextConsumers.Logs != nil {
+		return nil, configerror.ErrDataTypeIsNotSupported
+	}
+	return &ExampleConnector{nextConsumers: nextConsumers}, nil
+}
+
+// CreateMetricsConnector returns configerror.ErrDataTypeIsNotSupported.
+func (f *ExampleConnectorFactory) CreateMetricsConnector(
+	context.Context,
+	component.ConnectorCreateParams,
+	configmodels.Connector,
+	component.ConnectorConsumers,
+) (component.MetricsConnector, error) {
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+// CreateLogsConnector returns configerror.ErrDataTypeIsNotSupported.
+func (f *ExampleConnectorFactory) CreateLogsConnector(
+	context.Context,
+	component.ConnectorCreateParams,
+	configmodels.Connector,
+	component.ConnectorConsumers,
+) (component.LogsConnector, error) {
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+// ExampleConnector is an example connector consuming traces.
+type ExampleConnector struct {
+	nextConsumers component.ConnectorConsumers
+}
+
+// Start tells the connector to start.
+func (ec *ExampleConnector) Start(_ context.Context, _ component.Host) error {
+	return nil
+}
+
+// Shutdown is invoked during shutdown.
+func (ec *ExampleConnector) Shutdown(_ context.Context) error {
+	return nil
+}
+
+// ConsumeTraces sends the traces to the traces consumer and the number of spans, as
+// the "span_count" gauge, to the metrics consumer.
+func (ec *ExampleConnector) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if ec.nextConsumers.Metrics != nil {
+		md := pdata.NewMetrics()
+		md.ResourceMetrics().Resize(1)
+		ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
+		ilms.Resize(1)
+		ilms.At(0).Metrics().Resize(1)
+		metric := ilms.At(0).Metrics().At(0)
+		metric.SetName("span_count")
+		metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		metric.IntGauge().InitEmpty()
+		metric.IntGauge().DataPoints().Resize(1)
+		metric.IntGauge().DataPoints().At(0).SetValue(int64(td.SpanCount()))
+		if err := ec.nextConsumers.Metrics.ConsumeMetrics(ctx, md); err != nil {
+			return err
+		}
+	}
+	if ec.nextConsumers.Traces != nil {
+		return ec.nextConsumers.Traces.ConsumeTraces(ctx, td)
+	}
+	return nil
+}
+
 // ExampleExtensionCfg is for testing purposes. We are defining an example config and factory
 // for "exampleextension" extension type.
 type ExampleExtensionCfg struct {
@@ -443,12 +547,45 @@ type ExampleExtensionCfg struct {
 }
 
 type ExampleExtension struct {
+	mu           sync.Mutex
+	statusEvents []component.StatusEvent
+	components   []component.StatusEvent
 }
 
+var _ component.StatusWatcher = (*ExampleExtension)(nil)
+
 func (e *ExampleExtension) Start(_ context.Context, _ component.Host) error { return nil }
 
 func (e *ExampleExtension) Shutdown(_ context.Context) error { return nil }
 
+// ComponentStatusChanged records the status event.
+func (e *ExampleExtension) ComponentStatusChanged(event component.StatusEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.statusEvents = append(e.statusEvents, event)
+}
+
+// ComponentsChanged records the components.
+func (e *ExampleExtension) ComponentsChanged(components []component.StatusEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.components = append([]component.StatusEvent(nil), components...)
+}
+
+// Components returns the components of the last ComponentsChanged notification.
+func (e *ExampleExtension) Components() []component.StatusEvent {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]component.StatusEvent(nil), e.components...)
+}
+
+// StatusEvents returns the status events received by the extension.
+func (e *ExampleExtension) StatusEvents() []component.StatusEvent {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]component.StatusEvent(nil), e.statusEvents...)
+}
+
 // ExampleExtensionFactory is factory for ExampleExtensionCfg.
 type ExampleExtensionFactory struct {
 	FailCreation bool
@@ -503,6 +640,11 @@ func ExampleComponents() (
 	}
 
 	factories.Processors, err = component.MakeProcessorFactoryMap(&ExampleProcessorFactory{})
+	if err != nil {
+		return
+	}
+
+	factories.Connectors, err = component.MakeConnectorFactoryMap(&ExampleConnectorFactory{})
 
 	return
 }
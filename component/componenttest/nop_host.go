@@ -38,6 +38,11 @@ func (nh *NopHost) ReportFatalError(_ error) {
 	// Do nothing for now.
 }
 
+// ReportStatus is used to report a change of the status of a component.
+func (nh *NopHost) ReportStatus(_ component.StatusEvent) {
+	// Do nothing for now.
+}
+
 // GetFactory of the specified kind. Returns the factory for a component type.
 func (nh *NopHost) GetFactory(_ component.Kind, _ configmodels.Type) component.Factory {
 	return nil
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// Connector defines functions that all connectors must implement. A connector is
+// an exporter in some pipelines and a receiver in other pipelines: it consumes the
+// data exported by the former and sends data to the latter. The data it sends may
+// be of a different type than the data it consumes, e.g. metrics computed from traces.
+type Connector interface {
+	Component
+}
+
+// TracesConnector is a Connector that can consume traces.
+type TracesConnector interface {
+	Connector
+	consumer.TracesConsumer
+}
+
+// MetricsConnector is a Connector that can consume metrics.
+type MetricsConnector interface {
+	Connector
+	consumer.MetricsConsumer
+}
+
+// LogsConnector is a Connector that can consume logs.
+type LogsConnector interface {
+	Connector
+	consumer.LogsConsumer
+}
+
+// ConnectorCreateParams is passed to Create*Connector functions.
+type ConnectorCreateParams struct {
+	// Logger that the factory can use during creation and can pass to the created
+	// component to be used later as well.
+	Logger *zap.Logger
+
+	// ApplicationStartInfo can be used by components for informational purposes
+	ApplicationStartInfo ApplicationStartInfo
+}
+
+// ConnectorConsumers are the consumers a connector sends data to: one for each data
+// type of the pipelines the connector is a receiver of. The consumers of the data
+// types without such pipelines are nil.
+type ConnectorConsumers struct {
+	Traces  consumer.TracesConsumer
+	Metrics consumer.MetricsConsumer
+	Logs    consumer.LogsConsumer
+}
+
+// ConnectorFactory can create TracesConnector, MetricsConnector and LogsConnector.
+type ConnectorFactory interface {
+	Factory
+
+	// CreateDefaultConfig creates the default configuration for the Connector.
+	// This method can be called multiple times depending on the pipeline
+	// configuration and should not cause side-effects that prevent the creation
+	// of multiple instances of the Connector.
+	// The object returned by this method needs to pass the checks implemented by
+	// 'configcheck.ValidateConfig'. It is recommended to have such check in the
+	// tests of any implementation of the Factory interface.
+	CreateDefaultConfig() configmodels.Connector
+
+	// CreateTracesConnector creates a connector consuming traces and sending data
+	// to nextConsumers. If the connector does not support consuming traces or
+	// sending data to any of the non-nil nextConsumers, it returns
+	// configerror.ErrDataTypeIsNotSupported.
+	CreateTracesConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumers ConnectorConsumers,
+	) (TracesConnector, error)
+
+	// CreateMetricsConnector creates a connector consuming metrics and sending data
+	// to nextConsumers. If the connector does not support consuming metrics or
+	// sending data to any of the non-nil nextConsumers, it returns
+	// configerror.ErrDataTypeIsNotSupported.
+	CreateMetricsConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumers ConnectorConsumers,
+	) (MetricsConnector, error)
+
+	// CreateLogsConnector creates a connector consuming logs and sending data to
+	// nextConsumers. If the connector does not support consuming logs or sending
+	// data to any of the non-nil nextConsumers, it returns
+	// configerror.ErrDataTypeIsNotSupported.
+	CreateLogsConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumers ConnectorConsumers,
+	) (LogsConnector, error)
+}
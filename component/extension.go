@@ -46,6 +46,21 @@ type PipelineWatcher interface {
 	NotReady() error
 }
 
+// StatusWatcher is an extra interface for ServiceExtension hosted by the OpenTelemetry
+// Collector that is to be implemented by extensions interested in the status of the
+// components, e.g. a health check reporting the components that are failing.
+type StatusWatcher interface {
+	// ComponentStatusChanged notifies the ServiceExtension of a change of the status of a
+	// component. It is called from the goroutine of the component and must not block.
+	ComponentStatusChanged(event StatusEvent)
+
+	// ComponentsChanged notifies the ServiceExtension of the components of the pipelines
+	// when the host applies a configuration, at startup and after each reload. It gets a
+	// StatusOK event with the pipelines of each component: the components without a
+	// status are OK, the statuses of the components not in the list must be forgotten.
+	ComponentsChanged(components []StatusEvent)
+}
+
 // ExtensionCreateParams is passed to ExtensionFactory.Create* functions.
 type ExtensionCreateParams struct {
 	// Logger that the factory can use during creation and can pass to the created
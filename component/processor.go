@@ -58,6 +58,14 @@ type ProcessorCapabilities struct {
 	// does not modify the data it MUST set this flag to false. If the processor creates
 	// a copy of the data before modifying then this flag can be safely set to false.
 	MutatesConsumedData bool
+
+	// SupportsSharedData is set to true if the processor supports data shared with
+	// other pipelines, see pdata.Traces.Share: it calls Writable on the input before
+	// modifying it, and marks its output with MarkShared if it references shared input
+	// data without having made it writable. Otherwise the data is copied for each
+	// pipeline before being sent to the processor. It is always set to true for
+	// processors created with processorhelper.
+	SupportsSharedData bool
 }
 
 // ProcessorCreateParams is passed to Create* functions in ProcessorFactory.
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"time"
+)
+
+// Status is the status of a component as reported to the host.
+type Status int
+
+const (
+	// StatusOK means that the component works as expected.
+	StatusOK Status = iota
+	// StatusRecoverableError means that the component encountered an error that may go
+	// away, e.g. an exporter that cannot reach its backend.
+	StatusRecoverableError
+	// StatusPermanentError means that the component encountered an error that it cannot
+	// recover from without a change of its configuration or of its environment.
+	StatusPermanentError
+)
+
+// String returns the name of the status as used in logs and by the health check.
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusRecoverableError:
+		return "recoverable_error"
+	case StatusPermanentError:
+		return "permanent_error"
+	}
+	return "unknown"
+}
+
+// StatusEvent is a change of the status of a component reported to the host via
+// Host.ReportStatus.
+type StatusEvent struct {
+	// Kind is the kind of the component.
+	Kind Kind
+	// Name is the full name of the component in the configuration, e.g. "otlp/2".
+	Name   string
+	Status Status
+	// Err is the error that caused the status, nil for StatusOK.
+	Err error
+	// Timestamp is the time of the change, the host sets it to the current time
+	// if it is zero.
+	Timestamp time.Time
+	// Pipelines are the names of the pipelines of the component. They are set by the
+	// host, the components do not need to set them.
+	Pipelines []string
+}
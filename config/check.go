@@ -0,0 +1,320 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// serviceKeyName is the configuration key name for the service section.
+const serviceKeyName = "service"
+
+// KeyError is an error in the configuration under a given key.
+type KeyError struct {
+	// Key is the path of the key, e.g. ["receivers", "otlp/2"].
+	Key []string
+	// Err is the error found in the configuration of the key.
+	Err error
+}
+
+func (e *KeyError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error found in the configuration of the key.
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// LoadError is the error returned by Load and LoadWithSources when some of the
+// components or pipelines cannot be loaded.
+type LoadError struct {
+	// Errs are all the errors found, as *KeyError.
+	Errs []error
+	// Config is the configuration of the components and pipelines that could be loaded.
+	Config *configmodels.Config
+
+	// failed are the components and pipelines that could not be loaded, see checker.
+	failed map[string]bool
+}
+
+func (e *LoadError) Error() string {
+	return componenterror.CombineErrors(e.Errs).Error()
+}
+
+// Unwrap returns the first error found.
+func (e *LoadError) Unwrap() error {
+	return e.Errs[0]
+}
+
+// Check validates the configuration returned by Load like ValidateConfig, but instead of
+// stopping at the first error it returns all the errors found, as *KeyError. err is the
+// error returned by Load: if it is a *LoadError the configuration of the components that
+// could be loaded is validated after the errors it holds, and the references to the
+// components that could not be loaded are not reported again. Any other error is returned
+// alone. Check also returns the configuration that was validated, if any.
+func Check(cfg *configmodels.Config, err error) (*configmodels.Config, []error) {
+	c := &checker{failed: make(map[string]bool)}
+	var loadErr *LoadError
+	if errors.As(err, &loadErr) {
+		cfg = loadErr.Config
+		c.errs = append(c.errs, loadErr.Errs...)
+		for key := range loadErr.failed {
+			c.failed[key] = true
+		}
+	} else if err != nil {
+		return nil, []error{err}
+	}
+	if cfg == nil {
+		return nil, c.errs
+	}
+
+	c.checkConfig(cfg)
+	return cfg, c.errs
+}
+
+// load loads the configuration of the components and pipelines from v, collecting the
+// errors of those that cannot be loaded.
+func (c *checker) load(v *viper.Viper, rawCfg configSettings, exp *expander, factories component.Factories) *configmodels.Config {
+	cfg := &configmodels.Config{
+		Extensions: make(configmodels.Extensions),
+		Receivers:  make(configmodels.Receivers),
+		Exporters:  make(configmodels.Exporters),
+		Processors: make(configmodels.Processors),
+		Connectors: make(configmodels.Connectors),
+		Service: configmodels.Service{
+			Extensions: rawCfg.Service.Extensions,
+			Pipelines:  make(configmodels.Pipelines),
+			Telemetry:  rawCfg.Service.Telemetry,
+		},
+	}
+
+	// In the following section use v.GetStringMap(xyzKeyName) instead of rawCfg.Xyz, because
+	// UnmarshalExact will not unmarshal entries in the map[string]interface{} with nil values.
+	// GetStringMap does the correct thing.
+
+	c.loadSection(v, extensionsKeyName, func(key string, value interface{}) error {
+		loaded, err := loadExtensions(map[string]interface{}{key: value}, exp, factories.Extensions)
+		for name, ext := range loaded {
+			if cfg.Extensions[name] != nil {
+				return errorDuplicateName(extensionsKeyName, name)
+			}
+			cfg.Extensions[name] = ext
+		}
+		return err
+	})
+	c.loadSection(v, receiversKeyName, func(key string, value interface{}) error {
+		loaded, err := loadReceivers(map[string]interface{}{key: value}, exp, factories.Receivers)
+		for name, rcv := range loaded {
+			if cfg.Receivers[name] != nil {
+				return errorDuplicateName(receiversKeyName, name)
+			}
+			cfg.Receivers[name] = rcv
+		}
+		return err
+	})
+	c.loadSection(v, exportersKeyName, func(key string, value interface{}) error {
+		loaded, err := loadExporters(map[string]interface{}{key: value}, exp, factories.Exporters)
+		for name, expCfg := range loaded {
+			if cfg.Exporters[name] != nil {
+				return errorDuplicateName(exportersKeyName, name)
+			}
+			cfg.Exporters[name] = expCfg
+		}
+		return err
+	})
+	c.loadSection(v, processorsKeyName, func(key string, value interface{}) error {
+		loaded, err := loadProcessors(map[string]interface{}{key: value}, exp, factories.Processors)
+		for name, proc := range loaded {
+			if cfg.Processors[name] != nil {
+				return errorDuplicateName(processorsKeyName, name)
+			}
+			cfg.Processors[name] = proc
+		}
+		return err
+	})
+	c.loadSection(v, connectorsKeyName, func(key string, value interface{}) error {
+		loaded, err := loadConnectors(map[string]interface{}{key: value}, exp, factories.Connectors)
+		for name, conn := range loaded {
+			if cfg.Connectors[name] != nil {
+				return errorDuplicateName(connectorsKeyName, name)
+			}
+			cfg.Connectors[name] = conn
+		}
+		return err
+	})
+
+	pipelineKeys := make([]string, 0, len(rawCfg.Service.Pipelines))
+	for key := range rawCfg.Service.Pipelines {
+		pipelineKeys = append(pipelineKeys, key)
+	}
+	sort.Strings(pipelineKeys)
+	for _, key := range pipelineKeys {
+		loaded, err := loadPipelines(map[string]pipelineSettings{key: rawCfg.Service.Pipelines[key]})
+		for name, pipeline := range loaded {
+			if cfg.Service.Pipelines[name] != nil {
+				err = errorDuplicateName(pipelinesKeyName, name)
+				break
+			}
+			cfg.Service.Pipelines[name] = pipeline
+		}
+		if err != nil {
+			c.fail([]string{serviceKeyName, pipelinesKeyName, key}, pipelinesKeyName, key, err)
+		}
+	}
+
+	return cfg
+}
+
+// checker collects the errors found by Load and Check.
+type checker struct {
+	errs []error
+	// failed are the components and pipelines that could not be loaded, keyed by
+	// section and name.
+	failed map[string]bool
+}
+
+// loadSection calls load for each key of the section in v, in order.
+func (c *checker) loadSection(v *viper.Viper, section string, load func(key string, value interface{}) error) {
+	entries := v.GetStringMap(section)
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := load(key, entries[key]); err != nil {
+			c.fail([]string{section, key}, section, key, err)
+		}
+	}
+}
+
+func (c *checker) fail(key []string, section string, name string, err error) {
+	c.failed[failedKey(section, name)] = true
+	c.errs = append(c.errs, &KeyError{Key: key, Err: err})
+}
+
+func (c *checker) isFailed(section string, name string) bool {
+	return c.failed[failedKey(section, name)]
+}
+
+func failedKey(section string, name string) string {
+	_, fullName, _ := DecodeTypeAndName(name)
+	return section + typeAndNameSeparator + fullName
+}
+
+// checkConfig validates cfg like ValidateConfig, reporting all the errors.
+func (c *checker) checkConfig(cfg *configmodels.Config) {
+	if !c.hasFailures(receiversKeyName) {
+		c.check([]string{receiversKeyName}, validateReceivers(cfg))
+	}
+	if !c.hasFailures(exportersKeyName) {
+		c.check([]string{exportersKeyName}, validateExporters(cfg))
+	}
+	if len(cfg.Service.Pipelines) == 0 && !c.hasFailures(pipelinesKeyName) {
+		c.check([]string{serviceKeyName, pipelinesKeyName}, validatePipelines(cfg))
+	}
+
+	names := make([]string, 0, len(cfg.Service.Pipelines))
+	for name := range cfg.Service.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pipeline := *cfg.Service.Pipelines[name]
+		key := []string{serviceKeyName, pipelinesKeyName, name}
+
+		// Ignore the references to components that could not be loaded, they were reported already.
+		var failedReceivers, failedExporters, failedConnectors bool
+		pipeline.Receivers, failedReceivers = c.withoutFailed(receiversKeyName, pipeline.Receivers)
+		pipeline.Receivers, failedConnectors = c.withoutFailed(connectorsKeyName, pipeline.Receivers)
+		failedReceivers = failedReceivers || failedConnectors
+		pipeline.Exporters, failedExporters = c.withoutFailed(exportersKeyName, pipeline.Exporters)
+		pipeline.Exporters, failedConnectors = c.withoutFailed(connectorsKeyName, pipeline.Exporters)
+		failedExporters = failedExporters || failedConnectors
+		pipeline.Processors, _ = c.withoutFailed(processorsKeyName, pipeline.Processors)
+
+		if len(pipeline.Receivers) != 0 || !failedReceivers {
+			c.check(key, validatePipelineReceivers(cfg, &pipeline))
+		}
+		if len(pipeline.Exporters) != 0 || !failedExporters {
+			c.check(key, validatePipelineExporters(cfg, &pipeline))
+		}
+		c.check(key, validatePipelineProcessors(cfg, &pipeline))
+		c.check(key, validatePipelineFanOut(&pipeline))
+	}
+
+	connectorNames := make([]string, 0, len(cfg.Connectors))
+	for name := range cfg.Connectors {
+		connectorNames = append(connectorNames, name)
+	}
+	sort.Strings(connectorNames)
+	for _, name := range connectorNames {
+		c.check([]string{connectorsKeyName, name}, validateConnector(cfg, name))
+	}
+	c.check([]string{serviceKeyName, pipelinesKeyName}, validatePipelineCycles(cfg))
+
+	// The telemetry could reference a pipeline that could not be loaded.
+	if !c.hasFailures(pipelinesKeyName) {
+		c.check([]string{serviceKeyName, telemetryKeyName}, validateServiceTelemetry(cfg))
+	}
+
+	service := cfg.Service
+	cfg.Service.Extensions, _ = c.withoutFailed(extensionsKeyName, service.Extensions)
+	c.check([]string{serviceKeyName, extensionsKeyName}, validateServiceExtensions(cfg))
+	cfg.Service = service
+
+	c.errs = append(c.errs, validateComponents(cfg)...)
+}
+
+func (c *checker) check(key []string, err error) {
+	if err != nil {
+		c.errs = append(c.errs, &KeyError{Key: key, Err: err})
+	}
+}
+
+func (c *checker) hasFailures(section string) bool {
+	prefix := section + typeAndNameSeparator
+	for key := range c.failed {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutFailed returns the names without those of the components of the section
+// that could not be loaded, and whether any was removed.
+func (c *checker) withoutFailed(section string, names []string) ([]string, bool) {
+	var result []string
+	removed := false
+	for _, name := range names {
+		if c.isFailed(section, name) {
+			removed = true
+			continue
+		}
+		result = append(result, name)
+	}
+	return result, removed
+}
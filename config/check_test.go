@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestCheck(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	v := NewViper()
+	v.SetConfigFile(path.Join("testdata", "multiple-errors.yaml"))
+	require.NoError(t, v.ReadInConfig())
+
+	_, err = Load(v, factories)
+	var loadErr *LoadError
+	require.True(t, errors.As(err, &loadErr))
+	assert.Len(t, loadErr.Errs, 3)
+
+	cfg, errs := Check(nil, err)
+	require.NotNil(t, cfg)
+
+	var keys [][]string
+	var codes []configErrorCode
+	for _, err := range errs {
+		keyErr, ok := err.(*KeyError)
+		require.True(t, ok, err.Error())
+		keys = append(keys, keyErr.Key)
+		codes = append(codes, keyErr.Err.(*configError).code)
+	}
+	assert.Equal(t, [][]string{
+		{"receivers", "nosuchreceiver"},
+		{"exporters", "exampleexporter"},
+		{"service", "pipelines", "invalid"},
+		{"service", "pipelines", "metrics"},
+		{"service", "extensions"},
+	}, keys)
+	assert.Equal(t, []configErrorCode{
+		errUnknownType,
+		errUnmarshalTopLevelStructureError,
+		errUnknownType,
+		errPipelineProcessorNotExists,
+		errExtensionNotExists,
+	}, codes)
+
+	// The valid components are loaded.
+	assert.NotNil(t, cfg.Receivers["examplereceiver"])
+	assert.NotNil(t, cfg.Exporters["exampleexporter/2"])
+	assert.Len(t, cfg.Service.Pipelines, 2)
+	assert.Equal(t, []string{"exampleextension"}, cfg.Service.Extensions)
+}
+
+func TestCheck_Valid(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	v := NewViper()
+	v.SetConfigFile(path.Join("testdata", "valid-config.yaml"))
+	require.NoError(t, v.ReadInConfig())
+
+	expected, err := Load(v, factories)
+	require.NoError(t, err)
+	cfg, errs := Check(expected, err)
+	assert.Empty(t, errs)
+	assert.Same(t, expected, cfg)
+}
+
+func TestCheck_InvalidTopLevel(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	v := NewViper()
+	v.SetConfigFile(path.Join("testdata", "invalid-top-level-section.yaml"))
+	require.NoError(t, v.ReadInConfig())
+
+	cfg, errs := Check(Load(v, factories))
+	assert.Nil(t, cfg)
+	require.Len(t, errs, 1)
+	assert.Equal(t, errUnmarshalTopLevelStructureError, errs[0].(*configError).code)
+}
@@ -18,18 +18,24 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configsource"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
 // These are errors that can be returned by Load(). Note that error codes are not part
@@ -52,6 +58,12 @@ const (
 	errMissingReceivers
 	errMissingExporters
 	errUnmarshalTopLevelStructureError
+	errExpandConfigValues
+	errInvalidComponentConfig
+	errInvalidPipelineFanOut
+	errInvalidConnector
+	errPipelineCycle
+	errInvalidServiceTelemetry
 )
 
 type configError struct {
@@ -77,27 +89,36 @@ const (
 	// processorsKeyName is the configuration key name for processors section.
 	processorsKeyName = "processors"
 
+	// connectorsKeyName is the configuration key name for connectors section.
+	connectorsKeyName = "connectors"
+
 	// pipelinesKeyName is the configuration key name for pipelines section.
 	pipelinesKeyName = "pipelines"
+
+	// telemetryKeyName is the configuration key name for the telemetry section of the service.
+	telemetryKeyName = "telemetry"
 )
 
 type configSettings struct {
 	Receivers  map[string]map[string]interface{} `mapstructure:"receivers"`
 	Processors map[string]map[string]interface{} `mapstructure:"processors"`
 	Exporters  map[string]map[string]interface{} `mapstructure:"exporters"`
+	Connectors map[string]map[string]interface{} `mapstructure:"connectors"`
 	Extensions map[string]map[string]interface{} `mapstructure:"extensions"`
 	Service    serviceSettings                   `mapstructure:"service"`
 }
 
 type serviceSettings struct {
-	Extensions []string                    `mapstructure:"extensions"`
-	Pipelines  map[string]pipelineSettings `mapstructure:"pipelines"`
+	Extensions []string                      `mapstructure:"extensions"`
+	Pipelines  map[string]pipelineSettings   `mapstructure:"pipelines"`
+	Telemetry  configmodels.ServiceTelemetry `mapstructure:"telemetry"`
 }
 
 type pipelineSettings struct {
-	Receivers  []string `mapstructure:"receivers"`
-	Processors []string `mapstructure:"processors"`
-	Exporters  []string `mapstructure:"exporters"`
+	Receivers  []string                    `mapstructure:"receivers"`
+	Processors []string                    `mapstructure:"processors"`
+	Exporters  []string                    `mapstructure:"exporters"`
+	FanOut     configmodels.FanOutSettings `mapstructure:"fanout"`
 }
 
 // deprecatedUnmarshaler is the old/deprecated way to provide custom unmarshaler.
@@ -117,16 +138,25 @@ func NewViper() *viper.Viper {
 	return viper.NewWithOptions(viper.KeyDelimiter("::"))
 }
 
-// Load loads a Config from Viper.
+// Load loads a Config from Viper. If some of the components or pipelines cannot be
+// loaded the error is a *LoadError holding all the errors found.
 // After loading the config, need to check if it is valid by calling `ValidateConfig`.
 func Load(
 	v *viper.Viper,
 	factories component.Factories,
 ) (*configmodels.Config, error) {
+	return LoadWithSources(v, factories, nil)
+}
 
-	var config configmodels.Config
-
-	// Load the config.
+// LoadWithSources loads a Config from Viper, like Load, and resolves the references to
+// config sources in the values of the components' configuration, e.g. ${file:/run/secrets/token}
+// or ${env:HOST:-localhost}, using sources. If sources is nil only environment variables
+// can be referenced, as $VAR or ${VAR}.
+func LoadWithSources(
+	v *viper.Viper,
+	factories component.Factories,
+	sources *configsource.Manager,
+) (*configmodels.Config, error) {
 
 	// Struct to validate top level sections.
 	var rawCfg configSettings
@@ -137,46 +167,12 @@ func Load(
 		}
 	}
 
-	// In the following section use v.GetStringMap(xyzKeyName) instead of rawCfg.Xyz, because
-	// UnmarshalExact will not unmarshal entries in the map[string]interface{} with nil values.
-	// GetStringMap does the correct thing.
-
-	// Start with the service extensions.
-
-	extensions, err := loadExtensions(v.GetStringMap(extensionsKeyName), factories.Extensions)
-	if err != nil {
-		return nil, err
-	}
-	config.Extensions = extensions
-
-	// Load data components (receivers, exporters, and processors).
-
-	receivers, err := loadReceivers(v.GetStringMap(receiversKeyName), factories.Receivers)
-	if err != nil {
-		return nil, err
-	}
-	config.Receivers = receivers
-
-	exporters, err := loadExporters(v.GetStringMap(exportersKeyName), factories.Exporters)
-	if err != nil {
-		return nil, err
+	c := &checker{failed: make(map[string]bool)}
+	cfg := c.load(v, rawCfg, &expander{sources: sources}, factories)
+	if len(c.errs) != 0 {
+		return nil, &LoadError{Errs: c.errs, Config: cfg, failed: c.failed}
 	}
-	config.Exporters = exporters
-
-	processors, err := loadProcessors(v.GetStringMap(processorsKeyName), factories.Processors)
-	if err != nil {
-		return nil, err
-	}
-	config.Processors = processors
-
-	// Load the service and its data pipelines.
-	service, err := loadService(rawCfg.Service)
-	if err != nil {
-		return nil, err
-	}
-	config.Service = service
-
-	return &config, nil
+	return cfg, nil
 }
 
 // DecodeTypeAndName decodes a key in type[/name] format into type and fullName.
@@ -239,6 +235,20 @@ func errorUnmarshalError(component string, fullName string, err error) error {
 	}
 }
 
+func errorExpandError(component string, fullName string, err error) error {
+	return &configError{
+		code: errExpandConfigValues,
+		msg:  fmt.Sprintf("error expanding values of %s %q: %v", component, fullName, err),
+	}
+}
+
+func errorInvalidComponentConfig(component string, fullName string, err error) error {
+	return &configError{
+		code: errInvalidComponentConfig,
+		msg:  fmt.Sprintf("invalid configuration of %s %q: %v", component, fullName, err),
+	}
+}
+
 func errorDuplicateName(component string, fullName string) error {
 	return &configError{
 		code: errDuplicateName,
@@ -246,14 +256,13 @@ func errorDuplicateName(component string, fullName string) error {
 	}
 }
 
-func loadExtensions(exts map[string]interface{}, factories map[configmodels.Type]component.ExtensionFactory) (configmodels.Extensions, error) {
+func loadExtensions(exts map[string]interface{}, exp *expander, factories map[configmodels.Type]component.ExtensionFactory) (configmodels.Extensions, error) {
 	// Prepare resulting map.
 	extensions := make(configmodels.Extensions)
 
 	// Iterate over extensions and create a config for each.
 	for key, value := range exts {
 		componentConfig := viperFromStringMap(cast.ToStringMap(value))
-		expandEnvConfig(componentConfig)
 
 		// Decode the key into type and fullName components.
 		typeStr, fullName, err := DecodeTypeAndName(key)
@@ -261,6 +270,10 @@ func loadExtensions(exts map[string]interface{}, factories map[configmodels.Type
 			return nil, errorInvalidTypeAndNameKey(extensionsKeyName, key, err)
 		}
 
+		if err = exp.expandConfig(componentConfig); err != nil {
+			return nil, errorExpandError(extensionsKeyName, fullName, err)
+		}
+
 		// Find extension factory based on "type" that we read from config source.
 		factory := factories[typeStr]
 		if factory == nil {
@@ -288,18 +301,6 @@ func loadExtensions(exts map[string]interface{}, factories map[configmodels.Type
 	return extensions, nil
 }
 
-func loadService(rawService serviceSettings) (configmodels.Service, error) {
-	var ret configmodels.Service
-	ret.Extensions = rawService.Extensions
-
-	// Process the pipelines first so in case of error on them it can be properly
-	// reported.
-	pipelines, err := loadPipelines(rawService.Pipelines)
-	ret.Pipelines = pipelines
-
-	return ret, err
-}
-
 // LoadReceiver loads a receiver config from componentConfig using the provided factories.
 func LoadReceiver(componentConfig *viper.Viper, typeStr configmodels.Type, fullName string, factory component.ReceiverFactory) (configmodels.Receiver, error) {
 	// Create the default config for this receiver.
@@ -316,14 +317,13 @@ func LoadReceiver(componentConfig *viper.Viper, typeStr configmodels.Type, fullN
 	return receiverCfg, nil
 }
 
-func loadReceivers(recvs map[string]interface{}, factories map[configmodels.Type]component.ReceiverFactory) (configmodels.Receivers, error) {
+func loadReceivers(recvs map[string]interface{}, exp *expander, factories map[configmodels.Type]component.ReceiverFactory) (configmodels.Receivers, error) {
 	// Prepare resulting map
 	receivers := make(configmodels.Receivers)
 
 	// Iterate over input map and create a config for each.
 	for key, value := range recvs {
 		componentConfig := viperFromStringMap(cast.ToStringMap(value))
-		expandEnvConfig(componentConfig)
 
 		// Decode the key into type and fullName components.
 		typeStr, fullName, err := DecodeTypeAndName(key)
@@ -331,6 +331,10 @@ func loadReceivers(recvs map[string]interface{}, factories map[configmodels.Type
 			return nil, errorInvalidTypeAndNameKey(receiversKeyName, key, err)
 		}
 
+		if err = exp.expandConfig(componentConfig); err != nil {
+			return nil, errorExpandError(receiversKeyName, fullName, err)
+		}
+
 		// Find receiver factory based on "type" that we read from config source
 		factory := factories[typeStr]
 		if factory == nil {
@@ -353,14 +357,13 @@ func loadReceivers(recvs map[string]interface{}, factories map[configmodels.Type
 	return receivers, nil
 }
 
-func loadExporters(exps map[string]interface{}, factories map[configmodels.Type]component.ExporterFactory) (configmodels.Exporters, error) {
+func loadExporters(exps map[string]interface{}, exp *expander, factories map[configmodels.Type]component.ExporterFactory) (configmodels.Exporters, error) {
 	// Prepare resulting map
 	exporters := make(configmodels.Exporters)
 
 	// Iterate over Exporters and create a config for each.
 	for key, value := range exps {
 		componentConfig := viperFromStringMap(cast.ToStringMap(value))
-		expandEnvConfig(componentConfig)
 
 		// Decode the key into type and fullName components.
 		typeStr, fullName, err := DecodeTypeAndName(key)
@@ -368,6 +371,10 @@ func loadExporters(exps map[string]interface{}, factories map[configmodels.Type]
 			return nil, errorInvalidTypeAndNameKey(exportersKeyName, key, err)
 		}
 
+		if err = exp.expandConfig(componentConfig); err != nil {
+			return nil, errorExpandError(exportersKeyName, fullName, err)
+		}
+
 		// Find exporter factory based on "type" that we read from config source
 		factory := factories[typeStr]
 		if factory == nil {
@@ -395,14 +402,13 @@ func loadExporters(exps map[string]interface{}, factories map[configmodels.Type]
 	return exporters, nil
 }
 
-func loadProcessors(procs map[string]interface{}, factories map[configmodels.Type]component.ProcessorFactory) (configmodels.Processors, error) {
+func loadProcessors(procs map[string]interface{}, exp *expander, factories map[configmodels.Type]component.ProcessorFactory) (configmodels.Processors, error) {
 	// Prepare resulting map.
 	processors := make(configmodels.Processors)
 
 	// Iterate over processors and create a config for each.
 	for key, value := range procs {
 		componentConfig := viperFromStringMap(cast.ToStringMap(value))
-		expandEnvConfig(componentConfig)
 
 		// Decode the key into type and fullName components.
 		typeStr, fullName, err := DecodeTypeAndName(key)
@@ -410,6 +416,10 @@ func loadProcessors(procs map[string]interface{}, factories map[configmodels.Typ
 			return nil, errorInvalidTypeAndNameKey(processorsKeyName, key, err)
 		}
 
+		if err = exp.expandConfig(componentConfig); err != nil {
+			return nil, errorExpandError(processorsKeyName, fullName, err)
+		}
+
 		// Find processor factory based on "type" that we read from config source.
 		factory := factories[typeStr]
 		if factory == nil {
@@ -437,6 +447,51 @@ func loadProcessors(procs map[string]interface{}, factories map[configmodels.Typ
 	return processors, nil
 }
 
+func loadConnectors(conns map[string]interface{}, exp *expander, factories map[configmodels.Type]component.ConnectorFactory) (configmodels.Connectors, error) {
+	// Prepare resulting map.
+	connectors := make(configmodels.Connectors)
+
+	// Iterate over connectors and create a config for each.
+	for key, value := range conns {
+		componentConfig := viperFromStringMap(cast.ToStringMap(value))
+
+		// Decode the key into type and fullName components.
+		typeStr, fullName, err := DecodeTypeAndName(key)
+		if err != nil {
+			return nil, errorInvalidTypeAndNameKey(connectorsKeyName, key, err)
+		}
+
+		if err = exp.expandConfig(componentConfig); err != nil {
+			return nil, errorExpandError(connectorsKeyName, fullName, err)
+		}
+
+		// Find connector factory based on "type" that we read from config source.
+		factory := factories[typeStr]
+		if factory == nil {
+			return nil, errorUnknownType(connectorsKeyName, typeStr, fullName)
+		}
+
+		// Create the default config for this connector.
+		connectorCfg := factory.CreateDefaultConfig()
+		connectorCfg.SetName(fullName)
+
+		// Now that the default config struct is created we can Unmarshal into it
+		// and it will apply user-defined config on top of the default.
+		unm := unmarshaler(factory)
+		if err := unm(componentConfig, connectorCfg); err != nil {
+			return nil, errorUnmarshalError(connectorsKeyName, fullName, err)
+		}
+
+		if connectors[fullName] != nil {
+			return nil, errorDuplicateName(connectorsKeyName, fullName)
+		}
+
+		connectors[fullName] = connectorCfg
+	}
+
+	return connectors, nil
+}
+
 func loadPipelines(pipelinesConfig map[string]pipelineSettings) (configmodels.Pipelines, error) {
 	// Prepare resulting map.
 	pipelines := make(configmodels.Pipelines)
@@ -466,6 +521,7 @@ func loadPipelines(pipelinesConfig map[string]pipelineSettings) (configmodels.Pi
 		pipelineCfg.Receivers = rawPipeline.Receivers
 		pipelineCfg.Processors = rawPipeline.Processors
 		pipelineCfg.Exporters = rawPipeline.Exporters
+		pipelineCfg.FanOut = rawPipeline.FanOut
 
 		if pipelines[fullName] != nil {
 			return nil, errorDuplicateName(pipelinesKeyName, fullName)
@@ -495,17 +551,197 @@ func ValidateConfig(cfg *configmodels.Config, _ *zap.Logger) error {
 		return err
 	}
 
+	if errs := validateComponents(cfg); len(errs) != 0 {
+		return &configError{
+			code: errInvalidComponentConfig,
+			msg:  componenterror.CombineErrors(errs).Error(),
+		}
+	}
+
 	return nil
 }
 
+// validateComponents calls Validate on the configuration of all the components that
+// implement configmodels.Validator and returns all the errors, as *KeyError, sorted by
+// section and component name.
+func validateComponents(cfg *configmodels.Config) []error {
+	var errs []error
+
+	extensionNames := make([]string, 0, len(cfg.Extensions))
+	for name := range cfg.Extensions {
+		extensionNames = append(extensionNames, name)
+	}
+	sort.Strings(extensionNames)
+	for _, name := range extensionNames {
+		if validator, ok := cfg.Extensions[name].(configmodels.Validator); ok {
+			if err := validator.Validate(); err != nil {
+				errs = append(errs, componentKeyError(extensionsKeyName, name, err))
+			}
+		}
+	}
+
+	receiverNames := make([]string, 0, len(cfg.Receivers))
+	for name := range cfg.Receivers {
+		receiverNames = append(receiverNames, name)
+	}
+	sort.Strings(receiverNames)
+	for _, name := range receiverNames {
+		if validator, ok := cfg.Receivers[name].(configmodels.Validator); ok {
+			if err := validator.Validate(); err != nil {
+				errs = append(errs, componentKeyError(receiversKeyName, name, err))
+			}
+		}
+	}
+
+	processorNames := make([]string, 0, len(cfg.Processors))
+	for name := range cfg.Processors {
+		processorNames = append(processorNames, name)
+	}
+	sort.Strings(processorNames)
+	for _, name := range processorNames {
+		if validator, ok := cfg.Processors[name].(configmodels.Validator); ok {
+			if err := validator.Validate(); err != nil {
+				errs = append(errs, componentKeyError(processorsKeyName, name, err))
+			}
+		}
+	}
+
+	exporterNames := make([]string, 0, len(cfg.Exporters))
+	for name := range cfg.Exporters {
+		exporterNames = append(exporterNames, name)
+	}
+	sort.Strings(exporterNames)
+	for _, name := range exporterNames {
+		if validator, ok := cfg.Exporters[name].(configmodels.Validator); ok {
+			if err := validator.Validate(); err != nil {
+				errs = append(errs, componentKeyError(exportersKeyName, name, err))
+			}
+		}
+	}
+
+	connectorNames := make([]string, 0, len(cfg.Connectors))
+	for name := range cfg.Connectors {
+		connectorNames = append(connectorNames, name)
+	}
+	sort.Strings(connectorNames)
+	for _, name := range connectorNames {
+		if validator, ok := cfg.Connectors[name].(configmodels.Validator); ok {
+			if err := validator.Validate(); err != nil {
+				errs = append(errs, componentKeyError(connectorsKeyName, name, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// componentKeyError returns the error reported when the configuration of a component
+// is not valid.
+func componentKeyError(section, name string, err error) error {
+	return &KeyError{
+		Key: []string{section, name},
+		Err: errorInvalidComponentConfig(section, name, err),
+	}
+}
+
 func validateService(cfg *configmodels.Config) error {
 	if err := validatePipelines(cfg); err != nil {
 		return err
 	}
 
+	if err := validateConnectors(cfg); err != nil {
+		return err
+	}
+
+	if err := validateServiceTelemetry(cfg); err != nil {
+		return err
+	}
+
 	return validateServiceExtensions(cfg)
 }
 
+func validateLogsTelemetry(logs configmodels.LogsTelemetry) error {
+	if logs.Level != "" {
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(logs.Level)); err != nil {
+			return &configError{
+				code: errInvalidServiceTelemetry,
+				msg:  fmt.Sprintf("telemetry logs level: %v", err),
+			}
+		}
+	}
+	switch logs.Encoding {
+	case "", "json", "console":
+	default:
+		return &configError{
+			code: errInvalidServiceTelemetry,
+			msg:  fmt.Sprintf("telemetry logs encoding %q must be json or console", logs.Encoding),
+		}
+	}
+	if logs.Sampling != nil && (logs.Sampling.Initial < 0 || logs.Sampling.Thereafter < 0) {
+		return &configError{
+			code: errInvalidServiceTelemetry,
+			msg:  "telemetry logs sampling initial and thereafter must not be negative",
+		}
+	}
+	return nil
+}
+
+func validateServiceTelemetry(cfg *configmodels.Config) error {
+	telemetry := cfg.Service.Telemetry
+	if err := validateLogsTelemetry(telemetry.Logs); err != nil {
+		return err
+	}
+	if telemetry.Metrics.Level != "" {
+		if _, err := configtelemetry.ParseLevel(telemetry.Metrics.Level); err != nil {
+			return &configError{
+				code: errInvalidServiceTelemetry,
+				msg:  fmt.Sprintf("telemetry metrics level: %v", err),
+			}
+		}
+	}
+	if ratio := telemetry.Traces.SamplingRatio; ratio < 0 || ratio > 1 {
+		return &configError{
+			code: errInvalidServiceTelemetry,
+			msg:  fmt.Sprintf("telemetry traces sampling_ratio %v must be between 0 and 1", ratio),
+		}
+	}
+	if telemetry.Export.MetricsInterval < 0 {
+		return &configError{
+			code: errInvalidServiceTelemetry,
+			msg:  fmt.Sprintf("telemetry export metrics_interval %v must not be negative", telemetry.Export.MetricsInterval),
+		}
+	}
+
+	pipelines := []struct {
+		key      string
+		name     string
+		dataType configmodels.DataType
+	}{
+		{"traces_pipeline", telemetry.Export.TracesPipeline, configmodels.TracesDataType},
+		{"metrics_pipeline", telemetry.Export.MetricsPipeline, configmodels.MetricsDataType},
+	}
+	for _, p := range pipelines {
+		if p.name == "" {
+			continue
+		}
+		pipeline := cfg.Service.Pipelines[p.name]
+		if pipeline == nil {
+			return &configError{
+				code: errInvalidServiceTelemetry,
+				msg:  fmt.Sprintf("telemetry export %s references pipeline %q which does not exist", p.key, p.name),
+			}
+		}
+		if pipeline.InputType != p.dataType {
+			return &configError{
+				code: errInvalidServiceTelemetry,
+				msg:  fmt.Sprintf("telemetry export %s references pipeline %q which is not a %s pipeline", p.key, p.name, p.dataType),
+			}
+		}
+	}
+	return nil
+}
+
 func validateServiceExtensions(cfg *configmodels.Config) error {
 	if len(cfg.Service.Extensions) == 0 {
 		return nil
@@ -553,6 +789,25 @@ func validatePipeline(cfg *configmodels.Config, pipeline *configmodels.Pipeline)
 		return err
 	}
 
+	return validatePipelineFanOut(pipeline)
+}
+
+func validatePipelineFanOut(pipeline *configmodels.Pipeline) error {
+	switch pipeline.FanOut.Policy {
+	case "", configmodels.FanOutBlock, configmodels.FanOutDrop:
+	default:
+		return &configError{
+			code: errInvalidPipelineFanOut,
+			msg: fmt.Sprintf("pipeline %q has unknown fanout policy %q, must be %q or %q",
+				pipeline.Name, pipeline.FanOut.Policy, configmodels.FanOutBlock, configmodels.FanOutDrop),
+		}
+	}
+	if pipeline.FanOut.BufferSize < 0 {
+		return &configError{
+			code: errInvalidPipelineFanOut,
+			msg:  fmt.Sprintf("pipeline %q has negative fanout buffer_size %d", pipeline.Name, pipeline.FanOut.BufferSize),
+		}
+	}
 	return nil
 }
 
@@ -566,8 +821,9 @@ func validatePipelineReceivers(cfg *configmodels.Config, pipeline *configmodels.
 
 	// Validate pipeline receiver name references.
 	for _, ref := range pipeline.Receivers {
-		// Check that the name referenced in the pipeline's receivers exists in the top-level receivers
-		if cfg.Receivers[ref] == nil {
+		// Check that the name referenced in the pipeline's receivers exists in the top-level
+		// receivers or connectors.
+		if cfg.Receivers[ref] == nil && cfg.Connectors[ref] == nil {
 			return &configError{
 				code: errPipelineReceiverNotExists,
 				msg:  fmt.Sprintf("pipeline %q references receiver %q which does not exist", pipeline.Name, ref),
@@ -588,8 +844,9 @@ func validatePipelineExporters(cfg *configmodels.Config, pipeline *configmodels.
 
 	// Validate pipeline exporter name references.
 	for _, ref := range pipeline.Exporters {
-		// Check that the name referenced in the pipeline's Exporters exists in the top-level Exporters
-		if cfg.Exporters[ref] == nil {
+		// Check that the name referenced in the pipeline's Exporters exists in the top-level
+		// Exporters or Connectors.
+		if cfg.Exporters[ref] == nil && cfg.Connectors[ref] == nil {
 			return &configError{
 				code: errPipelineExporterNotExists,
 				msg:  fmt.Sprintf("pipeline %q references exporter %q which does not exist", pipeline.Name, ref),
@@ -619,6 +876,131 @@ func validatePipelineProcessors(cfg *configmodels.Config, pipeline *configmodels
 	return nil
 }
 
+func validateConnectors(cfg *configmodels.Config) error {
+	names := make([]string, 0, len(cfg.Connectors))
+	for name := range cfg.Connectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := validateConnector(cfg, name); err != nil {
+			return err
+		}
+	}
+
+	return validatePipelineCycles(cfg)
+}
+
+// validateConnector checks that the connector does not have the name of a receiver or
+// an exporter, and that it is an exporter of pipelines if it is a receiver of others
+// and vice versa.
+func validateConnector(cfg *configmodels.Config, name string) error {
+	if cfg.Receivers[name] != nil || cfg.Exporters[name] != nil {
+		return &configError{
+			code: errInvalidConnector,
+			msg:  fmt.Sprintf("connector %q has the same name as a receiver or an exporter", name),
+		}
+	}
+
+	exported, received := false, false
+	for _, pipeline := range cfg.Service.Pipelines {
+		exported = exported || containsName(pipeline.Exporters, name)
+		received = received || containsName(pipeline.Receivers, name)
+	}
+	if exported && !received {
+		return &configError{
+			code: errInvalidConnector,
+			msg:  fmt.Sprintf("connector %q is an exporter of pipelines but not a receiver of any pipeline", name),
+		}
+	}
+	if received && !exported {
+		return &configError{
+			code: errInvalidConnector,
+			msg:  fmt.Sprintf("connector %q is a receiver of pipelines but not an exporter of any pipeline", name),
+		}
+	}
+	return nil
+}
+
+// validatePipelineCycles checks that the pipelines connected through connectors do
+// not form a cycle, which would send the data around forever.
+func validatePipelineCycles(cfg *configmodels.Config) error {
+	if len(cfg.Connectors) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.Service.Pipelines))
+	for name := range cfg.Service.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// downstream returns the names of the pipelines receiving data from the pipeline.
+	downstream := func(pipeline *configmodels.Pipeline) []string {
+		var result []string
+		for _, name := range names {
+			for _, exp := range pipeline.Exporters {
+				if cfg.Connectors[exp] != nil && containsName(cfg.Service.Pipelines[name].Receivers, exp) {
+					result = append(result, name)
+					break
+				}
+			}
+		}
+		return result
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(names))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(append([]string(nil), path[indexOfName(path, name):]...), name)
+			return &configError{
+				code: errPipelineCycle,
+				msg:  fmt.Sprintf("pipelines form a cycle through connectors: %s", strings.Join(cycle, " -> ")),
+			}
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, next := range downstream(cfg.Service.Pipelines[name]) {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsName(names []string, name string) bool {
+	return indexOfName(names, name) >= 0
+}
+
+func indexOfName(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
 func validateReceivers(cfg *configmodels.Config) error {
 	// Currently there is no default receiver enabled. The configuration must specify at least one enabled receiver to
 	// be valid.
@@ -642,37 +1024,59 @@ func validateExporters(cfg *configmodels.Config) error {
 	return nil
 }
 
-// expandEnvConfig creates a new viper config with expanded values for all the values (simple, list or map value).
+// expander expands the references to environment variables and config sources in
+// the configuration values.
+type expander struct {
+	// sources resolves references to config sources, nil if only environment variables
+	// are expanded.
+	sources *configsource.Manager
+}
+
+// expandConfig expands the values for all the keys (simple, list or map value).
 // It does not expand the keys.
-func expandEnvConfig(v *viper.Viper) {
+func (e *expander) expandConfig(v *viper.Viper) error {
 	for _, k := range v.AllKeys() {
-		v.Set(k, expandStringValues(v.Get(k)))
+		value, err := e.expandStringValues(v.Get(k))
+		if err != nil {
+			return err
+		}
+		v.Set(k, value)
 	}
+	return nil
 }
 
-func expandStringValues(value interface{}) interface{} {
+func (e *expander) expandStringValues(value interface{}) (interface{}, error) {
 	switch v := value.(type) {
 	default:
-		return v
+		return v, nil
 	case string:
-		return expandEnv(v)
+		return e.expandString(v)
 	case []interface{}:
 		nslice := make([]interface{}, 0, len(v))
 		for _, vint := range v {
-			nslice = append(nslice, expandStringValues(vint))
+			nv, err := e.expandStringValues(vint)
+			if err != nil {
+				return nil, err
+			}
+			nslice = append(nslice, nv)
 		}
-		return nslice
+		return nslice, nil
 	case map[interface{}]interface{}:
 		nmap := make(map[interface{}]interface{}, len(v))
 		for k, vint := range v {
-			nmap[k] = expandStringValues(vint)
+			nv, err := e.expandStringValues(vint)
+			if err != nil {
+				return nil, err
+			}
+			nmap[k] = nv
 		}
-		return nmap
+		return nmap, nil
 	}
 }
 
-func expandEnv(s string) string {
-	return os.Expand(s, func(str string) string {
+func (e *expander) expandString(s string) (string, error) {
+	var err error
+	expanded := os.Expand(s, func(str string) string {
 		// This allows escaping environment variable substitution via $$, e.g.
 		// - $FOO will be substituted with env var FOO
 		// - $$FOO will be replaced with $FOO
@@ -680,8 +1084,19 @@ func expandEnv(s string) string {
 		if str == "$" {
 			return "$"
 		}
+		// ${<source>:<selector>} references a config source.
+		if e.sources != nil {
+			if i := strings.IndexByte(str, ':'); i > 0 {
+				value, rerr := e.sources.Resolve(context.Background(), str[:i], str[i+1:])
+				if rerr != nil && err == nil {
+					err = rerr
+				}
+				return value
+			}
+		}
 		return os.Getenv(str)
 	})
+	return expanded, err
 }
 
 func unmarshaler(factory component.Factory) component.CustomUnmarshaler {
@@ -15,9 +15,11 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configsource"
 )
 
 func TestDecodeConfig(t *testing.T) {
@@ -407,6 +410,79 @@ func TestEscapedEnvVars(t *testing.T) {
 		"Did not load pipeline config correctly")
 }
 
+func TestLoadWithSources(t *testing.T) {
+	assert.NoError(t, os.Setenv("CONFIG_SOURCES_VALUE", "some value"))
+	defer func() {
+		assert.NoError(t, os.Unsetenv("CONFIG_SOURCES_VALUE"))
+	}()
+
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	v := NewViper()
+	v.SetConfigFile(path.Join(".", "testdata", "config-with-sources.yaml"))
+	require.NoError(t, v.ReadInConfig())
+
+	sources := configsource.NewManager(configsource.DefaultSources())
+	defer sources.Close()
+	config, err := LoadWithSources(v, factories, sources)
+	require.NoError(t, err)
+
+	receiver := config.Receivers["examplereceiver"].(*componenttest.ExampleReceiver)
+	assert.Equal(t, "localhost:1234", receiver.Endpoint)
+	assert.Equal(t, "some secret", receiver.ExtraSetting)
+	assert.Equal(t, map[string]string{
+		"recv.1": "some value",
+		"recv.2": "some value",
+		"recv.3": "${file:testdata/sources/secret.txt}",
+	}, receiver.ExtraMapSetting)
+	assert.Equal(t, "Bearer some secret", config.Exporters["exampleexporter"].(*componenttest.ExampleExporter).ExtraSetting)
+
+	// Without sources the references are expanded as environment variables, as before.
+	config, err = Load(v, factories)
+	require.NoError(t, err)
+	assert.Equal(t, "", config.Receivers["examplereceiver"].(*componenttest.ExampleReceiver).ExtraSetting)
+}
+
+func TestLoadWithSources_Invalid(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		fileName string
+		expected string
+	}{
+		{
+			name:     "unknown_source",
+			fileName: "config-with-unknown-source.yaml",
+			expected: `error expanding values of receivers "examplereceiver": unknown config source "vault"`,
+		},
+		{
+			name:     "env_default_without_source",
+			fileName: "config-with-env-default-without-source.yaml",
+			expected: `error expanding values of receivers "examplereceiver": unknown config source "HOST", use ${env:HOST:-localhost} for an environment variable with a default value`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			v := NewViper()
+			v.SetConfigFile(path.Join(".", "testdata", test.fileName))
+			require.NoError(t, v.ReadInConfig())
+
+			sources := configsource.NewManager(configsource.DefaultSources())
+			defer sources.Close()
+			_, err := LoadWithSources(v, factories, sources)
+			require.Error(t, err)
+			var cfgErr *configError
+			require.True(t, errors.As(err, &cfgErr))
+			assert.Equal(t, errExpandConfigValues, cfgErr.code)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
 func TestDecodeConfig_MultiProto(t *testing.T) {
 	factories, err := componenttest.ExampleComponents()
 	assert.NoError(t, err)
@@ -510,6 +586,14 @@ func TestDecodeConfig_Invalid(t *testing.T) {
 		{name: "invalid-processor-sub-config", expected: errUnmarshalTopLevelStructureError},
 		{name: "invalid-receiver-sub-config", expected: errUnmarshalTopLevelStructureError},
 		{name: "invalid-pipeline-sub-config", expected: errUnmarshalTopLevelStructureError},
+		{name: "invalid-pipeline-fanout", expected: errInvalidPipelineFanOut, expectedMessage: "discard"},
+		{name: "connector-not-received", expected: errInvalidConnector, expectedMessage: "not a receiver"},
+		{name: "pipeline-connectors-cycle", expected: errPipelineCycle, expectedMessage: "traces/a -> traces/b -> traces/a"},
+		{name: "invalid-service-telemetry-sampling", expected: errInvalidServiceTelemetry, expectedMessage: "sampling_ratio"},
+		{name: "invalid-service-telemetry-pipeline", expected: errInvalidServiceTelemetry, expectedMessage: "not a traces pipeline"},
+		{name: "invalid-service-telemetry-logs-level", expected: errInvalidServiceTelemetry, expectedMessage: "logs level"},
+		{name: "invalid-service-telemetry-logs-encoding", expected: errInvalidServiceTelemetry, expectedMessage: "json or console"},
+		{name: "invalid-service-telemetry-metrics-level", expected: errInvalidServiceTelemetry, expectedMessage: "unknown metrics level"},
 	}
 
 	factories, err := componenttest.ExampleComponents()
@@ -521,8 +605,8 @@ func TestDecodeConfig_Invalid(t *testing.T) {
 			if err == nil {
 				t.Error("expected error but succeeded")
 			} else if test.expected != 0 {
-				cfgErr, ok := err.(*configError)
-				if !ok {
+				var cfgErr *configError
+				if !errors.As(err, &cfgErr) {
 					t.Errorf("expected config error code %v but got a different error '%v'", test.expected, err)
 				} else {
 					assert.Equal(t, test.expected, cfgErr.code, err)
@@ -536,6 +620,72 @@ func TestDecodeConfig_Invalid(t *testing.T) {
 	}
 }
 
+func TestDecodeConfig_PipelineFanOut(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	cfg, err := loadConfigFile(t, path.Join(".", "testdata", "pipeline-fanout.yaml"), factories)
+	require.NoError(t, err)
+
+	assert.Equal(t, configmodels.FanOutSettings{
+		Concurrent: true,
+		BufferSize: 50,
+		Policy:     configmodels.FanOutDrop,
+	}, cfg.Service.Pipelines["traces"].FanOut)
+	assert.Equal(t, configmodels.FanOutSettings{}, cfg.Service.Pipelines["metrics"].FanOut)
+}
+
+func TestDecodeConfig_PipelineConnectors(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	cfg, err := loadConfigFile(t, path.Join(".", "testdata", "pipeline-connectors.yaml"), factories)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, len(cfg.Connectors))
+	assert.Equal(t, &componenttest.ExampleConnectorCfg{
+		ConnectorSettings: configmodels.ConnectorSettings{
+			NameVal: "exampleconnector",
+			TypeVal: "exampleconnector",
+		},
+		ExtraSetting: "some connector string",
+	}, cfg.Connectors["exampleconnector"])
+	assert.Equal(t, []string{"exampleconnector"}, cfg.Service.Pipelines["traces/in"].Exporters)
+	assert.Equal(t, []string{"exampleconnector"}, cfg.Service.Pipelines["metrics/out"].Receivers)
+}
+
+func TestDecodeConfig_ServiceTelemetry(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	cfg, err := loadConfigFile(t, path.Join(".", "testdata", "service-telemetry.yaml"), factories)
+	require.NoError(t, err)
+
+	assert.Equal(t, configmodels.ServiceTelemetry{
+		Logs: configmodels.LogsTelemetry{
+			Level:            "debug",
+			Encoding:         "json",
+			Sampling:         &configmodels.LogsSampling{Initial: 10, Thereafter: 50},
+			OutputPaths:      []string{"stdout", "/var/log/otelcol.log"},
+			ErrorOutputPaths: []string{"stderr"},
+		},
+		Metrics: configmodels.MetricsTelemetry{
+			Address:        "localhost:9999",
+			Level:          "detailed",
+			ResourceLabels: map[string]string{"cluster": "eu-1"},
+		},
+		Traces: configmodels.TracesTelemetry{SamplingRatio: 0.25},
+		Export: configmodels.TelemetryExport{
+			Endpoint:        "collector:55680",
+			Insecure:        true,
+			Headers:         map[string]string{"tenant": "tier1"},
+			TracesPipeline:  "traces/self",
+			MetricsPipeline: "metrics/self",
+			MetricsInterval: 30 * time.Second,
+		},
+	}, cfg.Service.Telemetry)
+}
+
 func TestLoadEmptyConfig(t *testing.T) {
 	factories, err := componenttest.ExampleComponents()
 	assert.NoError(t, err)
@@ -558,6 +708,39 @@ func TestLoadEmptyConfig(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// validatingConfig is a component configuration that fails validation with err.
+type validatingConfig struct {
+	configmodels.ReceiverSettings
+	err error
+}
+
+func (cfg *validatingConfig) Validate() error {
+	return cfg.err
+}
+
+func TestValidateConfig_Components(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := loadConfigFile(t, path.Join(".", "testdata", "valid-config.yaml"), factories)
+	require.NoError(t, err)
+
+	cfg.Receivers["valid"] = &validatingConfig{}
+	assert.NoError(t, ValidateConfig(cfg, zap.NewNop()))
+
+	cfg.Processors["invalid/2"] = &validatingConfig{err: errors.New("second error")}
+	cfg.Receivers["invalid/1"] = &validatingConfig{err: errors.New("first error")}
+	err = ValidateConfig(cfg, zap.NewNop())
+	require.Error(t, err)
+	assert.Equal(t, errInvalidComponentConfig, err.(*configError).code)
+	assert.Equal(t, `[invalid configuration of receivers "invalid/1": first error; `+
+		`invalid configuration of processors "invalid/2": second error]`, err.Error())
+
+	errs := validateComponents(cfg)
+	require.Len(t, errs, 2)
+	assert.Equal(t, []string{"receivers", "invalid/1"}, errs[0].(*KeyError).Key)
+	assert.Equal(t, []string{"processors", "invalid/2"}, errs[1].(*KeyError).Key)
+}
+
 func loadConfigFile(t *testing.T, fileName string, factories component.Factories) (*configmodels.Config, error) {
 	// Read yaml config from file
 	v := NewViper()
@@ -14,9 +14,13 @@
 
 // Package configmodels defines the data models for entities. This file defines the
 // models for configuration format. The defined entities are:
-// Config (the top-level structure), Receivers, Exporters, Processors, Pipelines.
+// Config (the top-level structure), Receivers, Exporters, Processors, Connectors, Pipelines.
 package configmodels
 
+import (
+	"time"
+)
+
 /*
 Receivers, Exporters and Processors typically have common configuration settings, however
 sometimes specific implementations will have extra configuration settings.
@@ -36,6 +40,7 @@ type Config struct {
 	Receivers
 	Exporters
 	Processors
+	Connectors
 	Extensions
 	Service
 }
@@ -50,6 +55,14 @@ type NamedEntity interface {
 	SetName(name string)
 }
 
+// Validator is implemented by the configurations of the components that validate their
+// settings. The configurations are validated by config.ValidateConfig after they are loaded,
+// so that invalid settings are reported before the components are created.
+type Validator interface {
+	// Validate returns an error if the configuration is not valid.
+	Validate() error
+}
+
 // Receiver is the configuration of a receiver. Specific receivers must implement this
 // interface and will typically embed ReceiverSettings struct or a struct that extends it.
 type Receiver interface {
@@ -76,6 +89,17 @@ type Processor interface {
 // Processors is a map of names to Processors.
 type Processors map[string]Processor
 
+// Connector is the configuration of a connector. A connector is an exporter in
+// some pipelines and a receiver in others, it sends the data exported by the former
+// to the latter. Specific connectors must implement this interface and will
+// typically embed ConnectorSettings struct or a struct that extends it.
+type Connector interface {
+	NamedEntity
+}
+
+// Connectors is a map of names to Connectors.
+type Connectors map[string]Connector
+
 // DataType is the data type that is supported for collection. We currently support
 // collecting metrics, traces and logs, this can expand in the future.
 
@@ -95,11 +119,40 @@ const (
 
 // Pipeline defines a single pipeline.
 type Pipeline struct {
-	Name       string   `mapstructure:"-"`
-	InputType  DataType `mapstructure:"-"`
-	Receivers  []string `mapstructure:"receivers"`
-	Processors []string `mapstructure:"processors"`
-	Exporters  []string `mapstructure:"exporters"`
+	Name       string         `mapstructure:"-"`
+	InputType  DataType       `mapstructure:"-"`
+	Receivers  []string       `mapstructure:"receivers"`
+	Processors []string       `mapstructure:"processors"`
+	Exporters  []string       `mapstructure:"exporters"`
+	FanOut     FanOutSettings `mapstructure:"fanout"`
+}
+
+// FanOutPolicy is what a concurrent fan-out does with the data for an exporter
+// whose buffer is full.
+type FanOutPolicy string
+
+const (
+	// FanOutBlock waits until there is room in the buffer of the exporter, or until the
+	// context of the data is done. This is the default policy.
+	FanOutBlock FanOutPolicy = "block"
+
+	// FanOutDrop drops the data for the exporter.
+	FanOutDrop FanOutPolicy = "drop"
+)
+
+// FanOutSettings defines how a pipeline sends the data to its exporters.
+type FanOutSettings struct {
+	// Concurrent sends the data to each exporter from its own goroutine, through a
+	// bounded buffer, so that a slow exporter does not delay the others. By default
+	// the exporters are called one after the other.
+	Concurrent bool `mapstructure:"concurrent"`
+
+	// BufferSize is the number of batches buffered for each exporter when Concurrent
+	// is set. Zero means the default size.
+	BufferSize int `mapstructure:"buffer_size"`
+
+	// Policy is what is done with the data for an exporter whose buffer is full.
+	Policy FanOutPolicy `mapstructure:"policy"`
 }
 
 // Pipelines is a map of names to Pipelines.
@@ -122,6 +175,97 @@ type Service struct {
 
 	// Pipelines is the set of data pipelines configured for the service.
 	Pipelines Pipelines `mapstructure:"pipelines"`
+
+	// Telemetry is the configuration of the collector's own telemetry.
+	Telemetry ServiceTelemetry `mapstructure:"telemetry"`
+}
+
+// ServiceTelemetry defines the configuration of the collector's own telemetry.
+type ServiceTelemetry struct {
+	// Logs defines the collector's own logs, it overrides the --log-* flags.
+	Logs LogsTelemetry `mapstructure:"logs"`
+
+	// Metrics defines the collector's own metrics, it overrides the --metrics-* flags.
+	Metrics MetricsTelemetry `mapstructure:"metrics"`
+
+	// Traces defines how the operations of the pipelines are traced.
+	Traces TracesTelemetry `mapstructure:"traces"`
+
+	// Export defines where the collector's own spans and metrics are sent.
+	Export TelemetryExport `mapstructure:"export"`
+}
+
+// LogsTelemetry defines the collector's own logs. The settings left empty keep the
+// values of the command line flags.
+type LogsTelemetry struct {
+	// Level is the minimum level of the logs (DEBUG, INFO, WARN, ERROR, DPANIC, PANIC, FATAL).
+	Level string `mapstructure:"level"`
+
+	// Encoding is the format of the logs (json, console).
+	Encoding string `mapstructure:"encoding"`
+
+	// Sampling limits the number of logs with the same level and message, it is
+	// disabled in development builds unless set.
+	Sampling *LogsSampling `mapstructure:"sampling"`
+
+	// OutputPaths are the files or URLs the logs are written to, "stderr" by default.
+	OutputPaths []string `mapstructure:"output_paths"`
+
+	// ErrorOutputPaths are the files or URLs the errors of the logger itself are
+	// written to, "stderr" by default.
+	ErrorOutputPaths []string `mapstructure:"error_output_paths"`
+}
+
+// LogsSampling limits the number of logs with the same level and message each second:
+// the first Initial ones are written, then every Thereafter-th one.
+type LogsSampling struct {
+	Initial    int `mapstructure:"initial"`
+	Thereafter int `mapstructure:"thereafter"`
+}
+
+// MetricsTelemetry defines the collector's own metrics. The settings left empty keep
+// the values of the command line flags.
+type MetricsTelemetry struct {
+	// Address is the [address]:port the Prometheus metrics are exposed on.
+	Address string `mapstructure:"address"`
+
+	// Level is the level of the metrics (none, basic, normal, detailed).
+	Level string `mapstructure:"level"`
+
+	// ResourceLabels are added to all the metrics, as Prometheus labels and as
+	// resource attributes of the exported spans and metrics.
+	ResourceLabels map[string]string `mapstructure:"resource_labels"`
+}
+
+// TracesTelemetry defines how the collector traces the operations of its pipelines.
+type TracesTelemetry struct {
+	// SamplingRatio is the fraction of the operations that are traced, between 0 and 1.
+	// Zero keeps the default ratio of 1 in 10000.
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+}
+
+// TelemetryExport defines where the collector's own spans and metrics are sent, in
+// addition to zPages and to the Prometheus endpoint. Nothing is sent if neither an
+// endpoint nor a pipeline is set.
+type TelemetryExport struct {
+	// Endpoint is the address of an OTLP/gRPC endpoint the spans and metrics are sent to.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Insecure disables the transport security of the connection to Endpoint.
+	Insecure bool `mapstructure:"insecure"`
+
+	// Headers are sent with every request to Endpoint.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// TracesPipeline is the name of a traces pipeline of the collector the spans are sent to.
+	TracesPipeline string `mapstructure:"traces_pipeline"`
+
+	// MetricsPipeline is the name of a metrics pipeline of the collector the metrics are sent to.
+	MetricsPipeline string `mapstructure:"metrics_pipeline"`
+
+	// MetricsInterval is the interval at which the metrics are sent. Zero means the
+	// default interval of 10s.
+	MetricsInterval time.Duration `mapstructure:"metrics_interval"`
 }
 
 // Below are common setting structs for Receivers, Exporters and Processors.
@@ -198,6 +342,30 @@ func (proc *ProcessorSettings) Type() Type {
 
 var _ Processor = (*ProcessorSettings)(nil)
 
+// ConnectorSettings defines common settings for a connector configuration.
+// Specific connectors can embed this struct and extend it with more fields if needed.
+type ConnectorSettings struct {
+	TypeVal Type   `mapstructure:"-"`
+	NameVal string `mapstructure:"-"`
+}
+
+// Name gets the connector name.
+func (cs *ConnectorSettings) Name() string {
+	return cs.NameVal
+}
+
+// SetName sets the connector name.
+func (cs *ConnectorSettings) SetName(name string) {
+	cs.NameVal = name
+}
+
+// Type sets the connector type.
+func (cs *ConnectorSettings) Type() Type {
+	return cs.TypeVal
+}
+
+var _ Connector = (*ConnectorSettings)(nil)
+
 // ExtensionSettings defines common settings for a service extension configuration.
 // Specific extensions can embed this struct and extend it with more fields if needed.
 type ExtensionSettings struct {
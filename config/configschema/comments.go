@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema
+
+import (
+	"reflect"
+)
+
+// fieldDescription returns the doc comment of the field of the struct type t, or an
+// empty string if the type is not a configuration of the collector module.
+func fieldDescription(t reflect.Type, field string) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return ""
+	}
+	return fieldDescriptions[t.PkgPath()+"."+t.Name()+"."+field]
+}
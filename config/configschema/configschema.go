@@ -0,0 +1,374 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configschema generates JSON Schemas for the configuration of the collector
+// from the default configuration of the component factories, so that the configuration
+// files can be validated and autocompleted by editors and other tools.
+package configschema
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// draft07 is the JSON Schema version of the generated schemas.
+const draft07 = "http://json-schema.org/draft-07/schema#"
+
+// durationPattern matches the durations accepted in the configuration, see time.ParseDuration.
+const durationPattern = `^[-+]?([0-9]*(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$|^0$`
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Schema is a JSON Schema.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Type is either a single type name or a list of them.
+	Type    interface{} `json:"type,omitempty"`
+	Pattern string      `json:"pattern,omitempty"`
+	Default interface{} `json:"default,omitempty"`
+
+	Properties        map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties map[string]*Schema `json:"patternProperties,omitempty"`
+	// AdditionalProperties is either a boolean or a *Schema.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+	Items                *Schema     `json:"items,omitempty"`
+}
+
+// Generate returns the JSON Schema of the collector configuration using the components
+// of the given factories. The descriptions of the settings are the doc comments of the
+// configuration struct fields, they are only available for the components of the collector
+// module.
+func Generate(factories component.Factories) *Schema {
+	g := newGenerator()
+
+	var receivers, processors, exporters, connectors, extensions []component.Factory
+	for _, f := range factories.Receivers {
+		receivers = append(receivers, f)
+	}
+	for _, f := range factories.Processors {
+		processors = append(processors, f)
+	}
+	for _, f := range factories.Exporters {
+		exporters = append(exporters, f)
+	}
+	for _, f := range factories.Connectors {
+		connectors = append(connectors, f)
+	}
+	for _, f := range factories.Extensions {
+		extensions = append(extensions, f)
+	}
+
+	return &Schema{
+		Schema:               draft07,
+		Title:                "OpenTelemetry Collector configuration",
+		Type:                 "object",
+		AdditionalProperties: false,
+		Properties: map[string]*Schema{
+			"receivers":  g.componentsSchema("The receivers, keyed by type[/name].", receivers),
+			"processors": g.componentsSchema("The processors, keyed by type[/name].", processors),
+			"exporters":  g.componentsSchema("The exporters, keyed by type[/name].", exporters),
+			"connectors": g.componentsSchema("The connectors, keyed by type[/name].", connectors),
+			"extensions": g.componentsSchema("The extensions, keyed by type[/name].", extensions),
+			"service":    serviceSchema(),
+		},
+	}
+}
+
+// ComponentSchema returns the JSON Schema of a component configuration. cfg is the
+// default configuration of the component, its values are used as defaults.
+func ComponentSchema(cfg interface{}) *Schema {
+	return newGenerator().componentSchema(cfg)
+}
+
+type generator struct {
+	// visiting are the struct types being generated, to stop on recursive types.
+	visiting map[reflect.Type]bool
+}
+
+func newGenerator() *generator {
+	return &generator{
+		visiting: make(map[reflect.Type]bool),
+	}
+}
+
+// componentsSchema returns the schema of a section with the configuration of the given components.
+func (g *generator) componentsSchema(description string, factories []component.Factory) *Schema {
+	sort.Slice(factories, func(i, j int) bool { return factories[i].Type() < factories[j].Type() })
+
+	s := &Schema{
+		Description:          description,
+		Type:                 []string{"object", "null"},
+		PatternProperties:    make(map[string]*Schema, len(factories)),
+		AdditionalProperties: false,
+	}
+	for _, f := range factories {
+		cs := g.componentSchema(createDefaultConfig(f))
+		if hasCustomUnmarshaler(f) {
+			// The settings can be different from the fields of the configuration struct.
+			cs.AdditionalProperties = true
+		}
+		s.PatternProperties[typeNamePattern(string(f.Type()))] = cs
+	}
+	return s
+}
+
+// deprecatedUnmarshaler is the deprecated way for factories to provide a custom unmarshaler.
+type deprecatedUnmarshaler interface {
+	CustomUnmarshaler() component.CustomUnmarshaler
+}
+
+func hasCustomUnmarshaler(f component.Factory) bool {
+	if _, ok := f.(component.ConfigUnmarshaler); ok {
+		return true
+	}
+	if du, ok := f.(deprecatedUnmarshaler); ok {
+		return du.CustomUnmarshaler() != nil
+	}
+	return false
+}
+
+func (g *generator) componentSchema(cfg interface{}) *Schema {
+	return g.schemaFor(reflect.TypeOf(cfg), reflect.ValueOf(cfg))
+}
+
+// schemaFor returns the schema of the type t, v is the default value, it may be invalid.
+func (g *generator) schemaFor(t reflect.Type, v reflect.Value) *Schema {
+	if t == durationType {
+		s := &Schema{Type: "string", Pattern: durationPattern}
+		if v.IsValid() && v.Int() != 0 {
+			s.Default = time.Duration(v.Int()).String()
+		}
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if v.IsValid() && !v.IsNil() {
+			return g.schemaFor(t.Elem(), v.Elem())
+		}
+		return g.schemaFor(t.Elem(), reflect.Value{})
+
+	case reflect.Struct:
+		if g.visiting[t] {
+			return &Schema{}
+		}
+		g.visiting[t] = true
+		defer delete(g.visiting, t)
+
+		// Objects can be null in YAML, e.g. "grpc:" enables the protocol with the defaults.
+		s := &Schema{
+			Type:                 []string{"object", "null"},
+			Properties:           make(map[string]*Schema),
+			AdditionalProperties: false,
+		}
+		g.addFields(s, t, v)
+		return s
+
+	case reflect.Slice, reflect.Array:
+		s := &Schema{Type: "array", Items: g.schemaFor(t.Elem(), reflect.Value{})}
+		if v.IsValid() && v.Len() != 0 && isScalar(t.Elem()) {
+			values := make([]interface{}, v.Len())
+			for i := range values {
+				values[i] = v.Index(i).Interface()
+			}
+			s.Default = values
+		}
+		return s
+
+	case reflect.Map:
+		s := &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem(), reflect.Value{})}
+		if v.IsValid() && v.Len() != 0 && t.Key().Kind() == reflect.String && isScalar(t.Elem()) {
+			values := make(map[string]interface{}, v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				values[iter.Key().String()] = iter.Value().Interface()
+			}
+			s.Default = values
+		}
+		return s
+
+	case reflect.Interface:
+		// Any value.
+		return &Schema{}
+	}
+
+	s := &Schema{Type: scalarType(t)}
+	if v.IsValid() && !v.IsZero() {
+		s.Default = v.Interface()
+	}
+	return s
+}
+
+// addFields adds the properties for the fields of the struct type t to s, following the
+// same rules as mapstructure when decoding the configuration.
+func (g *generator) addFields(s *Schema, t reflect.Type, v reflect.Value) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			// Not exported.
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Uintptr, reflect.UnsafePointer:
+			continue
+		}
+
+		tagParts := strings.Split(f.Tag.Get("mapstructure"), ",")
+		name := tagParts[0]
+		if name == "-" {
+			continue
+		}
+
+		var fv reflect.Value
+		if v.IsValid() {
+			fv = v.Field(i)
+		}
+
+		if hasTagOption(tagParts[1:], "squash") {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+				if fv.IsValid() {
+					if fv.IsNil() {
+						fv = reflect.Value{}
+					} else {
+						fv = fv.Elem()
+					}
+				}
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft, fv)
+			}
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fs := g.schemaFor(f.Type, fv)
+		if description := fieldDescription(t, f.Name); description != "" {
+			fs.Description = description
+		}
+		s.Properties[name] = fs
+	}
+}
+
+func hasTagOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+func isScalar(t reflect.Type) bool {
+	return t != durationType && scalarType(t) != ""
+}
+
+func scalarType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return ""
+}
+
+// typeNamePattern returns the pattern of the keys of components of the given type.
+func typeNamePattern(typeStr string) string {
+	return "^" + regexp.QuoteMeta(typeStr) + "(/.+)?$"
+}
+
+func createDefaultConfig(f component.Factory) interface{} {
+	switch factory := f.(type) {
+	case component.ReceiverFactory:
+		return factory.CreateDefaultConfig()
+	case component.ProcessorFactory:
+		return factory.CreateDefaultConfig()
+	case component.ExporterFactory:
+		return factory.CreateDefaultConfig()
+	case component.ExtensionFactory:
+		return factory.CreateDefaultConfig()
+	case component.ConnectorFactory:
+		return factory.CreateDefaultConfig()
+	}
+	return nil
+}
+
+func serviceSchema() *Schema {
+	names := &Schema{Type: "array", Items: &Schema{Type: "string"}}
+	pipelineTypes := []string{
+		string(configmodels.TracesDataType),
+		string(configmodels.MetricsDataType),
+		string(configmodels.LogsDataType),
+	}
+	return &Schema{
+		Description:          "The extensions and pipelines enabled in the collector, and its own telemetry.",
+		Type:                 "object",
+		AdditionalProperties: false,
+		Properties: map[string]*Schema{
+			"extensions": {
+				Description: "The extensions to enable.",
+				Type:        "array",
+				Items:       &Schema{Type: "string"},
+			},
+			"pipelines": {
+				Description: "The pipelines, keyed by data type[/name].",
+				Type:        "object",
+				PatternProperties: map[string]*Schema{
+					"^(" + strings.Join(pipelineTypes, "|") + ")(/.+)?$": {
+						Type:                 "object",
+						AdditionalProperties: false,
+						Properties: map[string]*Schema{
+							"receivers":  names,
+							"processors": names,
+							"exporters":  names,
+							"fanout":     fanOutSchema(),
+						},
+					},
+				},
+				AdditionalProperties: false,
+			},
+			"telemetry": telemetrySchema(),
+		},
+	}
+}
+
+func telemetrySchema() *Schema {
+	s := ComponentSchema(configmodels.ServiceTelemetry{})
+	s.Description = "The collector's own logs, metrics and traces."
+	return s
+}
+
+func fanOutSchema() *Schema {
+	s := ComponentSchema(configmodels.FanOutSettings{})
+	s.Description = "How the data is sent to the exporters of the pipeline."
+	s.Properties["policy"].Pattern = "^(" + string(configmodels.FanOutBlock) + "|" + string(configmodels.FanOutDrop) + ")$"
+	return s
+}
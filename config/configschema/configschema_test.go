@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+type testServerSettings struct {
+	// Endpoint to listen on.
+	Endpoint string `mapstructure:"endpoint"`
+	// Timeout of the requests.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type testNested struct {
+	Enabled bool        `mapstructure:"enabled"` // Enabled turns the feature on.
+	Nested  *testNested `mapstructure:"nested"`
+}
+
+type testConfig struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+	testServerSettings            `mapstructure:",squash"`
+
+	// Headers to add to the requests.
+	Headers map[string]string `mapstructure:"headers"`
+	// Ratio of the data to keep.
+	Ratio      float64      `mapstructure:"ratio"`
+	Tags       []string     `mapstructure:"tags"`
+	Feature    *testNested  `mapstructure:"feature"`
+	Items      []testNested `mapstructure:"items"`
+	Any        interface{}  `mapstructure:"any"`
+	NoTag      int
+	Ignored    string `mapstructure:"-"`
+	unexported string
+}
+
+// setTestDescriptions adds the descriptions of the test types, which are not generated.
+func setTestDescriptions(t *testing.T) {
+	pkgPath := reflect.TypeOf(testConfig{}).PkgPath()
+	descriptions := map[string]string{
+		"testServerSettings.Endpoint": "Endpoint to listen on.",
+		"testServerSettings.Timeout":  "Timeout of the requests.",
+		"testNested.Enabled":          "Enabled turns the feature on.",
+		"testConfig.Headers":          "Headers to add to the requests.",
+		"testConfig.Ratio":            "Ratio of the data to keep.",
+	}
+	for key, description := range descriptions {
+		fieldDescriptions[pkgPath+"."+key] = description
+	}
+	t.Cleanup(func() {
+		for key := range descriptions {
+			delete(fieldDescriptions, pkgPath+"."+key)
+		}
+	})
+}
+
+func TestComponentSchema(t *testing.T) {
+	setTestDescriptions(t)
+	cfg := &testConfig{
+		ReceiverSettings:   configmodels.ReceiverSettings{TypeVal: "test", NameVal: "test"},
+		testServerSettings: testServerSettings{Endpoint: "localhost:1234", Timeout: 5 * time.Second},
+		Headers:            map[string]string{"x-tenant": "default"},
+		Tags:               []string{"a", "b"},
+		Feature:            &testNested{Enabled: true},
+	}
+
+	object := []string{"object", "null"}
+	nested := &Schema{
+		Type: object,
+		Properties: map[string]*Schema{
+			"enabled": {Type: "boolean", Description: "Enabled turns the feature on."},
+			// Recursive types are not expanded.
+			"nested": {},
+		},
+		AdditionalProperties: false,
+	}
+	feature := *nested
+	feature.Properties = map[string]*Schema{
+		"enabled": {Type: "boolean", Description: "Enabled turns the feature on.", Default: true},
+		"nested":  {},
+	}
+
+	assert.Equal(t, &Schema{
+		Type: object,
+		Properties: map[string]*Schema{
+			"endpoint": {Type: "string", Description: "Endpoint to listen on.", Default: "localhost:1234"},
+			"timeout":  {Type: "string", Pattern: durationPattern, Description: "Timeout of the requests.", Default: "5s"},
+			"headers": {
+				Type:                 "object",
+				Description:          "Headers to add to the requests.",
+				AdditionalProperties: &Schema{Type: "string"},
+				Default:              map[string]interface{}{"x-tenant": "default"},
+			},
+			"ratio":   {Type: "number", Description: "Ratio of the data to keep."},
+			"tags":    {Type: "array", Items: &Schema{Type: "string"}, Default: []interface{}{"a", "b"}},
+			"feature": &feature,
+			"items":   {Type: "array", Items: nested},
+			"any":     {},
+			"notag":   {Type: "integer"},
+		},
+		AdditionalProperties: false,
+	}, ComponentSchema(cfg))
+}
+
+func TestGenerate(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	schema := Generate(factories)
+	assert.Equal(t, draft07, schema.Schema)
+	for _, section := range []string{"receivers", "processors", "exporters", "connectors", "extensions", "service"} {
+		assert.Contains(t, schema.Properties, section)
+	}
+
+	receivers := schema.Properties["receivers"]
+	require.Contains(t, receivers.PatternProperties, "^examplereceiver(/.+)?$")
+	assert.Contains(t, receivers.PatternProperties, "^multireceiver(/.+)?$")
+	receiver := receivers.PatternProperties["^examplereceiver(/.+)?$"]
+	// The description is generated from the doc comment of confignet.TCPAddr.
+	assert.Equal(t, &Schema{Type: "string", Default: "localhost:1000", Description: fieldDescriptions["go.opentelemetry.io/collector/config/confignet.TCPAddr.Endpoint"]},
+		receiver.Properties["endpoint"])
+	assert.NotEmpty(t, receiver.Properties["endpoint"].Description)
+	assert.Equal(t, &Schema{Type: "string", Default: "some string"}, receiver.Properties["extra"])
+
+	// The schema can be serialized.
+	_, err = json.Marshal(schema)
+	require.NoError(t, err)
+}
+
+func TestGenerateServiceTelemetry(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	schema := Generate(factories)
+
+	telemetry := schema.Properties["service"].Properties["telemetry"]
+	require.NotNil(t, telemetry)
+	assert.Equal(t, fieldDescriptions["go.opentelemetry.io/collector/config/configmodels.ServiceTelemetry.Logs"],
+		telemetry.Properties["logs"].Description)
+
+	content, err := ioutil.ReadFile(path.Join("testdata", "service-telemetry.yaml"))
+	require.NoError(t, err)
+	var cfg map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(content, &cfg))
+	assertAllowed(t, schema, cfg, "")
+}
+
+// assertAllowed checks that all the properties of value are allowed by s, the types
+// of the values are not checked.
+func assertAllowed(t *testing.T, s *Schema, value interface{}, keyPath string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			itemPath := keyPath + "::" + key
+			is := propertySchema(s, key)
+			if assert.NotNil(t, is, "%s is not allowed by the schema", itemPath) {
+				assertAllowed(t, is, item, itemPath)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				assertAllowed(t, s.Items, item, fmt.Sprintf("%s[%d]", keyPath, i))
+			}
+		}
+	}
+}
+
+func propertySchema(s *Schema, key string) *Schema {
+	if ps, ok := s.Properties[key]; ok {
+		return ps
+	}
+	for pattern, ps := range s.PatternProperties {
+		if regexp.MustCompile(pattern).MatchString(key) {
+			return ps
+		}
+	}
+	switch additional := s.AdditionalProperties.(type) {
+	case *Schema:
+		return additional
+	case bool:
+		if !additional {
+			return nil
+		}
+	}
+	return &Schema{}
+}
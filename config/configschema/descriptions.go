@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by configschemagen. DO NOT EDIT.
+
+package configschema
+
+// fieldDescriptions are the doc comments of the configuration struct fields of the
+// collector module, keyed by "<package path>.<type>.<field>".
+var fieldDescriptions = map[string]string{
+	"go.opentelemetry.io/collector/config/configauth.Authentication.Attribute":                                                "The attribute (header name) to look for auth data. Optional, default value: \"authentication\".",
+	"go.opentelemetry.io/collector/config/configauth.Authentication.OIDC":                                                     "OIDC configures this receiver to use the given OIDC provider as the backend for the authentication mechanism. Required.",
+	"go.opentelemetry.io/collector/config/configauth.OIDC.Audience":                                                           "Audience of the token, used during the verification. For example: \"https://accounts.google.com\" or \"https://login.salesforce.com\". Required.",
+	"go.opentelemetry.io/collector/config/configauth.OIDC.GroupsClaim":                                                        "The claim that holds the subject's group membership information. Optional.",
+	"go.opentelemetry.io/collector/config/configauth.OIDC.IssuerCAPath":                                                       "The local path for the issuer CA's TLS server cert. Optional.",
+	"go.opentelemetry.io/collector/config/configauth.OIDC.IssuerURL":                                                          "IssuerURL is the base URL for the OIDC provider. Required.",
+	"go.opentelemetry.io/collector/config/configauth.OIDC.UsernameClaim":                                                      "The claim to use as the username, in case the token's 'sub' isn't the suitable source. Optional.",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCClientSettings.BalancerName":                                         "Sets the balancer in grpclb_policy to discover the servers. Default is pick_first https://github.com/grpc/grpc-go/blob/master/examples/features/load_balancing/README.md",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCClientSettings.Compression":                                          "The compression key for supported compression types within collector. Currently the only supported mode is `gzip`.",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCClientSettings.Endpoint":                                             "The target to which the exporter is going to send traces or metrics, using the gRPC protocol. The valid syntax is described at https://github.com/grpc/grpc/blob/master/doc/naming.md.",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCClientSettings.Headers":                                              "The headers associated with gRPC requests.",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCClientSettings.Keepalive":                                            "The keepalive parameters for gRPC client. See grpc.WithKeepaliveParams (https://godoc.org/google.golang.org/grpc#WithKeepaliveParams).",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCClientSettings.PerRPCAuth":                                           "PerRPCAuth parameter configures the client to send authentication data on a per-RPC basis.",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCClientSettings.ReadBufferSize":                                       "ReadBufferSize for gRPC client. See grpc.WithReadBufferSize (https://godoc.org/google.golang.org/grpc#WithReadBufferSize).",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCClientSettings.WaitForReady":                                         "WaitForReady parameter configures client to wait for ready state before sending data. (https://github.com/grpc/grpc/blob/master/doc/wait-for-ready.md)",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCClientSettings.WriteBufferSize":                                      "WriteBufferSize for gRPC gRPC. See grpc.WithWriteBufferSize (https://godoc.org/google.golang.org/grpc#WithWriteBufferSize).",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCServerSettings.Auth":                                                 "Auth for this receiver",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCServerSettings.Keepalive":                                            "Keepalive anchor for all the settings related to keepalive.",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCServerSettings.MaxConcurrentStreams":                                 "MaxConcurrentStreams sets the limit on the number of concurrent streams to each ServerTransport. It has effect only for streaming RPCs.",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCServerSettings.MaxRecvMsgSizeMiB":                                    "MaxRecvMsgSizeMiB sets the maximum size (in MiB) of messages accepted by the server.",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCServerSettings.ReadBufferSize":                                       "ReadBufferSize for gRPC server. See grpc.ReadBufferSize (https://godoc.org/google.golang.org/grpc#ReadBufferSize).",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCServerSettings.TLSSetting":                                           "Configures the protocol to use TLS. The default value is nil, which will cause the protocol to not use TLS.",
+	"go.opentelemetry.io/collector/config/configgrpc.GRPCServerSettings.WriteBufferSize":                                      "WriteBufferSize for gRPC server. See grpc.WriteBufferSize (https://godoc.org/google.golang.org/grpc#WriteBufferSize).",
+	"go.opentelemetry.io/collector/config/configgrpc.PerRPCAuthConfig.AuthType":                                               "AuthType represents the authentication type to use. Currently, only 'bearer' is supported.",
+	"go.opentelemetry.io/collector/config/configgrpc.PerRPCAuthConfig.BearerToken":                                            "BearerToken specifies the bearer token to use for every RPC.",
+	"go.opentelemetry.io/collector/config/confighttp.HTTPClientSettings.Endpoint":                                             "The target URL to send data to (e.g.: http://some.url:9411/v1/traces).",
+	"go.opentelemetry.io/collector/config/confighttp.HTTPClientSettings.Headers":                                              "Additional headers attached to each HTTP request sent by the client. Existing header values are overwritten if collision happens.",
+	"go.opentelemetry.io/collector/config/confighttp.HTTPClientSettings.ReadBufferSize":                                       "ReadBufferSize for HTTP client. See http.Transport.ReadBufferSize.",
+	"go.opentelemetry.io/collector/config/confighttp.HTTPClientSettings.Timeout":                                              "Timeout parameter configures `http.Client.Timeout`.",
+	"go.opentelemetry.io/collector/config/confighttp.HTTPClientSettings.WriteBufferSize":                                      "WriteBufferSize for HTTP client. See http.Transport.WriteBufferSize.",
+	"go.opentelemetry.io/collector/config/confighttp.HTTPServerSettings.CorsOrigins":                                          "CorsOrigins are the allowed CORS origins for HTTP/JSON requests to grpc-gateway adapter for the OTLP receiver. See github.com/rs/cors An empty list means that CORS is not enabled at all. A wildcard (*) can be used to match any origin or one or more characters of an origin.",
+	"go.opentelemetry.io/collector/config/confighttp.HTTPServerSettings.Endpoint":                                             "Endpoint configures the listening address for the server.",
+	"go.opentelemetry.io/collector/config/confighttp.HTTPServerSettings.TLSSetting":                                           "TLSSetting struct exposes TLS client configuration.",
+	"go.opentelemetry.io/collector/config/configmodels.FanOutSettings.BufferSize":                                             "BufferSize is the number of batches buffered for each exporter when Concurrent is set. Zero means the default size.",
+	"go.opentelemetry.io/collector/config/configmodels.FanOutSettings.Concurrent":                                             "Concurrent sends the data to each exporter from its own goroutine, through a bounded buffer, so that a slow exporter does not delay the others. By default the exporters are called one after the other.",
+	"go.opentelemetry.io/collector/config/configmodels.FanOutSettings.Policy":                                                 "Policy is what is done with the data for an exporter whose buffer is full.",
+	"go.opentelemetry.io/collector/config/configmodels.LogsTelemetry.Encoding":                                                "Encoding is the format of the logs (json, console).",
+	"go.opentelemetry.io/collector/config/configmodels.LogsTelemetry.ErrorOutputPaths":                                        "ErrorOutputPaths are the files or URLs the errors of the logger itself are written to, \"stderr\" by default.",
+	"go.opentelemetry.io/collector/config/configmodels.LogsTelemetry.Level":                                                   "Level is the minimum level of the logs (DEBUG, INFO, WARN, ERROR, DPANIC, PANIC, FATAL).",
+	"go.opentelemetry.io/collector/config/configmodels.LogsTelemetry.OutputPaths":                                             "OutputPaths are the files or URLs the logs are written to, \"stderr\" by default.",
+	"go.opentelemetry.io/collector/config/configmodels.LogsTelemetry.Sampling":                                                "Sampling limits the number of logs with the same level and message, it is disabled in development builds unless set.",
+	"go.opentelemetry.io/collector/config/configmodels.MetricsTelemetry.Address":                                              "Address is the [address]:port the Prometheus metrics are exposed on.",
+	"go.opentelemetry.io/collector/config/configmodels.MetricsTelemetry.Level":                                                "Level is the level of the metrics (none, basic, normal, detailed).",
+	"go.opentelemetry.io/collector/config/configmodels.MetricsTelemetry.ResourceLabels":                                       "ResourceLabels are added to all the metrics, as Prometheus labels and as resource attributes of the exported spans and metrics.",
+	"go.opentelemetry.io/collector/config/configmodels.Service.Extensions":                                                    "Extensions is the ordered list of extensions configured for the service.",
+	"go.opentelemetry.io/collector/config/configmodels.Service.Pipelines":                                                     "Pipelines is the set of data pipelines configured for the service.",
+	"go.opentelemetry.io/collector/config/configmodels.Service.Telemetry":                                                     "Telemetry is the configuration of the collector's own telemetry.",
+	"go.opentelemetry.io/collector/config/configmodels.ServiceTelemetry.Export":                                               "Export defines where the collector's own spans and metrics are sent.",
+	"go.opentelemetry.io/collector/config/configmodels.ServiceTelemetry.Logs":                                                 "Logs defines the collector's own logs, it overrides the --log-* flags.",
+	"go.opentelemetry.io/collector/config/configmodels.ServiceTelemetry.Metrics":                                              "Metrics defines the collector's own metrics, it overrides the --metrics-* flags.",
+	"go.opentelemetry.io/collector/config/configmodels.ServiceTelemetry.Traces":                                               "Traces defines how the operations of the pipelines are traced.",
+	"go.opentelemetry.io/collector/config/configmodels.TelemetryExport.Endpoint":                                              "Endpoint is the address of an OTLP/gRPC endpoint the spans and metrics are sent to.",
+	"go.opentelemetry.io/collector/config/configmodels.TelemetryExport.Headers":                                               "Headers are sent with every request to Endpoint.",
+	"go.opentelemetry.io/collector/config/configmodels.TelemetryExport.Insecure":                                              "Insecure disables the transport security of the connection to Endpoint.",
+	"go.opentelemetry.io/collector/config/configmodels.TelemetryExport.MetricsInterval":                                       "MetricsInterval is the interval at which the metrics are sent. Zero means the default interval of 10s.",
+	"go.opentelemetry.io/collector/config/configmodels.TelemetryExport.MetricsPipeline":                                       "MetricsPipeline is the name of a metrics pipeline of the collector the metrics are sent to.",
+	"go.opentelemetry.io/collector/config/configmodels.TelemetryExport.TracesPipeline":                                        "TracesPipeline is the name of a traces pipeline of the collector the spans are sent to.",
+	"go.opentelemetry.io/collector/config/configmodels.TracesTelemetry.SamplingRatio":                                         "SamplingRatio is the fraction of the operations that are traced, between 0 and 1. Zero keeps the default ratio of 1 in 10000.",
+	"go.opentelemetry.io/collector/config/confignet.NetAddr.Endpoint":                                                         "Endpoint configures the address for this network connection. For TCP and UDP networks, the address has the form \"host:port\". The host must be a literal IP address, or a host name that can be resolved to IP addresses. The port must be a literal port number or a service name. If the host is a literal IPv6 address it must be enclosed in square brackets, as in \"[2001:db8::1]:80\" or \"[fe80::1%zone]:80\". The zone specifies the scope of the literal IPv6 address as defined in RFC 4007.",
+	"go.opentelemetry.io/collector/config/confignet.NetAddr.Transport":                                                        "Transport to use. Known protocols are \"tcp\", \"tcp4\" (IPv4-only), \"tcp6\" (IPv6-only), \"udp\", \"udp4\" (IPv4-only), \"udp6\" (IPv6-only), \"ip\", \"ip4\" (IPv4-only), \"ip6\" (IPv6-only), \"unix\", \"unixgram\" and \"unixpacket\".",
+	"go.opentelemetry.io/collector/config/confignet.TCPAddr.Endpoint":                                                         "Endpoint configures the address for this network connection. The address has the form \"host:port\". The host must be a literal IP address, or a host name that can be resolved to IP addresses. The port must be a literal port number or a service name. If the host is a literal IPv6 address it must be enclosed in square brackets, as in \"[2001:db8::1]:80\" or \"[fe80::1%zone]:80\". The zone specifies the scope of the literal IPv6 address as defined in RFC 4007.",
+	"go.opentelemetry.io/collector/config/configtelemetry.TelemetrySetting.MetricsLevelStr":                                   "MetricsLevelStr is the level of telemetry metrics, the possible values are: - \"none\" indicates that no telemetry data should be collected; - \"basic\" is the recommended and covers the basics of the service telemetry. - \"normal\" adds some other indicators on top of basic. - \"detailed\" adds dimensions and views to the previous levels.",
+	"go.opentelemetry.io/collector/config/configtls.TLSClientSetting.Insecure":                                                "In gRPC when set to true, this is used to disable the client transport security. See https://godoc.org/google.golang.org/grpc#WithInsecure. In HTTP, this disables verifying the server's certificate chain and host name (InsecureSkipVerify in the tls Config). Please refer to https://godoc.org/crypto/tls#Config for more information. (optional, default false) TODO(ccaraman): With further research InsecureSkipVerify is a valid option for gRPC connections. Add that ability to the TLSClientSettings in a subsequent pr.",
+	"go.opentelemetry.io/collector/config/configtls.TLSClientSetting.ServerName":                                              "ServerName requested by client for virtual hosting. This sets the ServerName in the TLSConfig. Please refer to https://godoc.org/crypto/tls#Config for more information. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.TLSServerSetting.ClientCAFile":                                            "Path to the TLS cert to use by the server to verify a client certificate. (optional) This sets the ClientCAs and ClientAuth to RequireAndVerifyClientCert in the TLSConfig. Please refer to https://godoc.org/crypto/tls#Config for more information. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.TLSSetting.CAFile":                                                        "Path to the CA cert. For a client this verifies the server certificate. For a server this verifies client certificates. If empty uses system root CA. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.TLSSetting.CertFile":                                                      "Path to the TLS cert to use for TLS required connections. (optional)",
+	"go.opentelemetry.io/collector/config/configtls.TLSSetting.KeyFile":                                                       "Path to the TLS key to use for TLS required connections. (optional)",
+	"go.opentelemetry.io/collector/exporter/elasticsearchexporter.Config.LogsIndex":                                           "LogsIndex is the index name template used for log records. See README.md for the supported placeholders.",
+	"go.opentelemetry.io/collector/exporter/elasticsearchexporter.Config.Mapping":                                             "Mapping configures how telemetry is converted into Elasticsearch documents.",
+	"go.opentelemetry.io/collector/exporter/elasticsearchexporter.Config.TracesIndex":                                         "TracesIndex is the index name template used for spans. See README.md for the supported placeholders.",
+	"go.opentelemetry.io/collector/exporter/elasticsearchexporter.MappingSettings.Mode":                                       "Mode is either \"flattened\" or \"nested\".",
+	"go.opentelemetry.io/collector/exporter/exporterhelper.QueueSettings.Enabled":                                             "Enabled indicates whether to not enqueue batches before sending to the consumerSender.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper.QueueSettings.NumConsumers":                                        "NumConsumers is the number of consumers from the queue.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper.QueueSettings.QueueSize":                                           "QueueSize is the maximum number of batches allowed in queue at a given time.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper.ResourceToTelemetrySettings.Enabled":                               "Enabled indicates whether to not convert resource attributes to metric labels",
+	"go.opentelemetry.io/collector/exporter/exporterhelper.RetrySettings.Enabled":                                             "Enabled indicates whether to not retry sending batches in case of export failure.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper.RetrySettings.InitialInterval":                                     "InitialInterval the time to wait after the first failure before retrying.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper.RetrySettings.MaxElapsedTime":                                      "MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch. Once this value is reached, the data is discarded.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper.RetrySettings.MaxInterval":                                         "MaxInterval is the upper bound on backoff interval. Once this value is reached the delay between consecutive retries will always be `MaxInterval`.",
+	"go.opentelemetry.io/collector/exporter/exporterhelper.TimeoutSettings.Timeout":                                           "Timeout is the timeout for every attempt to send data to the backend.",
+	"go.opentelemetry.io/collector/exporter/fileexporter.Config.Path":                                                         "Path of the file to write to. Path is relative to current directory.",
+	"go.opentelemetry.io/collector/exporter/kafkaexporter.Config.Authentication":                                              "Authentication defines used authentication mechanism.",
+	"go.opentelemetry.io/collector/exporter/kafkaexporter.Config.Brokers":                                                     "The list of kafka brokers (default localhost:9092)",
+	"go.opentelemetry.io/collector/exporter/kafkaexporter.Config.Encoding":                                                    "Encoding of the messages (default \"otlp_proto\")",
+	"go.opentelemetry.io/collector/exporter/kafkaexporter.Config.Metadata":                                                    "Metadata is the namespace for metadata management properties used by the Client, and shared by the Producer/Consumer.",
+	"go.opentelemetry.io/collector/exporter/kafkaexporter.Config.ProtocolVersion":                                             "Kafka protocol version",
+	"go.opentelemetry.io/collector/exporter/kafkaexporter.Config.Topic":                                                       "The name of the kafka topic to export to (default \"otlp_spans\")",
+	"go.opentelemetry.io/collector/exporter/kafkaexporter.Metadata.Full":                                                      "Whether to maintain a full set of metadata for all topics, or just the minimal set that has been necessary so far. The full set is simpler and usually more convenient, but can take up a substantial amount of memory if you have many topics and partitions. Defaults to true.",
+	"go.opentelemetry.io/collector/exporter/kafkaexporter.Metadata.Retry":                                                     "Retry configuration for metadata. This configuration is useful to avoid race conditions when broker is starting at the same time as collector.",
+	"go.opentelemetry.io/collector/exporter/kafkaexporter.MetadataRetry.Backoff":                                              "How long to wait for leader election to occur before retrying (default 250ms). Similar to the JVM's `retry.backoff.ms`.",
+	"go.opentelemetry.io/collector/exporter/kafkaexporter.MetadataRetry.Max":                                                  "The total number of times to retry a metadata request when the cluster is in the middle of a leader election or at startup (default 3).",
+	"go.opentelemetry.io/collector/exporter/loggingexporter.Config.LogLevel":                                                  "LogLevel defines log level of the logging exporter; options are debug, info, warn, error.",
+	"go.opentelemetry.io/collector/exporter/loggingexporter.Config.SamplingInitial":                                           "SamplingInitial defines how many samples are initially logged during each second.",
+	"go.opentelemetry.io/collector/exporter/loggingexporter.Config.SamplingThereafter":                                        "SamplingThereafter defines the sampling rate after the initial samples are logged.",
+	"go.opentelemetry.io/collector/exporter/opencensusexporter.Config.NumWorkers":                                             "The number of workers that send the gRPC requests.",
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter.Config.LogsEndpoint":                                             "The URL to send logs to. If omitted the Endpoint + \"/v1/logs\" will be used.",
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter.Config.MetricsEndpoint":                                          "The URL to send metrics to. If omitted the Endpoint + \"/v1/metrics\" will be used.",
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter.Config.TracesEndpoint":                                           "The URL to send traces to. If omitted the Endpoint + \"/v1/traces\" will be used.",
+	"go.opentelemetry.io/collector/exporter/prometheusexporter.Config.ConstLabels":                                            "ConstLabels are values that are applied for every exported metric.",
+	"go.opentelemetry.io/collector/exporter/prometheusexporter.Config.EnableOpenMetrics":                                      "EnableOpenMetrics enables the OpenMetrics format when it is negotiated by the scraper, the exemplars of the counters and histograms are only exported in this format.",
+	"go.opentelemetry.io/collector/exporter/prometheusexporter.Config.Endpoint":                                               "The address on which the Prometheus scrape handler will be run on.",
+	"go.opentelemetry.io/collector/exporter/prometheusexporter.Config.Namespace":                                              "Namespace if set, exports metrics under the provided value.",
+	"go.opentelemetry.io/collector/exporter/prometheusexporter.Config.SendTimestamps":                                         "SendTimestamps will send the underlying scrape timestamp with the export",
+	"go.opentelemetry.io/collector/exporter/prometheusremotewriteexporter.Config.ExternalLabels":                              "ExternalLabels defines a map of label keys and values that are allowed to start with reserved prefix \"__\"",
+	"go.opentelemetry.io/collector/exporter/prometheusremotewriteexporter.Config.Namespace":                                   "prefix attached to each exported metric name See: https://prometheus.io/docs/practices/naming/#metric-names",
+	"go.opentelemetry.io/collector/exporter/syslogexporter.Config.Facility":                                                   "Facility is the syslog facility name used to compute the message priority (default \"user\").",
+	"go.opentelemetry.io/collector/exporter/syslogexporter.Config.Protocol":                                                   "Protocol is the message format, either \"rfc5424\" or \"rfc3164\" (default \"rfc5424\").",
+	"go.opentelemetry.io/collector/exporter/syslogexporter.Config.StructuredDataID":                                           "StructuredDataID is the SD-ID of the RFC 5424 structured data element that contains the log record attributes (default \"attrs@32473\").",
+	"go.opentelemetry.io/collector/exporter/syslogexporter.Config.TLSSetting":                                                 "TLSSetting configures TLS for the \"tcp\" transport. TLS is used when insecure is false.",
+	"go.opentelemetry.io/collector/extension/filestorageextension.Config.Directory":                                           "Directory is the directory the database files are created in, it must exist.",
+	"go.opentelemetry.io/collector/extension/filestorageextension.Config.Timeout":                                             "Timeout is how long to wait for the lock of a database file held by another process. Zero means waiting indefinitely.",
+	"go.opentelemetry.io/collector/extension/fluentbitextension.Config.Args":                                                  "Exec arguments to the FluentBit process. If you provide this, none of the standard args will be set, and only these provided args will be passed to FluentBit. The standard args will set the flush interval to 1 second, configure the forward output with the given `tcp_endpoint` option, enable the HTTP monitoring server in FluentBit, and set the config file to stdin. The only required arg is `--config=/dev/stdin`, since this extension passes the provided config to FluentBit via stdin. If you set args manually, you will be responsible for setting the forward output to the right port for the fluentforward receiver. See `process.go#constructArgs` of this extension source to see the current default args.",
+	"go.opentelemetry.io/collector/extension/fluentbitextension.Config.Config":                                                "A configuration for FluentBit. This is the text content of the config itself, not a path to a config file.",
+	"go.opentelemetry.io/collector/extension/fluentbitextension.Config.ExecutablePath":                                        "The path to the executable for FluentBit. Ideally should be an absolute path since the CWD of the collector is not guaranteed to be stable.",
+	"go.opentelemetry.io/collector/extension/fluentbitextension.Config.TCPEndpoint":                                           "The TCP `host:port` to which the subprocess should send log entries. This is required unless you are overridding `args` and providing the output configuration yourself either in `args` or `config`.",
+	"go.opentelemetry.io/collector/extension/healthcheckextension.Config.ExporterFailureThreshold":                            "ExporterFailureThreshold is how long an exporter can fail to send data before the health check reports the collector as not available. Zero, the default, means that the failures of the exporters do not change the availability.",
+	"go.opentelemetry.io/collector/extension/healthcheckextension.Config.Port":                                                "Port is the port used to publish the health check status. The default value is 13133.",
+	"go.opentelemetry.io/collector/extension/managementextension.Config.AcceptRemoteConfig":                                   "AcceptRemoteConfig enables applying the configurations sent by the management server. If it is disabled the collector only reports its status.",
+	"go.opentelemetry.io/collector/extension/managementextension.Config.Interval":                                             "Interval is the interval at which the collector reports to the management server and gets the configuration to apply.",
+	"go.opentelemetry.io/collector/extension/pprofextension.Config.BlockProfileFraction":                                      "Fraction of blocking events that are profiled. A value <= 0 disables profiling. See https://golang.org/pkg/runtime/#SetBlockProfileRate for details.",
+	"go.opentelemetry.io/collector/extension/pprofextension.Config.Endpoint":                                                  "Endpoint is the address and port in which the pprof will be listening to. Use localhost:<port> to make it available only locally, or \":<port>\" to make it available on all network interfaces.",
+	"go.opentelemetry.io/collector/extension/pprofextension.Config.MutexProfileFraction":                                      "Fraction of mutex contention events that are profiled. A value <= 0 disables profiling. See https://golang.org/pkg/runtime/#SetMutexProfileFraction for details.",
+	"go.opentelemetry.io/collector/extension/pprofextension.Config.SaveToFile":                                                "Optional file name to save the CPU profile to. The profiling starts when the Collector starts and is saved to the file when the Collector is terminated.",
+	"go.opentelemetry.io/collector/extension/subprocessextension.Config.Args":                                                 "Args are the arguments passed to the executable.",
+	"go.opentelemetry.io/collector/extension/subprocessextension.Config.Environment":                                          "Environment are the environment variables of the process in addition to the ones of the collector, in the NAME=value form. They are not a map since the keys of the configuration are case insensitive.",
+	"go.opentelemetry.io/collector/extension/subprocessextension.Config.ExecutablePath":                                       "ExecutablePath is the path of the executable to run. Ideally it is an absolute path since the working directory of the collector is not guaranteed to be stable.",
+	"go.opentelemetry.io/collector/extension/subprocessextension.Config.LogsPipeline":                                         "LogsPipeline is the name of the logs pipeline each line the process writes to its stdout or stderr is sent to as a log record. If it is not set the lines are written to the collector's log at debug level.",
+	"go.opentelemetry.io/collector/extension/subprocessextension.Config.MaxRestartDelay":                                      "MaxRestartDelay is the maximal delay before restarting the process.",
+	"go.opentelemetry.io/collector/extension/subprocessextension.Config.RestartDelay":                                         "RestartDelay is how long to wait before restarting the process after it exited. The delay doubles after each restart of a process that exited before running for MaxRestartDelay, up to MaxRestartDelay.",
+	"go.opentelemetry.io/collector/extension/subprocessextension.Config.ShutdownTimeout":                                      "ShutdownTimeout is how long to wait for the process to exit after it was sent SIGTERM on shutdown before it is killed.",
+	"go.opentelemetry.io/collector/extension/subprocessextension.Config.WorkingDirectory":                                     "WorkingDirectory is the working directory of the process, the one of the collector if it is not set.",
+	"go.opentelemetry.io/collector/extension/zpagesextension.Config.Endpoint":                                                 "Endpoint is the address and port in which the zPages will be listening to. Use localhost:<port> to make it available only locally, or \":<port>\" to make it available on all network interfaces.",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.Attribute.Key":                                             "Key specifies the attribute key.",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.Attribute.Value":                                           "Values specifies the value to match against. If it is not set, any value will match.",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.InstrumentationLibrary.Version":                            "version match expected actual match nil <blank> yes nil 1 yes <blank> <blank> yes <blank> 1 no 1 <blank> no 1 1 yes",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.MatchConfig.Exclude":                                       "Exclude specifies when this processor will not be applied to the span/logs which match the specified properties. Note: The `exclude` properties are checked after the `include` properties, if they exist, are checked. If `include` isn't specified, the `exclude` properties are checked against all span/logs. This is an optional field. If neither `include` and `exclude` are set, all span/logs are processed. If `exclude` is set and `include` isn't set, then all span/logs that do no match the properties in this structure are processed.",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.MatchConfig.Include":                                       "Include specifies the set of span/log properties that must be present in order for this processor to apply to it. Note: If `exclude` is specified, the span/log is compared against those properties after the `include` properties. This is an optional field. If neither `include` and `exclude` are set, all span/logs are processed. If `include` is set and `exclude` isn't set, then all span/logs matching the properties in this structure are processed.",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.MatchProperties.Attributes":                                "Attributes specifies the list of attributes to match against. All of these attributes must match exactly for a match to occur. Only match_type=strict is allowed if \"attributes\" are specified. This is an optional field.",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.MatchProperties.Libraries":                                 "Libraries specify the list of items to match the implementation library against. A match occurs if the span's implementation library matches at least one item in this list. This is an optional field.",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.MatchProperties.LogNames":                                  "LogNames is a list of strings that the LogRecord's name field must match against.",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.MatchProperties.Resources":                                 "Resources specify the list of items to match the resources against. A match occurs if the span's resources matches at least one item in this list. This is an optional field.",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.MatchProperties.Services":                                  "Services specify the list of of items to match service name against. A match occurs if the span's service name matches at least one item in this list. This is an optional field.",
+	"go.opentelemetry.io/collector/internal/processor/filterconfig.MatchProperties.SpanNames":                                 "SpanNames specify the list of items to match span name against. A match occurs if the span name matches at least one item in this list. This is an optional field.",
+	"go.opentelemetry.io/collector/internal/processor/filtermetric.MatchProperties.Expressions":                               "Expressions specifies the list of expr expressions to match metrics against. A match occurs if any datapoint in a metric matches at least one expression in this list.",
+	"go.opentelemetry.io/collector/internal/processor/filtermetric.MatchProperties.MatchType":                                 "MatchType specifies the type of matching desired",
+	"go.opentelemetry.io/collector/internal/processor/filtermetric.MatchProperties.MetricNames":                               "MetricNames specifies the list of string patterns to match metric names against. A match occurs if the metric name matches at least one string pattern in this list.",
+	"go.opentelemetry.io/collector/internal/processor/filtermetric.MatchProperties.RegexpConfig":                              "RegexpConfig specifies options for the Regexp match type",
+	"go.opentelemetry.io/collector/internal/processor/filterset/regexp.Config.CacheEnabled":                                   "CacheEnabled determines whether match results are LRU cached to make subsequent matches faster. Cache size is unlimited unless CacheMaxNumEntries is also specified.",
+	"go.opentelemetry.io/collector/internal/processor/filterset/regexp.Config.CacheMaxNumEntries":                             "CacheMaxNumEntries is the max number of entries of the LRU cache that stores match results. CacheMaxNumEntries is ignored if CacheEnabled is false.",
+	"go.opentelemetry.io/collector/processor/batchprocessor.Config.MergeResources":                                            "MergeResources enables grouping the data of identical resources and instrumentation libraries of a batch under a single one before it is sent, see pdata.NormalizeTraces.",
+	"go.opentelemetry.io/collector/processor/batchprocessor.Config.SendBatchMaxSize":                                          "SendBatchMaxSize is the maximum size of a batch. Larger batches are split into smaller units. Default value is 0, that means no maximum size.",
+	"go.opentelemetry.io/collector/processor/batchprocessor.Config.SendBatchSize":                                             "SendBatchSize is the size of a batch which after hit, will trigger it to be sent.",
+	"go.opentelemetry.io/collector/processor/batchprocessor.Config.Timeout":                                                   "Timeout sets the time after which a batch will be sent regardless of size.",
+	"go.opentelemetry.io/collector/processor/filterprocessor.MetricFilters.Exclude":                                           "Exclude match properties describe metrics that should be excluded from the Collector Service pipeline, all other metrics should be included. If both Include and Exclude are specified, Include filtering occurs first.",
+	"go.opentelemetry.io/collector/processor/filterprocessor.MetricFilters.Include":                                           "Include match properties describe metrics that should be included in the Collector Service pipeline, all other metrics should be dropped from further processing. If both Include and Exclude are specified, Include filtering occurs first.",
+	"go.opentelemetry.io/collector/processor/memorylimiter.Config.BallastSizeMiB":                                             "BallastSizeMiB is the size, in MiB, of the ballast size being used by the process.",
+	"go.opentelemetry.io/collector/processor/memorylimiter.Config.CheckInterval":                                              "CheckInterval is the time between measurements of memory usage for the purposes of avoiding going over the limits. Defaults to zero, so no checks will be performed.",
+	"go.opentelemetry.io/collector/processor/memorylimiter.Config.MemoryLimitMiB":                                             "MemoryLimitMiB is the maximum amount of memory, in MiB, targeted to be allocated by the process.",
+	"go.opentelemetry.io/collector/processor/memorylimiter.Config.MemoryLimitPercentage":                                      "MemoryLimitPercentage is the maximum amount of memory, in %, targeted to be allocated by the process. The fixed memory settings MemoryLimitMiB has a higher precedence.",
+	"go.opentelemetry.io/collector/processor/memorylimiter.Config.MemorySpikeLimitMiB":                                        "MemorySpikeLimitMiB is the maximum, in MiB, spike expected between the measurements of memory usage.",
+	"go.opentelemetry.io/collector/processor/memorylimiter.Config.MemorySpikePercentage":                                      "MemorySpikePercentage is the maximum, in percents against the total memory, spike expected between the measurements of memory usage.",
+	"go.opentelemetry.io/collector/processor/processorhelper.ActionKeyValue.Action":                                           "Action specifies the type of action to perform. The set of values are {INSERT, UPDATE, UPSERT, DELETE, HASH}. Both lower case and upper case are supported. INSERT - Inserts the key/value to attributes when the key does not exist. No action is applied to attributes where the key already exists. Either Value or FromAttribute must be set. UPDATE - Updates an existing key with a value. No action is applied to attributes where the key does not exist. Either Value or FromAttribute must be set. UPSERT - Performs insert or update action depending on the attributes containing the key. The key/value is insert to attributes that did not originally have the key. The key/value is updated for attributes where the key already existed. Either Value or FromAttribute must be set. DELETE - Deletes the attribute. If the key doesn't exist, no action is performed. HASH - Calculates the SHA-1 hash of an existing value and overwrites the value with it's SHA-1 hash result. EXTRACT - Extracts values using a regular expression rule from the input 'key' to target keys specified in the 'rule'. If a target key already exists, it will be overridden. This is a required field.",
+	"go.opentelemetry.io/collector/processor/processorhelper.ActionKeyValue.FromAttribute":                                    "FromAttribute specifies the attribute to use to populate the value. If the attribute doesn't exist, no action is performed.",
+	"go.opentelemetry.io/collector/processor/processorhelper.ActionKeyValue.Key":                                              "Key specifies the attribute to act upon. This is a required field.",
+	"go.opentelemetry.io/collector/processor/processorhelper.ActionKeyValue.RegexPattern":                                     "A regex pattern must be specified for the action EXTRACT. It uses the attribute specified by `key' to extract values from The target keys are inferred based on the names of the matcher groups provided and the names will be inferred based on the values of the matcher group. Note: All subexpressions must have a name. Note: The value type of the source key must be a string. If it isn't, no extraction will occur.",
+	"go.opentelemetry.io/collector/processor/processorhelper.ActionKeyValue.Value":                                            "Value specifies the value to populate for the key. The type of the value is inferred from the configuration.",
+	"go.opentelemetry.io/collector/processor/processorhelper.Settings.Actions":                                                "Actions specifies the list of attributes to act on. The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT}. This is a required field.",
+	"go.opentelemetry.io/collector/processor/queuedprocessor.Config.BackoffDelay":                                             "BackoffDelay is the amount of time a worker waits after a failed send before retrying.",
+	"go.opentelemetry.io/collector/processor/queuedprocessor.Config.NumWorkers":                                               "NumConsumers is the number of queue workers that dequeue batches and send them out.",
+	"go.opentelemetry.io/collector/processor/queuedprocessor.Config.QueueSize":                                                "QueueSize is the maximum number of batches allowed in queue at a given time.",
+	"go.opentelemetry.io/collector/processor/queuedprocessor.Config.RetryOnFailure":                                           "Retry indicates whether queue processor should retry span batches in case of processing failure.",
+	"go.opentelemetry.io/collector/processor/resourceprocessor.Config.AttributesActions":                                      "AttributesActions specifies the list of actions to be applied on resource attributes. The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT}.",
+	"go.opentelemetry.io/collector/processor/resourceprocessor.Config.Labels":                                                 "Deprecated: Use \"attributes.upsert\" instead.",
+	"go.opentelemetry.io/collector/processor/resourceprocessor.Config.ResourceType":                                           "ResourceType field is deprecated. Set \"opencensus.type\" key in \"attributes.upsert\" map instead.",
+	"go.opentelemetry.io/collector/processor/samplingprocessor/probabilisticsamplerprocessor.Config.HashSeed":                 "HashSeed allows one to configure the hashing seed. This is important in scenarios where multiple layers of collectors have different sampling rates: if they use the same seed all passing one layer may pass the other even if they have different sampling rates, configuring different seeds avoids that.",
+	"go.opentelemetry.io/collector/processor/samplingprocessor/probabilisticsamplerprocessor.Config.SamplingPercentage":       "SamplingPercentage is the percentage rate at which traces are going to be sampled. Defaults to zero, i.e.: no sample. Values greater or equal 100 are treated as \"sample all traces\".",
+	"go.opentelemetry.io/collector/processor/spanprocessor.Config.Rename":                                                     "Rename specifies the components required to re-name a span. The `from_attributes` field needs to be set for this processor to be properly configured. Note: The field name is `Rename` to avoid collision with the Name() method from configmodels.ProcessorSettings.NamedEntity",
+	"go.opentelemetry.io/collector/processor/spanprocessor.Name.FromAttributes":                                               "FromAttributes represents the attribute keys to pull the values from to generate the new span name. All attribute keys are required in the span to re-name a span. If any attribute is missing from the span, no re-name will occur. Note: The new span name is constructed in order of the `from_attributes` specified in the configuration. This field is required and cannot be empty.",
+	"go.opentelemetry.io/collector/processor/spanprocessor.Name.Separator":                                                    "Separator is the string used to separate attributes values in the new span name. If no value is set, no separator is used between attribute values. Used with FromAttributes only.",
+	"go.opentelemetry.io/collector/processor/spanprocessor.Name.ToAttributes":                                                 "ToAttributes specifies a configuration to extract attributes from span name.",
+	"go.opentelemetry.io/collector/processor/spanprocessor.ToAttributes.BreakAfterMatch":                                      "BreakAfterMatch specifies if processing of rules should stop after the first match. If it is false rule processing will continue to be performed over the modified span name.",
+	"go.opentelemetry.io/collector/processor/spanprocessor.ToAttributes.Rules":                                                "Rules is a list of rules to extract attribute values from span name. The values in the span name are replaced by extracted attribute names. Each rule in the list is a regex pattern string. Span name is checked against the regex. If it matches then all named subexpressions of the regex are extracted as attributes and are added to the span. Each subexpression name becomes an attribute name and subexpression matched portion becomes the attribute value. The matched portion in the span name is replaced by extracted attribute name. If the attributes already exist in the span then they will be overwritten. The process is repeated for all rules in the order they are specified. Each subsequent rule works on the span name that is the output after processing the previous rule.",
+	"go.opentelemetry.io/collector/receiver/fluentforwardreceiver.Config.ListenAddress":                                       "The address to listen on for incoming Fluent Forward events. Should be of the form `<ip addr>:<port>` (TCP) or `unix://<socket_path>` (Unix domain socket).",
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/diskscraper.Config.Include":                  "Include specifies a filter on the devices that should be included from the generated metrics. Exclude specifies a filter on the devices that should be excluded from the generated metrics. If neither `include` or `exclude` are set, metrics will be generated for all devices.",
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper.Config.ExcludeDevices":     "ExcludeDevices specifies a filter on the devices that should be excluded from the generated metrics.",
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper.Config.ExcludeFSTypes":     "ExcludeFSTypes specifies a filter on the filesystem types points that should be excluded from the generated metrics.",
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper.Config.ExcludeMountPoints": "ExcludeMountPoints specifies a filter on the mount points that should be excluded from the generated metrics.",
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper.Config.IncludeDevices":     "IncludeDevices specifies a filter on the devices that should be included in the generated metrics.",
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper.Config.IncludeFSTypes":     "IncludeFSTypes specifies a filter on the filesystem types that should be included in the generated metrics.",
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper.Config.IncludeMountPoints": "IncludeMountPoints specifies a filter on the mount points that should be included in the generated metrics.",
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/networkscraper.Config.Exclude":               "Exclude specifies a filter on the network interfaces that should be excluded from the generated metrics.",
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/networkscraper.Config.Include":               "Include specifies a filter on the network interfaces that should be included from the generated metrics.",
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processscraper.Config.Include":               "Include specifies a filter on the process names that should be included from the generated metrics. Exclude specifies a filter on the process names that should be excluded from the generated metrics. If neither `include` or `exclude` are set, process metrics will be generated for all processes.",
+	"go.opentelemetry.io/collector/receiver/kafkareceiver.Config.Brokers":                                                     "The list of kafka brokers (default localhost:9092)",
+	"go.opentelemetry.io/collector/receiver/kafkareceiver.Config.ClientID":                                                    "The consumer client ID that receiver will use (default \"otel-collector\")",
+	"go.opentelemetry.io/collector/receiver/kafkareceiver.Config.Encoding":                                                    "Encoding of the messages (default \"otlp_proto\")",
+	"go.opentelemetry.io/collector/receiver/kafkareceiver.Config.GroupID":                                                     "The consumer group that receiver will be consuming messages from (default \"otel-collector\")",
+	"go.opentelemetry.io/collector/receiver/kafkareceiver.Config.Metadata":                                                    "Metadata is the namespace for metadata management properties used by the Client, and shared by the Producer/Consumer.",
+	"go.opentelemetry.io/collector/receiver/kafkareceiver.Config.ProtocolVersion":                                             "Kafka protocol version",
+	"go.opentelemetry.io/collector/receiver/kafkareceiver.Config.Topic":                                                       "The name of the kafka topic to consume from (default \"otlp_spans\")",
+	"go.opentelemetry.io/collector/receiver/opencensusreceiver.Config.CorsOrigins":                                            "CorsOrigins are the allowed CORS origins for HTTP/JSON requests to grpc-gateway adapter for the OpenCensus receiver. See github.com/rs/cors An empty list means that CORS is not enabled at all. A wildcard (*) can be used to match any origin or one or more characters of an origin.",
+	"go.opentelemetry.io/collector/receiver/otlpreceiver.Config.Protocols":                                                    "Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).",
+	"go.opentelemetry.io/collector/receiver/prometheusreceiver.Config.ConfigPlaceholder":                                      "ConfigPlaceholder is just an entry to make the configuration pass a check that requires that all keys present in the config actually exist on the structure, ie.: it will error if an unknown key is present.",
+	"go.opentelemetry.io/collector/receiver/zipkinreceiver.Config.ParseStringTags":                                            "If enabled the zipkin receiver will attempt to parse string tags/binary annotations into int/bool/float. Disabled by default",
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configsource implements the sources of values referenced in the
+// configuration as ${<source>:<selector>}, e.g. ${file:/run/secrets/token} or
+// ${env:HOST:-localhost}.
+package configsource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source retrieves the values referenced in the configuration.
+type Source interface {
+	// Retrieve returns the value selected by selector. The format of the selector
+	// depends on the source, e.g. it is the file path for the "file" source.
+	Retrieve(ctx context.Context, selector string) (string, error)
+}
+
+// WatchableSource is a Source that can detect changes of the values it retrieved.
+type WatchableSource interface {
+	Source
+
+	// Watch blocks until the value selected by selector is different from value
+	// and returns nil, or until ctx is done and returns the context error.
+	Watch(ctx context.Context, selector string, value string) error
+}
+
+// DefaultSources returns the sources available by default:
+//   - "env": the value of an environment variable, "VAR" or "VAR:-default".
+//   - "file": the content of a file, without trailing newlines.
+//   - "http": the body of the response to a GET request to the given URL.
+func DefaultSources() map[string]Source {
+	return map[string]Source{
+		"env":  &EnvSource{},
+		"file": &FileSource{},
+		"http": &HTTPSource{},
+	}
+}
+
+// reference is a value referenced in the configuration.
+type reference struct {
+	source   string
+	selector string
+}
+
+// Manager resolves the references to config sources and watches the resolved
+// values for changes. A Manager is used for a single load of the configuration:
+// once a change is signaled the configuration has to be loaded again, with a new
+// Manager, to pick up the new values.
+type Manager struct {
+	sources map[string]Source
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	changed chan struct{}
+	once    sync.Once
+
+	mu      sync.Mutex
+	watched map[reference]bool
+}
+
+// NewManager creates a Manager resolving references to the given sources, keyed by
+// the name used in the references.
+func NewManager(sources map[string]Source) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		sources: sources,
+		ctx:     ctx,
+		cancel:  cancel,
+		changed: make(chan struct{}),
+		watched: make(map[reference]bool),
+	}
+}
+
+// Resolve returns the value selected by selector from the named source. If the
+// source is a WatchableSource the value is watched until the Manager is closed.
+func (m *Manager) Resolve(ctx context.Context, source, selector string) (string, error) {
+	src, ok := m.sources[source]
+	if !ok {
+		// ${VAR:-default} is the shell syntax for a default value, it is parsed as a
+		// reference to the source VAR.
+		if strings.HasPrefix(selector, "-") {
+			return "", fmt.Errorf("unknown config source %q, use ${env:%s:%s} for an environment variable with a default value", source, source, selector)
+		}
+		return "", fmt.Errorf("unknown config source %q", source)
+	}
+	value, err := src.Retrieve(ctx, selector)
+	if err != nil {
+		return "", fmt.Errorf("cannot retrieve %q from config source %q: %w", selector, source, err)
+	}
+
+	if ws, ok := src.(WatchableSource); ok {
+		m.watch(ws, reference{source: source, selector: selector}, value)
+	}
+	return value, nil
+}
+
+func (m *Manager) watch(ws WatchableSource, ref reference, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.watched[ref] || m.ctx.Err() != nil {
+		return
+	}
+	m.watched[ref] = true
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		if err := ws.Watch(m.ctx, ref.selector, value); err == nil {
+			m.once.Do(func() { close(m.changed) })
+		}
+	}()
+}
+
+// Changed returns a channel that is closed when any of the resolved values changed.
+func (m *Manager) Changed() <-chan struct{} {
+	return m.changed
+}
+
+// Close stops watching the resolved values.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	m.cancel()
+	m.mu.Unlock()
+	m.wg.Wait()
+	return nil
+}
+
+// pollForChange calls retrieve every interval until it returns a value different
+// from value or ctx is done. Retrieval errors are considered transient and ignored.
+func pollForChange(ctx context.Context, interval time.Duration, retrieve func() (string, error), value string) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if current, err := retrieve(); err == nil && current != value {
+				return nil
+			}
+		}
+	}
+}
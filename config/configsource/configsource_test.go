@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsource
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Resolve(t *testing.T) {
+	require.NoError(t, os.Setenv("CONFIGSOURCE_TEST_VAR", "value"))
+	defer os.Unsetenv("CONFIGSOURCE_TEST_VAR")
+
+	m := NewManager(DefaultSources())
+	defer m.Close()
+
+	value, err := m.Resolve(context.Background(), "env", "CONFIGSOURCE_TEST_VAR")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	_, err = m.Resolve(context.Background(), "vault", "secret/token")
+	assert.EqualError(t, err, `unknown config source "vault"`)
+
+	_, err = m.Resolve(context.Background(), "HOST", "-localhost")
+	assert.EqualError(t, err, `unknown config source "HOST", use ${env:HOST:-localhost} for an environment variable with a default value`)
+
+	_, err = m.Resolve(context.Background(), "file", filepath.Join("testdata", "nonexistent"))
+	assert.Error(t, err)
+}
+
+func TestManager_Changed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configsource")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(file, []byte("first\n"), 0600))
+
+	m := NewManager(map[string]Source{"file": &FileSource{PollInterval: 10 * time.Millisecond}})
+	value, err := m.Resolve(context.Background(), "file", file)
+	require.NoError(t, err)
+	assert.Equal(t, "first", value)
+
+	// Rewriting the same value is not a change.
+	require.NoError(t, ioutil.WriteFile(file, []byte("first"), 0600))
+	select {
+	case <-m.Changed():
+		t.Fatal("unexpected change notification")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("second"), 0600))
+	select {
+	case <-m.Changed():
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not notified")
+	}
+
+	require.NoError(t, m.Close())
+
+	// Values resolved after closing are not watched.
+	_, err = m.Resolve(context.Background(), "file", file)
+	require.NoError(t, err)
+	require.NoError(t, m.Close())
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsource
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFilePollInterval = 10 * time.Second
+	defaultHTTPPollInterval = time.Minute
+	defaultHTTPTimeout      = 10 * time.Second
+)
+
+// EnvSource retrieves the values of environment variables. The selector is the
+// name of the variable, optionally followed by ":-" and a default value used when
+// the variable is unset or empty, e.g. "HOST:-localhost".
+type EnvSource struct{}
+
+var _ Source = (*EnvSource)(nil)
+
+// Retrieve implements Source.
+func (s *EnvSource) Retrieve(_ context.Context, selector string) (string, error) {
+	name, def, hasDefault := selector, "", false
+	if i := strings.Index(selector, ":-"); i >= 0 {
+		name, def, hasDefault = selector[:i], selector[i+2:], true
+	}
+	if name == "" {
+		return "", fmt.Errorf("missing environment variable name in %q", selector)
+	}
+	if value := os.Getenv(name); value != "" || !hasDefault {
+		return value, nil
+	}
+	return def, nil
+}
+
+// FileSource retrieves the content of files, e.g. secrets mounted in a container.
+// The selector is the path of the file. Trailing newlines are removed.
+type FileSource struct {
+	// PollInterval is how often the files are checked for changes. Defaults to 10s.
+	PollInterval time.Duration
+}
+
+var _ WatchableSource = (*FileSource)(nil)
+
+// Retrieve implements Source.
+func (s *FileSource) Retrieve(_ context.Context, selector string) (string, error) {
+	content, err := ioutil.ReadFile(selector)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// Watch implements WatchableSource.
+func (s *FileSource) Watch(ctx context.Context, selector string, value string) error {
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = defaultFilePollInterval
+	}
+	return pollForChange(ctx, interval, func() (string, error) {
+		return s.Retrieve(ctx, selector)
+	}, value)
+}
+
+// HTTPSource retrieves values from HTTP endpoints. The selector is the URL, the
+// value is the body of the response to a GET request, without trailing newlines.
+// Responses with a status other than 2xx are errors.
+type HTTPSource struct {
+	// Client is the client used for the requests. Defaults to a client with a 10s timeout.
+	Client *http.Client
+	// PollInterval is how often the endpoints are checked for changes. Defaults to 1m.
+	PollInterval time.Duration
+}
+
+var _ WatchableSource = (*HTTPSource)(nil)
+
+// Retrieve implements Source.
+func (s *HTTPSource) Retrieve(ctx context.Context, selector string) (string, error) {
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, selector, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("unexpected response status %q", resp.Status)
+	}
+	return strings.TrimRight(string(body), "\r\n"), nil
+}
+
+// Watch implements WatchableSource.
+func (s *HTTPSource) Watch(ctx context.Context, selector string, value string) error {
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = defaultHTTPPollInterval
+	}
+	return pollForChange(ctx, interval, func() (string, error) {
+		return s.Retrieve(ctx, selector)
+	}, value)
+}
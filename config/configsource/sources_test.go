@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvSource(t *testing.T) {
+	require.NoError(t, os.Setenv("CONFIGSOURCE_TEST_SET", "set"))
+	defer os.Unsetenv("CONFIGSOURCE_TEST_SET")
+	require.NoError(t, os.Setenv("CONFIGSOURCE_TEST_EMPTY", ""))
+	defer os.Unsetenv("CONFIGSOURCE_TEST_EMPTY")
+
+	tests := []struct {
+		selector string
+		want     string
+	}{
+		{selector: "CONFIGSOURCE_TEST_SET", want: "set"},
+		{selector: "CONFIGSOURCE_TEST_SET:-default", want: "set"},
+		{selector: "CONFIGSOURCE_TEST_UNSET", want: ""},
+		{selector: "CONFIGSOURCE_TEST_UNSET:-default", want: "default"},
+		{selector: "CONFIGSOURCE_TEST_EMPTY:-default", want: "default"},
+		{selector: "CONFIGSOURCE_TEST_UNSET:-", want: ""},
+		{selector: "CONFIGSOURCE_TEST_UNSET:-http://localhost:8080", want: "http://localhost:8080"},
+	}
+
+	src := &EnvSource{}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			value, err := src.Retrieve(context.Background(), tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+
+	_, err := src.Retrieve(context.Background(), ":-default")
+	assert.Error(t, err)
+}
+
+func TestFileSource(t *testing.T) {
+	src := &FileSource{}
+	value, err := src.Retrieve(context.Background(), filepath.Join("testdata", "token"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	_, err = src.Retrieve(context.Background(), filepath.Join("testdata", "nonexistent"))
+	assert.Error(t, err)
+}
+
+func TestHTTPSource(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			// The value changes after the second request.
+			if atomic.AddInt32(&requests, 1) <= 2 {
+				w.Write([]byte("first\n"))
+			} else {
+				w.Write([]byte("second\n"))
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	src := &HTTPSource{PollInterval: 10 * time.Millisecond}
+	value, err := src.Retrieve(context.Background(), server.URL+"/token")
+	require.NoError(t, err)
+	assert.Equal(t, "first", value)
+
+	_, err = src.Retrieve(context.Background(), server.URL+"/missing")
+	assert.EqualError(t, err, `unexpected response status "404 Not Found"`)
+
+	_, err = src.Retrieve(context.Background(), "://invalid")
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, src.Watch(ctx, server.URL+"/token", value))
+	assert.EqualValues(t, 3, atomic.LoadInt32(&requests))
+
+	// Watching stops when the context is done.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, src.Watch(ctx, server.URL+"/token", "second"))
+}
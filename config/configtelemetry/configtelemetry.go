@@ -70,7 +70,7 @@ func (l *Level) String() string {
 }
 
 func (l *Level) Set(s string) error {
-	lvl, err := parseLevel(s)
+	lvl, err := ParseLevel(s)
 	if err != nil {
 		return err
 	}
@@ -84,6 +84,13 @@ func GetMetricsLevelFlagValue() Level {
 	return *metricsLevelPtr
 }
 
+// SetMetricsLevel overrides the value of the "--metrics-level" flag, the service uses it
+// to apply the level set in its configuration before the components are created.
+// IMPORTANT: This must be used only in the core collector code for the moment.
+func SetMetricsLevel(level Level) {
+	*metricsLevelPtr = level
+}
+
 // TelemetrySetting exposes the common Telemetry configuration for one component.
 type TelemetrySetting struct {
 	// MetricsLevelStr is the level of telemetry metrics, the possible values are:
@@ -105,12 +112,12 @@ func DefaultTelemetrySetting() TelemetrySetting {
 // GetMetricsLevel returns the parsed level, or error if unknown value.
 // Empty string is consider unknown value.
 func (ts TelemetrySetting) GetMetricsLevel() (Level, error) {
-	return parseLevel(ts.MetricsLevelStr)
+	return ParseLevel(ts.MetricsLevelStr)
 }
 
 // ParseLevel returns the Level represented by the string. The parsing is case-insensitive
 // and it returns error if the string value is unknown.
-func parseLevel(str string) (Level, error) {
+func ParseLevel(str string) (Level, error) {
 	str = strings.ToLower(str)
 
 	switch str {
@@ -57,7 +57,7 @@ func TestParseFrom(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.str, func(t *testing.T) {
-			lvl, err := parseLevel(test.str)
+			lvl, err := ParseLevel(test.str)
 			if test.err {
 				assert.Error(t, err)
 			} else {
@@ -165,3 +165,9 @@ func TestDefaultTelemetrySettings(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, LevelBasic, lvl)
 }
+
+func TestSetMetricsLevel(t *testing.T) {
+	defer SetMetricsLevel(GetMetricsLevelFlagValue())
+	SetMetricsLevel(LevelDetailed)
+	assert.Equal(t, LevelDetailed, GetMetricsLevelFlagValue())
+}
@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+// redactedValue replaces the values of secret settings in RedactSecrets.
+const redactedValue = "[REDACTED]"
+
+// secretKeyParts are the parts of setting names that denote secret values.
+var secretKeyParts = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"api_key",
+	"apikey",
+	"private_key",
+	"authorization",
+	"credential",
+}
+
+// ReadConfigFiles reads and merges the given configuration files and directories
+// (see MergeConfigFiles) into v, replacing any configuration previously read into v.
+func ReadConfigFiles(v *viper.Viper, paths []string) error {
+	merged, err := MergeConfigFiles(paths)
+	if err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	v.SetConfigType("yaml")
+	return v.ReadConfig(bytes.NewReader(out))
+}
+
+// ExpandConfigPaths returns the configuration files for the given paths. A path that
+// is a directory is replaced by the .yaml and .yml files it contains, in lexical order.
+func ExpandConfigPaths(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("config file not specified")
+	}
+
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("error loading config file %q: %v", path, err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("error loading config directory %q: %v", path, err)
+		}
+		var dirFiles []string
+		for _, entry := range entries {
+			if !entry.IsDir() && IsConfigFileName(entry.Name()) {
+				dirFiles = append(dirFiles, filepath.Join(path, entry.Name()))
+			}
+		}
+		if len(dirFiles) == 0 {
+			return nil, fmt.Errorf("config directory %q does not contain any .yaml or .yml file", path)
+		}
+		sort.Strings(dirFiles)
+		files = append(files, dirFiles...)
+	}
+	return files, nil
+}
+
+// IsConfigFileName returns true if the file name has a configuration file extension.
+func IsConfigFileName(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// MergeConfigFiles reads the YAML configuration files and directories (see ExpandConfigPaths)
+// and deep merges them in order. Maps are merged recursively, any other value, including
+// lists, in a later file replaces the value from the earlier files. Keys are compared case
+// insensitively, the same way they are looked up when loading the configuration.
+func MergeConfigFiles(paths []string) (map[string]interface{}, error) {
+	files, err := ExpandConfigPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]interface{})
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error loading config file %q: %v", file, err)
+		}
+		data, err := ParseConfig(content)
+		if err != nil {
+			return nil, fmt.Errorf("error loading config file %q: %v", file, err)
+		}
+		mergeMaps(merged, data)
+	}
+	return merged, nil
+}
+
+// ParseConfig parses a YAML configuration into maps with string keys, like the ones
+// returned by MergeConfigFiles.
+func ParseConfig(content []byte) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+	return normalizeMap(data).(map[string]interface{}), nil
+}
+
+// mergeMaps merges src into dst.
+func mergeMaps(dst, src map[string]interface{}) {
+	for srcKey, srcVal := range src {
+		dstKey, found := findKey(dst, srcKey)
+		if !found {
+			dst[srcKey] = srcVal
+			continue
+		}
+
+		dstMap, dstIsMap := dst[dstKey].(map[string]interface{})
+		srcMap, srcIsMap := srcVal.(map[string]interface{})
+		if dstIsMap && srcIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		// Keep the key as spelled in the later file.
+		delete(dst, dstKey)
+		dst[srcKey] = srcVal
+	}
+}
+
+func findKey(m map[string]interface{}, key string) (string, bool) {
+	if _, ok := m[key]; ok {
+		return key, true
+	}
+	for k := range m {
+		if strings.EqualFold(k, key) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// normalizeMap converts the map[interface{}]interface{} produced by the YAML parser
+// to map[string]interface{}, recursively.
+func normalizeMap(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			result[fmt.Sprint(k)] = normalizeMap(val)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			result[k] = normalizeMap(val)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, val := range v {
+			result[i] = normalizeMap(val)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// RedactSecrets returns a copy of the configuration with the values of all settings
+// whose name suggests they contain a secret (e.g. "password", "token", "api_key" or
+// "authorization") replaced by "[REDACTED]".
+func RedactSecrets(cfg map[string]interface{}) map[string]interface{} {
+	return redactValue(cfg).(map[string]interface{})
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			if val != nil && isSecretKey(k) {
+				result[k] = redactedValue
+				continue
+			}
+			result[k] = redactValue(val)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, val := range v {
+			result[i] = redactValue(val)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
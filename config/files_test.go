@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestExpandConfigPaths(t *testing.T) {
+	files, err := ExpandConfigPaths([]string{
+		path.Join("testdata", "merge", "base.yaml"),
+		path.Join("testdata", "merge", "conf.d"),
+		path.Join("testdata", "merge", "override.yaml"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		path.Join("testdata", "merge", "base.yaml"),
+		path.Join("testdata", "merge", "conf.d", "10-exporters.yaml"),
+		path.Join("testdata", "merge", "conf.d", "20-receivers.yml"),
+		path.Join("testdata", "merge", "override.yaml"),
+	}, files)
+
+	_, err = ExpandConfigPaths(nil)
+	assert.EqualError(t, err, "config file not specified")
+
+	_, err = ExpandConfigPaths([]string{path.Join("testdata", "merge", "nonexistent.yaml")})
+	assert.Error(t, err)
+
+	// A directory without config files is an error.
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	_, err = ExpandConfigPaths([]string{dir})
+	assert.Error(t, err)
+}
+
+func TestMergeConfigFiles(t *testing.T) {
+	merged, err := MergeConfigFiles([]string{
+		path.Join("testdata", "merge", "base.yaml"),
+		path.Join("testdata", "merge", "conf.d"),
+		path.Join("testdata", "merge", "override.yaml"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"examplereceiver": map[string]interface{}{
+				"extra": "from directory",
+			},
+			"examplereceiver/myreceiver": map[string]interface{}{
+				"endpoint": "localhost:12345",
+				// Scalars and lists are replaced.
+				"extra":      "overridden",
+				"extra_list": []interface{}{"c"},
+			},
+		},
+		"exporters": map[string]interface{}{
+			"exampleexporter": map[string]interface{}{
+				"extra":     "some export string",
+				"extra_int": 3,
+				// Maps are merged.
+				"extra_map": map[string]interface{}{
+					"authorization": "Bearer secret",
+					"region":        "eu-west",
+				},
+			},
+		},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers": []interface{}{"examplereceiver", "examplereceiver/myreceiver"},
+					"exporters": []interface{}{"exampleexporter"},
+				},
+			},
+		},
+	}, merged)
+}
+
+func TestMergeMapsCaseInsensitive(t *testing.T) {
+	dst := map[string]interface{}{
+		"Receivers": map[string]interface{}{"otlp": nil},
+	}
+	mergeMaps(dst, map[string]interface{}{
+		"receivers": map[string]interface{}{"jaeger": nil},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"Receivers": map[string]interface{}{"otlp": nil, "jaeger": nil},
+	}, dst)
+}
+
+func TestReadConfigFiles(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	v := NewViper()
+	require.NoError(t, ReadConfigFiles(v, []string{
+		path.Join("testdata", "merge", "base.yaml"),
+		path.Join("testdata", "merge", "override.yaml"),
+	}))
+	cfg, err := Load(v, factories)
+	require.NoError(t, err)
+	require.NoError(t, ValidateConfig(cfg, zap.NewNop()))
+
+	rcv := cfg.Receivers["examplereceiver/myreceiver"].(*componenttest.ExampleReceiver)
+	assert.Equal(t, "localhost:12345", rcv.Endpoint)
+	assert.Equal(t, "overridden", rcv.ExtraSetting)
+	assert.Equal(t, []string{"c"}, rcv.ExtraListSetting)
+	assert.Equal(t, []string{"examplereceiver", "examplereceiver/myreceiver"}, cfg.Service.Pipelines["traces"].Receivers)
+
+	// Reading again replaces the previous configuration.
+	require.NoError(t, ReadConfigFiles(v, []string{path.Join("testdata", "merge", "base.yaml")}))
+	cfg, err = Load(v, factories)
+	require.NoError(t, err)
+	assert.Equal(t, "some string", cfg.Receivers["examplereceiver/myreceiver"].(*componenttest.ExampleReceiver).ExtraSetting)
+	assert.Equal(t, []string{"examplereceiver"}, cfg.Service.Pipelines["traces"].Receivers)
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+receivers:
+  examplereceiver:
+    extra_list: [1, {nested: true}]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"examplereceiver": map[string]interface{}{
+				"extra_list": []interface{}{1, map[string]interface{}{"nested": true}},
+			},
+		},
+	}, cfg)
+
+	cfg, err = ParseConfig(nil)
+	require.NoError(t, err)
+	assert.Empty(t, cfg)
+
+	_, err = ParseConfig([]byte("- not a map"))
+	assert.Error(t, err)
+}
+
+func TestRedactSecrets(t *testing.T) {
+	cfg := map[string]interface{}{
+		"exporters": map[string]interface{}{
+			"otlphttp": map[string]interface{}{
+				"endpoint": "https://example.com",
+				"headers": map[string]interface{}{
+					"Authorization": "Bearer 1234",
+					"x-scope":       "tenant",
+				},
+				"key_file": "/var/lib/key.pem",
+			},
+			"kafka": map[string]interface{}{
+				"auth": map[string]interface{}{
+					"plain_text": map[string]interface{}{
+						"username": "user",
+						"password": "pass",
+					},
+				},
+			},
+			"custom": map[string]interface{}{
+				"api_key":  "abc",
+				"token":    nil,
+				"services": []interface{}{map[string]interface{}{"client_secret": "xyz"}},
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"exporters": map[string]interface{}{
+			"otlphttp": map[string]interface{}{
+				"endpoint": "https://example.com",
+				"headers": map[string]interface{}{
+					"Authorization": "[REDACTED]",
+					"x-scope":       "tenant",
+				},
+				"key_file": "/var/lib/key.pem",
+			},
+			"kafka": map[string]interface{}{
+				"auth": map[string]interface{}{
+					"plain_text": map[string]interface{}{
+						"username": "user",
+						"password": "[REDACTED]",
+					},
+				},
+			},
+			"custom": map[string]interface{}{
+				"api_key":  "[REDACTED]",
+				"token":    nil,
+				"services": []interface{}{map[string]interface{}{"client_secret": "[REDACTED]"}},
+			},
+		},
+	}, RedactSecrets(cfg))
+
+	// The original configuration is not modified.
+	assert.Equal(t, "pass", cfg["exporters"].(map[string]interface{})["kafka"].(map[string]interface{})["auth"].(map[string]interface{})["plain_text"].(map[string]interface{})["password"])
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardconnector
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "forward"
+)
+
+// NewFactory creates a factory for the forward connector.
+func NewFactory() component.ConnectorFactory {
+	return &factory{}
+}
+
+type factory struct{}
+
+var _ component.ConnectorFactory = (*factory)(nil)
+
+// Type gets the type of the Connector config created by this factory.
+func (f *factory) Type() configmodels.Type {
+	return typeStr
+}
+
+// CreateDefaultConfig creates the default configuration for the Connector.
+func (f *factory) CreateDefaultConfig() configmodels.Connector {
+	return &Config{
+		ConnectorSettings: configmodels.ConnectorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+	}
+}
+
+// CreateTracesConnector creates a connector forwarding traces to traces pipelines.
+func (f *factory) CreateTracesConnector(
+	_ context.Context,
+	_ component.ConnectorCreateParams,
+	_ configmodels.Connector,
+	nextConsumers component.ConnectorConsumers,
+) (component.TracesConnector, error) {
+	if nextConsumers.Traces == nil || nextConsumers.Metrics != nil || nextConsumers.Logs != nil {
+		return nil, configerror.ErrDataTypeIsNotSupported
+	}
+	return &tracesConnector{nextConsumer: nextConsumers.Traces}, nil
+}
+
+// CreateMetricsConnector creates a connector forwarding metrics to metrics pipelines.
+func (f *factory) CreateMetricsConnector(
+	_ context.Context,
+	_ component.ConnectorCreateParams,
+	_ configmodels.Connector,
+	nextConsumers component.ConnectorConsumers,
+) (component.MetricsConnector, error) {
+	if nextConsumers.Metrics == nil || nextConsumers.Traces != nil || nextConsumers.Logs != nil {
+		return nil, configerror.ErrDataTypeIsNotSupported
+	}
+	return &metricsConnector{nextConsumer: nextConsumers.Metrics}, nil
+}
+
+// CreateLogsConnector creates a connector forwarding logs to logs pipelines.
+func (f *factory) CreateLogsConnector(
+	_ context.Context,
+	_ component.ConnectorCreateParams,
+	_ configmodels.Connector,
+	nextConsumers component.ConnectorConsumers,
+) (component.LogsConnector, error) {
+	if nextConsumers.Logs == nil || nextConsumers.Traces != nil || nextConsumers.Metrics != nil {
+		return nil, configerror.ErrDataTypeIsNotSupported
+	}
+	return &logsConnector{nextConsumer: nextConsumers.Logs}, nil
+}
+
+// nopComponent implements the lifecycle of the connectors, which have nothing to
+// start nor shut down.
+type nopComponent struct{}
+
+func (nopComponent) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (nopComponent) Shutdown(context.Context) error {
+	return nil
+}
+
+type tracesConnector struct {
+	nopComponent
+	nextConsumer consumer.TracesConsumer
+}
+
+func (c *tracesConnector) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	return c.nextConsumer.ConsumeTraces(ctx, td)
+}
+
+type metricsConnector struct {
+	nopComponent
+	nextConsumer consumer.MetricsConsumer
+}
+
+func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return c.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+type logsConnector struct {
+	nopComponent
+	nextConsumer consumer.LogsConsumer
+}
+
+func (c *logsConnector) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return c.nextConsumer.ConsumeLogs(ctx, ld)
+}
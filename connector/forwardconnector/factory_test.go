@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateTracesConnector(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	sink := new(consumertest.TracesSink)
+
+	conn, err := factory.CreateTracesConnector(context.Background(), component.ConnectorCreateParams{}, cfg,
+		component.ConnectorConsumers{Traces: sink})
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	td := testdata.GenerateTraceDataOneSpan()
+	assert.NoError(t, conn.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, conn.Shutdown(context.Background()))
+	assert.Equal(t, 1, sink.SpansCount())
+
+	_, err = factory.CreateTracesConnector(context.Background(), component.ConnectorCreateParams{}, cfg,
+		component.ConnectorConsumers{Traces: sink, Metrics: new(consumertest.MetricsSink)})
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+}
+
+func TestCreateMetricsConnector(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	sink := new(consumertest.MetricsSink)
+
+	conn, err := factory.CreateMetricsConnector(context.Background(), component.ConnectorCreateParams{}, cfg,
+		component.ConnectorConsumers{Metrics: sink})
+	require.NoError(t, err)
+	md := testdata.GenerateMetricsOneMetric()
+	assert.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, 1, sink.MetricsCount())
+
+	_, err = factory.CreateMetricsConnector(context.Background(), component.ConnectorCreateParams{}, cfg,
+		component.ConnectorConsumers{Logs: new(consumertest.LogsSink)})
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+}
+
+func TestCreateLogsConnector(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	sink := new(consumertest.LogsSink)
+
+	conn, err := factory.CreateLogsConnector(context.Background(), component.ConnectorCreateParams{}, cfg,
+		component.ConnectorConsumers{Logs: sink})
+	require.NoError(t, err)
+	ld := testdata.GenerateLogDataOneLog()
+	assert.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, 1, sink.LogRecordsCount())
+
+	_, err = factory.CreateLogsConnector(context.Background(), component.ConnectorCreateParams{}, cfg,
+		component.ConnectorConsumers{Traces: new(consumertest.TracesSink)})
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+}
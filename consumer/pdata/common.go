@@ -18,7 +18,10 @@ package pdata
 // such as timestamps, attributes, etc.
 
 import (
+	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	otlpcommon "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/common/v1"
@@ -319,6 +322,8 @@ func (a AttributeValue) CopyTo(dest AttributeValue) {
 }
 
 // Equal checks for equality, it returns true if the objects are equal otherwise false.
+// Maps are equal if they have the same keys with equal values, regardless of order,
+// arrays are equal if they have equal elements in the same order.
 func (a AttributeValue) Equal(av AttributeValue) bool {
 	if (*a.orig) == nil || (*a.orig).Value == nil {
 		return (*av.orig) == nil || (*av.orig).Value == nil
@@ -326,6 +331,9 @@ func (a AttributeValue) Equal(av AttributeValue) bool {
 	if (*av.orig) == nil || (*av.orig).Value == nil {
 		return false
 	}
+	if a.Type() != av.Type() {
+		return false
+	}
 
 	switch v := (*a.orig).Value.(type) {
 	case *otlpcommon.AnyValue_StringValue:
@@ -336,11 +344,116 @@ func (a AttributeValue) Equal(av AttributeValue) bool {
 		return v.IntValue == (*av.orig).GetIntValue()
 	case *otlpcommon.AnyValue_DoubleValue:
 		return v.DoubleValue == (*av.orig).GetDoubleValue()
+	case *otlpcommon.AnyValue_KvlistValue:
+		return a.MapVal().Equal(av.MapVal())
+	case *otlpcommon.AnyValue_ArrayValue:
+		return a.ArrayVal().Equal(av.ArrayVal())
 	}
-	// TODO: handle MAP and ARRAY data types.
 	return false
 }
 
+// AsRaw converts the AttributeValue to a standard go value: nil, string, int64, float64,
+// bool, map[string]interface{} for maps and []interface{} for arrays.
+//
+// Calling this function on zero-initialized AttributeValue will cause a panic.
+func (a AttributeValue) AsRaw() interface{} {
+	switch a.Type() {
+	case AttributeValueSTRING:
+		return a.StringVal()
+	case AttributeValueINT:
+		return a.IntVal()
+	case AttributeValueDOUBLE:
+		return a.DoubleVal()
+	case AttributeValueBOOL:
+		return a.BoolVal()
+	case AttributeValueMAP:
+		return a.MapVal().AsRaw()
+	case AttributeValueARRAY:
+		return a.ArrayVal().AsRaw()
+	}
+	return nil
+}
+
+// String renders the AttributeValue in a JSON-like form, the keys of maps are sorted.
+func (a AttributeValue) String() string {
+	var sb strings.Builder
+	writeAttributeValue(&sb, a)
+	return sb.String()
+}
+
+// attributeValueFromRaw sets dest to the OTLP representation of the raw value.
+func attributeValueFromRaw(dest *otlpcommon.AnyValue, raw interface{}) error {
+	switch val := raw.(type) {
+	case nil:
+		dest.Value = nil
+	case string:
+		dest.Value = &otlpcommon.AnyValue_StringValue{StringValue: val}
+	case bool:
+		dest.Value = &otlpcommon.AnyValue_BoolValue{BoolValue: val}
+	case int:
+		dest.Value = &otlpcommon.AnyValue_IntValue{IntValue: int64(val)}
+	case int8:
+		dest.Value = &otlpcommon.AnyValue_IntValue{IntValue: int64(val)}
+	case int16:
+		dest.Value = &otlpcommon.AnyValue_IntValue{IntValue: int64(val)}
+	case int32:
+		dest.Value = &otlpcommon.AnyValue_IntValue{IntValue: int64(val)}
+	case int64:
+		dest.Value = &otlpcommon.AnyValue_IntValue{IntValue: val}
+	case uint:
+		dest.Value = &otlpcommon.AnyValue_IntValue{IntValue: int64(val)}
+	case uint8:
+		dest.Value = &otlpcommon.AnyValue_IntValue{IntValue: int64(val)}
+	case uint16:
+		dest.Value = &otlpcommon.AnyValue_IntValue{IntValue: int64(val)}
+	case uint32:
+		dest.Value = &otlpcommon.AnyValue_IntValue{IntValue: int64(val)}
+	case uint64:
+		dest.Value = &otlpcommon.AnyValue_IntValue{IntValue: int64(val)}
+	case float32:
+		dest.Value = &otlpcommon.AnyValue_DoubleValue{DoubleValue: float64(val)}
+	case float64:
+		dest.Value = &otlpcommon.AnyValue_DoubleValue{DoubleValue: val}
+	case map[string]interface{}:
+		kvs, err := keyValuesFromRaw(val)
+		if err != nil {
+			return err
+		}
+		dest.Value = &otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{Values: kvs}}
+	case []interface{}:
+		values := make([]*otlpcommon.AnyValue, len(val))
+		for i := range val {
+			values[i] = &otlpcommon.AnyValue{}
+			if err := attributeValueFromRaw(values[i], val[i]); err != nil {
+				return err
+			}
+		}
+		dest.Value = &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{Values: values}}
+	default:
+		return fmt.Errorf("unsupported attribute value type %T", raw)
+	}
+	return nil
+}
+
+// keyValuesFromRaw returns the OTLP representation of the raw map.
+func keyValuesFromRaw(rawMap map[string]interface{}) ([]otlpcommon.KeyValue, error) {
+	if len(rawMap) == 0 {
+		return nil, nil
+	}
+	anyVals := make([]otlpcommon.AnyValue, len(rawMap))
+	origs := make([]otlpcommon.KeyValue, len(rawMap))
+	ix := 0
+	for k, v := range rawMap {
+		if err := attributeValueFromRaw(&anyVals[ix], v); err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", k, err)
+		}
+		origs[ix].Key = k
+		origs[ix].Value = &anyVals[ix]
+		ix++
+	}
+	return origs, nil
+}
+
 func newAttributeKeyValueString(k string, v string) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k, Value: &otlpcommon.AnyValue{}}
 	akv := AttributeValue{&orig.Value}
@@ -684,6 +797,99 @@ func (am AttributeMap) CopyTo(dest AttributeMap) {
 	*dest.orig = origs
 }
 
+// InitFromRaw overwrites the entire AttributeMap with the values of the given standard go map.
+// Supported values are nil, strings, bools, integers, floats, map[string]interface{} and
+// []interface{} with elements of these types. If an unsupported value is found, an error is
+// returned and the AttributeMap is left unchanged.
+func (am AttributeMap) InitFromRaw(rawMap map[string]interface{}) error {
+	origs, err := keyValuesFromRaw(rawMap)
+	if err != nil {
+		return err
+	}
+	*am.orig = origs
+	return nil
+}
+
+// AsRaw converts the AttributeMap to a standard go map, see AttributeValue.AsRaw for
+// the types of the values.
+func (am AttributeMap) AsRaw() map[string]interface{} {
+	rawMap := make(map[string]interface{}, len(*am.orig))
+	am.ForEach(func(k string, v AttributeValue) {
+		rawMap[k] = v.AsRaw()
+	})
+	return rawMap
+}
+
+// Equal returns true if both maps have the same keys with equal values, regardless of
+// the order of the entries.
+func (am AttributeMap) Equal(other AttributeMap) bool {
+	if len(*am.orig) != len(*other.orig) {
+		return false
+	}
+	for i := range *am.orig {
+		akv := &(*am.orig)[i]
+		v, ok := other.Get(akv.Key)
+		if !ok || !newAttributeValue(&akv.Value).Equal(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns a hash of the entries of the map that does not depend on their order.
+// Equal maps have the same hash, also across processes, so it can be used as a key to
+// group data with the same attributes.
+func (am AttributeMap) Hash() uint64 {
+	return keyValuesHash(*am.orig)
+}
+
+// ForEachSorted iterates over the elements in the map in the order of their keys,
+// without changing the order of the map.
+func (am AttributeMap) ForEachSorted(f func(k string, v AttributeValue)) {
+	indexes := make([]int, len(*am.orig))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return (*am.orig)[indexes[i]].Key < (*am.orig)[indexes[j]].Key
+	})
+	for _, i := range indexes {
+		kv := &(*am.orig)[i]
+		f(kv.Key, AttributeValue{&kv.Value})
+	}
+}
+
+// String renders the AttributeMap in a JSON-like form with sorted keys, e.g.
+// {"a":"b","c":123}.
+func (am AttributeMap) String() string {
+	var sb strings.Builder
+	writeAttributeMap(&sb, am)
+	return sb.String()
+}
+
+// Equal returns true if both arrays have equal elements in the same order.
+func (es AnyValueArray) Equal(other AnyValueArray) bool {
+	if es.Len() != other.Len() {
+		return false
+	}
+	for i := 0; i < es.Len(); i++ {
+		if !es.At(i).Equal(other.At(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// AsRaw converts the AnyValueArray to a standard go slice, see AttributeValue.AsRaw for
+// the types of the elements.
+func (es AnyValueArray) AsRaw() []interface{} {
+	rawSlice := make([]interface{}, es.Len())
+	for i := 0; i < es.Len(); i++ {
+		rawSlice[i] = es.At(i).AsRaw()
+	}
+	return rawSlice
+}
+
 // StringMap stores a map of attribute keys to values.
 type StringMap struct {
 	orig *[]otlpcommon.StringKeyValue
@@ -843,6 +1049,116 @@ func (sm StringMap) Sort() StringMap {
 	return sm
 }
 
+// AsRaw converts the StringMap to a standard go map.
+func (sm StringMap) AsRaw() map[string]string {
+	rawMap := make(map[string]string, len(*sm.orig))
+	sm.ForEach(func(k string, v string) {
+		rawMap[k] = v
+	})
+	return rawMap
+}
+
+// Equal returns true if both maps have the same keys with equal values, regardless of
+// the order of the entries.
+func (sm StringMap) Equal(other StringMap) bool {
+	if len(*sm.orig) != len(*other.orig) {
+		return false
+	}
+	for i := range *sm.orig {
+		skv := &(*sm.orig)[i]
+		v, ok := other.Get(skv.Key)
+		if !ok || v != skv.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns a hash of the entries of the map that does not depend on their order.
+// Equal maps have the same hash, also across processes, and it is the same as the
+// hash of an AttributeMap with the same string values.
+func (sm StringMap) Hash() uint64 {
+	return stringKeyValuesHash(*sm.orig)
+}
+
+// ForEachSorted iterates over the elements in the map in the order of their keys,
+// without changing the order of the map.
+func (sm StringMap) ForEachSorted(f func(k string, v string)) {
+	indexes := make([]int, len(*sm.orig))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return (*sm.orig)[indexes[i]].Key < (*sm.orig)[indexes[j]].Key
+	})
+	for _, i := range indexes {
+		skv := &(*sm.orig)[i]
+		f(skv.Key, skv.Value)
+	}
+}
+
+// String renders the StringMap in a JSON-like form with sorted keys, e.g. {"a":"b"}.
+func (sm StringMap) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	first := true
+	sm.ForEachSorted(func(k string, v string) {
+		if !first {
+			sb.WriteByte(',')
+		}
+		first = false
+		sb.WriteString(strconv.Quote(k))
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Quote(v))
+	})
+	sb.WriteByte('}')
+	return sb.String()
+}
+
 func newStringKeyValue(k, v string) otlpcommon.StringKeyValue {
 	return otlpcommon.StringKeyValue{Key: k, Value: v}
 }
+
+// writeAttributeValue writes the JSON-like rendering of the value to sb.
+func writeAttributeValue(sb *strings.Builder, v AttributeValue) {
+	switch v.Type() {
+	case AttributeValueSTRING:
+		sb.WriteString(strconv.Quote(v.StringVal()))
+	case AttributeValueINT:
+		sb.WriteString(strconv.FormatInt(v.IntVal(), 10))
+	case AttributeValueDOUBLE:
+		sb.WriteString(strconv.FormatFloat(v.DoubleVal(), 'f', -1, 64))
+	case AttributeValueBOOL:
+		sb.WriteString(strconv.FormatBool(v.BoolVal()))
+	case AttributeValueMAP:
+		writeAttributeMap(sb, v.MapVal())
+	case AttributeValueARRAY:
+		arr := v.ArrayVal()
+		sb.WriteByte('[')
+		for i := 0; i < arr.Len(); i++ {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeAttributeValue(sb, arr.At(i))
+		}
+		sb.WriteByte(']')
+	default:
+		sb.WriteString("null")
+	}
+}
+
+// writeAttributeMap writes the JSON-like rendering of the map to sb, with sorted keys.
+func writeAttributeMap(sb *strings.Builder, am AttributeMap) {
+	sb.WriteByte('{')
+	first := true
+	am.ForEachSorted(func(k string, v AttributeValue) {
+		if !first {
+			sb.WriteByte(',')
+		}
+		first = false
+		sb.WriteString(strconv.Quote(k))
+		sb.WriteByte(':')
+		writeAttributeValue(sb, v)
+	})
+	sb.WriteByte('}')
+}
@@ -1152,3 +1152,258 @@ func TestAnyValueArrayWithNilValues(t *testing.T) {
 	assert.EqualValues(t, AttributeValueSTRING, val.Type())
 	assert.EqualValues(t, "other_value", val.StringVal())
 }
+
+func TestAttributeValueEqual_Types(t *testing.T) {
+	assert.False(t, NewAttributeValueString("").Equal(NewAttributeValueInt(0)))
+	assert.False(t, NewAttributeValueInt(0).Equal(NewAttributeValueDouble(0)))
+	assert.False(t, NewAttributeValueBool(false).Equal(NewAttributeValueString("")))
+	assert.False(t, NewAttributeValueMap().Equal(NewAttributeValueArray()))
+}
+
+func TestAttributeValueEqual_Nested(t *testing.T) {
+	newValue := func() AttributeValue {
+		v := NewAttributeValueMap()
+		v.MapVal().InsertString("a", "b")
+		arr := NewAttributeValueArray()
+		arr.ArrayVal().Append(NewAttributeValueInt(1))
+		arr.ArrayVal().Append(NewAttributeValueString("c"))
+		v.MapVal().Insert("arr", arr)
+		return v
+	}
+	v1 := newValue()
+	v2 := newValue()
+	assert.True(t, v1.Equal(v2))
+
+	// The order of the map entries does not matter.
+	v2.MapVal().Sort()
+	v1.MapVal().Delete("a")
+	v1.MapVal().InsertString("a", "b")
+	assert.True(t, v1.Equal(v2))
+
+	v2.MapVal().UpdateString("a", "c")
+	assert.False(t, v1.Equal(v2))
+
+	// The order of the array elements matters.
+	v2 = newValue()
+	arr, _ := v2.MapVal().Get("arr")
+	arr.ArrayVal().At(0).SetStringVal("c")
+	arr.ArrayVal().At(1).SetIntVal(1)
+	assert.False(t, v1.Equal(v2))
+
+	arr.ArrayVal().Resize(1)
+	assert.False(t, v1.Equal(v2))
+}
+
+func TestAttributeMap_Equal(t *testing.T) {
+	am := NewAttributeMap().InitFromMap(map[string]AttributeValue{
+		"str": NewAttributeValueString("value"),
+		"int": NewAttributeValueInt(1),
+	})
+	assert.True(t, am.Equal(am))
+	assert.True(t, NewAttributeMap().Equal(NewAttributeMap()))
+	assert.False(t, am.Equal(NewAttributeMap()))
+	assert.False(t, NewAttributeMap().Equal(am))
+
+	other := NewAttributeMap()
+	other.InsertInt("int", 1)
+	other.InsertString("str", "value")
+	assert.True(t, am.Equal(other))
+	assert.True(t, other.Equal(am))
+
+	other.UpdateInt("int", 2)
+	assert.False(t, am.Equal(other))
+
+	other.Delete("int")
+	other.InsertInt("other", 1)
+	assert.False(t, am.Equal(other))
+}
+
+func TestAttributeMap_Hash(t *testing.T) {
+	am := NewAttributeMap()
+	am.InsertString("a", "b")
+	am.InsertInt("c", 1)
+	other := NewAttributeMap()
+	other.InsertInt("c", 1)
+	other.InsertString("a", "b")
+	assert.Equal(t, am.Hash(), other.Hash())
+
+	other.UpdateInt("c", 2)
+	assert.NotEqual(t, am.Hash(), other.Hash())
+	assert.NotEqual(t, am.Hash(), NewAttributeMap().Hash())
+}
+
+func TestAttributeMap_ForEachSorted(t *testing.T) {
+	am := NewAttributeMap()
+	am.InsertString("c", "3")
+	am.InsertString("a", "1")
+	am.InsertString("b", "2")
+
+	var values []string
+	am.ForEachSorted(func(k string, v AttributeValue) {
+		values = append(values, k+"="+v.StringVal())
+	})
+	assert.Equal(t, []string{"a=1", "b=2", "c=3"}, values)
+
+	// The map is not modified.
+	var keys []string
+	am.ForEach(func(k string, _ AttributeValue) {
+		keys = append(keys, k)
+	})
+	assert.Equal(t, []string{"c", "a", "b"}, keys)
+
+	NewAttributeMap().ForEachSorted(func(string, AttributeValue) {
+		t.Fail()
+	})
+}
+
+func TestAttributeMap_AsRaw(t *testing.T) {
+	am := NewAttributeMap()
+	am.InsertNull("null")
+	am.InsertString("str", "value")
+	am.InsertInt("int", 1)
+	am.InsertDouble("double", 1.5)
+	am.InsertBool("bool", true)
+	nested := NewAttributeValueMap()
+	nested.MapVal().InsertString("a", "b")
+	am.Insert("map", nested)
+	arr := NewAttributeValueArray()
+	arr.ArrayVal().Append(NewAttributeValueInt(1))
+	arr.ArrayVal().Append(nested)
+	am.Insert("array", arr)
+
+	assert.Equal(t, map[string]interface{}{
+		"null":   nil,
+		"str":    "value",
+		"int":    int64(1),
+		"double": 1.5,
+		"bool":   true,
+		"map":    map[string]interface{}{"a": "b"},
+		"array":  []interface{}{int64(1), map[string]interface{}{"a": "b"}},
+	}, am.AsRaw())
+	assert.Equal(t, map[string]interface{}{}, NewAttributeMap().AsRaw())
+}
+
+func TestAttributeMap_InitFromRaw(t *testing.T) {
+	raw := map[string]interface{}{
+		"null":    nil,
+		"str":     "value",
+		"int":     1,
+		"int8":    int8(2),
+		"uint64":  uint64(3),
+		"float32": float32(1.5),
+		"double":  2.5,
+		"bool":    true,
+		"map":     map[string]interface{}{"a": "b"},
+		"array":   []interface{}{1, "c", map[string]interface{}{"d": false}},
+	}
+	am := NewAttributeMap()
+	require.NoError(t, am.InitFromRaw(raw))
+
+	nested := NewAttributeValueMap()
+	nested.MapVal().InsertString("a", "b")
+	arr := NewAttributeValueArray()
+	arr.ArrayVal().Append(NewAttributeValueInt(1))
+	arr.ArrayVal().Append(NewAttributeValueString("c"))
+	nestedInArray := NewAttributeValueMap()
+	nestedInArray.MapVal().InsertBool("d", false)
+	arr.ArrayVal().Append(nestedInArray)
+	expected := NewAttributeMap().InitFromMap(map[string]AttributeValue{
+		"null":    NewAttributeValueNull(),
+		"str":     NewAttributeValueString("value"),
+		"int":     NewAttributeValueInt(1),
+		"int8":    NewAttributeValueInt(2),
+		"uint64":  NewAttributeValueInt(3),
+		"float32": NewAttributeValueDouble(1.5),
+		"double":  NewAttributeValueDouble(2.5),
+		"bool":    NewAttributeValueBool(true),
+		"map":     nested,
+		"array":   arr,
+	})
+	assert.True(t, expected.Equal(am), "%v != %v", expected, am)
+
+	// Round trip with the standard go types.
+	require.NoError(t, am.InitFromRaw(expected.AsRaw()))
+	assert.True(t, expected.Equal(am))
+
+	require.NoError(t, am.InitFromRaw(nil))
+	assert.Equal(t, 0, am.Len())
+}
+
+func TestAttributeMap_InitFromRawInvalid(t *testing.T) {
+	am := NewAttributeMap()
+	am.InsertString("a", "b")
+	err := am.InitFromRaw(map[string]interface{}{"k": []interface{}{struct{}{}}})
+	assert.EqualError(t, err, `attribute "k": unsupported attribute value type struct {}`)
+	// The map is unchanged.
+	assert.Equal(t, map[string]interface{}{"a": "b"}, am.AsRaw())
+}
+
+func TestAttributeMap_String(t *testing.T) {
+	am := NewAttributeMap()
+	assert.Equal(t, "{}", am.String())
+
+	am.InsertString("str", "quote\"")
+	am.InsertInt("int", 1)
+	am.InsertDouble("double", 1.5)
+	am.InsertBool("bool", false)
+	am.InsertNull("null")
+	nested := NewAttributeValueMap()
+	nested.MapVal().InsertString("b", "c")
+	nested.MapVal().InsertString("a", "d")
+	am.Insert("map", nested)
+	arr := NewAttributeValueArray()
+	arr.ArrayVal().Append(NewAttributeValueInt(1))
+	arr.ArrayVal().Append(NewAttributeValueString("x"))
+	am.Insert("array", arr)
+
+	assert.Equal(t,
+		`{"array":[1,"x"],"bool":false,"double":1.5,"int":1,"map":{"a":"d","b":"c"},"null":null,"str":"quote\""}`,
+		am.String())
+	assert.Equal(t, `{"a":"d","b":"c"}`, nested.String())
+	assert.Equal(t, `"x"`, NewAttributeValueString("x").String())
+}
+
+func TestStringMap_Equal(t *testing.T) {
+	sm := NewStringMap().InitFromMap(map[string]string{"a": "1", "b": "2"})
+	other := NewStringMap()
+	other.Insert("b", "2")
+	other.Insert("a", "1")
+	assert.True(t, sm.Equal(other))
+	assert.Equal(t, sm.Hash(), other.Hash())
+
+	other.Update("a", "3")
+	assert.False(t, sm.Equal(other))
+	assert.NotEqual(t, sm.Hash(), other.Hash())
+
+	assert.False(t, sm.Equal(NewStringMap()))
+	assert.True(t, NewStringMap().Equal(NewStringMap()))
+}
+
+func TestStringMap_ForEachSorted(t *testing.T) {
+	sm := NewStringMap()
+	sm.Insert("b", "2")
+	sm.Insert("c", "3")
+	sm.Insert("a", "1")
+
+	var values []string
+	sm.ForEachSorted(func(k string, v string) {
+		values = append(values, k+"="+v)
+	})
+	assert.Equal(t, []string{"a=1", "b=2", "c=3"}, values)
+	k, _ := sm.get("b")
+	assert.Equal(t, &(*sm.orig)[0], k)
+}
+
+func TestStringMap_AsRaw(t *testing.T) {
+	raw := map[string]string{"a": "1", "b": "2"}
+	assert.Equal(t, raw, NewStringMap().InitFromMap(raw).AsRaw())
+	assert.Equal(t, map[string]string{}, NewStringMap().AsRaw())
+}
+
+func TestStringMap_String(t *testing.T) {
+	assert.Equal(t, "{}", NewStringMap().String())
+	sm := NewStringMap()
+	sm.Insert("b", "2")
+	sm.Insert("a", "\"1\"")
+	assert.Equal(t, `{"a":"\"1\"","b":"2"}`, sm.String())
+}
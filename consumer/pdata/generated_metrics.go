@@ -18,6 +18,7 @@
 package pdata
 
 import (
+	otlpcommon "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/common/v1"
 	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/metrics/v1"
 )
 
@@ -1024,6 +1025,79 @@ func (ms DoubleHistogram) CopyTo(dest DoubleHistogram) {
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
 
+// ExponentialHistogram represents the type of a metric that is calculated by aggregating as an
+// ExponentialHistogram of all reported double measurements over a time interval.
+//
+// This is a reference type, if passed by value and callee modifies it the
+// caller will see the modification.
+//
+// Must use NewExponentialHistogram function to create new instances.
+// Important: zero-initialized instance is not valid for use.
+type ExponentialHistogram struct {
+	// orig points to the pointer otlpmetrics.ExponentialHistogram field contained somewhere else.
+	// We use pointer-to-pointer to be able to modify it in InitEmpty func.
+	orig **otlpmetrics.ExponentialHistogram
+}
+
+func newExponentialHistogram(orig **otlpmetrics.ExponentialHistogram) ExponentialHistogram {
+	return ExponentialHistogram{orig}
+}
+
+// NewExponentialHistogram creates a new "nil" ExponentialHistogram.
+// To initialize the struct call "InitEmpty".
+//
+// This must be used only in testing code since no "Set" method available.
+func NewExponentialHistogram() ExponentialHistogram {
+	orig := (*otlpmetrics.ExponentialHistogram)(nil)
+	return newExponentialHistogram(&orig)
+}
+
+// InitEmpty overwrites the current value with empty.
+func (ms ExponentialHistogram) InitEmpty() {
+	*ms.orig = &otlpmetrics.ExponentialHistogram{}
+}
+
+// IsNil returns true if the underlying data are nil.
+//
+// Important: All other functions will cause a runtime error if this returns "true".
+func (ms ExponentialHistogram) IsNil() bool {
+	return *ms.orig == nil
+}
+
+// AggregationTemporality returns the aggregationtemporality associated with this ExponentialHistogram.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogram) AggregationTemporality() AggregationTemporality {
+	return AggregationTemporality((*ms.orig).AggregationTemporality)
+}
+
+// SetAggregationTemporality replaces the aggregationtemporality associated with this ExponentialHistogram.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogram) SetAggregationTemporality(v AggregationTemporality) {
+	(*ms.orig).AggregationTemporality = otlpmetrics.AggregationTemporality(v)
+}
+
+// DataPoints returns the DataPoints associated with this ExponentialHistogram.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogram) DataPoints() ExponentialHistogramDataPointSlice {
+	return newExponentialHistogramDataPointSlice(&(*ms.orig).DataPoints)
+}
+
+// CopyTo copies all properties from the current struct to the dest.
+func (ms ExponentialHistogram) CopyTo(dest ExponentialHistogram) {
+	if ms.IsNil() {
+		*dest.orig = nil
+		return
+	}
+	if dest.IsNil() {
+		dest.InitEmpty()
+	}
+	dest.SetAggregationTemporality(ms.AggregationTemporality())
+	ms.DataPoints().CopyTo(dest.DataPoints())
+}
+
 // DoubleSummary represents the type of a metric that is calculated by aggregating as a Summary of all reported double measurements over a time interval.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -2067,6 +2141,356 @@ func (ms DoubleHistogramDataPoint) CopyTo(dest DoubleHistogramDataPoint) {
 	ms.Exemplars().CopyTo(dest.Exemplars())
 }
 
+// ExponentialHistogramDataPointSlice logically represents a slice of ExponentialHistogramDataPoint.
+//
+// This is a reference type, if passed by value and callee modifies it the
+// caller will see the modification.
+//
+// Must use NewExponentialHistogramDataPointSlice function to create new instances.
+// Important: zero-initialized instance is not valid for use.
+type ExponentialHistogramDataPointSlice struct {
+	// orig points to the slice otlpmetrics.ExponentialHistogramDataPoint field contained somewhere else.
+	// We use pointer-to-slice to be able to modify it in functions like Resize.
+	orig *[]*otlpmetrics.ExponentialHistogramDataPoint
+}
+
+func newExponentialHistogramDataPointSlice(orig *[]*otlpmetrics.ExponentialHistogramDataPoint) ExponentialHistogramDataPointSlice {
+	return ExponentialHistogramDataPointSlice{orig}
+}
+
+// NewExponentialHistogramDataPointSlice creates a ExponentialHistogramDataPointSlice with 0 elements.
+// Can use "Resize" to initialize with a given length.
+func NewExponentialHistogramDataPointSlice() ExponentialHistogramDataPointSlice {
+	orig := []*otlpmetrics.ExponentialHistogramDataPoint(nil)
+	return ExponentialHistogramDataPointSlice{&orig}
+}
+
+// Len returns the number of elements in the slice.
+//
+// Returns "0" for a newly instance created with "NewExponentialHistogramDataPointSlice()".
+func (es ExponentialHistogramDataPointSlice) Len() int {
+	return len(*es.orig)
+}
+
+// At returns the element at the given index.
+//
+// This function is used mostly for iterating over all the values in the slice:
+// for i := 0; i < es.Len(); i++ {
+//     e := es.At(i)
+//     ... // Do something with the element
+// }
+func (es ExponentialHistogramDataPointSlice) At(ix int) ExponentialHistogramDataPoint {
+	return newExponentialHistogramDataPoint(&(*es.orig)[ix])
+}
+
+// MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
+// The current slice will be cleared.
+func (es ExponentialHistogramDataPointSlice) MoveAndAppendTo(dest ExponentialHistogramDataPointSlice) {
+	if *dest.orig == nil {
+		// We can simply move the entire vector and avoid any allocations.
+		*dest.orig = *es.orig
+	} else {
+		*dest.orig = append(*dest.orig, *es.orig...)
+	}
+	*es.orig = nil
+}
+
+// CopyTo copies all elements from the current slice to the dest.
+func (es ExponentialHistogramDataPointSlice) CopyTo(dest ExponentialHistogramDataPointSlice) {
+	srcLen := es.Len()
+	destCap := cap(*dest.orig)
+	if srcLen <= destCap {
+		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
+		for i := range *es.orig {
+			newExponentialHistogramDataPoint(&(*es.orig)[i]).CopyTo(newExponentialHistogramDataPoint(&(*dest.orig)[i]))
+		}
+		return
+	}
+	origs := make([]otlpmetrics.ExponentialHistogramDataPoint, srcLen)
+	wrappers := make([]*otlpmetrics.ExponentialHistogramDataPoint, srcLen)
+	for i := range *es.orig {
+		wrappers[i] = &origs[i]
+		newExponentialHistogramDataPoint(&(*es.orig)[i]).CopyTo(newExponentialHistogramDataPoint(&wrappers[i]))
+	}
+	*dest.orig = wrappers
+}
+
+// Resize is an operation that resizes the slice:
+// 1. If the newLen <= len then equivalent with slice[0:newLen:cap].
+// 2. If the newLen > len then (newLen - cap) empty elements will be appended to the slice.
+//
+// Here is how a new ExponentialHistogramDataPointSlice can be initialized:
+// es := NewExponentialHistogramDataPointSlice()
+// es.Resize(4)
+// for i := 0; i < es.Len(); i++ {
+//     e := es.At(i)
+//     // Here should set all the values for e.
+// }
+func (es ExponentialHistogramDataPointSlice) Resize(newLen int) {
+	oldLen := len(*es.orig)
+	oldCap := cap(*es.orig)
+	if newLen <= oldLen {
+		*es.orig = (*es.orig)[:newLen:oldCap]
+		return
+	}
+
+	if newLen > oldCap {
+		newOrig := make([]*otlpmetrics.ExponentialHistogramDataPoint, oldLen, newLen)
+		copy(newOrig, *es.orig)
+		*es.orig = newOrig
+	}
+
+	// Add extra empty elements to the array.
+	extraOrigs := make([]otlpmetrics.ExponentialHistogramDataPoint, newLen-oldLen)
+	for i := range extraOrigs {
+		*es.orig = append(*es.orig, &extraOrigs[i])
+	}
+}
+
+// Append will increase the length of the ExponentialHistogramDataPointSlice by one and set the
+// given ExponentialHistogramDataPoint at that new position.  The original ExponentialHistogramDataPoint
+// could still be referenced so do not reuse it after passing it to this
+// method.
+func (es ExponentialHistogramDataPointSlice) Append(e ExponentialHistogramDataPoint) {
+	*es.orig = append(*es.orig, *e.orig)
+}
+
+// ExponentialHistogramDataPoint is a single data point in a timeseries that describes the
+// time-varying values of a ExponentialHistogram of double values. For a scale s the
+// buckets have boundaries at powers of base = 2^(2^-s), the bucket with index i covers
+// the values in (base^i, base^(i+1)].
+//
+// This is a reference type, if passed by value and callee modifies it the
+// caller will see the modification.
+//
+// Must use NewExponentialHistogramDataPoint function to create new instances.
+// Important: zero-initialized instance is not valid for use.
+type ExponentialHistogramDataPoint struct {
+	// orig points to the pointer otlpmetrics.ExponentialHistogramDataPoint field contained somewhere else.
+	// We use pointer-to-pointer to be able to modify it in InitEmpty func.
+	orig **otlpmetrics.ExponentialHistogramDataPoint
+}
+
+func newExponentialHistogramDataPoint(orig **otlpmetrics.ExponentialHistogramDataPoint) ExponentialHistogramDataPoint {
+	return ExponentialHistogramDataPoint{orig}
+}
+
+// NewExponentialHistogramDataPoint creates a new "nil" ExponentialHistogramDataPoint.
+// To initialize the struct call "InitEmpty".
+//
+// This must be used only in testing code since no "Set" method available.
+func NewExponentialHistogramDataPoint() ExponentialHistogramDataPoint {
+	orig := (*otlpmetrics.ExponentialHistogramDataPoint)(nil)
+	return newExponentialHistogramDataPoint(&orig)
+}
+
+// InitEmpty overwrites the current value with empty.
+func (ms ExponentialHistogramDataPoint) InitEmpty() {
+	*ms.orig = &otlpmetrics.ExponentialHistogramDataPoint{}
+}
+
+// IsNil returns true if the underlying data are nil.
+//
+// Important: All other functions will cause a runtime error if this returns "true".
+func (ms ExponentialHistogramDataPoint) IsNil() bool {
+	return *ms.orig == nil
+}
+
+// LabelsMap returns the Labels associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) LabelsMap() StringMap {
+	return newStringMap(&(*ms.orig).Labels)
+}
+
+// StartTime returns the starttime associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) StartTime() TimestampUnixNano {
+	return TimestampUnixNano((*ms.orig).StartTimeUnixNano)
+}
+
+// SetStartTime replaces the starttime associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) SetStartTime(v TimestampUnixNano) {
+	(*ms.orig).StartTimeUnixNano = uint64(v)
+}
+
+// Timestamp returns the timestamp associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) Timestamp() TimestampUnixNano {
+	return TimestampUnixNano((*ms.orig).TimeUnixNano)
+}
+
+// SetTimestamp replaces the timestamp associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) SetTimestamp(v TimestampUnixNano) {
+	(*ms.orig).TimeUnixNano = uint64(v)
+}
+
+// Count returns the count associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) Count() uint64 {
+	return (*ms.orig).Count
+}
+
+// SetCount replaces the count associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) SetCount(v uint64) {
+	(*ms.orig).Count = v
+}
+
+// Sum returns the sum associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) Sum() float64 {
+	return (*ms.orig).Sum
+}
+
+// SetSum replaces the sum associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) SetSum(v float64) {
+	(*ms.orig).Sum = v
+}
+
+// Scale returns the scale associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) Scale() int32 {
+	return (*ms.orig).Scale
+}
+
+// SetScale replaces the scale associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) SetScale(v int32) {
+	(*ms.orig).Scale = v
+}
+
+// ZeroCount returns the zerocount associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) ZeroCount() uint64 {
+	return (*ms.orig).ZeroCount
+}
+
+// SetZeroCount replaces the zerocount associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) SetZeroCount(v uint64) {
+	(*ms.orig).ZeroCount = v
+}
+
+// Positive returns the positive associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) Positive() ExponentialHistogramBuckets {
+	return newExponentialHistogramBuckets(&(*ms.orig).Positive)
+}
+
+// Negative returns the negative associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) Negative() ExponentialHistogramBuckets {
+	return newExponentialHistogramBuckets(&(*ms.orig).Negative)
+}
+
+// Exemplars returns the Exemplars associated with this ExponentialHistogramDataPoint.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramDataPoint) Exemplars() DoubleExemplarSlice {
+	return newDoubleExemplarSlice(&(*ms.orig).Exemplars)
+}
+
+// CopyTo copies all properties from the current struct to the dest.
+func (ms ExponentialHistogramDataPoint) CopyTo(dest ExponentialHistogramDataPoint) {
+	if ms.IsNil() {
+		*dest.orig = nil
+		return
+	}
+	if dest.IsNil() {
+		dest.InitEmpty()
+	}
+	ms.LabelsMap().CopyTo(dest.LabelsMap())
+	dest.SetStartTime(ms.StartTime())
+	dest.SetTimestamp(ms.Timestamp())
+	dest.SetCount(ms.Count())
+	dest.SetSum(ms.Sum())
+	dest.SetScale(ms.Scale())
+	dest.SetZeroCount(ms.ZeroCount())
+	ms.Positive().CopyTo(dest.Positive())
+	ms.Negative().CopyTo(dest.Negative())
+	ms.Exemplars().CopyTo(dest.Exemplars())
+}
+
+// ExponentialHistogramBuckets are a set of bucket counts, encoded in a contiguous array of counts.
+//
+// This is a reference type, if passed by value and callee modifies it the
+// caller will see the modification.
+//
+// Must use NewExponentialHistogramBuckets function to create new instances.
+// Important: zero-initialized instance is not valid for use.
+type ExponentialHistogramBuckets struct {
+	// orig points to the pointer otlpmetrics.ExponentialHistogramDataPoint_Buckets field contained somewhere else.
+	orig *otlpmetrics.ExponentialHistogramDataPoint_Buckets
+}
+
+func newExponentialHistogramBuckets(orig *otlpmetrics.ExponentialHistogramDataPoint_Buckets) ExponentialHistogramBuckets {
+	return ExponentialHistogramBuckets{orig: orig}
+}
+
+// NewExponentialHistogramBuckets creates a new empty ExponentialHistogramBuckets.
+//
+// This must be used only in testing code since no "Set" method available.
+func NewExponentialHistogramBuckets() ExponentialHistogramBuckets {
+	return newExponentialHistogramBuckets(&otlpmetrics.ExponentialHistogramDataPoint_Buckets{})
+}
+
+// Deprecated: This function will be removed soon.
+func (ms ExponentialHistogramBuckets) InitEmpty() {
+	*ms.orig = otlpmetrics.ExponentialHistogramDataPoint_Buckets{}
+}
+
+// Offset returns the offset associated with this ExponentialHistogramBuckets.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramBuckets) Offset() int32 {
+	return (*ms.orig).Offset
+}
+
+// SetOffset replaces the offset associated with this ExponentialHistogramBuckets.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramBuckets) SetOffset(v int32) {
+	(*ms.orig).Offset = v
+}
+
+// BucketCounts returns the bucketcounts associated with this ExponentialHistogramBuckets.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramBuckets) BucketCounts() []uint64 {
+	return (*ms.orig).BucketCounts
+}
+
+// SetBucketCounts replaces the bucketcounts associated with this ExponentialHistogramBuckets.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms ExponentialHistogramBuckets) SetBucketCounts(v []uint64) {
+	(*ms.orig).BucketCounts = v
+}
+
+// CopyTo copies all properties from the current struct to the dest.
+func (ms ExponentialHistogramBuckets) CopyTo(dest ExponentialHistogramBuckets) {
+	dest.SetOffset(ms.Offset())
+	dest.SetBucketCounts(ms.BucketCounts())
+}
+
 // DoubleSummaryDataPointSlice logically represents a slice of DoubleSummaryDataPoint.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -2689,6 +3113,34 @@ func (ms IntExemplar) FilteredLabels() StringMap {
 	return newStringMap(&(*ms.orig).FilteredLabels)
 }
 
+// TraceID returns the traceid associated with this IntExemplar.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms IntExemplar) TraceID() TraceID {
+	return TraceID((*ms.orig).TraceId)
+}
+
+// SetTraceID replaces the traceid associated with this IntExemplar.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms IntExemplar) SetTraceID(v TraceID) {
+	(*ms.orig).TraceId = otlpcommon.TraceID(v)
+}
+
+// SpanID returns the spanid associated with this IntExemplar.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms IntExemplar) SpanID() SpanID {
+	return SpanID((*ms.orig).SpanId)
+}
+
+// SetSpanID replaces the spanid associated with this IntExemplar.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms IntExemplar) SetSpanID(v SpanID) {
+	(*ms.orig).SpanId = otlpcommon.SpanID(v)
+}
+
 // CopyTo copies all properties from the current struct to the dest.
 func (ms IntExemplar) CopyTo(dest IntExemplar) {
 	if ms.IsNil() {
@@ -2701,6 +3153,8 @@ func (ms IntExemplar) CopyTo(dest IntExemplar) {
 	dest.SetTimestamp(ms.Timestamp())
 	dest.SetValue(ms.Value())
 	ms.FilteredLabels().CopyTo(dest.FilteredLabels())
+	dest.SetTraceID(ms.TraceID())
+	dest.SetSpanID(ms.SpanID())
 }
 
 // DoubleExemplarSlice logically represents a slice of DoubleExemplar.
@@ -2893,6 +3347,34 @@ func (ms DoubleExemplar) FilteredLabels() StringMap {
 	return newStringMap(&(*ms.orig).FilteredLabels)
 }
 
+// TraceID returns the traceid associated with this DoubleExemplar.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms DoubleExemplar) TraceID() TraceID {
+	return TraceID((*ms.orig).TraceId)
+}
+
+// SetTraceID replaces the traceid associated with this DoubleExemplar.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms DoubleExemplar) SetTraceID(v TraceID) {
+	(*ms.orig).TraceId = otlpcommon.TraceID(v)
+}
+
+// SpanID returns the spanid associated with this DoubleExemplar.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms DoubleExemplar) SpanID() SpanID {
+	return SpanID((*ms.orig).SpanId)
+}
+
+// SetSpanID replaces the spanid associated with this DoubleExemplar.
+//
+// Important: This causes a runtime error if IsNil() returns "true".
+func (ms DoubleExemplar) SetSpanID(v SpanID) {
+	(*ms.orig).SpanId = otlpcommon.SpanID(v)
+}
+
 // CopyTo copies all properties from the current struct to the dest.
 func (ms DoubleExemplar) CopyTo(dest DoubleExemplar) {
 	if ms.IsNil() {
@@ -2905,4 +3387,6 @@ func (ms DoubleExemplar) CopyTo(dest DoubleExemplar) {
 	dest.SetTimestamp(ms.Timestamp())
 	dest.SetValue(ms.Value())
 	ms.FilteredLabels().CopyTo(dest.FilteredLabels())
+	dest.SetTraceID(ms.TraceID())
+	dest.SetSpanID(ms.SpanID())
 }
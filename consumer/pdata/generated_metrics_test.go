@@ -684,6 +684,39 @@ func TestDoubleHistogram_DataPoints(t *testing.T) {
 	assert.EqualValues(t, testValDataPoints, ms.DataPoints())
 }
 
+func TestExponentialHistogram_InitEmpty(t *testing.T) {
+	ms := NewExponentialHistogram()
+	assert.True(t, ms.IsNil())
+	ms.InitEmpty()
+	assert.False(t, ms.IsNil())
+}
+
+func TestExponentialHistogram_CopyTo(t *testing.T) {
+	ms := NewExponentialHistogram()
+	NewExponentialHistogram().CopyTo(ms)
+	assert.True(t, ms.IsNil())
+	generateTestExponentialHistogram().CopyTo(ms)
+	assert.EqualValues(t, generateTestExponentialHistogram(), ms)
+}
+
+func TestExponentialHistogram_AggregationTemporality(t *testing.T) {
+	ms := NewExponentialHistogram()
+	ms.InitEmpty()
+	assert.EqualValues(t, AggregationTemporalityUnspecified, ms.AggregationTemporality())
+	testValAggregationTemporality := AggregationTemporalityCumulative
+	ms.SetAggregationTemporality(testValAggregationTemporality)
+	assert.EqualValues(t, testValAggregationTemporality, ms.AggregationTemporality())
+}
+
+func TestExponentialHistogram_DataPoints(t *testing.T) {
+	ms := NewExponentialHistogram()
+	ms.InitEmpty()
+	assert.EqualValues(t, NewExponentialHistogramDataPointSlice(), ms.DataPoints())
+	fillTestExponentialHistogramDataPointSlice(ms.DataPoints())
+	testValDataPoints := generateTestExponentialHistogramDataPointSlice()
+	assert.EqualValues(t, testValDataPoints, ms.DataPoints())
+}
+
 func TestDoubleSummary_InitEmpty(t *testing.T) {
 	ms := NewDoubleSummary()
 	assert.True(t, ms.IsNil())
@@ -1474,6 +1507,249 @@ func TestDoubleHistogramDataPoint_Exemplars(t *testing.T) {
 	assert.EqualValues(t, testValExemplars, ms.Exemplars())
 }
 
+func TestExponentialHistogramDataPointSlice(t *testing.T) {
+	es := NewExponentialHistogramDataPointSlice()
+	assert.EqualValues(t, 0, es.Len())
+	es = newExponentialHistogramDataPointSlice(&[]*otlpmetrics.ExponentialHistogramDataPoint{})
+	assert.EqualValues(t, 0, es.Len())
+
+	es.Resize(7)
+	emptyVal := NewExponentialHistogramDataPoint()
+	emptyVal.InitEmpty()
+	testVal := generateTestExponentialHistogramDataPoint()
+	assert.EqualValues(t, 7, es.Len())
+	for i := 0; i < es.Len(); i++ {
+		assert.EqualValues(t, emptyVal, es.At(i))
+		fillTestExponentialHistogramDataPoint(es.At(i))
+		assert.EqualValues(t, testVal, es.At(i))
+	}
+}
+
+func TestExponentialHistogramDataPointSlice_MoveAndAppendTo(t *testing.T) {
+	// Test MoveAndAppendTo to empty
+	expectedSlice := generateTestExponentialHistogramDataPointSlice()
+	dest := NewExponentialHistogramDataPointSlice()
+	src := generateTestExponentialHistogramDataPointSlice()
+	src.MoveAndAppendTo(dest)
+	assert.EqualValues(t, generateTestExponentialHistogramDataPointSlice(), dest)
+	assert.EqualValues(t, 0, src.Len())
+	assert.EqualValues(t, expectedSlice.Len(), dest.Len())
+
+	// Test MoveAndAppendTo empty slice
+	src.MoveAndAppendTo(dest)
+	assert.EqualValues(t, generateTestExponentialHistogramDataPointSlice(), dest)
+	assert.EqualValues(t, 0, src.Len())
+	assert.EqualValues(t, expectedSlice.Len(), dest.Len())
+
+	// Test MoveAndAppendTo not empty slice
+	generateTestExponentialHistogramDataPointSlice().MoveAndAppendTo(dest)
+	assert.EqualValues(t, 2*expectedSlice.Len(), dest.Len())
+	for i := 0; i < expectedSlice.Len(); i++ {
+		assert.EqualValues(t, expectedSlice.At(i), dest.At(i))
+		assert.EqualValues(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
+	}
+}
+
+func TestExponentialHistogramDataPointSlice_CopyTo(t *testing.T) {
+	dest := NewExponentialHistogramDataPointSlice()
+	// Test CopyTo to empty
+	NewExponentialHistogramDataPointSlice().CopyTo(dest)
+	assert.EqualValues(t, NewExponentialHistogramDataPointSlice(), dest)
+
+	// Test CopyTo larger slice
+	generateTestExponentialHistogramDataPointSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestExponentialHistogramDataPointSlice(), dest)
+
+	// Test CopyTo same size slice
+	generateTestExponentialHistogramDataPointSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestExponentialHistogramDataPointSlice(), dest)
+}
+
+func TestExponentialHistogramDataPointSlice_Resize(t *testing.T) {
+	es := generateTestExponentialHistogramDataPointSlice()
+	emptyVal := NewExponentialHistogramDataPoint()
+	emptyVal.InitEmpty()
+	// Test Resize less elements.
+	const resizeSmallLen = 4
+	expectedEs := make(map[*otlpmetrics.ExponentialHistogramDataPoint]bool, resizeSmallLen)
+	for i := 0; i < resizeSmallLen; i++ {
+		expectedEs[*(es.At(i).orig)] = true
+	}
+	assert.Equal(t, resizeSmallLen, len(expectedEs))
+	es.Resize(resizeSmallLen)
+	assert.Equal(t, resizeSmallLen, es.Len())
+	foundEs := make(map[*otlpmetrics.ExponentialHistogramDataPoint]bool, resizeSmallLen)
+	for i := 0; i < es.Len(); i++ {
+		foundEs[*(es.At(i).orig)] = true
+	}
+	assert.EqualValues(t, expectedEs, foundEs)
+
+	// Test Resize more elements.
+	const resizeLargeLen = 7
+	oldLen := es.Len()
+	expectedEs = make(map[*otlpmetrics.ExponentialHistogramDataPoint]bool, oldLen)
+	for i := 0; i < oldLen; i++ {
+		expectedEs[*(es.At(i).orig)] = true
+	}
+	assert.Equal(t, oldLen, len(expectedEs))
+	es.Resize(resizeLargeLen)
+	assert.Equal(t, resizeLargeLen, es.Len())
+	foundEs = make(map[*otlpmetrics.ExponentialHistogramDataPoint]bool, oldLen)
+	for i := 0; i < oldLen; i++ {
+		foundEs[*(es.At(i).orig)] = true
+	}
+	assert.EqualValues(t, expectedEs, foundEs)
+	for i := oldLen; i < resizeLargeLen; i++ {
+		assert.EqualValues(t, emptyVal, es.At(i))
+	}
+
+	// Test Resize 0 elements.
+	es.Resize(0)
+	assert.Equal(t, 0, es.Len())
+}
+
+func TestExponentialHistogramDataPointSlice_Append(t *testing.T) {
+	es := generateTestExponentialHistogramDataPointSlice()
+	emptyVal := NewExponentialHistogramDataPoint()
+	emptyVal.InitEmpty()
+
+	es.Append(emptyVal)
+	assert.EqualValues(t, *(es.At(7)).orig, *emptyVal.orig)
+
+	emptyVal2 := NewExponentialHistogramDataPoint()
+	emptyVal2.InitEmpty()
+
+	es.Append(emptyVal2)
+	assert.EqualValues(t, *(es.At(8)).orig, *emptyVal2.orig)
+
+	assert.Equal(t, 9, es.Len())
+}
+
+func TestExponentialHistogramDataPoint_InitEmpty(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	assert.True(t, ms.IsNil())
+	ms.InitEmpty()
+	assert.False(t, ms.IsNil())
+}
+
+func TestExponentialHistogramDataPoint_CopyTo(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	NewExponentialHistogramDataPoint().CopyTo(ms)
+	assert.True(t, ms.IsNil())
+	generateTestExponentialHistogramDataPoint().CopyTo(ms)
+	assert.EqualValues(t, generateTestExponentialHistogramDataPoint(), ms)
+}
+
+func TestExponentialHistogramDataPoint_LabelsMap(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	ms.InitEmpty()
+	assert.EqualValues(t, NewStringMap(), ms.LabelsMap())
+	fillTestStringMap(ms.LabelsMap())
+	testValLabelsMap := generateTestStringMap()
+	assert.EqualValues(t, testValLabelsMap, ms.LabelsMap())
+}
+
+func TestExponentialHistogramDataPoint_StartTime(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	ms.InitEmpty()
+	assert.EqualValues(t, TimestampUnixNano(0), ms.StartTime())
+	testValStartTime := TimestampUnixNano(1234567890)
+	ms.SetStartTime(testValStartTime)
+	assert.EqualValues(t, testValStartTime, ms.StartTime())
+}
+
+func TestExponentialHistogramDataPoint_Timestamp(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	ms.InitEmpty()
+	assert.EqualValues(t, TimestampUnixNano(0), ms.Timestamp())
+	testValTimestamp := TimestampUnixNano(1234567890)
+	ms.SetTimestamp(testValTimestamp)
+	assert.EqualValues(t, testValTimestamp, ms.Timestamp())
+}
+
+func TestExponentialHistogramDataPoint_Count(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	ms.InitEmpty()
+	assert.EqualValues(t, uint64(0), ms.Count())
+	testValCount := uint64(17)
+	ms.SetCount(testValCount)
+	assert.EqualValues(t, testValCount, ms.Count())
+}
+
+func TestExponentialHistogramDataPoint_Sum(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	ms.InitEmpty()
+	assert.EqualValues(t, float64(0.0), ms.Sum())
+	testValSum := float64(17.13)
+	ms.SetSum(testValSum)
+	assert.EqualValues(t, testValSum, ms.Sum())
+}
+
+func TestExponentialHistogramDataPoint_Scale(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	ms.InitEmpty()
+	assert.EqualValues(t, int32(0), ms.Scale())
+	testValScale := int32(4)
+	ms.SetScale(testValScale)
+	assert.EqualValues(t, testValScale, ms.Scale())
+}
+
+func TestExponentialHistogramDataPoint_ZeroCount(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	ms.InitEmpty()
+	assert.EqualValues(t, uint64(0), ms.ZeroCount())
+	testValZeroCount := uint64(201)
+	ms.SetZeroCount(testValZeroCount)
+	assert.EqualValues(t, testValZeroCount, ms.ZeroCount())
+}
+
+func TestExponentialHistogramDataPoint_Positive(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	ms.InitEmpty()
+	fillTestExponentialHistogramBuckets(ms.Positive())
+	assert.EqualValues(t, generateTestExponentialHistogramBuckets(), ms.Positive())
+}
+
+func TestExponentialHistogramDataPoint_Negative(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	ms.InitEmpty()
+	fillTestExponentialHistogramBuckets(ms.Negative())
+	assert.EqualValues(t, generateTestExponentialHistogramBuckets(), ms.Negative())
+}
+
+func TestExponentialHistogramDataPoint_Exemplars(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	ms.InitEmpty()
+	assert.EqualValues(t, NewDoubleExemplarSlice(), ms.Exemplars())
+	fillTestDoubleExemplarSlice(ms.Exemplars())
+	testValExemplars := generateTestDoubleExemplarSlice()
+	assert.EqualValues(t, testValExemplars, ms.Exemplars())
+}
+
+func TestExponentialHistogramBuckets_CopyTo(t *testing.T) {
+	ms := NewExponentialHistogramBuckets()
+	generateTestExponentialHistogramBuckets().CopyTo(ms)
+	assert.EqualValues(t, generateTestExponentialHistogramBuckets(), ms)
+}
+
+func TestExponentialHistogramBuckets_Offset(t *testing.T) {
+	ms := NewExponentialHistogramBuckets()
+	ms.InitEmpty()
+	assert.EqualValues(t, int32(0), ms.Offset())
+	testValOffset := int32(909)
+	ms.SetOffset(testValOffset)
+	assert.EqualValues(t, testValOffset, ms.Offset())
+}
+
+func TestExponentialHistogramBuckets_BucketCounts(t *testing.T) {
+	ms := NewExponentialHistogramBuckets()
+	ms.InitEmpty()
+	assert.EqualValues(t, []uint64(nil), ms.BucketCounts())
+	testValBucketCounts := []uint64{1, 2, 3}
+	ms.SetBucketCounts(testValBucketCounts)
+	assert.EqualValues(t, testValBucketCounts, ms.BucketCounts())
+}
+
 func TestDoubleSummaryDataPointSlice(t *testing.T) {
 	es := NewDoubleSummaryDataPointSlice()
 	assert.EqualValues(t, 0, es.Len())
@@ -1972,6 +2248,24 @@ func TestIntExemplar_FilteredLabels(t *testing.T) {
 	assert.EqualValues(t, testValFilteredLabels, ms.FilteredLabels())
 }
 
+func TestIntExemplar_TraceID(t *testing.T) {
+	ms := NewIntExemplar()
+	ms.InitEmpty()
+	assert.EqualValues(t, NewTraceID([16]byte{}), ms.TraceID())
+	testValTraceID := NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	ms.SetTraceID(testValTraceID)
+	assert.EqualValues(t, testValTraceID, ms.TraceID())
+}
+
+func TestIntExemplar_SpanID(t *testing.T) {
+	ms := NewIntExemplar()
+	ms.InitEmpty()
+	assert.EqualValues(t, NewSpanID([8]byte{}), ms.SpanID())
+	testValSpanID := NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	ms.SetSpanID(testValSpanID)
+	assert.EqualValues(t, testValSpanID, ms.SpanID())
+}
+
 func TestDoubleExemplarSlice(t *testing.T) {
 	es := NewDoubleExemplarSlice()
 	assert.EqualValues(t, 0, es.Len())
@@ -2132,6 +2426,24 @@ func TestDoubleExemplar_FilteredLabels(t *testing.T) {
 	assert.EqualValues(t, testValFilteredLabels, ms.FilteredLabels())
 }
 
+func TestDoubleExemplar_TraceID(t *testing.T) {
+	ms := NewDoubleExemplar()
+	ms.InitEmpty()
+	assert.EqualValues(t, NewTraceID([16]byte{}), ms.TraceID())
+	testValTraceID := NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	ms.SetTraceID(testValTraceID)
+	assert.EqualValues(t, testValTraceID, ms.TraceID())
+}
+
+func TestDoubleExemplar_SpanID(t *testing.T) {
+	ms := NewDoubleExemplar()
+	ms.InitEmpty()
+	assert.EqualValues(t, NewSpanID([8]byte{}), ms.SpanID())
+	testValSpanID := NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	ms.SetSpanID(testValSpanID)
+	assert.EqualValues(t, testValSpanID, ms.SpanID())
+}
+
 func generateTestResourceMetricsSlice() ResourceMetricsSlice {
 	tv := NewResourceMetricsSlice()
 	fillTestResourceMetricsSlice(tv)
@@ -2284,6 +2596,18 @@ func fillTestDoubleHistogram(tv DoubleHistogram) {
 	fillTestDoubleHistogramDataPointSlice(tv.DataPoints())
 }
 
+func generateTestExponentialHistogram() ExponentialHistogram {
+	tv := NewExponentialHistogram()
+	tv.InitEmpty()
+	fillTestExponentialHistogram(tv)
+	return tv
+}
+
+func fillTestExponentialHistogram(tv ExponentialHistogram) {
+	tv.SetAggregationTemporality(AggregationTemporalityCumulative)
+	fillTestExponentialHistogramDataPointSlice(tv.DataPoints())
+}
+
 func generateTestDoubleSummary() DoubleSummary {
 	tv := NewDoubleSummary()
 	tv.InitEmpty()
@@ -2413,6 +2737,50 @@ func fillTestDoubleHistogramDataPoint(tv DoubleHistogramDataPoint) {
 	fillTestDoubleExemplarSlice(tv.Exemplars())
 }
 
+func generateTestExponentialHistogramDataPointSlice() ExponentialHistogramDataPointSlice {
+	tv := NewExponentialHistogramDataPointSlice()
+	fillTestExponentialHistogramDataPointSlice(tv)
+	return tv
+}
+
+func fillTestExponentialHistogramDataPointSlice(tv ExponentialHistogramDataPointSlice) {
+	tv.Resize(7)
+	for i := 0; i < tv.Len(); i++ {
+		fillTestExponentialHistogramDataPoint(tv.At(i))
+	}
+}
+
+func generateTestExponentialHistogramDataPoint() ExponentialHistogramDataPoint {
+	tv := NewExponentialHistogramDataPoint()
+	tv.InitEmpty()
+	fillTestExponentialHistogramDataPoint(tv)
+	return tv
+}
+
+func fillTestExponentialHistogramDataPoint(tv ExponentialHistogramDataPoint) {
+	fillTestStringMap(tv.LabelsMap())
+	tv.SetStartTime(TimestampUnixNano(1234567890))
+	tv.SetTimestamp(TimestampUnixNano(1234567890))
+	tv.SetCount(uint64(17))
+	tv.SetSum(float64(17.13))
+	tv.SetScale(int32(4))
+	tv.SetZeroCount(uint64(201))
+	fillTestExponentialHistogramBuckets(tv.Positive())
+	fillTestExponentialHistogramBuckets(tv.Negative())
+	fillTestDoubleExemplarSlice(tv.Exemplars())
+}
+
+func generateTestExponentialHistogramBuckets() ExponentialHistogramBuckets {
+	tv := NewExponentialHistogramBuckets()
+	fillTestExponentialHistogramBuckets(tv)
+	return tv
+}
+
+func fillTestExponentialHistogramBuckets(tv ExponentialHistogramBuckets) {
+	tv.SetOffset(int32(909))
+	tv.SetBucketCounts([]uint64{1, 2, 3})
+}
+
 func generateTestDoubleSummaryDataPointSlice() DoubleSummaryDataPointSlice {
 	tv := NewDoubleSummaryDataPointSlice()
 	fillTestDoubleSummaryDataPointSlice(tv)
@@ -2491,6 +2859,8 @@ func fillTestIntExemplar(tv IntExemplar) {
 	tv.SetTimestamp(TimestampUnixNano(1234567890))
 	tv.SetValue(int64(-17))
 	fillTestStringMap(tv.FilteredLabels())
+	tv.SetTraceID(NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1}))
+	tv.SetSpanID(NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
 }
 
 func generateTestDoubleExemplarSlice() DoubleExemplarSlice {
@@ -2517,4 +2887,6 @@ func fillTestDoubleExemplar(tv DoubleExemplar) {
 	tv.SetTimestamp(TimestampUnixNano(1234567890))
 	tv.SetValue(float64(17.13))
 	fillTestStringMap(tv.FilteredLabels())
+	tv.SetTraceID(NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1}))
+	tv.SetSpanID(NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
 }
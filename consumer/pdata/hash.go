@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+// This file contains the hashing of attributes, used to find identical resources
+// without comparing all of them.
+
+import (
+	"math"
+
+	otlpcommon "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/common/v1"
+)
+
+// FNV-1a, see hash/fnv, inlined to hash without allocating.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+func fnvByte(h uint64, b byte) uint64 {
+	h ^= uint64(b)
+	h *= fnvPrime64
+	return h
+}
+
+func fnvString(h uint64, s string) uint64 {
+	for i := 0; i < len(s); i++ {
+		h = fnvByte(h, s[i])
+	}
+	return h
+}
+
+func fnvUint64(h uint64, v uint64) uint64 {
+	for i := 0; i < 8; i++ {
+		h = fnvByte(h, byte(v))
+		v >>= 8
+	}
+	return h
+}
+
+// keyValuesHash returns a hash of the attributes that does not depend on their order.
+// It is stable: the same attributes always have the same hash, also across processes.
+func keyValuesHash(kvs []otlpcommon.KeyValue) uint64 {
+	// The sum of the hashes of the attributes does not depend on their order.
+	var sum uint64
+	for i := range kvs {
+		h := fnvString(fnvOffset64, kvs[i].Key)
+		// The separator is not a valid first byte of a UTF-8 string.
+		h = fnvByte(h, 0xff)
+		sum += anyValueHash(h, kvs[i].Value)
+	}
+	return sum
+}
+
+// stringKeyValuesHash returns a hash of the string attributes that does not depend on
+// their order, it is equal to the hash of the same attributes as string AnyValues.
+func stringKeyValuesHash(kvs []otlpcommon.StringKeyValue) uint64 {
+	var sum uint64
+	for i := range kvs {
+		h := fnvString(fnvOffset64, kvs[i].Key)
+		h = fnvByte(h, 0xff)
+		sum += fnvString(fnvByte(h, byte(AttributeValueSTRING)), kvs[i].Value)
+	}
+	return sum
+}
+
+// anyValueHash adds the type and the value of v to h.
+func anyValueHash(h uint64, v *otlpcommon.AnyValue) uint64 {
+	if v == nil {
+		return fnvByte(h, byte(AttributeValueNULL))
+	}
+	switch val := v.Value.(type) {
+	case *otlpcommon.AnyValue_StringValue:
+		return fnvString(fnvByte(h, byte(AttributeValueSTRING)), val.StringValue)
+	case *otlpcommon.AnyValue_BoolValue:
+		b := byte(0)
+		if val.BoolValue {
+			b = 1
+		}
+		return fnvByte(fnvByte(h, byte(AttributeValueBOOL)), b)
+	case *otlpcommon.AnyValue_IntValue:
+		return fnvUint64(fnvByte(h, byte(AttributeValueINT)), uint64(val.IntValue))
+	case *otlpcommon.AnyValue_DoubleValue:
+		d := val.DoubleValue
+		if d == 0 {
+			// -0 is equal to 0.
+			d = 0
+		}
+		return fnvUint64(fnvByte(h, byte(AttributeValueDOUBLE)), math.Float64bits(d))
+	case *otlpcommon.AnyValue_KvlistValue:
+		h = fnvByte(h, byte(AttributeValueMAP))
+		if val.KvlistValue != nil {
+			h = fnvUint64(h, keyValuesHash(val.KvlistValue.Values))
+		}
+		return h
+	case *otlpcommon.AnyValue_ArrayValue:
+		h = fnvByte(h, byte(AttributeValueARRAY))
+		if val.ArrayValue != nil {
+			for _, elem := range val.ArrayValue.Values {
+				h = anyValueHash(h, elem)
+			}
+		}
+		return h
+	}
+	return fnvByte(h, byte(AttributeValueNULL))
+}
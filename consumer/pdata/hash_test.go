@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	otlpcommon "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/common/v1"
+)
+
+func TestKeyValuesHash(t *testing.T) {
+	am := NewAttributeMap().InitFromMap(map[string]AttributeValue{
+		"str":    NewAttributeValueString("value"),
+		"int":    NewAttributeValueInt(1),
+		"double": NewAttributeValueDouble(1.5),
+		"bool":   NewAttributeValueBool(true),
+	})
+	reversed := NewAttributeMap()
+	for i := len(*am.orig) - 1; i >= 0; i-- {
+		reversed.Insert((*am.orig)[i].Key, newAttributeValue(&(*am.orig)[i].Value))
+	}
+	assert.Equal(t, keyValuesHash(*am.orig), keyValuesHash(*reversed.orig))
+
+	// The hash is stable across processes.
+	single := NewAttributeMap().InitFromMap(map[string]AttributeValue{"str": NewAttributeValueString("value")})
+	assert.Equal(t, uint64(0xe43f97bc39da5bcd), keyValuesHash(*single.orig))
+
+	different := []map[string]AttributeValue{
+		{},
+		{"str": NewAttributeValueString("other")},
+		{"other": NewAttributeValueString("value")},
+		{"str": NewAttributeValueNull()},
+		{"str": NewAttributeValueInt(1)},
+		{"str": NewAttributeValueDouble(1)},
+		{"str": NewAttributeValueBool(false)},
+		{"str": NewAttributeValueString("value"), "int": NewAttributeValueInt(1)},
+	}
+	hashes := map[uint64]bool{keyValuesHash(*single.orig): true}
+	for _, attrs := range different {
+		hash := keyValuesHash(*NewAttributeMap().InitFromMap(attrs).orig)
+		assert.False(t, hashes[hash], "%v", attrs)
+		hashes[hash] = true
+	}
+}
+
+func TestKeyValuesHash_Double(t *testing.T) {
+	zero := []otlpcommon.KeyValue{newAttributeKeyValueDouble("d", 0)}
+	negativeZero := []otlpcommon.KeyValue{newAttributeKeyValueDouble("d", math.Copysign(0, -1))}
+	assert.Equal(t, keyValuesHash(zero), keyValuesHash(negativeZero))
+}
+
+func TestKeyValuesHash_Nested(t *testing.T) {
+	newMap := func(keys ...string) AttributeValue {
+		v := NewAttributeValueMap()
+		for _, k := range keys {
+			v.MapVal().InsertString(k, k)
+		}
+		return v
+	}
+	newArray := func(elems ...string) AttributeValue {
+		v := NewAttributeValueArray()
+		for _, e := range elems {
+			v.ArrayVal().Append(NewAttributeValueString(e))
+		}
+		return v
+	}
+	hash := func(v AttributeValue) uint64 {
+		return keyValuesHash(*NewAttributeMap().InitFromMap(map[string]AttributeValue{"k": v}).orig)
+	}
+
+	assert.Equal(t, hash(newMap("a", "b")), hash(newMap("b", "a")))
+	assert.NotEqual(t, hash(newMap("a", "b")), hash(newMap("a")))
+	assert.NotEqual(t, hash(newArray("a", "b")), hash(newArray("b", "a")))
+	assert.NotEqual(t, hash(newArray("a")), hash(newMap("a")))
+}
+
+func TestStringKeyValuesHash(t *testing.T) {
+	sm := NewStringMap().InitFromMap(map[string]string{"a": "1", "b": "2"})
+	am := NewAttributeMap().InitFromMap(map[string]AttributeValue{
+		"b": NewAttributeValueString("2"),
+		"a": NewAttributeValueString("1"),
+	})
+	assert.Equal(t, keyValuesHash(*am.orig), stringKeyValuesHash(*sm.orig))
+	assert.NotEqual(t, stringKeyValuesHash(*sm.orig), stringKeyValuesHash(*NewStringMap().InitFromMap(map[string]string{"a": "2", "b": "1"}).orig))
+}
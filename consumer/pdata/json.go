@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"bytes"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+)
+
+// jsonMarshaler follows the OTLP/JSON encoding: lowerCamelCase field names,
+// enums as integers and hex-encoded trace and span ids (see TraceID and SpanID).
+var jsonMarshaler = &jsonpb.Marshaler{EnumsAsInts: true}
+
+// jsonUnmarshaler accepts both field name styles and enums as names or integers,
+// unknown fields are ignored so that data from newer senders can still be read.
+var jsonUnmarshaler = &jsonpb.Unmarshaler{AllowUnknownFields: true}
+
+func marshalJSON(msg proto.Message) ([]byte, error) {
+	buf := bytes.Buffer{}
+	if err := jsonMarshaler.Marshal(&buf, msg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalJSON(data []byte, msg proto.Message) error {
+	return jsonUnmarshaler.Unmarshal(bytes.NewReader(data), msg)
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracesOtlpJSONEncoding(t *testing.T) {
+	td := NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.InstrumentationLibrarySpans().Resize(1)
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	ils.Spans().Resize(1)
+	span := ils.Spans().At(0)
+	span.SetTraceID(NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetName("operation")
+	span.SetKind(SpanKindSERVER)
+	span.SetStartTime(TimestampUnixNano(1234))
+
+	bytes, err := td.ToOtlpJSONBytes()
+	require.NoError(t, err)
+	json := string(bytes)
+	assert.Contains(t, json, `"resourceSpans":[`)
+	assert.Contains(t, json, `"instrumentationLibrarySpans":[`)
+	assert.Contains(t, json, `"traceId":"0102030405060708090a0b0c0d0e0f10"`)
+	assert.Contains(t, json, `"spanId":"0102030405060708"`)
+	assert.Contains(t, json, `"kind":2`)
+	assert.Contains(t, json, `"startTimeUnixNano":"1234"`)
+}
+
+func TestTracesFromOtlpJSONBytes(t *testing.T) {
+	json := `{"resourceSpans":[{"instrumentationLibrarySpans":[{"spans":[{
+		"traceId":"0102030405060708090a0b0c0d0e0f10",
+		"spanId":"0102030405060708",
+		"name":"operation",
+		"kind":"SPAN_KIND_CLIENT",
+		"start_time_unix_nano":1234,
+		"unknownField":"ignored"
+	}]}]}]}`
+
+	td := NewTraces()
+	require.NoError(t, td.FromOtlpJSONBytes([]byte(json)))
+	require.Equal(t, 1, td.SpanCount())
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), span.TraceID())
+	assert.Equal(t, NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}), span.SpanID())
+	assert.Equal(t, "operation", span.Name())
+	assert.Equal(t, SpanKindCLIENT, span.Kind())
+	assert.Equal(t, TimestampUnixNano(1234), span.StartTime())
+}
+
+func TestTracesFromOtlpJSONBytes_InvalidTraceID(t *testing.T) {
+	json := `{"resourceSpans":[{"instrumentationLibrarySpans":[{"spans":[{"traceId":"01"}]}]}]}`
+	assert.Error(t, NewTraces().FromOtlpJSONBytes([]byte(json)))
+}
+
+func TestTracesOtlpJSONRoundTrip(t *testing.T) {
+	td := NewTraces()
+	fillTestResourceSpansSlice(td.ResourceSpans())
+	bytes, err := td.ToOtlpJSONBytes()
+	require.NoError(t, err)
+
+	got := NewTraces()
+	require.NoError(t, got.FromOtlpJSONBytes(bytes))
+	assert.Equal(t, td, got)
+
+	// The decoded data is encoded to the same JSON.
+	gotBytes, err := got.ToOtlpJSONBytes()
+	require.NoError(t, err)
+	assert.Equal(t, string(bytes), string(gotBytes))
+}
+
+func TestMetricsOtlpJSONRoundTrip(t *testing.T) {
+	md := NewMetrics()
+	fillTestResourceMetricsSlice(md.ResourceMetrics())
+	bytes, err := md.ToOtlpJSONBytes()
+	require.NoError(t, err)
+
+	got := NewMetrics()
+	require.NoError(t, got.FromOtlpJSONBytes(bytes))
+	assert.Equal(t, md, got)
+
+	// The decoded data is encoded to the same JSON.
+	gotBytes, err := got.ToOtlpJSONBytes()
+	require.NoError(t, err)
+	assert.Equal(t, string(bytes), string(gotBytes))
+}
+
+func TestLogsOtlpJSONRoundTrip(t *testing.T) {
+	ld := NewLogs()
+	fillTestResourceLogsSlice(ld.ResourceLogs())
+	bytes, err := ld.ToOtlpJSONBytes()
+	require.NoError(t, err)
+
+	got := NewLogs()
+	require.NoError(t, got.FromOtlpJSONBytes(bytes))
+	assert.Equal(t, ld, got)
+
+	// The decoded data is encoded to the same JSON.
+	gotBytes, err := got.ToOtlpJSONBytes()
+	require.NoError(t, err)
+	assert.Equal(t, string(bytes), string(gotBytes))
+}
@@ -30,19 +30,21 @@ import (
 // Important: zero-initialized instance is not valid for use.
 type Logs struct {
 	orig *[]*otlplogs.ResourceLogs
+	// shared is the state of the data if it is shared with other Logs, see Share.
+	shared *shareState
 }
 
 // NewLogs creates a new Logs.
 func NewLogs() Logs {
 	orig := []*otlplogs.ResourceLogs(nil)
-	return Logs{&orig}
+	return Logs{orig: &orig}
 }
 
 // LogsFromInternalRep creates the internal Logs representation from the ProtoBuf. Should
 // not be used outside this module. This is intended to be used only by OTLP exporter and
 // File exporter, which legitimately need to work with OTLP Protobuf structs.
 func LogsFromInternalRep(logs internal.OtlpLogsWrapper) Logs {
-	return Logs{logs.Orig}
+	return Logs{orig: logs.Orig}
 }
 
 // InternalRep returns internal representation of the logs. Should not be used outside
@@ -74,11 +76,98 @@ func (ld Logs) FromOtlpProtoBytes(data []byte) error {
 	return nil
 }
 
+// ToOtlpJSONBytes converts the internal Logs to OTLP Collector
+// ExportLogsServiceRequest JSON bytes, as used by OTLP/HTTP with JSON encoding.
+func (ld Logs) ToOtlpJSONBytes() ([]byte, error) {
+	logs := otlpcollectorlog.ExportLogsServiceRequest{
+		ResourceLogs: *ld.orig,
+	}
+	return marshalJSON(&logs)
+}
+
+// FromOtlpJSONBytes converts OTLP Collector ExportLogsServiceRequest
+// JSON bytes to the internal Logs. Overrides current data.
+// Calling this function on zero-initialized structure causes panic.
+// Use it with NewLogs or on existing initialized Logs.
+func (ld Logs) FromOtlpJSONBytes(data []byte) error {
+	logs := otlpcollectorlog.ExportLogsServiceRequest{}
+	if err := unmarshalJSON(data, &logs); err != nil {
+		return err
+	}
+	*ld.orig = logs.ResourceLogs
+	return nil
+}
+
 // Clone returns a copy of Logs.
 func (ld Logs) Clone() Logs {
 	rls := NewResourceLogsSlice()
 	ld.ResourceLogs().CopyTo(rls)
-	return Logs(rls)
+	return Logs{orig: rls.orig}
+}
+
+// Share returns n Logs sharing the data of ld with copy-on-write semantics, ld
+// must not be used anymore. The data can be read from all of them, but a ResourceLogs
+// must be made writable with WritableResourceLogs before modifying anything in it, or all
+// of them with Writable.
+func (ld Logs) Share(n int) []Logs {
+	sd := newSharedData(ld.shared.sharedData(), n)
+	shares := make([]Logs, n)
+	for i := range shares {
+		orig := make([]*otlplogs.ResourceLogs, len(*ld.orig))
+		copy(orig, *ld.orig)
+		shares[i] = Logs{orig: &orig, shared: newShareState(sd)}
+	}
+	return shares
+}
+
+// MarkShared returns Logs with the data of ld that is always copied when made
+// writable. It is used by consumers that aggregate shared data without copying it,
+// which is then shared with an unknown number of other Logs.
+func (ld Logs) MarkShared() Logs {
+	orig := make([]*otlplogs.ResourceLogs, len(*ld.orig))
+	copy(orig, *ld.orig)
+	return Logs{orig: &orig, shared: newShareState(unownedData)}
+}
+
+// IsShared returns true if the data of ld may be shared with other Logs, see Share.
+func (ld Logs) IsShared() bool {
+	return ld.shared.isShared()
+}
+
+// Writable returns Logs with the data of ld that can be modified, ld must not
+// be used anymore. If the data is shared with other Logs the ResourceLogs that were not
+// made writable yet are copied, unless all the others already made the data writable.
+func (ld Logs) Writable() Logs {
+	if ld.shared.own() {
+		return Logs{orig: ld.orig}
+	}
+	// Copy the data before releasing it, the last user may modify it once released.
+	rls := *ld.orig
+	for i := range rls {
+		if rls[i] != nil && !ld.shared.isCopied(rls[i]) {
+			rls[i] = cloneResourceLogs(rls[i])
+		}
+	}
+	ld.shared.release()
+	return Logs{orig: ld.orig}
+}
+
+// WritableResourceLogs returns the ResourceLogs at index i of ld that can be modified, the other
+// ResourceLogs keep being shared. If the data is shared with other Logs only this ResourceLogs
+// is copied, unless all the others already made the data writable.
+func (ld Logs) WritableResourceLogs(i int) ResourceLogs {
+	rl := &(*ld.orig)[i]
+	if *rl != nil && ld.shared.mustCopy(*rl) {
+		*rl = cloneResourceLogs(*rl)
+		ld.shared.setCopied(*rl)
+	}
+	return newResourceLogs(rl)
+}
+
+func cloneResourceLogs(orig *otlplogs.ResourceLogs) *otlplogs.ResourceLogs {
+	clone := NewResourceLogs()
+	newResourceLogs(&orig).CopyTo(clone)
+	return *clone.orig
 }
 
 // LogRecordCount calculates the total number of log records.
@@ -113,8 +202,10 @@ func (ld Logs) SizeBytes() int {
 	return size
 }
 
+// ResourceLogs returns the ResourceLogsSlice of ld. If ld is shared, see Share, the
+// ResourceLogs must only be read, modifying them requires WritableResourceLogs or Writable first.
 func (ld Logs) ResourceLogs() ResourceLogsSlice {
-	return ResourceLogsSlice(ld)
+	return newResourceLogsSlice(ld.orig)
 }
 
 // SeverityNumber is the public alias of otlplogs.SeverityNumber from internal package.
@@ -89,12 +89,89 @@ func TestLogsFromInvalidOtlpProtoBytes(t *testing.T) {
 	assert.EqualError(t, err, "unexpected EOF")
 }
 
+func TestLogsFromInvalidOtlpJSONBytes(t *testing.T) {
+	err := NewLogs().FromOtlpJSONBytes([]byte("{"))
+	assert.Error(t, err)
+}
+
 func TestLogsClone(t *testing.T) {
 	logs := NewLogs()
 	fillTestResourceLogsSlice(logs.ResourceLogs())
 	assert.EqualValues(t, logs, logs.Clone())
 }
 
+func TestLogsShare(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	assert.False(t, logs.IsShared())
+	assert.Equal(t, logs, logs.Writable())
+
+	shares := logs.Share(3)
+	require.Len(t, shares, 3)
+	for _, share := range shares {
+		assert.True(t, share.IsShared())
+		assert.True(t, share.ResourceLogs().At(0).Resource() == logs.ResourceLogs().At(0).Resource())
+	}
+
+	// The top-level slice is owned by each share.
+	shares[0].ResourceLogs().Resize(0)
+	assert.Equal(t, logs.ResourceLogs().Len(), shares[1].ResourceLogs().Len())
+
+	// The data is copied until the last share makes it writable.
+	writable := shares[1].Writable()
+	assert.False(t, writable.IsShared())
+	assert.True(t, writable.ResourceLogs().At(0).Resource() != logs.ResourceLogs().At(0).Resource())
+	assert.EqualValues(t, logs.ResourceLogs(), writable.ResourceLogs())
+	writable = shares[2].Writable()
+	assert.True(t, writable.ResourceLogs().At(0).Resource() != logs.ResourceLogs().At(0).Resource())
+	writable = shares[0].Writable()
+	assert.Equal(t, 0, writable.ResourceLogs().Len())
+
+	// Sharing a share adds users to the data.
+	shares = logs.Share(1)[0].Share(2)
+	assert.True(t, shares[0].Writable().ResourceLogs().At(0).Resource() != logs.ResourceLogs().At(0).Resource())
+	assert.True(t, shares[1].Writable().ResourceLogs().At(0).Resource() == logs.ResourceLogs().At(0).Resource())
+}
+
+func TestLogsShareWritableResourceLogs(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	orig := append([]*otlplogs.ResourceLogs(nil), *logs.orig...)
+	shares := logs.Share(2)
+
+	// Only the modified ResourceLogs is copied, the others keep being shared.
+	rl := shares[0].WritableResourceLogs(1)
+	rl.InstrumentationLibraryLogs().At(0).Logs().At(0).SetName("modified")
+	assert.True(t, *rl.orig != orig[1])
+	for i := range orig {
+		if i != 1 {
+			assert.True(t, (*shares[0].orig)[i] == orig[i])
+		}
+		assert.True(t, (*shares[1].orig)[i] == orig[i])
+	}
+	assert.Equal(t, "modified", shares[0].ResourceLogs().At(1).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
+	assert.NotEqual(t, "modified", shares[1].ResourceLogs().At(1).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
+	assert.True(t, *shares[0].WritableResourceLogs(1).orig == *rl.orig)
+
+	// Once the other share made the data writable, it is modified in place.
+	shares[1].Writable()
+	assert.True(t, *shares[0].WritableResourceLogs(2).orig == orig[2])
+	assert.False(t, shares[0].IsShared())
+}
+
+func TestLogsMarkShared(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	shared := logs.MarkShared()
+	assert.True(t, shared.IsShared())
+	for i := 0; i < 2; i++ {
+		writable := shared.Writable()
+		assert.True(t, writable.ResourceLogs().At(0).Resource() != logs.ResourceLogs().At(0).Resource())
+		assert.EqualValues(t, logs.ResourceLogs(), writable.ResourceLogs())
+	}
+	assert.True(t, shared.Share(2)[1].Writable().ResourceLogs().At(0).Resource() != logs.ResourceLogs().At(0).Resource())
+}
+
 func BenchmarkLogsClone(b *testing.B) {
 	logs := NewLogs()
 	fillTestResourceLogsSlice(logs.ResourceLogs())
@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	otlpcommon "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/common/v1"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/metrics/v1"
+	otlpresource "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/resource/v1"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+)
+
+// MergeTraces moves the spans of tds to a single Traces, the tds must not be used
+// anymore. The spans of identical resources and instrumentation libraries are grouped
+// under the first of them, in the order of tds. The result is shared if any of tds is.
+func MergeTraces(tds ...Traces) Traces {
+	var rss []*otlptrace.ResourceSpans
+	// index has the resources of the result by hash.
+	index := make(map[uint64][]*otlptrace.ResourceSpans)
+	shared := false
+	for _, td := range tds {
+		shared = shared || td.IsShared()
+		for _, rs := range *td.orig {
+			if rs == nil {
+				continue
+			}
+			var destRs *otlptrace.ResourceSpans
+			hash := resourceHash(&rs.Resource)
+			for _, r := range index[hash] {
+				if resourcesEqual(&r.Resource, &rs.Resource) {
+					destRs = r
+					break
+				}
+			}
+			if destRs == nil {
+				destRs = &otlptrace.ResourceSpans{Resource: rs.Resource}
+				rss = append(rss, destRs)
+				index[hash] = append(index[hash], destRs)
+			}
+			for _, ils := range rs.InstrumentationLibrarySpans {
+				if ils == nil {
+					continue
+				}
+				var destIls *otlptrace.InstrumentationLibrarySpans
+				for _, il := range destRs.InstrumentationLibrarySpans {
+					if instrumentationLibrariesEqual(il.InstrumentationLibrary, ils.InstrumentationLibrary) {
+						destIls = il
+						break
+					}
+				}
+				if destIls == nil {
+					destIls = &otlptrace.InstrumentationLibrarySpans{InstrumentationLibrary: ils.InstrumentationLibrary}
+					destRs.InstrumentationLibrarySpans = append(destRs.InstrumentationLibrarySpans, destIls)
+				}
+				destIls.Spans = append(destIls.Spans, ils.Spans...)
+			}
+		}
+	}
+	return tracesPart(rss, shared)
+}
+
+// NormalizeTraces groups the spans of identical resources and instrumentation libraries
+// of td under a single one, td must not be used anymore. It is equivalent to MergeTraces
+// with td only.
+func NormalizeTraces(td Traces) Traces {
+	return MergeTraces(td)
+}
+
+// MergeMetrics moves the metrics of mds to a single Metrics, the mds must not be used
+// anymore. The metrics of identical resources and instrumentation libraries are grouped
+// under the first of them, in the order of mds. The metrics themselves are not merged.
+// The result is shared if any of mds is.
+func MergeMetrics(mds ...Metrics) Metrics {
+	var rms []*otlpmetrics.ResourceMetrics
+	// index has the resources of the result by hash.
+	index := make(map[uint64][]*otlpmetrics.ResourceMetrics)
+	shared := false
+	for _, md := range mds {
+		shared = shared || md.IsShared()
+		for _, rm := range *md.orig {
+			if rm == nil {
+				continue
+			}
+			var destRm *otlpmetrics.ResourceMetrics
+			hash := resourceHash(&rm.Resource)
+			for _, r := range index[hash] {
+				if resourcesEqual(&r.Resource, &rm.Resource) {
+					destRm = r
+					break
+				}
+			}
+			if destRm == nil {
+				destRm = &otlpmetrics.ResourceMetrics{Resource: rm.Resource}
+				rms = append(rms, destRm)
+				index[hash] = append(index[hash], destRm)
+			}
+			for _, ilm := range rm.InstrumentationLibraryMetrics {
+				if ilm == nil {
+					continue
+				}
+				var destIlm *otlpmetrics.InstrumentationLibraryMetrics
+				for _, il := range destRm.InstrumentationLibraryMetrics {
+					if instrumentationLibrariesEqual(il.InstrumentationLibrary, ilm.InstrumentationLibrary) {
+						destIlm = il
+						break
+					}
+				}
+				if destIlm == nil {
+					destIlm = &otlpmetrics.InstrumentationLibraryMetrics{InstrumentationLibrary: ilm.InstrumentationLibrary}
+					destRm.InstrumentationLibraryMetrics = append(destRm.InstrumentationLibraryMetrics, destIlm)
+				}
+				destIlm.Metrics = append(destIlm.Metrics, ilm.Metrics...)
+			}
+		}
+	}
+	return metricsPart(rms, shared)
+}
+
+// NormalizeMetrics groups the metrics of identical resources and instrumentation
+// libraries of md under a single one, md must not be used anymore. It is equivalent to
+// MergeMetrics with md only.
+func NormalizeMetrics(md Metrics) Metrics {
+	return MergeMetrics(md)
+}
+
+// MergeLogs moves the log records of lds to a single Logs, the lds must not be used
+// anymore. The log records of identical resources and instrumentation libraries are
+// grouped under the first of them, in the order of lds. The result is shared if any of
+// lds is.
+func MergeLogs(lds ...Logs) Logs {
+	var rls []*otlplogs.ResourceLogs
+	// index has the resources of the result by hash.
+	index := make(map[uint64][]*otlplogs.ResourceLogs)
+	shared := false
+	for _, ld := range lds {
+		shared = shared || ld.IsShared()
+		for _, rl := range *ld.orig {
+			if rl == nil {
+				continue
+			}
+			var destRl *otlplogs.ResourceLogs
+			hash := resourceHash(&rl.Resource)
+			for _, r := range index[hash] {
+				if resourcesEqual(&r.Resource, &rl.Resource) {
+					destRl = r
+					break
+				}
+			}
+			if destRl == nil {
+				destRl = &otlplogs.ResourceLogs{Resource: rl.Resource}
+				rls = append(rls, destRl)
+				index[hash] = append(index[hash], destRl)
+			}
+			for _, ill := range rl.InstrumentationLibraryLogs {
+				if ill == nil {
+					continue
+				}
+				var destIll *otlplogs.InstrumentationLibraryLogs
+				for _, il := range destRl.InstrumentationLibraryLogs {
+					if instrumentationLibrariesEqual(il.InstrumentationLibrary, ill.InstrumentationLibrary) {
+						destIll = il
+						break
+					}
+				}
+				if destIll == nil {
+					destIll = &otlplogs.InstrumentationLibraryLogs{InstrumentationLibrary: ill.InstrumentationLibrary}
+					destRl.InstrumentationLibraryLogs = append(destRl.InstrumentationLibraryLogs, destIll)
+				}
+				destIll.Logs = append(destIll.Logs, ill.Logs...)
+			}
+		}
+	}
+	return logsPart(rls, shared)
+}
+
+// NormalizeLogs groups the log records of identical resources and instrumentation
+// libraries of ld under a single one, ld must not be used anymore. It is equivalent to
+// MergeLogs with ld only.
+func NormalizeLogs(ld Logs) Logs {
+	return MergeLogs(ld)
+}
+
+// resourceHash returns a hash of the resource, equal resources have the same hash.
+func resourceHash(resource *otlpresource.Resource) uint64 {
+	return fnvUint64(keyValuesHash(resource.Attributes), uint64(resource.DroppedAttributesCount))
+}
+
+// resourcesEqual returns true if the resources have the same attributes, in any order,
+// and the same number of dropped attributes.
+func resourcesEqual(a, b *otlpresource.Resource) bool {
+	return a.DroppedAttributesCount == b.DroppedAttributesCount &&
+		newAttributeMap(&a.Attributes).Equal(newAttributeMap(&b.Attributes))
+}
+
+// instrumentationLibrariesEqual returns true if the libraries have the same name and
+// version, a nil library is equal to an empty one.
+func instrumentationLibrariesEqual(a, b *otlpcommon.InstrumentationLibrary) bool {
+	return a.GetName() == b.GetName() && a.GetVersion() == b.GetVersion()
+}
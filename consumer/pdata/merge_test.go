@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	otlpcommon "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/common/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/metrics/v1"
+	otlpresource "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/resource/v1"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+)
+
+func TestMergeTraces(t *testing.T) {
+	td1 := TracesFromOtlp([]*otlptrace.ResourceSpans{
+		{
+			Resource: testResource("r", "a", "host.name", "h"),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{InstrumentationLibrary: testLibrary("x"), Spans: testSpans("1")},
+				{Spans: testSpans("2")},
+			},
+		},
+	})
+	td2 := TracesFromOtlp([]*otlptrace.ResourceSpans{
+		{
+			// Same resource with the attributes in another order.
+			Resource: testResource("host.name", "h", "r", "a"),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{InstrumentationLibrary: testLibrary("y"), Spans: testSpans("3")},
+				{InstrumentationLibrary: testLibrary("x"), Spans: testSpans("4")},
+				{InstrumentationLibrary: &otlpcommon.InstrumentationLibrary{}, Spans: testSpans("5")},
+			},
+		},
+		{
+			Resource: testResource("r", "b"),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{InstrumentationLibrary: testLibrary("x"), Spans: testSpans("6")},
+			},
+		},
+	})
+	td3 := TracesFromOtlp([]*otlptrace.ResourceSpans{
+		{
+			// Same attributes but a different number of dropped attributes.
+			Resource: func() otlpresource.Resource {
+				r := testResource("r", "b")
+				r.DroppedAttributesCount = 1
+				return r
+			}(),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{InstrumentationLibrary: testLibrary("x"), Spans: testSpans("7")},
+			},
+		},
+	})
+
+	merged := MergeTraces(td1, td2, td3, NewTraces())
+	assert.False(t, merged.IsShared())
+	require.Equal(t, 3, merged.ResourceSpans().Len())
+	assert.Equal(t, 7, merged.SpanCount())
+	assert.Equal(t, map[string][]string{
+		"a/x": {"1", "4"},
+		"a/":  {"2", "5"},
+		"a/y": {"3"},
+		"b/x": {"6", "7"},
+	}, spanNames(merged))
+	assert.Equal(t, 3, merged.ResourceSpans().At(0).InstrumentationLibrarySpans().Len())
+	assert.Equal(t, uint32(1), (*merged.orig)[2].Resource.DroppedAttributesCount)
+}
+
+func TestMergeTraces_Shared(t *testing.T) {
+	shares := newSplitTestTraces().Share(2)
+	merged := MergeTraces(newSplitTestTraces(), shares[0])
+	assert.True(t, merged.IsShared())
+	assert.Equal(t, 20, merged.SpanCount())
+	assert.Equal(t, 2, merged.ResourceSpans().Len())
+}
+
+func TestMergeTraces_Empty(t *testing.T) {
+	assert.Equal(t, 0, MergeTraces().ResourceSpans().Len())
+}
+
+func TestMergeMetrics(t *testing.T) {
+	md1 := MetricsFromOtlp([]*otlpmetrics.ResourceMetrics{
+		{
+			Resource: testResource("r", "a"),
+			InstrumentationLibraryMetrics: []*otlpmetrics.InstrumentationLibraryMetrics{
+				{InstrumentationLibrary: testLibrary("x"), Metrics: []*otlpmetrics.Metric{{Name: "m1"}}},
+			},
+		},
+	})
+	md2 := MetricsFromOtlp([]*otlpmetrics.ResourceMetrics{
+		{
+			Resource: testResource("r", "a"),
+			InstrumentationLibraryMetrics: []*otlpmetrics.InstrumentationLibraryMetrics{
+				{InstrumentationLibrary: testLibrary("x"), Metrics: []*otlpmetrics.Metric{{Name: "m1"}, {Name: "m2"}}},
+			},
+		},
+	})
+
+	merged := MergeMetrics(md1, md2)
+	require.Equal(t, 1, merged.ResourceMetrics().Len())
+	require.Equal(t, 1, merged.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Len())
+	// The metrics are not merged.
+	assert.Equal(t, 3, merged.MetricCount())
+}
+
+func TestMergeLogs(t *testing.T) {
+	ld := newSplitTestLogs()
+	merged := MergeLogs(ld, newSplitTestLogs())
+	require.Equal(t, 2, merged.ResourceLogs().Len())
+	assert.Equal(t, 10, merged.LogRecordCount())
+	assert.Equal(t, 1, merged.ResourceLogs().At(1).InstrumentationLibraryLogs().Len())
+}
+
+func TestNormalizeTraces(t *testing.T) {
+	td := TracesFromOtlp([]*otlptrace.ResourceSpans{
+		{
+			Resource: testResource("r", "a"),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{InstrumentationLibrary: testLibrary("x"), Spans: testSpans("1")},
+			},
+		},
+		{
+			Resource: testResource("r", "b"),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{InstrumentationLibrary: testLibrary("x"), Spans: testSpans("2")},
+			},
+		},
+		{
+			Resource: testResource("r", "a"),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{InstrumentationLibrary: testLibrary("x"), Spans: testSpans("3")},
+			},
+		},
+	})
+
+	normalized := NormalizeTraces(td)
+	assert.Equal(t, 2, normalized.ResourceSpans().Len())
+	assert.Equal(t, map[string][]string{"a/x": {"1", "3"}, "b/x": {"2"}}, spanNames(normalized))
+}
+
+func TestNormalizeMetrics(t *testing.T) {
+	md := MetricsFromOtlp([]*otlpmetrics.ResourceMetrics{
+		{
+			Resource: testResource("r", "a"),
+			InstrumentationLibraryMetrics: []*otlpmetrics.InstrumentationLibraryMetrics{
+				{InstrumentationLibrary: testLibrary("x"), Metrics: []*otlpmetrics.Metric{{Name: "m1"}}},
+				{InstrumentationLibrary: testLibrary("x"), Metrics: []*otlpmetrics.Metric{{Name: "m2"}}},
+			},
+		},
+	})
+
+	normalized := NormalizeMetrics(md)
+	require.Equal(t, 1, normalized.ResourceMetrics().Len())
+	require.Equal(t, 1, normalized.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Len())
+	assert.Equal(t, 2, normalized.MetricCount())
+}
+
+func TestNormalizeLogs(t *testing.T) {
+	ld := MergeLogs(newSplitTestLogs(), newSplitTestLogs())
+	*ld.orig = append(*ld.orig, *newSplitTestLogs().orig...)
+	require.Equal(t, 4, ld.ResourceLogs().Len())
+
+	normalized := NormalizeLogs(ld)
+	assert.Equal(t, 2, normalized.ResourceLogs().Len())
+	assert.Equal(t, 15, normalized.LogRecordCount())
+}
+
+// newBenchmarkTraces returns traces as received from many instances of a few services:
+// the spans of each request are in their own ResourceSpans.
+func newBenchmarkTraces(requests int) Traces {
+	rss := make([]*otlptrace.ResourceSpans, requests)
+	for i := range rss {
+		service := strconv.Itoa(i % 5)
+		pod := strconv.Itoa(i % 20)
+		rss[i] = &otlptrace.ResourceSpans{
+			Resource: testResource(
+				"service.name", "service-"+service,
+				"service.namespace", "shop",
+				"service.version", "1.2.3",
+				"service.instance.id", "instance-"+pod,
+				"host.name", "node-"+strconv.Itoa(i%4),
+				"k8s.namespace.name", "production",
+				"k8s.pod.name", "service-"+service+"-"+pod,
+				"k8s.deployment.name", "service-"+service,
+				"telemetry.sdk.name", "opentelemetry",
+				"telemetry.sdk.language", "go",
+			),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{
+					InstrumentationLibrary: testLibrary("net/http"),
+					Spans:                  testSpans("GET /", "SELECT", "SELECT", "POST /cart", "redis GET"),
+				},
+			},
+		}
+	}
+	return TracesFromOtlp(rss)
+}
+
+func BenchmarkNormalizeTraces(b *testing.B) {
+	tds := make([]Traces, b.N)
+	for i := range tds {
+		tds[i] = newBenchmarkTraces(200)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		normalized := NormalizeTraces(tds[i])
+		if normalized.ResourceSpans().Len() != 20 {
+			b.Fatalf("unexpected number of resources: %d", normalized.ResourceSpans().Len())
+		}
+	}
+	b.StopTimer()
+
+	// The size of the encoded traces shrinks as the resources are sent once.
+	td := newBenchmarkTraces(200)
+	b.ReportMetric(float64(td.Size()), "bytes_before")
+	b.ReportMetric(float64(NormalizeTraces(td).Size()), "bytes_after")
+}
+
+func BenchmarkResourceHash(b *testing.B) {
+	resource := &(*newBenchmarkTraces(1).orig)[0].Resource
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = resourceHash(resource)
+	}
+}
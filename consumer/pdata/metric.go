@@ -38,17 +38,19 @@ func (at AggregationTemporality) String() string {
 // part of the internal package.
 type Metrics struct {
 	orig *[]*otlpmetrics.ResourceMetrics
+	// shared is the state of the data if it is shared with other Metrics, see Share.
+	shared *shareState
 }
 
 // NewMetricData creates a new MetricData.
 func NewMetrics() Metrics {
 	orig := []*otlpmetrics.ResourceMetrics(nil)
-	return Metrics{&orig}
+	return Metrics{orig: &orig}
 }
 
 // MetricDataFromOtlp creates the internal MetricData representation from the OTLP.
 func MetricsFromOtlp(orig []*otlpmetrics.ResourceMetrics) Metrics {
-	return Metrics{&orig}
+	return Metrics{orig: &orig}
 }
 
 // MetricDataToOtlp converts the internal MetricData to the OTLP.
@@ -79,13 +81,102 @@ func (md Metrics) FromOtlpProtoBytes(data []byte) error {
 	return nil
 }
 
+// ToOtlpJSONBytes converts the internal Metrics to OTLP Collector
+// ExportMetricsServiceRequest JSON bytes, as used by OTLP/HTTP with JSON encoding.
+func (md Metrics) ToOtlpJSONBytes() ([]byte, error) {
+	metrics := otlpcollectormetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: *md.orig,
+	}
+	return marshalJSON(&metrics)
+}
+
+// FromOtlpJSONBytes converts OTLP Collector ExportMetricsServiceRequest
+// JSON bytes to the internal Metrics. Overrides current data.
+// Calling this function on zero-initialized structure causes panic.
+// Use it with NewMetrics or on existing initialized Metrics.
+func (md Metrics) FromOtlpJSONBytes(data []byte) error {
+	metrics := otlpcollectormetrics.ExportMetricsServiceRequest{}
+	if err := unmarshalJSON(data, &metrics); err != nil {
+		return err
+	}
+	*md.orig = metrics.ResourceMetrics
+	return nil
+}
+
 // Clone returns a copy of MetricData.
 func (md Metrics) Clone() Metrics {
 	rms := NewResourceMetricsSlice()
 	md.ResourceMetrics().CopyTo(rms)
-	return Metrics(rms)
+	return Metrics{orig: rms.orig}
+}
+
+// Share returns n Metrics sharing the data of md with copy-on-write semantics, md
+// must not be used anymore. The data can be read from all of them, but a ResourceMetrics
+// must be made writable with WritableResourceMetrics before modifying anything in it, or all
+// of them with Writable.
+func (md Metrics) Share(n int) []Metrics {
+	sd := newSharedData(md.shared.sharedData(), n)
+	shares := make([]Metrics, n)
+	for i := range shares {
+		orig := make([]*otlpmetrics.ResourceMetrics, len(*md.orig))
+		copy(orig, *md.orig)
+		shares[i] = Metrics{orig: &orig, shared: newShareState(sd)}
+	}
+	return shares
+}
+
+// MarkShared returns Metrics with the data of md that is always copied when made
+// writable. It is used by consumers that aggregate shared data without copying it,
+// which is then shared with an unknown number of other Metrics.
+func (md Metrics) MarkShared() Metrics {
+	orig := make([]*otlpmetrics.ResourceMetrics, len(*md.orig))
+	copy(orig, *md.orig)
+	return Metrics{orig: &orig, shared: newShareState(unownedData)}
 }
 
+// IsShared returns true if the data of md may be shared with other Metrics, see Share.
+func (md Metrics) IsShared() bool {
+	return md.shared.isShared()
+}
+
+// Writable returns Metrics with the data of md that can be modified, md must not
+// be used anymore. If the data is shared with other Metrics the ResourceMetrics that were not
+// made writable yet are copied, unless all the others already made the data writable.
+func (md Metrics) Writable() Metrics {
+	if md.shared.own() {
+		return Metrics{orig: md.orig}
+	}
+	// Copy the data before releasing it, the last user may modify it once released.
+	rms := *md.orig
+	for i := range rms {
+		if rms[i] != nil && !md.shared.isCopied(rms[i]) {
+			rms[i] = cloneResourceMetrics(rms[i])
+		}
+	}
+	md.shared.release()
+	return Metrics{orig: md.orig}
+}
+
+// WritableResourceMetrics returns the ResourceMetrics at index i of md that can be modified, the other
+// ResourceMetrics keep being shared. If the data is shared with other Metrics only this ResourceMetrics
+// is copied, unless all the others already made the data writable.
+func (md Metrics) WritableResourceMetrics(i int) ResourceMetrics {
+	rm := &(*md.orig)[i]
+	if *rm != nil && md.shared.mustCopy(*rm) {
+		*rm = cloneResourceMetrics(*rm)
+		md.shared.setCopied(*rm)
+	}
+	return newResourceMetrics(rm)
+}
+
+func cloneResourceMetrics(orig *otlpmetrics.ResourceMetrics) *otlpmetrics.ResourceMetrics {
+	clone := NewResourceMetrics()
+	newResourceMetrics(&orig).CopyTo(clone)
+	return *clone.orig
+}
+
+// ResourceMetrics returns the ResourceMetricsSlice of md. If md is shared, see Share, the
+// ResourceMetrics must only be read, modifying them requires WritableResourceMetrics or Writable first.
 func (md Metrics) ResourceMetrics() ResourceMetricsSlice {
 	return newResourceMetricsSlice(md.orig)
 }
@@ -178,6 +269,11 @@ func (md Metrics) MetricAndDataPointCount() (metricCount int, dataPointCount int
 					dataPointCount += m.DoubleHistogram().DataPoints().Len()
 				case MetricDataTypeDoubleSummary:
 					dataPointCount += m.DoubleSummary().DataPoints().Len()
+				case MetricDataTypeExponentialHistogram:
+					if m.ExponentialHistogram().IsNil() {
+						continue
+					}
+					dataPointCount += m.ExponentialHistogram().DataPoints().Len()
 				}
 			}
 		}
@@ -197,6 +293,7 @@ const (
 	MetricDataTypeIntHistogram
 	MetricDataTypeDoubleHistogram
 	MetricDataTypeDoubleSummary
+	MetricDataTypeExponentialHistogram
 )
 
 func (mdt MetricDataType) String() string {
@@ -217,6 +314,8 @@ func (mdt MetricDataType) String() string {
 		return "DoubleHistogram"
 	case MetricDataTypeDoubleSummary:
 		return "DoubleSummary"
+	case MetricDataTypeExponentialHistogram:
+		return "ExponentialHistogram"
 	}
 	return ""
 }
@@ -239,6 +338,8 @@ func (ms Metric) DataType() MetricDataType {
 		return MetricDataTypeDoubleHistogram
 	case *otlpmetrics.Metric_DoubleSummary:
 		return MetricDataTypeDoubleSummary
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		return MetricDataTypeExponentialHistogram
 	}
 	return MetricDataTypeNone
 }
@@ -261,6 +362,8 @@ func (ms Metric) SetDataType(ty MetricDataType) {
 		(*ms.orig).Data = &otlpmetrics.Metric_DoubleHistogram{}
 	case MetricDataTypeDoubleSummary:
 		(*ms.orig).Data = &otlpmetrics.Metric_DoubleSummary{}
+	case MetricDataTypeExponentialHistogram:
+		(*ms.orig).Data = &otlpmetrics.Metric_ExponentialHistogram{}
 	}
 }
 
@@ -313,6 +416,13 @@ func (ms Metric) DoubleSummary() DoubleSummary {
 	return newDoubleSummary(&(*ms.orig).Data.(*otlpmetrics.Metric_DoubleSummary).DoubleSummary)
 }
 
+// ExponentialHistogram returns the data as ExponentialHistogram.
+// Calling this function when DataType() != MetricDataTypeExponentialHistogram will cause a panic.
+// Calling this function on zero-initialized Metric will cause a panic.
+func (ms Metric) ExponentialHistogram() ExponentialHistogram {
+	return newExponentialHistogram(&(*ms.orig).Data.(*otlpmetrics.Metric_ExponentialHistogram).ExponentialHistogram)
+}
+
 func copyData(src, dest *otlpmetrics.Metric) {
 	switch srcData := (src).Data.(type) {
 	case *otlpmetrics.Metric_IntGauge:
@@ -343,5 +453,9 @@ func copyData(src, dest *otlpmetrics.Metric) {
 		data := &otlpmetrics.Metric_DoubleSummary{}
 		newDoubleSummary(&srcData.DoubleSummary).CopyTo(newDoubleSummary(&data.DoubleSummary))
 		dest.Data = data
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		data := &otlpmetrics.Metric_ExponentialHistogram{}
+		newExponentialHistogram(&srcData.ExponentialHistogram).CopyTo(newExponentialHistogram(&data.ExponentialHistogram))
+		dest.Data = data
 	}
 }
@@ -86,6 +86,14 @@ func TestCopyData(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ExponentialHistogram",
+			src: &otlpmetrics.Metric{
+				Data: &otlpmetrics.Metric_ExponentialHistogram{
+					ExponentialHistogram: &otlpmetrics.ExponentialHistogram{},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -121,6 +129,9 @@ func TestDataType(t *testing.T) {
 	m.SetDataType(MetricDataTypeDoubleHistogram)
 	assert.Equal(t, MetricDataTypeDoubleHistogram, m.DataType())
 	assert.True(t, m.DoubleHistogram().IsNil())
+	m.SetDataType(MetricDataTypeExponentialHistogram)
+	assert.Equal(t, MetricDataTypeExponentialHistogram, m.DataType())
+	assert.True(t, m.ExponentialHistogram().IsNil())
 	m.InitEmpty()
 	assert.Equal(t, MetricDataTypeNone, m.DataType())
 }
@@ -731,12 +742,89 @@ func TestMetricsFromInvalidOtlpProtoBytes(t *testing.T) {
 	assert.EqualError(t, err, "unexpected EOF")
 }
 
+func TestMetricsFromInvalidOtlpJSONBytes(t *testing.T) {
+	err := NewMetrics().FromOtlpJSONBytes([]byte("{"))
+	assert.Error(t, err)
+}
+
 func TestMetricsClone(t *testing.T) {
 	metrics := NewMetrics()
 	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
 	assert.EqualValues(t, metrics, metrics.Clone())
 }
 
+func TestMetricsShare(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	assert.False(t, metrics.IsShared())
+	assert.Equal(t, metrics, metrics.Writable())
+
+	shares := metrics.Share(3)
+	require.Len(t, shares, 3)
+	for _, share := range shares {
+		assert.True(t, share.IsShared())
+		assert.True(t, share.ResourceMetrics().At(0).Resource() == metrics.ResourceMetrics().At(0).Resource())
+	}
+
+	// The top-level slice is owned by each share.
+	shares[0].ResourceMetrics().Resize(0)
+	assert.Equal(t, metrics.ResourceMetrics().Len(), shares[1].ResourceMetrics().Len())
+
+	// The data is copied until the last share makes it writable.
+	writable := shares[1].Writable()
+	assert.False(t, writable.IsShared())
+	assert.True(t, writable.ResourceMetrics().At(0).Resource() != metrics.ResourceMetrics().At(0).Resource())
+	assert.EqualValues(t, metrics.ResourceMetrics(), writable.ResourceMetrics())
+	writable = shares[2].Writable()
+	assert.True(t, writable.ResourceMetrics().At(0).Resource() != metrics.ResourceMetrics().At(0).Resource())
+	writable = shares[0].Writable()
+	assert.Equal(t, 0, writable.ResourceMetrics().Len())
+
+	// Sharing a share adds users to the data.
+	shares = metrics.Share(1)[0].Share(2)
+	assert.True(t, shares[0].Writable().ResourceMetrics().At(0).Resource() != metrics.ResourceMetrics().At(0).Resource())
+	assert.True(t, shares[1].Writable().ResourceMetrics().At(0).Resource() == metrics.ResourceMetrics().At(0).Resource())
+}
+
+func TestMetricsShareWritableResourceMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	orig := append([]*otlpmetrics.ResourceMetrics(nil), *metrics.orig...)
+	shares := metrics.Share(2)
+
+	// Only the modified ResourceMetrics is copied, the others keep being shared.
+	rm := shares[0].WritableResourceMetrics(1)
+	rm.InstrumentationLibraryMetrics().At(0).Metrics().At(0).SetName("modified")
+	assert.True(t, *rm.orig != orig[1])
+	for i := range orig {
+		if i != 1 {
+			assert.True(t, (*shares[0].orig)[i] == orig[i])
+		}
+		assert.True(t, (*shares[1].orig)[i] == orig[i])
+	}
+	assert.Equal(t, "modified", shares[0].ResourceMetrics().At(1).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
+	assert.NotEqual(t, "modified", shares[1].ResourceMetrics().At(1).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
+	assert.True(t, *shares[0].WritableResourceMetrics(1).orig == *rm.orig)
+
+	// Once the other share made the data writable, it is modified in place.
+	shares[1].Writable()
+	assert.True(t, *shares[0].WritableResourceMetrics(2).orig == orig[2])
+	assert.False(t, shares[0].IsShared())
+}
+
+func TestMetricsMarkShared(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	shared := metrics.MarkShared()
+	assert.True(t, shared.IsShared())
+	for i := 0; i < 2; i++ {
+		writable := shared.Writable()
+		assert.True(t, writable.ResourceMetrics().At(0).Resource() != metrics.ResourceMetrics().At(0).Resource())
+		assert.EqualValues(t, metrics.ResourceMetrics(), writable.ResourceMetrics())
+	}
+	assert.True(t, shared.Share(2)[1].Writable().ResourceMetrics().At(0).Resource() != metrics.ResourceMetrics().At(0).Resource())
+}
+
 func BenchmarkMetricsClone(b *testing.B) {
 	metrics := NewMetrics()
 	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
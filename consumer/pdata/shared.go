@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"sync"
+)
+
+// This file defines the state shared by the Traces, Metrics and Logs that share
+// the same data with copy-on-write semantics.
+//
+// Sharing is done at the level of the resources: the top-level slice of resources
+// is owned by each Traces, Metrics or Logs, the resources below it are shared. A
+// resource must be made writable before modifying anything in it, which copies this
+// resource only, unless no other Traces, Metrics or Logs still shares the data. The
+// other resources keep being shared.
+
+// sharedData is the state of data shared by multiple Traces, Metrics or Logs.
+type sharedData struct {
+	// unowned is true if the data is shared with an unknown number of users, it
+	// is always copied to be made writable.
+	unowned bool
+
+	mu sync.Mutex
+	// refs is the number of users sharing the data that did not make it writable.
+	refs int
+}
+
+// unownedData is the state of data shared with an unknown number of users.
+var unownedData = &sharedData{unowned: true}
+
+// newSharedData returns the state of data shared by n users. If sd is not nil
+// the data is already shared and one of its users is replaced by the n new ones.
+func newSharedData(sd *sharedData, n int) *sharedData {
+	if sd == nil {
+		return &sharedData{refs: n}
+	}
+	if !sd.unowned {
+		sd.mu.Lock()
+		sd.refs += n - 1
+		sd.mu.Unlock()
+	}
+	return sd
+}
+
+// own stops sharing the data for one of its users if it is the last user sharing
+// it, who can then modify it. It returns false if the data is still shared.
+func (sd *sharedData) own() bool {
+	if sd.unowned {
+		return false
+	}
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	if sd.refs > 1 {
+		return false
+	}
+	sd.refs = 0
+	return true
+}
+
+// release stops sharing the data for one of its users, which must not read it
+// anymore: the last user sharing it may modify it as soon as it is released.
+func (sd *sharedData) release() {
+	if sd.unowned {
+		return
+	}
+	sd.mu.Lock()
+	sd.refs--
+	sd.mu.Unlock()
+}
+
+// shareState is the state of one of the Traces, Metrics or Logs sharing data.
+type shareState struct {
+	data *sharedData
+	// owned is set once the user made all the data writable, it is not shared anymore.
+	owned bool
+	// copied are the resources copied by the user, which it can modify.
+	copied map[interface{}]struct{}
+}
+
+func newShareState(data *sharedData) *shareState {
+	return &shareState{data: data, copied: make(map[interface{}]struct{})}
+}
+
+// isShared returns true if the data of the user of ss may be shared, ss may be nil.
+func (ss *shareState) isShared() bool {
+	return ss != nil && !ss.owned
+}
+
+// sharedData returns the state of the shared data, nil if it is not shared.
+func (ss *shareState) sharedData() *sharedData {
+	if !ss.isShared() {
+		return nil
+	}
+	return ss.data
+}
+
+// isCopied returns true if the resource was copied by the user.
+func (ss *shareState) isCopied(resource interface{}) bool {
+	_, ok := ss.copied[resource]
+	return ok
+}
+
+// mustCopy returns true if the resource must be copied to be modified, i.e. if it
+// was not copied yet and is still shared with other users.
+func (ss *shareState) mustCopy(resource interface{}) bool {
+	if !ss.isShared() {
+		return false
+	}
+	if ss.isCopied(resource) {
+		return false
+	}
+	if ss.data.own() {
+		ss.owned = true
+		return false
+	}
+	return true
+}
+
+// setCopied records that the resource was copied by the user, who can modify it.
+func (ss *shareState) setCopied(resource interface{}) {
+	ss.copied[resource] = struct{}{}
+}
+
+// own makes all the data writable for the user. It returns true if the data is not
+// shared anymore, false if the resources not copied yet must be copied, and then
+// released with release.
+func (ss *shareState) own() bool {
+	if !ss.isShared() || ss.data.own() {
+		ss.setOwned()
+		return true
+	}
+	return false
+}
+
+// release stops sharing the data once the user copied all its resources.
+func (ss *shareState) release() {
+	ss.data.release()
+	ss.setOwned()
+}
+
+func (ss *shareState) setOwned() {
+	if ss != nil {
+		ss.owned = true
+		ss.copied = nil
+	}
+}
@@ -0,0 +1,520 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	otlpcommon "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/common/v1"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/metrics/v1"
+	otlpresource "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/resource/v1"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+)
+
+// SplitLimits are the limits of each part of a split, zero means no limit.
+type SplitLimits struct {
+	// MaxItems is the maximal number of spans, data points or log records of a part.
+	MaxItems int
+	// MaxBytes is the maximal size of a part encoded as an OTLP export request, see
+	// ToOtlpProtoBytes. A single item larger than MaxBytes is put in its own part.
+	MaxBytes int
+}
+
+// splitPart tracks the items and bytes of the part being filled by a split.
+type splitPart struct {
+	limits SplitLimits
+	items  int
+	bytes  int
+}
+
+// fits returns true if the given number of items and bytes can be added to the part,
+// always true if the part is empty.
+func (p *splitPart) fits(items int, bytes int) bool {
+	if p.items == 0 && p.bytes == 0 {
+		return true
+	}
+	if p.limits.MaxItems > 0 && p.items+items > p.limits.MaxItems {
+		return false
+	}
+	return p.limits.MaxBytes <= 0 || p.bytes+bytes <= p.limits.MaxBytes
+}
+
+func (p *splitPart) add(items int, bytes int) {
+	p.items += items
+	p.bytes += bytes
+}
+
+func (p *splitPart) reset() {
+	p.items = 0
+	p.bytes = 0
+}
+
+// sovSize returns the size of x encoded as a protobuf varint.
+func sovSize(x uint64) int {
+	n := 1
+	for x >= 1<<7 {
+		x >>= 7
+		n++
+	}
+	return n
+}
+
+// fieldSize returns the size of a length-delimited field with a content of the given
+// size, all the fields of OTLP have a single byte tag.
+func fieldSize(size int) int {
+	return 1 + sovSize(uint64(size)) + size
+}
+
+// containerOverhead is the maximal size of the tag and length of a container message,
+// the length is bounded by a varint of 5 bytes.
+const containerOverhead = 1 + 5
+
+// resourceOverhead returns the maximal size added by a new resource container, 0 if
+// the parts are not limited in size.
+func resourceOverhead(limits SplitLimits, resource *otlpresource.Resource) int {
+	if limits.MaxBytes <= 0 {
+		return 0
+	}
+	return containerOverhead + fieldSize(resource.Size())
+}
+
+// libraryOverhead returns the maximal size added by a new instrumentation library
+// container, 0 if the parts are not limited in size.
+func libraryOverhead(limits SplitLimits, il *otlpcommon.InstrumentationLibrary) int {
+	if limits.MaxBytes <= 0 {
+		return 0
+	}
+	if il == nil {
+		return containerOverhead
+	}
+	return containerOverhead + fieldSize(il.Size())
+}
+
+// copyResource returns a deep copy of the resource, the copies in the parts of a split
+// are modified independently.
+func copyResource(resource *otlpresource.Resource) otlpresource.Resource {
+	dest := otlpresource.Resource{}
+	newResource(resource).CopyTo(newResource(&dest))
+	return dest
+}
+
+func copyInstrumentationLibrary(il *otlpcommon.InstrumentationLibrary) *otlpcommon.InstrumentationLibrary {
+	if il == nil {
+		return nil
+	}
+	dest := *il
+	return &dest
+}
+
+// SplitTraces splits td in parts respecting the limits, the spans are moved to the
+// parts and td must not be used anymore. The resources and instrumentation libraries
+// of the spans are copied to each part they have spans in, the ones without spans are
+// dropped. td is returned as is if it respects the limits.
+func SplitTraces(td Traces, limits SplitLimits) []Traces {
+	if (limits.MaxItems <= 0 || td.SpanCount() <= limits.MaxItems) &&
+		(limits.MaxBytes <= 0 || fieldSize(td.Size()) <= limits.MaxBytes) {
+		return []Traces{td}
+	}
+
+	var parts []Traces
+	var rss []*otlptrace.ResourceSpans
+	var destRs *otlptrace.ResourceSpans
+	var destIls *otlptrace.InstrumentationLibrarySpans
+	part := splitPart{limits: limits}
+	for _, rs := range *td.orig {
+		if rs == nil {
+			continue
+		}
+		destRs = nil
+		rsOverhead := resourceOverhead(limits, &rs.Resource)
+		for _, ils := range rs.InstrumentationLibrarySpans {
+			if ils == nil {
+				continue
+			}
+			destIls = nil
+			ilsOverhead := libraryOverhead(limits, ils.InstrumentationLibrary)
+			for _, span := range ils.Spans {
+				if span == nil {
+					continue
+				}
+				bytes := 0
+				if limits.MaxBytes > 0 {
+					bytes = fieldSize(span.Size())
+				}
+				if !part.fits(1, bytes+containerBytes(destRs == nil, rsOverhead, destIls == nil, ilsOverhead)) {
+					parts = append(parts, tracesPart(rss, td.IsShared()))
+					rss, destRs, destIls = nil, nil, nil
+					part.reset()
+				}
+				bytes += containerBytes(destRs == nil, rsOverhead, destIls == nil, ilsOverhead)
+				if destRs == nil {
+					destRs = &otlptrace.ResourceSpans{Resource: copyResource(&rs.Resource)}
+					rss = append(rss, destRs)
+				}
+				if destIls == nil {
+					destIls = &otlptrace.InstrumentationLibrarySpans{
+						InstrumentationLibrary: copyInstrumentationLibrary(ils.InstrumentationLibrary),
+					}
+					destRs.InstrumentationLibrarySpans = append(destRs.InstrumentationLibrarySpans, destIls)
+				}
+				destIls.Spans = append(destIls.Spans, span)
+				part.add(1, bytes)
+			}
+		}
+	}
+	if len(rss) > 0 {
+		parts = append(parts, tracesPart(rss, td.IsShared()))
+	}
+	return parts
+}
+
+func tracesPart(rss []*otlptrace.ResourceSpans, shared bool) Traces {
+	td := Traces{orig: &rss}
+	if shared {
+		return td.MarkShared()
+	}
+	return td
+}
+
+// containerBytes returns the size of the containers created for an item.
+func containerBytes(newResource bool, resourceOverhead int, newLibrary bool, libraryOverhead int) int {
+	bytes := 0
+	if newResource {
+		bytes += resourceOverhead
+	}
+	if newLibrary {
+		bytes += libraryOverhead
+	}
+	return bytes
+}
+
+// SplitLogs splits ld in parts respecting the limits, the log records are moved to
+// the parts and ld must not be used anymore. The resources and instrumentation
+// libraries of the log records are copied to each part they have log records in, the
+// ones without log records are dropped. ld is returned as is if it respects the limits.
+func SplitLogs(ld Logs, limits SplitLimits) []Logs {
+	if (limits.MaxItems <= 0 || ld.LogRecordCount() <= limits.MaxItems) &&
+		(limits.MaxBytes <= 0 || fieldSize(ld.SizeBytes()) <= limits.MaxBytes) {
+		return []Logs{ld}
+	}
+
+	var parts []Logs
+	var rls []*otlplogs.ResourceLogs
+	var destRl *otlplogs.ResourceLogs
+	var destIll *otlplogs.InstrumentationLibraryLogs
+	part := splitPart{limits: limits}
+	for _, rl := range *ld.orig {
+		if rl == nil {
+			continue
+		}
+		destRl = nil
+		rlOverhead := resourceOverhead(limits, &rl.Resource)
+		for _, ill := range rl.InstrumentationLibraryLogs {
+			if ill == nil {
+				continue
+			}
+			destIll = nil
+			illOverhead := libraryOverhead(limits, ill.InstrumentationLibrary)
+			for _, lr := range ill.Logs {
+				if lr == nil {
+					continue
+				}
+				bytes := 0
+				if limits.MaxBytes > 0 {
+					bytes = fieldSize(lr.Size())
+				}
+				if !part.fits(1, bytes+containerBytes(destRl == nil, rlOverhead, destIll == nil, illOverhead)) {
+					parts = append(parts, logsPart(rls, ld.IsShared()))
+					rls, destRl, destIll = nil, nil, nil
+					part.reset()
+				}
+				bytes += containerBytes(destRl == nil, rlOverhead, destIll == nil, illOverhead)
+				if destRl == nil {
+					destRl = &otlplogs.ResourceLogs{Resource: copyResource(&rl.Resource)}
+					rls = append(rls, destRl)
+				}
+				if destIll == nil {
+					destIll = &otlplogs.InstrumentationLibraryLogs{
+						InstrumentationLibrary: copyInstrumentationLibrary(ill.InstrumentationLibrary),
+					}
+					destRl.InstrumentationLibraryLogs = append(destRl.InstrumentationLibraryLogs, destIll)
+				}
+				destIll.Logs = append(destIll.Logs, lr)
+				part.add(1, bytes)
+			}
+		}
+	}
+	if len(rls) > 0 {
+		parts = append(parts, logsPart(rls, ld.IsShared()))
+	}
+	return parts
+}
+
+func logsPart(rls []*otlplogs.ResourceLogs, shared bool) Logs {
+	ld := Logs{orig: &rls}
+	if shared {
+		return ld.MarkShared()
+	}
+	return ld
+}
+
+// SplitMetrics splits md in parts respecting the limits, the data points are moved to
+// the parts and md must not be used anymore. A metric with data points in several parts
+// is copied to each of them with its descriptor, a metric without data points is added
+// to the part being filled. The resources and instrumentation libraries
+// are copied to each part they have metrics in, the ones without metrics are dropped.
+// md is returned as is if it respects the limits.
+func SplitMetrics(md Metrics, limits SplitLimits) []Metrics {
+	_, dataPointCount := md.MetricAndDataPointCount()
+	if (limits.MaxItems <= 0 || dataPointCount <= limits.MaxItems) &&
+		(limits.MaxBytes <= 0 || fieldSize(md.Size()) <= limits.MaxBytes) {
+		return []Metrics{md}
+	}
+
+	var parts []Metrics
+	var rms []*otlpmetrics.ResourceMetrics
+	var destRm *otlpmetrics.ResourceMetrics
+	var destIlm *otlpmetrics.InstrumentationLibraryMetrics
+	var destMetric *otlpmetrics.Metric
+	part := splitPart{limits: limits}
+	for _, rm := range *md.orig {
+		if rm == nil {
+			continue
+		}
+		destRm = nil
+		rmOverhead := resourceOverhead(limits, &rm.Resource)
+		for _, ilm := range rm.InstrumentationLibraryMetrics {
+			if ilm == nil {
+				continue
+			}
+			destIlm = nil
+			ilmOverhead := libraryOverhead(limits, ilm.InstrumentationLibrary)
+			addMetric := func(metric *otlpmetrics.Metric) {
+				if destRm == nil {
+					destRm = &otlpmetrics.ResourceMetrics{Resource: copyResource(&rm.Resource)}
+					rms = append(rms, destRm)
+				}
+				if destIlm == nil {
+					destIlm = &otlpmetrics.InstrumentationLibraryMetrics{
+						InstrumentationLibrary: copyInstrumentationLibrary(ilm.InstrumentationLibrary),
+					}
+					destRm.InstrumentationLibraryMetrics = append(destRm.InstrumentationLibraryMetrics, destIlm)
+				}
+				destMetric = metric
+				destIlm.Metrics = append(destIlm.Metrics, metric)
+			}
+			for _, metric := range ilm.Metrics {
+				if metric == nil {
+					continue
+				}
+				count := dataPointsLen(metric)
+				if count == 0 {
+					bytes := 0
+					if limits.MaxBytes > 0 {
+						bytes = fieldSize(metric.Size())
+					}
+					if !part.fits(0, bytes+containerBytes(destRm == nil, rmOverhead, destIlm == nil, ilmOverhead)) {
+						parts = append(parts, metricsPart(rms, md.IsShared()))
+						rms, destRm, destIlm = nil, nil, nil
+						part.reset()
+					}
+					part.add(0, bytes+containerBytes(destRm == nil, rmOverhead, destIlm == nil, ilmOverhead))
+					addMetric(metric)
+					destMetric = nil
+					continue
+				}
+				destMetric = nil
+				metricOverhead := 0
+				if limits.MaxBytes > 0 {
+					metricOverhead = metricDescriptorOverhead(metric)
+				}
+				for i := 0; i < count; i++ {
+					bytes := 0
+					if limits.MaxBytes > 0 {
+						bytes = fieldSize(dataPointSize(metric, i))
+					}
+					newMetricBytes := 0
+					if destMetric == nil {
+						newMetricBytes = metricOverhead
+					}
+					if !part.fits(1, bytes+newMetricBytes+containerBytes(destRm == nil, rmOverhead, destIlm == nil, ilmOverhead)) {
+						parts = append(parts, metricsPart(rms, md.IsShared()))
+						rms, destRm, destIlm, destMetric = nil, nil, nil, nil
+						part.reset()
+						newMetricBytes = metricOverhead
+					}
+					bytes += newMetricBytes + containerBytes(destRm == nil, rmOverhead, destIlm == nil, ilmOverhead)
+					if destMetric == nil {
+						addMetric(newMetricWithDescriptor(metric))
+					}
+					appendDataPoint(destMetric, metric, i)
+					part.add(1, bytes)
+				}
+			}
+		}
+	}
+	if len(rms) > 0 {
+		parts = append(parts, metricsPart(rms, md.IsShared()))
+	}
+	return parts
+}
+
+func metricsPart(rms []*otlpmetrics.ResourceMetrics, shared bool) Metrics {
+	md := Metrics{orig: &rms}
+	if shared {
+		return md.MarkShared()
+	}
+	return md
+}
+
+// metricDescriptorOverhead returns the maximal size of a metric without data points.
+func metricDescriptorOverhead(metric *otlpmetrics.Metric) int {
+	// The data has a container and at most the temporality and the monotonic flag.
+	return containerOverhead + fieldSize(len(metric.Name)) + fieldSize(len(metric.Description)) +
+		fieldSize(len(metric.Unit)) + containerOverhead + 2*2
+}
+
+// newMetricWithDescriptor returns a metric with the descriptor of metric and no data
+// points.
+func newMetricWithDescriptor(metric *otlpmetrics.Metric) *otlpmetrics.Metric {
+	dest := &otlpmetrics.Metric{
+		Name:        metric.Name,
+		Description: metric.Description,
+		Unit:        metric.Unit,
+	}
+	switch data := metric.Data.(type) {
+	case *otlpmetrics.Metric_IntGauge:
+		dest.Data = &otlpmetrics.Metric_IntGauge{IntGauge: &otlpmetrics.IntGauge{}}
+	case *otlpmetrics.Metric_DoubleGauge:
+		dest.Data = &otlpmetrics.Metric_DoubleGauge{DoubleGauge: &otlpmetrics.DoubleGauge{}}
+	case *otlpmetrics.Metric_IntSum:
+		dest.Data = &otlpmetrics.Metric_IntSum{IntSum: &otlpmetrics.IntSum{
+			AggregationTemporality: data.IntSum.AggregationTemporality,
+			IsMonotonic:            data.IntSum.IsMonotonic,
+		}}
+	case *otlpmetrics.Metric_DoubleSum:
+		dest.Data = &otlpmetrics.Metric_DoubleSum{DoubleSum: &otlpmetrics.DoubleSum{
+			AggregationTemporality: data.DoubleSum.AggregationTemporality,
+			IsMonotonic:            data.DoubleSum.IsMonotonic,
+		}}
+	case *otlpmetrics.Metric_IntHistogram:
+		dest.Data = &otlpmetrics.Metric_IntHistogram{IntHistogram: &otlpmetrics.IntHistogram{
+			AggregationTemporality: data.IntHistogram.AggregationTemporality,
+		}}
+	case *otlpmetrics.Metric_DoubleHistogram:
+		dest.Data = &otlpmetrics.Metric_DoubleHistogram{DoubleHistogram: &otlpmetrics.DoubleHistogram{
+			AggregationTemporality: data.DoubleHistogram.AggregationTemporality,
+		}}
+	case *otlpmetrics.Metric_DoubleSummary:
+		dest.Data = &otlpmetrics.Metric_DoubleSummary{DoubleSummary: &otlpmetrics.DoubleSummary{}}
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		dest.Data = &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: &otlpmetrics.ExponentialHistogram{
+			AggregationTemporality: data.ExponentialHistogram.AggregationTemporality,
+		}}
+	}
+	return dest
+}
+
+// dataPointsLen returns the number of data points of the metric.
+func dataPointsLen(metric *otlpmetrics.Metric) int {
+	switch data := metric.Data.(type) {
+	case *otlpmetrics.Metric_IntGauge:
+		if data.IntGauge != nil {
+			return len(data.IntGauge.DataPoints)
+		}
+	case *otlpmetrics.Metric_DoubleGauge:
+		if data.DoubleGauge != nil {
+			return len(data.DoubleGauge.DataPoints)
+		}
+	case *otlpmetrics.Metric_IntSum:
+		if data.IntSum != nil {
+			return len(data.IntSum.DataPoints)
+		}
+	case *otlpmetrics.Metric_DoubleSum:
+		if data.DoubleSum != nil {
+			return len(data.DoubleSum.DataPoints)
+		}
+	case *otlpmetrics.Metric_IntHistogram:
+		if data.IntHistogram != nil {
+			return len(data.IntHistogram.DataPoints)
+		}
+	case *otlpmetrics.Metric_DoubleHistogram:
+		if data.DoubleHistogram != nil {
+			return len(data.DoubleHistogram.DataPoints)
+		}
+	case *otlpmetrics.Metric_DoubleSummary:
+		if data.DoubleSummary != nil {
+			return len(data.DoubleSummary.DataPoints)
+		}
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		if data.ExponentialHistogram != nil {
+			return len(data.ExponentialHistogram.DataPoints)
+		}
+	}
+	return 0
+}
+
+// dataPointSize returns the size of the i-th data point of the metric.
+func dataPointSize(metric *otlpmetrics.Metric, i int) int {
+	switch data := metric.Data.(type) {
+	case *otlpmetrics.Metric_IntGauge:
+		return data.IntGauge.DataPoints[i].Size()
+	case *otlpmetrics.Metric_DoubleGauge:
+		return data.DoubleGauge.DataPoints[i].Size()
+	case *otlpmetrics.Metric_IntSum:
+		return data.IntSum.DataPoints[i].Size()
+	case *otlpmetrics.Metric_DoubleSum:
+		return data.DoubleSum.DataPoints[i].Size()
+	case *otlpmetrics.Metric_IntHistogram:
+		return data.IntHistogram.DataPoints[i].Size()
+	case *otlpmetrics.Metric_DoubleHistogram:
+		return data.DoubleHistogram.DataPoints[i].Size()
+	case *otlpmetrics.Metric_DoubleSummary:
+		return data.DoubleSummary.DataPoints[i].Size()
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		return data.ExponentialHistogram.DataPoints[i].Size()
+	}
+	return 0
+}
+
+// appendDataPoint appends the i-th data point of src to dest, they must have the same
+// data type.
+func appendDataPoint(dest, src *otlpmetrics.Metric, i int) {
+	switch data := src.Data.(type) {
+	case *otlpmetrics.Metric_IntGauge:
+		d := dest.Data.(*otlpmetrics.Metric_IntGauge).IntGauge
+		d.DataPoints = append(d.DataPoints, data.IntGauge.DataPoints[i])
+	case *otlpmetrics.Metric_DoubleGauge:
+		d := dest.Data.(*otlpmetrics.Metric_DoubleGauge).DoubleGauge
+		d.DataPoints = append(d.DataPoints, data.DoubleGauge.DataPoints[i])
+	case *otlpmetrics.Metric_IntSum:
+		d := dest.Data.(*otlpmetrics.Metric_IntSum).IntSum
+		d.DataPoints = append(d.DataPoints, data.IntSum.DataPoints[i])
+	case *otlpmetrics.Metric_DoubleSum:
+		d := dest.Data.(*otlpmetrics.Metric_DoubleSum).DoubleSum
+		d.DataPoints = append(d.DataPoints, data.DoubleSum.DataPoints[i])
+	case *otlpmetrics.Metric_IntHistogram:
+		d := dest.Data.(*otlpmetrics.Metric_IntHistogram).IntHistogram
+		d.DataPoints = append(d.DataPoints, data.IntHistogram.DataPoints[i])
+	case *otlpmetrics.Metric_DoubleHistogram:
+		d := dest.Data.(*otlpmetrics.Metric_DoubleHistogram).DoubleHistogram
+		d.DataPoints = append(d.DataPoints, data.DoubleHistogram.DataPoints[i])
+	case *otlpmetrics.Metric_DoubleSummary:
+		d := dest.Data.(*otlpmetrics.Metric_DoubleSummary).DoubleSummary
+		d.DataPoints = append(d.DataPoints, data.DoubleSummary.DataPoints[i])
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		d := dest.Data.(*otlpmetrics.Metric_ExponentialHistogram).ExponentialHistogram
+		d.DataPoints = append(d.DataPoints, data.ExponentialHistogram.DataPoints[i])
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal"
+	otlpcommon "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/common/v1"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/metrics/v1"
+	otlpresource "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/resource/v1"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+)
+
+// testResource returns a resource with the attributes given as key and value pairs.
+func testResource(keyValues ...string) otlpresource.Resource {
+	resource := otlpresource.Resource{}
+	for i := 0; i < len(keyValues); i += 2 {
+		resource.Attributes = append(resource.Attributes, newAttributeKeyValueString(keyValues[i], keyValues[i+1]))
+	}
+	return resource
+}
+
+func testLibrary(name string) *otlpcommon.InstrumentationLibrary {
+	return &otlpcommon.InstrumentationLibrary{Name: name, Version: "1.0"}
+}
+
+func testSpans(names ...string) []*otlptrace.Span {
+	spans := make([]*otlptrace.Span, len(names))
+	for i, name := range names {
+		spans[i] = &otlptrace.Span{Name: name}
+	}
+	return spans
+}
+
+// spanNames returns the names of the spans of td by resource attribute "r" and library.
+func spanNames(td Traces) map[string][]string {
+	names := make(map[string][]string)
+	for _, rs := range *td.orig {
+		r, _ := newAttributeMap(&rs.Resource.Attributes).Get("r")
+		for _, ils := range rs.InstrumentationLibrarySpans {
+			key := r.StringVal() + "/" + ils.InstrumentationLibrary.GetName()
+			for _, span := range ils.Spans {
+				names[key] = append(names[key], span.Name)
+			}
+		}
+	}
+	return names
+}
+
+func newSplitTestTraces() Traces {
+	return TracesFromOtlp([]*otlptrace.ResourceSpans{
+		{
+			Resource: testResource("r", "a"),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{InstrumentationLibrary: testLibrary("x"), Spans: testSpans("1", "2", "3")},
+				{InstrumentationLibrary: testLibrary("y"), Spans: testSpans("4", "5")},
+			},
+		},
+		{
+			Resource: testResource("r", "b"),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{InstrumentationLibrary: testLibrary("x"), Spans: testSpans("6", "7", "8")},
+				{InstrumentationLibrary: testLibrary("y")},
+				{InstrumentationLibrary: testLibrary("z"), Spans: testSpans("9", "10")},
+			},
+		},
+	})
+}
+
+func TestSplitTraces_WithinLimits(t *testing.T) {
+	td := newSplitTestTraces()
+	parts := SplitTraces(td, SplitLimits{MaxItems: 10, MaxBytes: 10000})
+	require.Len(t, parts, 1)
+	assert.Equal(t, td, parts[0])
+
+	parts = SplitTraces(td, SplitLimits{})
+	require.Len(t, parts, 1)
+	assert.Equal(t, td, parts[0])
+}
+
+func TestSplitTraces_MaxItems(t *testing.T) {
+	parts := SplitTraces(newSplitTestTraces(), SplitLimits{MaxItems: 4})
+	require.Len(t, parts, 3)
+	assert.Equal(t, map[string][]string{"a/x": {"1", "2", "3"}, "a/y": {"4"}}, spanNames(parts[0]))
+	assert.Equal(t, map[string][]string{"a/y": {"5"}, "b/x": {"6", "7", "8"}}, spanNames(parts[1]))
+	assert.Equal(t, map[string][]string{"b/z": {"9", "10"}}, spanNames(parts[2]))
+
+	// The resources and libraries of the parts are copies.
+	parts[0].ResourceSpans().At(0).Resource().Attributes().UpdateString("r", "c")
+	parts[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(1).InstrumentationLibrary().SetName("w")
+	assert.Equal(t, map[string][]string{"a/y": {"5"}, "b/x": {"6", "7", "8"}}, spanNames(parts[1]))
+	assert.False(t, parts[1].IsShared())
+}
+
+func TestSplitTraces_MaxBytes(t *testing.T) {
+	td := NewTraces()
+	rss := make([]*otlptrace.ResourceSpans, 10)
+	for i := range rss {
+		rss[i] = &otlptrace.ResourceSpans{
+			Resource: testResource("r", strconv.Itoa(i), "host.name", "host-"+strconv.Itoa(i%3)),
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+				{InstrumentationLibrary: testLibrary("x"), Spans: testSpans("a", "bb", "ccc")},
+				{Spans: testSpans("dddd", "eeeee")},
+			},
+		}
+	}
+	*td.orig = rss
+	total, err := td.ToOtlpProtoBytes()
+	require.NoError(t, err)
+
+	const maxBytes = 200
+	parts := SplitTraces(td, SplitLimits{MaxBytes: maxBytes})
+	assert.Greater(t, len(parts), len(total)/maxBytes)
+	spanCount := 0
+	for _, part := range parts {
+		buf, err := part.ToOtlpProtoBytes()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(buf), maxBytes)
+		spanCount += part.SpanCount()
+	}
+	assert.Equal(t, 50, spanCount)
+}
+
+func TestSplitTraces_ItemLargerThanMaxBytes(t *testing.T) {
+	td := newSplitTestTraces()
+	parts := SplitTraces(td, SplitLimits{MaxBytes: 1})
+	require.Len(t, parts, 10)
+	for _, part := range parts {
+		assert.Equal(t, 1, part.SpanCount())
+	}
+}
+
+func TestSplitTraces_Shared(t *testing.T) {
+	shares := newSplitTestTraces().Share(2)
+	parts := SplitTraces(shares[0], SplitLimits{MaxItems: 5})
+	require.Len(t, parts, 2)
+	for _, part := range parts {
+		assert.True(t, part.IsShared())
+	}
+}
+
+func newSplitTestMetric(name string, data interface{}) *otlpmetrics.Metric {
+	metric := &otlpmetrics.Metric{Name: name, Description: "description of " + name, Unit: "1"}
+	switch d := data.(type) {
+	case *otlpmetrics.IntGauge:
+		metric.Data = &otlpmetrics.Metric_IntGauge{IntGauge: d}
+	case *otlpmetrics.DoubleGauge:
+		metric.Data = &otlpmetrics.Metric_DoubleGauge{DoubleGauge: d}
+	case *otlpmetrics.IntSum:
+		metric.Data = &otlpmetrics.Metric_IntSum{IntSum: d}
+	case *otlpmetrics.DoubleSum:
+		metric.Data = &otlpmetrics.Metric_DoubleSum{DoubleSum: d}
+	case *otlpmetrics.IntHistogram:
+		metric.Data = &otlpmetrics.Metric_IntHistogram{IntHistogram: d}
+	case *otlpmetrics.DoubleHistogram:
+		metric.Data = &otlpmetrics.Metric_DoubleHistogram{DoubleHistogram: d}
+	case *otlpmetrics.DoubleSummary:
+		metric.Data = &otlpmetrics.Metric_DoubleSummary{DoubleSummary: d}
+	}
+	return metric
+}
+
+func newSplitTestMetrics() Metrics {
+	cumulative := otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+	return MetricsFromOtlp([]*otlpmetrics.ResourceMetrics{
+		{
+			Resource: testResource("r", "a"),
+			InstrumentationLibraryMetrics: []*otlpmetrics.InstrumentationLibraryMetrics{
+				{
+					InstrumentationLibrary: testLibrary("x"),
+					Metrics: []*otlpmetrics.Metric{
+						newSplitTestMetric("int_gauge", &otlpmetrics.IntGauge{
+							DataPoints: []*otlpmetrics.IntDataPoint{{Value: 1}, {Value: 2}},
+						}),
+						newSplitTestMetric("double_gauge", &otlpmetrics.DoubleGauge{
+							DataPoints: []*otlpmetrics.DoubleDataPoint{{Value: 1}},
+						}),
+						newSplitTestMetric("int_sum", &otlpmetrics.IntSum{
+							DataPoints:             []*otlpmetrics.IntDataPoint{{Value: 1}, {Value: 2}, {Value: 3}},
+							AggregationTemporality: cumulative,
+							IsMonotonic:            true,
+						}),
+						newSplitTestMetric("empty", &otlpmetrics.IntGauge{}),
+					},
+				},
+			},
+		},
+		{
+			Resource: testResource("r", "b"),
+			InstrumentationLibraryMetrics: []*otlpmetrics.InstrumentationLibraryMetrics{
+				{
+					InstrumentationLibrary: testLibrary("y"),
+					Metrics: []*otlpmetrics.Metric{
+						newSplitTestMetric("double_sum", &otlpmetrics.DoubleSum{
+							DataPoints:             []*otlpmetrics.DoubleDataPoint{{Value: 1}, {Value: 2}},
+							AggregationTemporality: cumulative,
+						}),
+						newSplitTestMetric("int_histogram", &otlpmetrics.IntHistogram{
+							DataPoints:             []*otlpmetrics.IntHistogramDataPoint{{Count: 1}},
+							AggregationTemporality: cumulative,
+						}),
+						newSplitTestMetric("double_histogram", &otlpmetrics.DoubleHistogram{
+							DataPoints:             []*otlpmetrics.DoubleHistogramDataPoint{{Count: 1}, {Count: 2}},
+							AggregationTemporality: cumulative,
+						}),
+						newSplitTestMetric("double_summary", &otlpmetrics.DoubleSummary{
+							DataPoints: []*otlpmetrics.DoubleSummaryDataPoint{{Count: 1}},
+						}),
+					},
+				},
+			},
+		},
+	})
+}
+
+// metricPoints returns the number of data points of each metric of md by name.
+func metricPoints(md Metrics) map[string][]int {
+	points := make(map[string][]int)
+	for _, rm := range *md.orig {
+		for _, ilm := range rm.InstrumentationLibraryMetrics {
+			for _, metric := range ilm.Metrics {
+				points[metric.Name] = append(points[metric.Name], dataPointsLen(metric))
+			}
+		}
+	}
+	return points
+}
+
+func TestSplitMetrics_MaxItems(t *testing.T) {
+	md := newSplitTestMetrics()
+	expected := md.Clone()
+	parts := SplitMetrics(md, SplitLimits{MaxItems: 4})
+	require.Len(t, parts, 3)
+	assert.Equal(t, map[string][]int{"int_gauge": {2}, "double_gauge": {1}, "int_sum": {1}}, metricPoints(parts[0]))
+	assert.Equal(t, map[string][]int{"int_sum": {2}, "empty": {0}, "double_sum": {2}}, metricPoints(parts[1]))
+	assert.Equal(t, map[string][]int{"int_histogram": {1}, "double_histogram": {2}, "double_summary": {1}}, metricPoints(parts[2]))
+
+	// Merging the parts gives back the metrics, except for the split metric.
+	merged := MergeMetrics(parts...)
+	intSum := (*merged.orig)[0].InstrumentationLibraryMetrics[0].Metrics[2]
+	splitIntSum := (*merged.orig)[0].InstrumentationLibraryMetrics[0].Metrics[3]
+	assert.Equal(t, "int_sum", splitIntSum.Name)
+	intSum.GetIntSum().DataPoints = append(intSum.GetIntSum().DataPoints, splitIntSum.GetIntSum().DataPoints...)
+	metrics := (*merged.orig)[0].InstrumentationLibraryMetrics[0].Metrics
+	(*merged.orig)[0].InstrumentationLibraryMetrics[0].Metrics = append(metrics[:3], metrics[4:]...)
+	assert.Equal(t, expected, merged)
+}
+
+func TestSplitMetrics_MaxBytes(t *testing.T) {
+	md := newSplitTestMetrics()
+	const maxBytes = 100
+	parts := SplitMetrics(md, SplitLimits{MaxBytes: maxBytes})
+	assert.Greater(t, len(parts), 1)
+	dataPointCount := 0
+	for _, part := range parts {
+		buf, err := part.ToOtlpProtoBytes()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(buf), maxBytes)
+		_, count := part.MetricAndDataPointCount()
+		dataPointCount += count
+	}
+	assert.Equal(t, 12, dataPointCount)
+}
+
+func newSplitTestLogs() Logs {
+	logs := func(names ...string) []*otlplogs.LogRecord {
+		lrs := make([]*otlplogs.LogRecord, len(names))
+		for i, name := range names {
+			lrs[i] = &otlplogs.LogRecord{Name: name}
+		}
+		return lrs
+	}
+	return LogsFromInternalRep(internal.LogsFromOtlp([]*otlplogs.ResourceLogs{
+		{
+			Resource: testResource("r", "a"),
+			InstrumentationLibraryLogs: []*otlplogs.InstrumentationLibraryLogs{
+				{InstrumentationLibrary: testLibrary("x"), Logs: logs("1", "2", "3")},
+			},
+		},
+		{
+			Resource: testResource("r", "b"),
+			InstrumentationLibraryLogs: []*otlplogs.InstrumentationLibraryLogs{
+				{Logs: logs("4", "5")},
+			},
+		},
+	}))
+}
+
+func TestSplitLogs_MaxItems(t *testing.T) {
+	ld := newSplitTestLogs()
+	expected := ld.Clone()
+	parts := SplitLogs(ld, SplitLimits{MaxItems: 2})
+	require.Len(t, parts, 3)
+	assert.Equal(t, 2, parts[0].LogRecordCount())
+	assert.Equal(t, 2, parts[1].LogRecordCount())
+	assert.Equal(t, 1, parts[2].LogRecordCount())
+	assert.Equal(t, 2, parts[1].ResourceLogs().Len())
+	assert.Equal(t, expected, MergeLogs(parts...))
+}
+
+func TestSplitLogs_MaxBytes(t *testing.T) {
+	ld := newSplitTestLogs()
+	const maxBytes = 40
+	parts := SplitLogs(ld, SplitLimits{MaxBytes: maxBytes})
+	assert.Greater(t, len(parts), 1)
+	logCount := 0
+	for _, part := range parts {
+		buf, err := part.ToOtlpProtoBytes()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(buf), maxBytes)
+		logCount += part.LogRecordCount()
+	}
+	assert.Equal(t, 5, logCount)
+}
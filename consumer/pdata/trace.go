@@ -26,17 +26,19 @@ import (
 // in-memory representation.
 type Traces struct {
 	orig *[]*otlptrace.ResourceSpans
+	// shared is the state of the data if it is shared with other Traces, see Share.
+	shared *shareState
 }
 
 // NewTraces creates a new Traces.
 func NewTraces() Traces {
 	orig := []*otlptrace.ResourceSpans(nil)
-	return Traces{&orig}
+	return Traces{orig: &orig}
 }
 
 // TracesFromOtlp creates the internal Traces representation from the OTLP.
 func TracesFromOtlp(orig []*otlptrace.ResourceSpans) Traces {
-	return Traces{&orig}
+	return Traces{orig: &orig}
 }
 
 // TracesToOtlp converts the internal Traces to the OTLP.
@@ -66,11 +68,98 @@ func (td Traces) FromOtlpProtoBytes(data []byte) error {
 	return nil
 }
 
+// ToOtlpJSONBytes converts the internal Traces to OTLP Collector
+// ExportTraceServiceRequest JSON bytes, as used by OTLP/HTTP with JSON encoding.
+func (td Traces) ToOtlpJSONBytes() ([]byte, error) {
+	traces := otlpcollectortrace.ExportTraceServiceRequest{
+		ResourceSpans: *td.orig,
+	}
+	return marshalJSON(&traces)
+}
+
+// FromOtlpJSONBytes converts OTLP Collector ExportTraceServiceRequest
+// JSON bytes to the internal Traces. Overrides current data.
+// Calling this function on zero-initialized structure causes panic.
+// Use it with NewTraces or on existing initialized Traces.
+func (td Traces) FromOtlpJSONBytes(data []byte) error {
+	traces := otlpcollectortrace.ExportTraceServiceRequest{}
+	if err := unmarshalJSON(data, &traces); err != nil {
+		return err
+	}
+	*td.orig = traces.ResourceSpans
+	return nil
+}
+
 // Clone returns a copy of Traces.
 func (td Traces) Clone() Traces {
 	rss := NewResourceSpansSlice()
 	td.ResourceSpans().CopyTo(rss)
-	return Traces(rss)
+	return Traces{orig: rss.orig}
+}
+
+// Share returns n Traces sharing the data of td with copy-on-write semantics, td
+// must not be used anymore. The data can be read from all of them, but a ResourceSpans
+// must be made writable with WritableResourceSpans before modifying anything in it, or all
+// of them with Writable.
+func (td Traces) Share(n int) []Traces {
+	sd := newSharedData(td.shared.sharedData(), n)
+	shares := make([]Traces, n)
+	for i := range shares {
+		orig := make([]*otlptrace.ResourceSpans, len(*td.orig))
+		copy(orig, *td.orig)
+		shares[i] = Traces{orig: &orig, shared: newShareState(sd)}
+	}
+	return shares
+}
+
+// MarkShared returns Traces with the data of td that is always copied when made
+// writable. It is used by consumers that aggregate shared data without copying it,
+// which is then shared with an unknown number of other Traces.
+func (td Traces) MarkShared() Traces {
+	orig := make([]*otlptrace.ResourceSpans, len(*td.orig))
+	copy(orig, *td.orig)
+	return Traces{orig: &orig, shared: newShareState(unownedData)}
+}
+
+// IsShared returns true if the data of td may be shared with other Traces, see Share.
+func (td Traces) IsShared() bool {
+	return td.shared.isShared()
+}
+
+// Writable returns Traces with the data of td that can be modified, td must not
+// be used anymore. If the data is shared with other Traces the ResourceSpans that were not
+// made writable yet are copied, unless all the others already made the data writable.
+func (td Traces) Writable() Traces {
+	if td.shared.own() {
+		return Traces{orig: td.orig}
+	}
+	// Copy the data before releasing it, the last user may modify it once released.
+	rss := *td.orig
+	for i := range rss {
+		if rss[i] != nil && !td.shared.isCopied(rss[i]) {
+			rss[i] = cloneResourceSpans(rss[i])
+		}
+	}
+	td.shared.release()
+	return Traces{orig: td.orig}
+}
+
+// WritableResourceSpans returns the ResourceSpans at index i of td that can be modified, the other
+// ResourceSpans keep being shared. If the data is shared with other Traces only this ResourceSpans
+// is copied, unless all the others already made the data writable.
+func (td Traces) WritableResourceSpans(i int) ResourceSpans {
+	rs := &(*td.orig)[i]
+	if *rs != nil && td.shared.mustCopy(*rs) {
+		*rs = cloneResourceSpans(*rs)
+		td.shared.setCopied(*rs)
+	}
+	return newResourceSpans(rs)
+}
+
+func cloneResourceSpans(orig *otlptrace.ResourceSpans) *otlptrace.ResourceSpans {
+	clone := NewResourceSpans()
+	newResourceSpans(&orig).CopyTo(clone)
+	return *clone.orig
 }
 
 // SpanCount calculates the total number of spans.
@@ -106,6 +195,8 @@ func (td Traces) Size() int {
 	return size
 }
 
+// ResourceSpans returns the ResourceSpansSlice of td. If td is shared, see Share, the
+// ResourceSpans must only be read, modifying them requires WritableResourceSpans or Writable first.
 func (td Traces) ResourceSpans() ResourceSpansSlice {
 	return newResourceSpansSlice(td.orig)
 }
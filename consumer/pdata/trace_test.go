@@ -15,6 +15,7 @@
 package pdata
 
 import (
+	"sync"
 	"testing"
 
 	gogoproto "github.com/gogo/protobuf/proto"
@@ -204,12 +205,114 @@ func TestTracesFromInvalidOtlpProtoBytes(t *testing.T) {
 	assert.EqualError(t, err, "unexpected EOF")
 }
 
+func TestTracesFromInvalidOtlpJSONBytes(t *testing.T) {
+	err := NewTraces().FromOtlpJSONBytes([]byte("{"))
+	assert.Error(t, err)
+}
+
 func TestTracesClone(t *testing.T) {
 	traces := NewTraces()
 	fillTestResourceSpansSlice(traces.ResourceSpans())
 	assert.EqualValues(t, traces, traces.Clone())
 }
 
+func TestTracesShare(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	assert.False(t, traces.IsShared())
+	assert.Equal(t, traces, traces.Writable())
+
+	shares := traces.Share(3)
+	require.Len(t, shares, 3)
+	for _, share := range shares {
+		assert.True(t, share.IsShared())
+		assert.True(t, share.ResourceSpans().At(0).Resource() == traces.ResourceSpans().At(0).Resource())
+	}
+
+	// The top-level slice is owned by each share.
+	shares[0].ResourceSpans().Resize(0)
+	assert.Equal(t, traces.ResourceSpans().Len(), shares[1].ResourceSpans().Len())
+
+	// The data is copied until the last share makes it writable.
+	writable := shares[1].Writable()
+	assert.False(t, writable.IsShared())
+	assert.True(t, writable.ResourceSpans().At(0).Resource() != traces.ResourceSpans().At(0).Resource())
+	assert.EqualValues(t, traces.ResourceSpans(), writable.ResourceSpans())
+	writable = shares[2].Writable()
+	assert.True(t, writable.ResourceSpans().At(0).Resource() != traces.ResourceSpans().At(0).Resource())
+	writable = shares[0].Writable()
+	assert.Equal(t, 0, writable.ResourceSpans().Len())
+
+	// Sharing a share adds users to the data.
+	shares = traces.Share(1)[0].Share(2)
+	assert.True(t, shares[0].Writable().ResourceSpans().At(0).Resource() != traces.ResourceSpans().At(0).Resource())
+	assert.True(t, shares[1].Writable().ResourceSpans().At(0).Resource() == traces.ResourceSpans().At(0).Resource())
+}
+
+func TestTracesShareConcurrentWritable(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	expected := traces.Clone()
+	expected.ResourceSpans().At(0).Resource().Attributes().UpsertString("k", "modified")
+
+	// The shares are made writable and modified concurrently, run with -race. The
+	// results are checked at the end, calling t would synchronize the goroutines.
+	shares := traces.Share(4)
+	writables := make([]Traces, len(shares))
+	var wg sync.WaitGroup
+	for i := range shares {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			writables[i] = shares[i].Writable()
+			writables[i].ResourceSpans().At(0).Resource().Attributes().UpsertString("k", "modified")
+		}(i)
+	}
+	wg.Wait()
+	for _, writable := range writables {
+		assert.EqualValues(t, expected.ResourceSpans(), writable.ResourceSpans())
+	}
+}
+
+func TestTracesShareWritableResourceSpans(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	orig := append([]*otlptrace.ResourceSpans(nil), *traces.orig...)
+	shares := traces.Share(2)
+
+	// Only the modified ResourceSpans is copied, the others keep being shared.
+	rs := shares[0].WritableResourceSpans(1)
+	rs.InstrumentationLibrarySpans().At(0).Spans().At(0).SetName("modified")
+	assert.True(t, *rs.orig != orig[1])
+	for i := range orig {
+		if i != 1 {
+			assert.True(t, (*shares[0].orig)[i] == orig[i])
+		}
+		assert.True(t, (*shares[1].orig)[i] == orig[i])
+	}
+	assert.Equal(t, "modified", shares[0].ResourceSpans().At(1).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
+	assert.NotEqual(t, "modified", shares[1].ResourceSpans().At(1).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
+	assert.True(t, *shares[0].WritableResourceSpans(1).orig == *rs.orig)
+
+	// Once the other share made the data writable, it is modified in place.
+	shares[1].Writable()
+	assert.True(t, *shares[0].WritableResourceSpans(2).orig == orig[2])
+	assert.False(t, shares[0].IsShared())
+}
+
+func TestTracesMarkShared(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	shared := traces.MarkShared()
+	assert.True(t, shared.IsShared())
+	for i := 0; i < 2; i++ {
+		writable := shared.Writable()
+		assert.True(t, writable.ResourceSpans().At(0).Resource() != traces.ResourceSpans().At(0).Resource())
+		assert.EqualValues(t, traces.ResourceSpans(), writable.ResourceSpans())
+	}
+	assert.True(t, shared.Share(2)[1].Writable().ResourceSpans().At(0).Resource() != traces.ResourceSpans().At(0).Resource())
+}
+
 func BenchmarkTracesClone(b *testing.B) {
 	traces := NewTraces()
 	fillTestResourceSpansSlice(traces.ResourceSpans())
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// MappingFlattened writes attribute maps as dotted top-level keys, e.g. "Attributes.http.method".
+	MappingFlattened = "flattened"
+	// MappingNested splits attribute keys on "." and writes them as nested objects.
+	MappingNested = "nested"
+)
+
+// Config defines configuration for Elasticsearch exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// LogsIndex is the index name template used for log records.
+	// See README.md for the supported placeholders.
+	LogsIndex string `mapstructure:"logs_index"`
+
+	// TracesIndex is the index name template used for spans.
+	// See README.md for the supported placeholders.
+	TracesIndex string `mapstructure:"traces_index"`
+
+	// Mapping configures how telemetry is converted into Elasticsearch documents.
+	Mapping MappingSettings `mapstructure:"mapping"`
+}
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if err := exporterhelper.ValidateSettings(nil, &cfg.QueueSettings, &cfg.RetrySettings); err != nil {
+		errs = append(errs, err)
+	}
+	switch cfg.Mapping.Mode {
+	case "", MappingFlattened, MappingNested:
+	default:
+		errs = append(errs, fmt.Errorf("mapping: unknown mode %q, must be %q or %q", cfg.Mapping.Mode, MappingFlattened, MappingNested))
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// MappingSettings defines how telemetry is converted into Elasticsearch documents.
+type MappingSettings struct {
+	// Mode is either "flattened" or "nested".
+	Mode string `mapstructure:"mode"`
+}
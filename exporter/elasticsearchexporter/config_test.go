@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters["elasticsearch"]
+	assert.Equal(t, e0, factory.CreateDefaultConfig())
+
+	e1 := cfg.Exporters["elasticsearch/customname"]
+	assert.Equal(t, e1,
+		&Config{
+			ExporterSettings: configmodels.ExporterSettings{
+				NameVal: "elasticsearch/customname",
+				TypeVal: "elasticsearch",
+			},
+			RetrySettings: exporterhelper.RetrySettings{
+				Enabled:         true,
+				InitialInterval: 10 * time.Second,
+				MaxInterval:     1 * time.Minute,
+				MaxElapsedTime:  10 * time.Minute,
+			},
+			QueueSettings: exporterhelper.QueueSettings{
+				Enabled:      true,
+				NumConsumers: 2,
+				QueueSize:    10,
+			},
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: "https://elastic.example.com:9200",
+				Timeout:  10 * time.Second,
+				Headers: map[string]string{
+					"authorization": "ApiKey dGVzdA==",
+				},
+			},
+			LogsIndex:   "logs-%{service.name}-%{+2006.01}",
+			TracesIndex: "traces-%{+2006.01.02}",
+			Mapping: MappingSettings{
+				Mode: MappingNested,
+			},
+		})
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Mapping.Mode = MappingNested
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Mapping.Mode = "dotted"
+	assert.EqualError(t, cfg.Validate(), `mapping: unknown mode "dotted", must be "flattened" or "nested"`)
+
+	cfg.QueueSettings.QueueSize = 0
+	assert.EqualError(t, cfg.Validate(),
+		`[sending_queue: queue_size must be positive; mapping: unknown mode "dotted", must be "flattened" or "nested"]`)
+}
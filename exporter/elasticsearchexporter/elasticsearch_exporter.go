@@ -0,0 +1,331 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/subset"
+)
+
+const (
+	headerRetryAfter         = "Retry-After"
+	maxHTTPResponseReadBytes = 64 * 1024
+)
+
+type elasticsearchExporter struct {
+	client  *http.Client
+	bulkURL string
+	index   *indexTemplate
+	encoder *encoder
+	logger  *zap.Logger
+}
+
+// bulkItem is a single document of a bulk request together with the position
+// of the pdata item it was created from.
+type bulkItem struct {
+	index string
+	doc   []byte
+	pos   subset.Position
+}
+
+// positions returns the positions in the original data of the given items.
+func positions(items []bulkItem) []subset.Position {
+	result := make([]subset.Position, len(items))
+	for i, item := range items {
+		result[i] = item.pos
+	}
+	return result
+}
+
+// bulkResponse is the subset of the Elasticsearch _bulk API response used by the exporter.
+type bulkResponse struct {
+	Errors bool                          `json:"errors"`
+	Items  []map[string]bulkItemResponse `json:"items"`
+}
+
+type bulkItemResponse struct {
+	Index  string `json:"_index"`
+	Status int    `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error,omitempty"`
+}
+
+func newExporter(cfg *Config, logger *zap.Logger, index string) (*elasticsearchExporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("endpoint must be specified")
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return nil, errors.New("endpoint must be a valid URL")
+	}
+
+	tmpl, err := newIndexTemplate(index)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := newEncoder(cfg.Mapping.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &elasticsearchExporter{
+		client:  client,
+		bulkURL: strings.TrimSuffix(cfg.Endpoint, "/") + "/_bulk",
+		index:   tmpl,
+		encoder: enc,
+		logger:  logger,
+	}, nil
+}
+
+func (e *elasticsearchExporter) pushLogData(ctx context.Context, ld pdata.Logs) (int, error) {
+	var items []bulkItem
+	dropped := 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		resource := rl.Resource()
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				record := logs.At(k)
+				if record.IsNil() {
+					continue
+				}
+				doc, err := e.encoder.encodeLog(resource, ill.InstrumentationLibrary(), record)
+				if err != nil {
+					e.logger.Warn("Dropping log record that cannot be encoded.", zap.Error(err))
+					dropped++
+					continue
+				}
+				items = append(items, bulkItem{
+					index: e.index.render(itemTime(record.Timestamp()), record.Attributes(), resource.Attributes()),
+					doc:   doc,
+					pos:   subset.Position{ResourceIndex: i, LibraryIndex: j, Index: k},
+				})
+			}
+		}
+	}
+
+	retry, itemsDropped, err := e.bulk(ctx, items)
+	if err != nil {
+		return ld.LogRecordCount(), err
+	}
+	dropped += itemsDropped
+	if len(retry) > 0 {
+		return dropped + len(retry), consumererror.PartialLogsError(
+			fmt.Errorf("%d log records were rejected with a retryable status", len(retry)),
+			subset.Logs(ld, positions(retry)))
+	}
+	return dropped, nil
+}
+
+func (e *elasticsearchExporter) pushTraceData(ctx context.Context, td pdata.Traces) (int, error) {
+	var items []bulkItem
+	dropped := 0
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		resource := rs.Resource()
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				doc, err := e.encoder.encodeSpan(resource, ils.InstrumentationLibrary(), span)
+				if err != nil {
+					e.logger.Warn("Dropping span that cannot be encoded.", zap.Error(err))
+					dropped++
+					continue
+				}
+				items = append(items, bulkItem{
+					index: e.index.render(itemTime(span.StartTime()), span.Attributes(), resource.Attributes()),
+					doc:   doc,
+					pos:   subset.Position{ResourceIndex: i, LibraryIndex: j, Index: k},
+				})
+			}
+		}
+	}
+
+	retry, itemsDropped, err := e.bulk(ctx, items)
+	if err != nil {
+		return td.SpanCount(), err
+	}
+	dropped += itemsDropped
+	if len(retry) > 0 {
+		return dropped + len(retry), consumererror.PartialTracesError(
+			fmt.Errorf("%d spans were rejected with a retryable status", len(retry)),
+			subset.Traces(td, positions(retry)))
+	}
+	return dropped, nil
+}
+
+// bulk sends all items in a single _bulk request. It returns the items that
+// must be retried and the number of items that were rejected permanently.
+func (e *elasticsearchExporter) bulk(ctx context.Context, items []bulkItem) ([]bulkItem, int, error) {
+	if len(items) == 0 {
+		return nil, 0, nil
+	}
+
+	var body bytes.Buffer
+	for _, item := range items {
+		action, err := json.Marshal(map[string]map[string]string{"index": {"_index": item.index}})
+		if err != nil {
+			return nil, 0, consumererror.Permanent(err)
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(item.doc)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.bulkURL, &body)
+	if err != nil {
+		return nil, 0, consumererror.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to make an HTTP request: %w", err)
+	}
+
+	defer func() {
+		// Discard any remaining response body when we are done reading.
+		io.CopyN(ioutil.Discard, resp.Body, maxHTTPResponseReadBytes)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, 0, responseError(resp, e.bulkURL)
+	}
+
+	var bulkResp bulkResponse
+	if err = json.NewDecoder(resp.Body).Decode(&bulkResp); err != nil {
+		// The documents were accepted but we cannot tell which ones failed, retrying could duplicate data.
+		return nil, 0, consumererror.Permanent(fmt.Errorf("failed to decode bulk response: %w", err))
+	}
+	if !bulkResp.Errors {
+		return nil, 0, nil
+	}
+	if len(bulkResp.Items) != len(items) {
+		return nil, 0, consumererror.Permanent(fmt.Errorf(
+			"bulk response contains %d items, expected %d", len(bulkResp.Items), len(items)))
+	}
+
+	var retry []bulkItem
+	dropped := 0
+	for i, result := range bulkResp.Items {
+		for _, itemResp := range result {
+			switch {
+			case itemResp.Status >= 200 && itemResp.Status <= 299:
+			case itemResp.Status == http.StatusTooManyRequests || itemResp.Status >= 500:
+				retry = append(retry, items[i])
+			default:
+				dropped++
+				fields := []zap.Field{
+					zap.String("index", items[i].index),
+					zap.Int("status", itemResp.Status),
+				}
+				if itemResp.Error != nil {
+					fields = append(fields,
+						zap.String("error_type", itemResp.Error.Type),
+						zap.String("error_reason", itemResp.Error.Reason))
+				}
+				e.logger.Warn("Dropping document rejected by Elasticsearch.", fields...)
+			}
+		}
+	}
+	return retry, dropped, nil
+}
+
+// responseError converts a failed HTTP response into an error that the
+// exporterhelper retry logic understands.
+func responseError(resp *http.Response, url string) error {
+	respBytes, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseReadBytes))
+	formattedErr := fmt.Errorf(
+		"error exporting items, request to %s responded with HTTP Status Code %d, Message=%s",
+		url, resp.StatusCode, string(respBytes))
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		// Fallback to 0 if the Retry-After header is not present. This will trigger the
+		// default backoff policy by our caller (retry handler).
+		retryAfter := 0
+		if val := resp.Header.Get(headerRetryAfter); val != "" {
+			if seconds, err := strconv.Atoi(val); err == nil {
+				retryAfter = seconds
+			}
+		}
+		return exporterhelper.NewThrottleRetry(formattedErr, time.Duration(retryAfter)*time.Second)
+	}
+
+	if resp.StatusCode >= 400 && resp.StatusCode <= 499 {
+		// Report the failure as permanent, the request will be rejected again.
+		return consumererror.Permanent(formattedErr)
+	}
+
+	// All other errors are retryable, so don't wrap them in consumererror.Permanent().
+	return formattedErr
+}
+
+// itemTime returns the time used to render the date part of index names.
+// Items without timestamp are indexed using the current time.
+func itemTime(ts pdata.TimestampUnixNano) time.Time {
+	if ts == 0 {
+		return time.Now()
+	}
+	return pdata.UnixNanoToTime(ts)
+}
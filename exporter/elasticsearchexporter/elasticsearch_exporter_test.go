@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+)
+
+// bulkStub is a minimal stand-in for the Elasticsearch _bulk API.
+type bulkStub struct {
+	mu sync.Mutex
+	// indexes contains the index of every document received so far.
+	indexes []string
+	// docs contains every document received so far.
+	docs []map[string]interface{}
+	// itemStatus returns the status for the n-th document ever received.
+	itemStatus func(n int) int
+	// status is the HTTP status code of the response, if not 200 no items are returned.
+	status int
+}
+
+func (s *bulkStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if s.status != 0 && s.status != http.StatusOK {
+		w.WriteHeader(s.status)
+		return
+	}
+
+	resp := bulkResponse{}
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		var action map[string]map[string]string
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !scanner.Scan() {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		status := http.StatusCreated
+		if s.itemStatus != nil {
+			status = s.itemStatus(len(s.indexes))
+		}
+		s.indexes = append(s.indexes, action["index"]["_index"])
+		s.docs = append(s.docs, doc)
+
+		item := bulkItemResponse{Index: action["index"]["_index"], Status: status}
+		if status >= 300 {
+			resp.Errors = true
+		}
+		resp.Items = append(resp.Items, map[string]bulkItemResponse{"index": item})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func newTestExporter(t *testing.T, endpoint string) *elasticsearchExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	exp, err := newExporter(cfg, zap.NewNop(), "logs-%{+2006.01.02}")
+	require.NoError(t, err)
+	return exp
+}
+
+func TestPushLogData(t *testing.T) {
+	stub := &bulkStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL)
+	dropped, err := exp.pushLogData(context.Background(), testdata.GenerateLogDataTwoLogsSameResourceOneDifferent())
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	require.Len(t, stub.docs, 3)
+	assert.Equal(t, "logs-2020.02.11", stub.indexes[0])
+	assert.Equal(t, "logA", stub.docs[0]["Name"])
+	assert.Equal(t, "This is a log message", stub.docs[0]["Body"])
+}
+
+func TestPushTraceData(t *testing.T) {
+	stub := &bulkStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL)
+	dropped, err := exp.pushTraceData(context.Background(), testdata.GenerateTraceDataTwoSpansSameResourceOneDifferent())
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	require.Len(t, stub.docs, 3)
+	assert.Equal(t, "operationA", stub.docs[0]["Name"])
+}
+
+func TestPushLogDataItemErrors(t *testing.T) {
+	stub := &bulkStub{
+		itemStatus: func(n int) int {
+			switch n {
+			case 0:
+				// Mapping errors are dropped.
+				return http.StatusBadRequest
+			case 2:
+				return http.StatusTooManyRequests
+			default:
+				return http.StatusCreated
+			}
+		},
+	}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL)
+	ld := testdata.GenerateLogDataTwoLogsSameResourceOneDifferent()
+	dropped, err := exp.pushLogData(context.Background(), ld)
+	require.Error(t, err)
+	assert.Equal(t, 2, dropped)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	partialErr, ok := err.(consumererror.PartialError)
+	require.True(t, ok)
+	failed := partialErr.GetLogs()
+	require.Equal(t, 1, failed.LogRecordCount())
+	expected := ld.ResourceLogs().At(1).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.EqualValues(t, expected, failed.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0))
+}
+
+func TestPushRequestErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		permanent bool
+	}{
+		{name: "TooManyRequests", status: http.StatusTooManyRequests},
+		{name: "ServerError", status: http.StatusInternalServerError},
+		{name: "BadRequest", status: http.StatusBadRequest, permanent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&bulkStub{status: tt.status})
+			defer server.Close()
+
+			exp := newTestExporter(t, server.URL)
+			td := testdata.GenerateTraceDataOneSpan()
+			dropped, err := exp.pushTraceData(context.Background(), td)
+			require.Error(t, err)
+			assert.Equal(t, td.SpanCount(), dropped)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestLogsExporterRetriesRejectedItems(t *testing.T) {
+	stub := &bulkStub{
+		itemStatus: func(n int) int {
+			if n == 1 {
+				return http.StatusTooManyRequests
+			}
+			return http.StatusCreated
+		},
+	}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.QueueSettings.Enabled = false
+	cfg.RetrySettings.InitialInterval = 10 * time.Millisecond
+
+	exp, err := factory.CreateLogsExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer exp.Shutdown(context.Background())
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testdata.GenerateLogDataTwoLogsSameResource()))
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	// Two documents in the first request, only the rejected one in the second.
+	require.Len(t, stub.docs, 3)
+	assert.Equal(t, stub.docs[1], stub.docs[2])
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateExporters(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		mustFail bool
+	}{
+		{
+			name:   "Valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:     "NoEndpoint",
+			modify:   func(cfg *Config) { cfg.Endpoint = "" },
+			mustFail: true,
+		},
+		{
+			name: "InvalidMappingMode",
+			modify: func(cfg *Config) {
+				cfg.Mapping.Mode = "unknown"
+			},
+			mustFail: true,
+		},
+		{
+			name: "InvalidIndex",
+			modify: func(cfg *Config) {
+				cfg.LogsIndex = "logs-%{+2006"
+				cfg.TracesIndex = "traces-%{}"
+			},
+			mustFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Endpoint = "http://localhost:9200"
+			tt.modify(cfg)
+
+			params := component.ExporterCreateParams{Logger: zap.NewNop()}
+			texp, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+			if tt.mustFail {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, texp)
+			}
+
+			lexp, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+			if tt.mustFail {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, lexp)
+			}
+		})
+	}
+}
+
+func TestCreateMetricsExporterNotSupported(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "http://localhost:9200"
+
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	_, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	assert.Error(t, err)
+}
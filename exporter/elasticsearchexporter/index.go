@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// indexTemplate renders index names from a template such as "logs-%{service.name}-%{+2006.01.02}".
+//
+// Two kinds of placeholders are supported:
+//   - %{+layout} is replaced with the item timestamp (UTC) formatted using the Go time layout.
+//   - %{key} is replaced with the value of the attribute "key", looked up in the item
+//     attributes first and then in the resource attributes. Missing attributes render as "".
+//
+// Elasticsearch only accepts lowercase index names so the rendered name is always lowercased.
+type indexTemplate struct {
+	parts []indexPart
+}
+
+type indexPart struct {
+	literal    string
+	dateLayout string
+	attribute  string
+}
+
+func newIndexTemplate(tmpl string) (*indexTemplate, error) {
+	if tmpl == "" {
+		return nil, errors.New("index name must be specified")
+	}
+
+	var parts []indexPart
+	rest := tmpl
+	for rest != "" {
+		start := strings.Index(rest, "%{")
+		if start < 0 {
+			parts = append(parts, indexPart{literal: rest})
+			break
+		}
+		if start > 0 {
+			parts = append(parts, indexPart{literal: rest[:start]})
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in index name %q", tmpl)
+		}
+		placeholder := rest[start+2 : start+end]
+		switch {
+		case placeholder == "" || placeholder == "+":
+			return nil, fmt.Errorf("empty placeholder in index name %q", tmpl)
+		case strings.HasPrefix(placeholder, "+"):
+			parts = append(parts, indexPart{dateLayout: placeholder[1:]})
+		default:
+			parts = append(parts, indexPart{attribute: placeholder})
+		}
+		rest = rest[start+end+1:]
+	}
+
+	return &indexTemplate{parts: parts}, nil
+}
+
+// render returns the index name for an item with the given timestamp and attribute maps.
+// The attribute maps are searched in the given order.
+func (t *indexTemplate) render(ts time.Time, attrs ...pdata.AttributeMap) string {
+	var sb strings.Builder
+	for _, p := range t.parts {
+		switch {
+		case p.dateLayout != "":
+			sb.WriteString(ts.UTC().Format(p.dateLayout))
+		case p.attribute != "":
+			for _, am := range attrs {
+				if v, ok := am.Get(p.attribute); ok {
+					sb.WriteString(attributeValueToString(v))
+					break
+				}
+			}
+		default:
+			sb.WriteString(p.literal)
+		}
+	}
+	return strings.ToLower(sb.String())
+}
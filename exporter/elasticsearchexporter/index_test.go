@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestIndexTemplateRender(t *testing.T) {
+	ts := time.Date(2020, 2, 11, 20, 26, 13, 0, time.UTC)
+	attrs := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"env": pdata.NewAttributeValueString("Prod"),
+	})
+	resourceAttrs := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"env":          pdata.NewAttributeValueString("ignored"),
+		"service.name": pdata.NewAttributeValueString("checkout"),
+	})
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{template: "otel-logs", expected: "otel-logs"},
+		{template: "otel-logs-%{+2006.01.02}", expected: "otel-logs-2020.02.11"},
+		{template: "%{service.name}-%{env}-%{+2006.01}", expected: "checkout-prod-2020.02"},
+		{template: "logs-%{missing}", expected: "logs-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			tmpl, err := newIndexTemplate(tt.template)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tmpl.render(ts, attrs, resourceAttrs))
+		})
+	}
+}
+
+func TestIndexTemplateInvalid(t *testing.T) {
+	for _, tmpl := range []string{"", "logs-%{+2006", "logs-%{}", "logs-%{+}"} {
+		_, err := newIndexTemplate(tmpl)
+		assert.Error(t, err, tmpl)
+	}
+}
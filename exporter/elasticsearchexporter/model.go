@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// document is the JSON representation of a single Elasticsearch document.
+type document map[string]interface{}
+
+// encoder converts pdata items into Elasticsearch documents.
+type encoder struct {
+	// nested indicates that dotted keys are expanded into nested objects.
+	nested bool
+}
+
+func newEncoder(mode string) (*encoder, error) {
+	switch mode {
+	case MappingFlattened, "":
+		return &encoder{nested: false}, nil
+	case MappingNested:
+		return &encoder{nested: true}, nil
+	default:
+		return nil, fmt.Errorf("unknown mapping mode %q", mode)
+	}
+}
+
+func (e *encoder) encodeLog(resource pdata.Resource, il pdata.InstrumentationLibrary, record pdata.LogRecord) ([]byte, error) {
+	doc := document{}
+	e.put(doc, "@timestamp", formatTimestamp(record.Timestamp()))
+	if record.TraceID().IsValid() {
+		e.put(doc, "TraceId", record.TraceID().HexString())
+	}
+	if record.SpanID().IsValid() {
+		e.put(doc, "SpanId", record.SpanID().HexString())
+	}
+	if record.Flags() != 0 {
+		e.put(doc, "TraceFlags", record.Flags())
+	}
+	if record.SeverityText() != "" {
+		e.put(doc, "SeverityText", record.SeverityText())
+	}
+	if record.SeverityNumber() != pdata.SeverityNumberUNDEFINED {
+		e.put(doc, "SeverityNumber", int32(record.SeverityNumber()))
+	}
+	if record.Name() != "" {
+		e.put(doc, "Name", record.Name())
+	}
+	if !record.Body().IsNil() {
+		e.putValue(doc, "Body", record.Body())
+	}
+	e.putAttributes(doc, "Attributes", record.Attributes())
+	e.putResource(doc, resource, il)
+	return json.Marshal(doc)
+}
+
+func (e *encoder) encodeSpan(resource pdata.Resource, il pdata.InstrumentationLibrary, span pdata.Span) ([]byte, error) {
+	doc := document{}
+	e.put(doc, "@timestamp", formatTimestamp(span.StartTime()))
+	e.put(doc, "EndTimestamp", formatTimestamp(span.EndTime()))
+	e.put(doc, "TraceId", span.TraceID().HexString())
+	e.put(doc, "SpanId", span.SpanID().HexString())
+	if span.ParentSpanID().IsValid() {
+		e.put(doc, "ParentSpanId", span.ParentSpanID().HexString())
+	}
+	if span.TraceState() != "" {
+		e.put(doc, "TraceState", string(span.TraceState()))
+	}
+	e.put(doc, "Name", span.Name())
+	e.put(doc, "Kind", span.Kind().String())
+	e.put(doc, "Duration", int64(span.EndTime()-span.StartTime()))
+	if !span.Status().IsNil() {
+		e.put(doc, "Status.Code", span.Status().Code().String())
+		if span.Status().Message() != "" {
+			e.put(doc, "Status.Message", span.Status().Message())
+		}
+	}
+	e.putAttributes(doc, "Attributes", span.Attributes())
+
+	if events := span.Events(); events.Len() > 0 {
+		var docs []document
+		for i := 0; i < events.Len(); i++ {
+			event := events.At(i)
+			if event.IsNil() {
+				continue
+			}
+			eventDoc := document{}
+			e.put(eventDoc, "@timestamp", formatTimestamp(event.Timestamp()))
+			e.put(eventDoc, "Name", event.Name())
+			e.putAttributes(eventDoc, "Attributes", event.Attributes())
+			docs = append(docs, eventDoc)
+		}
+		e.put(doc, "Events", docs)
+	}
+
+	if links := span.Links(); links.Len() > 0 {
+		var docs []document
+		for i := 0; i < links.Len(); i++ {
+			link := links.At(i)
+			if link.IsNil() {
+				continue
+			}
+			linkDoc := document{}
+			e.put(linkDoc, "TraceId", link.TraceID().HexString())
+			e.put(linkDoc, "SpanId", link.SpanID().HexString())
+			if link.TraceState() != "" {
+				e.put(linkDoc, "TraceState", string(link.TraceState()))
+			}
+			e.putAttributes(linkDoc, "Attributes", link.Attributes())
+			docs = append(docs, linkDoc)
+		}
+		e.put(doc, "Links", docs)
+	}
+
+	e.putResource(doc, resource, il)
+	return json.Marshal(doc)
+}
+
+func (e *encoder) putResource(doc document, resource pdata.Resource, il pdata.InstrumentationLibrary) {
+	e.putAttributes(doc, "Resource", resource.Attributes())
+	if il.IsNil() {
+		return
+	}
+	if il.Name() != "" {
+		e.put(doc, "InstrumentationLibrary.Name", il.Name())
+	}
+	if il.Version() != "" {
+		e.put(doc, "InstrumentationLibrary.Version", il.Version())
+	}
+}
+
+func (e *encoder) putAttributes(doc document, prefix string, am pdata.AttributeMap) {
+	am.ForEach(func(k string, v pdata.AttributeValue) {
+		e.putValue(doc, prefix+"."+k, v)
+	})
+}
+
+// putValue stores an AttributeValue under the given path. Map values are expanded
+// so that each leaf is stored according to the mapping mode.
+func (e *encoder) putValue(doc document, path string, v pdata.AttributeValue) {
+	if v.Type() == pdata.AttributeValueMAP {
+		e.putAttributes(doc, path, v.MapVal())
+		return
+	}
+	e.put(doc, path, v.AsRaw())
+}
+
+// put stores a value under the given dotted path. In flattened mode the path is used
+// as is, in nested mode every path segment becomes an object.
+func (e *encoder) put(doc document, path string, value interface{}) {
+	if !e.nested {
+		doc[path] = value
+		return
+	}
+
+	segments := strings.Split(path, ".")
+	cur := doc
+	for i, segment := range segments[:len(segments)-1] {
+		existing, ok := cur[segment]
+		if !ok {
+			next := document{}
+			cur[segment] = next
+			cur = next
+			continue
+		}
+		next, isObject := existing.(document)
+		if !isObject {
+			// A leaf already uses this key, keep the rest of the path dotted to avoid losing data.
+			cur[strings.Join(segments[i:], ".")] = value
+			return
+		}
+		cur = next
+	}
+	cur[segments[len(segments)-1]] = value
+}
+
+func attributeValueToString(v pdata.AttributeValue) string {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return strconv.FormatInt(v.IntVal(), 10)
+	case pdata.AttributeValueDOUBLE:
+		return strconv.FormatFloat(v.DoubleVal(), 'f', -1, 64)
+	case pdata.AttributeValueBOOL:
+		return strconv.FormatBool(v.BoolVal())
+	case pdata.AttributeValueNULL:
+		return ""
+	default:
+		b, _ := json.Marshal(v.AsRaw())
+		return string(b)
+	}
+}
+
+func formatTimestamp(ts pdata.TimestampUnixNano) string {
+	return pdata.UnixNanoToTime(ts).UTC().Format(time.RFC3339Nano)
+}
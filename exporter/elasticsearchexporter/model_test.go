@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newTestLogRecord() (pdata.Resource, pdata.InstrumentationLibrary, pdata.LogRecord) {
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString("service.name", "checkout")
+
+	il := pdata.NewInstrumentationLibrary()
+	il.InitEmpty()
+	il.SetName("lib")
+
+	record := pdata.NewLogRecord()
+	record.InitEmpty()
+	record.SetTimestamp(pdata.TimeToUnixNano(time.Date(2020, 2, 11, 20, 26, 13, 0, time.UTC)))
+	record.SetSeverityNumber(pdata.SeverityNumberINFO)
+	record.SetSeverityText("Info")
+	record.Body().SetStringVal("hello")
+	record.Attributes().InsertString("http.method", "GET")
+	record.Attributes().InsertInt("http.status_code", 200)
+	return resource, il, record
+}
+
+func TestEncodeLogFlattened(t *testing.T) {
+	enc, err := newEncoder(MappingFlattened)
+	require.NoError(t, err)
+
+	resource, il, record := newTestLogRecord()
+	doc, err := enc.encodeLog(resource, il, record)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2020-02-11T20:26:13Z",
+		"SeverityNumber": 9,
+		"SeverityText": "Info",
+		"Body": "hello",
+		"Attributes.http.method": "GET",
+		"Attributes.http.status_code": 200,
+		"Resource.service.name": "checkout",
+		"InstrumentationLibrary.Name": "lib"
+	}`, string(doc))
+}
+
+func TestEncodeLogNested(t *testing.T) {
+	enc, err := newEncoder(MappingNested)
+	require.NoError(t, err)
+
+	resource, il, record := newTestLogRecord()
+	doc, err := enc.encodeLog(resource, il, record)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2020-02-11T20:26:13Z",
+		"SeverityNumber": 9,
+		"SeverityText": "Info",
+		"Body": "hello",
+		"Attributes": {"http": {"method": "GET", "status_code": 200}},
+		"Resource": {"service": {"name": "checkout"}},
+		"InstrumentationLibrary": {"Name": "lib"}
+	}`, string(doc))
+}
+
+func TestEncodeNestedConflict(t *testing.T) {
+	enc, err := newEncoder(MappingNested)
+	require.NoError(t, err)
+
+	doc := document{}
+	enc.put(doc, "Attributes.a", "leaf")
+	enc.put(doc, "Attributes.a.b", "value")
+	assert.Equal(t, document{"Attributes": document{"a": "leaf", "a.b": "value"}}, doc)
+}
+
+func TestEncodeSpan(t *testing.T) {
+	enc, err := newEncoder(MappingFlattened)
+	require.NoError(t, err)
+
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	il := pdata.NewInstrumentationLibrary()
+
+	start := time.Date(2020, 2, 11, 20, 26, 12, 0, time.UTC)
+	span := pdata.NewSpan()
+	span.InitEmpty()
+	span.SetTraceID(pdata.NewTraceID([16]byte{1}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{2}))
+	span.SetName("operationA")
+	span.SetKind(pdata.SpanKindSERVER)
+	span.SetStartTime(pdata.TimeToUnixNano(start))
+	span.SetEndTime(pdata.TimeToUnixNano(start.Add(time.Second)))
+	span.Attributes().InsertBool("error", true)
+	span.Events().Resize(1)
+	span.Events().At(0).SetName("event")
+	span.Events().At(0).SetTimestamp(pdata.TimeToUnixNano(start))
+
+	doc, err := enc.encodeSpan(resource, il, span)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2020-02-11T20:26:12Z",
+		"EndTimestamp": "2020-02-11T20:26:13Z",
+		"TraceId": "01000000000000000000000000000000",
+		"SpanId": "0200000000000000",
+		"Name": "operationA",
+		"Kind": "SPAN_KIND_SERVER",
+		"Duration": 1000000000,
+		"Attributes.error": true,
+		"Events": [{"@timestamp": "2020-02-11T20:26:12Z", "Name": "event"}]
+	}`, string(doc))
+}
+
+func TestAttributeValueToString(t *testing.T) {
+	arr := pdata.NewAttributeValueArray()
+	arr.ArrayVal().Append(pdata.NewAttributeValueString("a"))
+	arr.ArrayVal().Append(pdata.NewAttributeValueDouble(1.5))
+
+	m := pdata.NewAttributeValueMap()
+	m.MapVal().InsertBool("b", false)
+
+	assert.Equal(t, `["a",1.5]`, attributeValueToString(arr))
+	assert.Equal(t, `{"b":false}`, attributeValueToString(m))
+	assert.Equal(t, "", attributeValueToString(pdata.NewAttributeValueNull()))
+	assert.Equal(t, "1.5", attributeValueToString(pdata.NewAttributeValueDouble(1.5)))
+}
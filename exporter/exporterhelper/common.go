@@ -16,6 +16,7 @@ package exporterhelper
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -45,6 +46,36 @@ func CreateDefaultTimeoutSettings() TimeoutSettings {
 	}
 }
 
+// Validate checks that the timeout is not negative, zero means no timeout.
+func (ts *TimeoutSettings) Validate() error {
+	if ts.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
+
+// ValidateSettings validates the given exporter settings and reports all the
+// failures, settings that the exporter does not have can be passed as nil.
+func ValidateSettings(ts *TimeoutSettings, qs *QueueSettings, rs *RetrySettings) error {
+	var errs []error
+	if ts != nil {
+		if err := ts.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if qs != nil {
+		if err := qs.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if rs != nil {
+		if err := rs.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
 // request is an abstraction of an individual request (batch of data) independent of the type of the data (traces, metrics, logs).
 type request interface {
 	// context returns the Context of the requests.
@@ -169,6 +200,7 @@ type baseExporter struct {
 	cfg                        configmodels.Exporter
 	sender                     requestSender
 	qrSender                   *queuedRetrySender
+	statusSender               *statusSender
 	start                      Start
 	shutdown                   Shutdown
 	startOnce                  sync.Once
@@ -185,7 +217,8 @@ func newBaseExporter(cfg configmodels.Exporter, logger *zap.Logger, options ...E
 		convertResourceToTelemetry: opts.ResourceToTelemetrySettings.Enabled,
 	}
 
-	be.qrSender = newQueuedRetrySender(opts.QueueSettings, opts.RetrySettings, &timeoutSender{cfg: opts.TimeoutSettings}, logger)
+	be.statusSender = &statusSender{name: cfg.Name(), nextSender: &timeoutSender{cfg: opts.TimeoutSettings}}
+	be.qrSender = newQueuedRetrySender(opts.QueueSettings, opts.RetrySettings, be.statusSender, logger)
 	be.sender = be.qrSender
 
 	return be
@@ -208,18 +241,27 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 			return
 		}
 
-		// If no error then start the queuedRetrySender.
+		// If no error then start reporting the status and the queuedRetrySender.
+		be.statusSender.start(host)
 		be.qrSender.start()
 	})
 	return err
 }
 
+// QueueStatus returns the current state of the sending queue and of the retries.
+func (be *baseExporter) QueueStatus() QueueStatus {
+	return be.qrSender.status()
+}
+
 // Shutdown all senders and exporter and is invoked during service shutdown.
 func (be *baseExporter) Shutdown(ctx context.Context) error {
 	err := componenterror.ErrAlreadyStopped
 	be.shutdownOnce.Do(func() {
-		// First shutdown the queued retry sender
-		be.qrSender.shutdown()
+		// The exporter may be replaced by a new one with the same name, whose status
+		// must not be overridden by the failures of the data drained below.
+		be.statusSender.stop()
+		// First shutdown the queued retry sender, it drains the queue until the deadline of ctx.
+		be.qrSender.shutdown(ctx)
 		// Last shutdown the wrapped exporter itself.
 		err = be.shutdown(ctx)
 	})
@@ -243,3 +285,59 @@ func (ts *timeoutSender) send(req request) (int, error) {
 	}
 	return req.export(ctx)
 }
+
+// statusSender is a request sender that reports the status of the exporter to the host
+// when the result of the attempts to send data changes from success to failure or back.
+type statusSender struct {
+	name       string
+	nextSender requestSender
+
+	mu     sync.Mutex
+	host   component.Host
+	status component.Status
+}
+
+// start reports that the exporter is OK, and then the changes of its status to host.
+func (ss *statusSender) start(host component.Host) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.host = host
+	ss.status = component.StatusOK
+	host.ReportStatus(component.StatusEvent{
+		Kind:   component.KindExporter,
+		Name:   ss.name,
+		Status: component.StatusOK,
+	})
+}
+
+// stop stops reporting the status of the exporter.
+func (ss *statusSender) stop() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.host = nil
+}
+
+// send implements the requestSender interface
+func (ss *statusSender) send(req request) (int, error) {
+	n, err := ss.nextSender.send(req)
+	status := component.StatusOK
+	switch {
+	case consumererror.IsPermanent(err):
+		status = component.StatusPermanentError
+	case err != nil:
+		status = component.StatusRecoverableError
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.host != nil && status != ss.status {
+		ss.status = status
+		ss.host.ReportStatus(component.StatusEvent{
+			Kind:   component.KindExporter,
+			Name:   ss.name,
+			Status: status,
+			Err:    err,
+		})
+	}
+	return n, err
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/trace"
@@ -25,6 +26,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/data/testdata"
 )
 
 var defaultExporterCfg = &configmodels.ExporterSettings{
@@ -37,6 +41,34 @@ func TestErrorToStatus(t *testing.T) {
 	require.Equal(t, trace.Status{Code: trace.StatusCodeUnknown, Message: "my_error"}, errToStatus(errors.New("my_error")))
 }
 
+func TestTimeoutSettings_Validate(t *testing.T) {
+	tCfg := CreateDefaultTimeoutSettings()
+	require.NoError(t, tCfg.Validate())
+
+	tCfg.Timeout = 0
+	require.NoError(t, tCfg.Validate())
+
+	tCfg.Timeout = -time.Second
+	require.EqualError(t, tCfg.Validate(), "timeout must not be negative")
+}
+
+func TestValidateSettings(t *testing.T) {
+	tCfg := CreateDefaultTimeoutSettings()
+	qCfg := CreateDefaultQueueSettings()
+	rCfg := CreateDefaultRetrySettings()
+	require.NoError(t, ValidateSettings(&tCfg, &qCfg, &rCfg))
+	require.NoError(t, ValidateSettings(nil, nil, nil))
+
+	tCfg.Timeout = -time.Second
+	require.EqualError(t, ValidateSettings(&tCfg, &qCfg, &rCfg), "timeout must not be negative")
+
+	qCfg.QueueSize = 0
+	rCfg.InitialInterval = -time.Second
+	require.EqualError(t, ValidateSettings(&tCfg, &qCfg, &rCfg),
+		"[timeout must not be negative; sending_queue: queue_size must be positive; retry_on_failure: intervals must not be negative]")
+	require.EqualError(t, ValidateSettings(nil, &qCfg, nil), "sending_queue: queue_size must be positive")
+}
+
 func TestBaseExporter(t *testing.T) {
 	be := newBaseExporter(defaultExporterCfg, zap.NewNop())
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -56,6 +88,50 @@ func TestBaseExporterWithOptions(t *testing.T) {
 	require.Error(t, be.Shutdown(context.Background()))
 }
 
+// statusHost records the status events reported by the components.
+type statusHost struct {
+	component.Host
+	events []component.StatusEvent
+}
+
+func (sh *statusHost) ReportStatus(event component.StatusEvent) {
+	sh.events = append(sh.events, event)
+}
+
+func TestBaseExporterReportsStatus(t *testing.T) {
+	var pushErr error
+	te, err := NewTraceExporter(defaultExporterCfg, zap.NewNop(), func(context.Context, pdata.Traces) (int, error) {
+		return 0, pushErr
+	})
+	require.NoError(t, err)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, te.Start(context.Background(), host))
+
+	td := testdata.GenerateTraceDataOneSpan()
+	// The status is reported on start, and then only when it changes.
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	pushErr = errors.New("my error")
+	require.Error(t, te.ConsumeTraces(context.Background(), td))
+	require.Error(t, te.ConsumeTraces(context.Background(), td))
+	pushErr = consumererror.Permanent(errors.New("my permanent error"))
+	require.Error(t, te.ConsumeTraces(context.Background(), td))
+	pushErr = nil
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	require.Len(t, host.events, 4)
+	for _, event := range host.events {
+		require.Equal(t, component.KindExporter, event.Kind)
+		require.Equal(t, "test", event.Name)
+	}
+	require.Equal(t, component.StatusOK, host.events[0].Status)
+	require.Equal(t, component.StatusRecoverableError, host.events[1].Status)
+	require.EqualError(t, host.events[1].Err, "my error")
+	require.Equal(t, component.StatusPermanentError, host.events[2].Status)
+	require.Equal(t, component.StatusOK, host.events[3].Status)
+	require.NoError(t, host.events[3].Err)
+}
+
 func errToStatus(err error) trace.Status {
 	if err != nil {
 		return trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()}
@@ -18,6 +18,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff"
@@ -52,6 +54,21 @@ func CreateDefaultQueueSettings() QueueSettings {
 	}
 }
 
+// Validate checks that the number of consumers and the queue size are positive when
+// the queue is enabled.
+func (qs *QueueSettings) Validate() error {
+	if !qs.Enabled {
+		return nil
+	}
+	if qs.NumConsumers <= 0 {
+		return errors.New("sending_queue: num_consumers must be positive")
+	}
+	if qs.QueueSize <= 0 {
+		return errors.New("sending_queue: queue_size must be positive")
+	}
+	return nil
+}
+
 // RetrySettings defines configuration for retrying batches in case of export failure.
 // The current supported strategy is exponential backoff.
 type RetrySettings struct {
@@ -77,14 +94,70 @@ func CreateDefaultRetrySettings() RetrySettings {
 	}
 }
 
+// Validate checks that the intervals are not negative and that the initial interval
+// is not greater than the maximum interval when retries are enabled.
+func (rs *RetrySettings) Validate() error {
+	if !rs.Enabled {
+		return nil
+	}
+	if rs.InitialInterval < 0 || rs.MaxInterval < 0 || rs.MaxElapsedTime < 0 {
+		return errors.New("retry_on_failure: intervals must not be negative")
+	}
+	if rs.MaxInterval != 0 && rs.InitialInterval > rs.MaxInterval {
+		return fmt.Errorf("retry_on_failure: initial_interval %v must not be greater than max_interval %v", rs.InitialInterval, rs.MaxInterval)
+	}
+	return nil
+}
+
+// QueueStatus is the state of the sending queue and of the retries of an exporter.
+type QueueStatus struct {
+	// QueueEnabled is true if the sending queue is enabled, QueueSize and QueueCapacity
+	// are zero if it is not.
+	QueueEnabled  bool
+	QueueSize     int
+	QueueCapacity int
+	// RetryEnabled is true if the failed requests are retried.
+	RetryEnabled bool
+	// RetryingRequests is the number of requests waiting to be retried.
+	RetryingRequests int64
+	// LastRetryError is the error of the last failed attempt that was retried, nil if
+	// no attempt was retried.
+	LastRetryError error
+	// LastRetryTime is the time of the last failed attempt that was retried.
+	LastRetryTime time.Time
+	// FlushedItems and DroppedItems are the number of items that were respectively sent
+	// and dropped while the sending queue was drained on shutdown.
+	FlushedItems int64
+	DroppedItems int64
+}
+
+// QueueStatusReporter is implemented by the exporters created with this package, it
+// can be used to inspect the state of their sending queue and of their retries.
+type QueueStatusReporter interface {
+	// QueueStatus returns the current state of the sending queue and of the retries.
+	QueueStatus() QueueStatus
+}
+
 type queuedRetrySender struct {
 	cfg            QueueSettings
 	consumerSender requestSender
+	retrySender    *retrySender
 	queue          *queue.BoundedQueue
 	retryStopCh    chan struct{}
 	logger         *zap.Logger
+
+	// queuedItems is the number of items in the queue or being sent by the queue consumers,
+	// sentItems is the number of items sent by the queue consumers. They are updated atomically.
+	queuedItems int64
+	sentItems   int64
+	// flushedItems and droppedItems are set once the queue is drained on shutdown.
+	flushedItems int64
+	droppedItems int64
 }
 
+// drainPollInterval is the interval at which the queue is checked while it is drained.
+const drainPollInterval = 10 * time.Millisecond
+
 func createSampledLogger(logger *zap.Logger) *zap.Logger {
 	if logger.Core().Enabled(zapcore.DebugLevel) {
 		// Debugging is enabled. Don't do any sampling.
@@ -107,17 +180,19 @@ func createSampledLogger(logger *zap.Logger) *zap.Logger {
 func newQueuedRetrySender(qCfg QueueSettings, rCfg RetrySettings, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
 	sampledLogger := createSampledLogger(logger)
+	rs := &retrySender{
+		cfg:        rCfg,
+		nextSender: nextSender,
+		stopCh:     retryStopCh,
+		logger:     sampledLogger,
+	}
 	return &queuedRetrySender{
-		cfg: qCfg,
-		consumerSender: &retrySender{
-			cfg:        rCfg,
-			nextSender: nextSender,
-			stopCh:     retryStopCh,
-			logger:     sampledLogger,
-		},
-		queue:       queue.NewBoundedQueue(qCfg.QueueSize, func(item interface{}) {}),
-		retryStopCh: retryStopCh,
-		logger:      sampledLogger,
+		cfg:            qCfg,
+		consumerSender: rs,
+		retrySender:    rs,
+		queue:          queue.NewBoundedQueue(qCfg.QueueSize, func(item interface{}) {}),
+		retryStopCh:    retryStopCh,
+		logger:         sampledLogger,
 	}
 }
 
@@ -125,7 +200,10 @@ func newQueuedRetrySender(qCfg QueueSettings, rCfg RetrySettings, nextSender req
 func (qrs *queuedRetrySender) start() {
 	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
 		req := item.(request)
-		_, _ = qrs.consumerSender.send(req)
+		count := int64(req.count())
+		dropped, _ := qrs.consumerSender.send(req)
+		atomic.AddInt64(&qrs.sentItems, count-int64(dropped))
+		atomic.AddInt64(&qrs.queuedItems, -count)
 	})
 }
 
@@ -146,7 +224,10 @@ func (qrs *queuedRetrySender) send(req request) (int, error) {
 	// The grpc/http based receivers will cancel the request context after this function returns.
 	req.setContext(noCancellationContext{Context: req.context()})
 
+	// The items are counted before they are produced, a consumer may send them right away.
+	atomic.AddInt64(&qrs.queuedItems, int64(req.count()))
 	if !qrs.queue.Produce(req) {
+		atomic.AddInt64(&qrs.queuedItems, -int64(req.count()))
 		qrs.logger.Error(
 			"Dropping data because sending_queue is full. Try increasing queue_size.",
 			zap.Int("dropped_items", req.count()),
@@ -157,14 +238,66 @@ func (qrs *queuedRetrySender) send(req request) (int, error) {
 	return 0, nil
 }
 
-// shutdown is invoked during service shutdown.
-func (qrs *queuedRetrySender) shutdown() {
-	// First stop the retry goroutines, so that unblocks the queue workers.
+// status returns the current state of the queue and of the retries.
+func (qrs *queuedRetrySender) status() QueueStatus {
+	status := QueueStatus{
+		QueueEnabled:     qrs.cfg.Enabled,
+		RetryEnabled:     qrs.retrySender.cfg.Enabled,
+		RetryingRequests: atomic.LoadInt64(&qrs.retrySender.retrying),
+	}
+	if qrs.cfg.Enabled {
+		status.QueueSize = qrs.queue.Size()
+		status.QueueCapacity = qrs.queue.Capacity()
+	}
+	qrs.retrySender.mu.Lock()
+	status.LastRetryError = qrs.retrySender.lastErr
+	status.LastRetryTime = qrs.retrySender.lastErrTime
+	qrs.retrySender.mu.Unlock()
+	status.FlushedItems = atomic.LoadInt64(&qrs.flushedItems)
+	status.DroppedItems = atomic.LoadInt64(&qrs.droppedItems)
+	return status
+}
+
+// shutdown is invoked during service shutdown. If ctx has a deadline the queue is drained
+// until it is empty or until ctx is done, the items that are still queued are then dropped.
+// Without a deadline the queue is not drained, the retries could take arbitrarily long.
+func (qrs *queuedRetrySender) shutdown(ctx context.Context) {
+	queued := atomic.LoadInt64(&qrs.queuedItems)
+	sent := atomic.LoadInt64(&qrs.sentItems)
+	if _, ok := ctx.Deadline(); ok && qrs.cfg.Enabled && queued > 0 {
+		qrs.logger.Info("Draining sending queue...", zap.Int64("queued_items", queued))
+		qrs.waitEmpty(ctx)
+	}
+
+	// Stop the retry goroutines, so that unblocks the queue workers.
 	close(qrs.retryStopCh)
 
-	// Stop the queued sender, this will drain the queue and will call the retry (which is stopped) that will only
-	// try once every request.
+	// Stop the queued sender, the requests being sent are tried once more and the ones
+	// still in the queue are dropped.
 	qrs.queue.Stop()
+
+	if !qrs.cfg.Enabled || queued == 0 {
+		return
+	}
+	flushed := atomic.LoadInt64(&qrs.sentItems) - sent
+	atomic.StoreInt64(&qrs.flushedItems, flushed)
+	atomic.StoreInt64(&qrs.droppedItems, queued-flushed)
+	qrs.logger.Info("Sending queue drained.",
+		zap.Int64("flushed_items", flushed),
+		zap.Int64("dropped_items", queued-flushed))
+}
+
+// waitEmpty waits until all the queued items are sent or until ctx is done.
+func (qrs *queuedRetrySender) waitEmpty(ctx context.Context) {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for atomic.LoadInt64(&qrs.queuedItems) > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
 // TODO: Clean this by forcing all exporters to return an internal error type that always include the information about retries.
@@ -185,6 +318,13 @@ type retrySender struct {
 	nextSender requestSender
 	stopCh     chan struct{}
 	logger     *zap.Logger
+
+	// retrying is the number of requests waiting to be retried, it is updated atomically.
+	retrying int64
+	// mu protects the last error that was retried and its time.
+	mu          sync.Mutex
+	lastErr     error
+	lastErrTime time.Time
 }
 
 // send implements the requestSender interface
@@ -266,15 +406,23 @@ func (rs *retrySender) send(req request) (int, error) {
 			zap.String("interval", backoffDelayStr),
 		)
 		retryNum++
+		rs.mu.Lock()
+		rs.lastErr = err
+		rs.lastErrTime = time.Now()
+		rs.mu.Unlock()
 
 		// back-off, but get interrupted when shutting down or request is cancelled or timed out.
+		atomic.AddInt64(&rs.retrying, 1)
 		select {
 		case <-req.context().Done():
+			atomic.AddInt64(&rs.retrying, -1)
 			return req.count(), fmt.Errorf("request is cancelled or timed out %w", err)
 		case <-rs.stopCh:
+			atomic.AddInt64(&rs.retrying, -1)
 			return req.count(), fmt.Errorf("interrupted due to shutdown %w", err)
 		case <-time.After(backoffDelay):
 		}
+		atomic.AddInt64(&rs.retrying, -1)
 	}
 }
 
@@ -138,7 +138,10 @@ func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
 		assert.Equal(t, 0, droppedItems)
 	})
 
-	assert.NoError(t, be.Shutdown(context.Background()))
+	// The drain deadline is already expired, the queue is not drained.
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	assert.NoError(t, be.Shutdown(ctx))
 
 	// TODO: Ensure that queue is drained, and uncomment the next 3 lines.
 	//  https://github.com/jaegertracing/jaeger/pull/2349
@@ -147,6 +150,78 @@ func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
 	// ocs.checkSendItemsCount(t, 3)
 	ocs.checkDroppedItemsCount(t, 2)
 	// require.Zero(t, be.qrSender.queue.Size())
+	status := be.QueueStatus()
+	assert.EqualValues(t, 5, status.FlushedItems+status.DroppedItems)
+	assert.GreaterOrEqual(t, status.DroppedItems, int64(2))
+}
+
+func TestQueuedRetry_DrainOnShutdown(t *testing.T) {
+	qCfg := CreateDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := CreateDefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg))
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	firstMockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	secondMockR := newMockRequest(context.Background(), 3, nil)
+	ocs.run(func() {
+		_, err := be.sender.send(firstMockR)
+		require.NoError(t, err)
+	})
+	ocs.run(func() {
+		_, err := be.sender.send(secondMockR)
+		require.NoError(t, err)
+	})
+
+	// The first request is retried and the second one is sent before the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, be.Shutdown(ctx))
+
+	firstMockR.checkNumRequests(t, 2)
+	secondMockR.checkNumRequests(t, 1)
+	ocs.checkSendItemsCount(t, 5)
+	ocs.checkDroppedItemsCount(t, 0)
+	status := be.QueueStatus()
+	assert.EqualValues(t, 5, status.FlushedItems)
+	assert.Zero(t, status.DroppedItems)
+}
+
+func TestQueuedRetry_QueueStatus(t *testing.T) {
+	qCfg := CreateDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := CreateDefaultRetrySettings()
+	rCfg.InitialInterval = time.Hour
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg))
+	assert.Equal(t, QueueStatus{
+		QueueEnabled:  true,
+		QueueCapacity: qCfg.QueueSize,
+		RetryEnabled:  true,
+	}, be.QueueStatus())
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The first request waits for its retry, the second one stays in the queue.
+	_, err := be.sender.send(newMockRequest(context.Background(), 2, errors.New("transient error")))
+	require.NoError(t, err)
+	_, err = be.sender.send(newMockRequest(context.Background(), 3, nil))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return be.QueueStatus().RetryingRequests == 1
+	}, time.Second, time.Millisecond)
+
+	status := be.QueueStatus()
+	assert.Equal(t, 1, status.QueueSize)
+	assert.EqualError(t, status.LastRetryError, "transient error")
+	assert.False(t, status.LastRetryTime.IsZero())
+
+	// Do not wait for the retry, the items are dropped.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, be.Shutdown(ctx))
+	assert.Zero(t, be.QueueStatus().RetryingRequests)
 }
 
 func TestQueuedRetry_DoNotPreserveCancellation(t *testing.T) {
@@ -460,3 +535,36 @@ func (ocs *observabilityConsumerSender) checkSendItemsCount(t *testing.T, want i
 func (ocs *observabilityConsumerSender) checkDroppedItemsCount(t *testing.T, want int) {
 	assert.EqualValues(t, want, atomic.LoadInt64(&ocs.droppedItemsCount))
 }
+
+func TestQueueSettings_Validate(t *testing.T) {
+	qCfg := CreateDefaultQueueSettings()
+	assert.NoError(t, qCfg.Validate())
+
+	qCfg.QueueSize = 0
+	assert.EqualError(t, qCfg.Validate(), "sending_queue: queue_size must be positive")
+
+	qCfg.QueueSize = 10
+	qCfg.NumConsumers = -1
+	assert.EqualError(t, qCfg.Validate(), "sending_queue: num_consumers must be positive")
+
+	// Not validated when the queue is disabled.
+	qCfg.Enabled = false
+	assert.NoError(t, qCfg.Validate())
+}
+
+func TestRetrySettings_Validate(t *testing.T) {
+	rCfg := CreateDefaultRetrySettings()
+	assert.NoError(t, rCfg.Validate())
+
+	rCfg.MaxElapsedTime = -time.Second
+	assert.EqualError(t, rCfg.Validate(), "retry_on_failure: intervals must not be negative")
+
+	rCfg.MaxElapsedTime = time.Minute
+	rCfg.InitialInterval = time.Minute
+	rCfg.MaxInterval = time.Second
+	assert.EqualError(t, rCfg.Validate(), "retry_on_failure: initial_interval 1m0s must not be greater than max_interval 1s")
+
+	// Not validated when retries are disabled.
+	rCfg.Enabled = false
+	assert.NoError(t, rCfg.Validate())
+}
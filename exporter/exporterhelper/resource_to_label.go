@@ -88,6 +88,8 @@ func addLabelsToMetric(metric *pdata.Metric, labelMap pdata.StringMap) {
 		addLabelsToIntHistogramDataPoints(metric.IntHistogram().DataPoints(), labelMap)
 	case pdata.MetricDataTypeDoubleHistogram:
 		addLabelsToDoubleHistogramDataPoints(metric.DoubleHistogram().DataPoints(), labelMap)
+	case pdata.MetricDataTypeExponentialHistogram:
+		addLabelsToExponentialHistogramDataPoints(metric.ExponentialHistogram().DataPoints(), labelMap)
 	}
 }
 
@@ -131,6 +133,16 @@ func addLabelsToDoubleHistogramDataPoints(ps pdata.DoubleHistogramDataPointSlice
 	}
 }
 
+func addLabelsToExponentialHistogramDataPoints(ps pdata.ExponentialHistogramDataPointSlice, newLabelMap pdata.StringMap) {
+	for i := 0; i < ps.Len(); i++ {
+		dataPoint := ps.At(i)
+		if dataPoint.IsNil() {
+			continue
+		}
+		joinStringMaps(newLabelMap, dataPoint.LabelsMap())
+	}
+}
+
 func joinStringMaps(from, to pdata.StringMap) {
 	from.ForEach(func(k, v string) {
 		to.Upsert(k, v)
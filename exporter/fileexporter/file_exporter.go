@@ -19,53 +19,46 @@ import (
 	"io"
 	"sync"
 
-	"github.com/gogo/protobuf/jsonpb"
-	"github.com/gogo/protobuf/proto"
-
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
-	"go.opentelemetry.io/collector/internal"
-	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/logs/v1"
-	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/metrics/v1"
-	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/trace/v1"
 )
 
-// Marshaler configuration used for marhsaling Protobuf to JSON. Use default config.
-var marshaler = &jsonpb.Marshaler{}
-
 // fileExporter is the implementation of file exporter that writes telemetry data to a file
-// in Protobuf-JSON format.
+// in OTLP/JSON format.
 type fileExporter struct {
 	file  io.WriteCloser
 	mutex sync.Mutex
 }
 
 func (e *fileExporter) ConsumeTraces(_ context.Context, td pdata.Traces) error {
-	request := otlptrace.ExportTraceServiceRequest{
-		ResourceSpans: pdata.TracesToOtlp(td),
+	buf, err := td.ToOtlpJSONBytes()
+	if err != nil {
+		return err
 	}
-	return exportMessageAsLine(e, &request)
+	return exportMessageAsLine(e, buf)
 }
 
 func (e *fileExporter) ConsumeMetrics(_ context.Context, md pdata.Metrics) error {
-	request := otlpmetrics.ExportMetricsServiceRequest{
-		ResourceMetrics: pdata.MetricsToOtlp(md),
+	buf, err := md.ToOtlpJSONBytes()
+	if err != nil {
+		return err
 	}
-	return exportMessageAsLine(e, &request)
+	return exportMessageAsLine(e, buf)
 }
 
 func (e *fileExporter) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
-	request := otlplogs.ExportLogsServiceRequest{
-		ResourceLogs: internal.LogsToOtlp(ld.InternalRep()),
+	buf, err := ld.ToOtlpJSONBytes()
+	if err != nil {
+		return err
 	}
-	return exportMessageAsLine(e, &request)
+	return exportMessageAsLine(e, buf)
 }
 
-func exportMessageAsLine(e *fileExporter, message proto.Message) error {
+func exportMessageAsLine(e *fileExporter, buf []byte) error {
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	if err := marshaler.Marshal(e.file, message); err != nil {
+	if _, err := e.file.Write(buf); err != nil {
 		return err
 	}
 	if _, err := io.WriteString(e.file, "\n"); err != nil {
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package subset copies selected items of pdata, it is shared by exporters
+// returning the items that were not exported with a partial error.
+package subset
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Position is the position of a single item (span or log record) in a
+// pdata.Traces or pdata.Logs.
+type Position struct {
+	ResourceIndex int
+	LibraryIndex  int
+	Index         int
+}
+
+// Logs returns a copy of the log records at the given positions. It is
+// meant to build the data returned with a partial error when only some of the
+// records were exported. The positions must be in the same order as in ld.
+func Logs(ld pdata.Logs, positions []Position) pdata.Logs {
+	result := pdata.NewLogs()
+	var destRl pdata.ResourceLogs
+	var destIll pdata.InstrumentationLibraryLogs
+	lastRs, lastIl := -1, -1
+	for _, pos := range positions {
+		rl := ld.ResourceLogs().At(pos.ResourceIndex)
+		if pos.ResourceIndex != lastRs {
+			destRl = pdata.NewResourceLogs()
+			destRl.InitEmpty()
+			rl.Resource().CopyTo(destRl.Resource())
+			result.ResourceLogs().Append(destRl)
+			lastRs, lastIl = pos.ResourceIndex, -1
+		}
+		ill := rl.InstrumentationLibraryLogs().At(pos.LibraryIndex)
+		if pos.LibraryIndex != lastIl {
+			destIll = pdata.NewInstrumentationLibraryLogs()
+			destIll.InitEmpty()
+			ill.InstrumentationLibrary().CopyTo(destIll.InstrumentationLibrary())
+			destRl.InstrumentationLibraryLogs().Append(destIll)
+			lastIl = pos.LibraryIndex
+		}
+		lr := pdata.NewLogRecord()
+		ill.Logs().At(pos.Index).CopyTo(lr)
+		destIll.Logs().Append(lr)
+	}
+	return result
+}
+
+// Traces returns a copy of the spans at the given positions. It is meant
+// to build the data returned with a partial error when only some of the spans
+// were exported. The positions must be in the same order as in td.
+func Traces(td pdata.Traces, positions []Position) pdata.Traces {
+	result := pdata.NewTraces()
+	var destRs pdata.ResourceSpans
+	var destIls pdata.InstrumentationLibrarySpans
+	lastRs, lastIl := -1, -1
+	for _, pos := range positions {
+		rs := td.ResourceSpans().At(pos.ResourceIndex)
+		if pos.ResourceIndex != lastRs {
+			destRs = pdata.NewResourceSpans()
+			destRs.InitEmpty()
+			rs.Resource().CopyTo(destRs.Resource())
+			result.ResourceSpans().Append(destRs)
+			lastRs, lastIl = pos.ResourceIndex, -1
+		}
+		ils := rs.InstrumentationLibrarySpans().At(pos.LibraryIndex)
+		if pos.LibraryIndex != lastIl {
+			destIls = pdata.NewInstrumentationLibrarySpans()
+			destIls.InitEmpty()
+			ils.InstrumentationLibrary().CopyTo(destIls.InstrumentationLibrary())
+			destRs.InstrumentationLibrarySpans().Append(destIls)
+			lastIl = pos.LibraryIndex
+		}
+		span := pdata.NewSpan()
+		ils.Spans().At(pos.Index).CopyTo(span)
+		destIls.Spans().Append(span)
+	}
+	return result
+}
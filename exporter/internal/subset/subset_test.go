@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal/data/testdata"
+)
+
+func TestLogs(t *testing.T) {
+	ld := testdata.GenerateLogDataTwoLogsSameResourceOneDifferent()
+
+	all := []Position{{0, 0, 0}, {0, 0, 1}, {1, 0, 0}}
+	assert.EqualValues(t, ld, Logs(ld, all))
+
+	subset := Logs(ld, []Position{{0, 0, 1}, {1, 0, 0}})
+	require.Equal(t, 2, subset.ResourceLogs().Len())
+	assert.Equal(t, 2, subset.LogRecordCount())
+	for i := 0; i < 2; i++ {
+		assert.EqualValues(t, ld.ResourceLogs().At(i).Resource(), subset.ResourceLogs().At(i).Resource())
+	}
+	assert.EqualValues(t,
+		ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(1),
+		subset.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0))
+	assert.EqualValues(t,
+		ld.ResourceLogs().At(1).InstrumentationLibraryLogs().At(0).Logs().At(0),
+		subset.ResourceLogs().At(1).InstrumentationLibraryLogs().At(0).Logs().At(0))
+
+	assert.Equal(t, 0, Logs(ld, nil).ResourceLogs().Len())
+}
+
+func TestTraces(t *testing.T) {
+	td := testdata.GenerateTraceDataTwoSpansSameResourceOneDifferent()
+
+	all := []Position{{0, 0, 0}, {0, 0, 1}, {1, 0, 0}}
+	assert.EqualValues(t, td, Traces(td, all))
+
+	subset := Traces(td, []Position{{0, 0, 1}, {1, 0, 0}})
+	require.Equal(t, 2, subset.ResourceSpans().Len())
+	assert.Equal(t, 2, subset.SpanCount())
+	for i := 0; i < 2; i++ {
+		assert.EqualValues(t, td.ResourceSpans().At(i).Resource(), subset.ResourceSpans().At(i).Resource())
+	}
+	assert.EqualValues(t,
+		td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(1),
+		subset.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0))
+	assert.EqualValues(t,
+		td.ResourceSpans().At(1).InstrumentationLibrarySpans().At(0).Spans().At(0),
+		subset.ResourceSpans().At(1).InstrumentationLibrarySpans().At(0).Spans().At(0))
+
+	assert.Equal(t, 0, Traces(td, nil).ResourceSpans().Len())
+}
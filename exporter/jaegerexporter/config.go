@@ -29,3 +29,8 @@ type Config struct {
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 }
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	return exporterhelper.ValidateSettings(&cfg.TimeoutSettings, &cfg.QueueSettings, &cfg.RetrySettings)
+}
@@ -45,6 +45,11 @@ type Config struct {
 	Authentication Authentication `mapstructure:"auth"`
 }
 
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	return exporterhelper.ValidateSettings(&cfg.TimeoutSettings, &cfg.QueueSettings, &cfg.RetrySettings)
+}
+
 // Metadata defines configuration for retrieving metadata from the broker.
 type Metadata struct {
 	// Whether to maintain a full set of metadata for all topics, or just
@@ -109,6 +109,10 @@ func (b *logDataBuffer) logMetricDataPoints(m pdata.Metric) {
 	case pdata.MetricDataTypeDoubleSummary:
 		data := m.DoubleSummary()
 		b.logDoubleSummaryDataPoints(data.DataPoints())
+	case pdata.MetricDataTypeExponentialHistogram:
+		data := m.ExponentialHistogram()
+		b.logEntry("     -> AggregationTemporality: %s", data.AggregationTemporality().String())
+		b.logExponentialHistogramDataPoints(data.DataPoints())
 	}
 }
 
@@ -229,6 +233,35 @@ func (b *logDataBuffer) logDoubleSummaryDataPoints(ps pdata.DoubleSummaryDataPoi
 	}
 }
 
+func (b *logDataBuffer) logExponentialHistogramDataPoints(ps pdata.ExponentialHistogramDataPointSlice) {
+	for i := 0; i < ps.Len(); i++ {
+		p := ps.At(i)
+		if p.IsNil() {
+			continue
+		}
+
+		b.logEntry("ExponentialHistogramDataPoints #%d", i)
+		b.logDataPointLabels(p.LabelsMap())
+
+		b.logEntry("StartTime: %d", p.StartTime())
+		b.logEntry("Timestamp: %d", p.Timestamp())
+		b.logEntry("Count: %d", p.Count())
+		b.logEntry("Sum: %f", p.Sum())
+		b.logEntry("Scale: %d", p.Scale())
+		b.logEntry("ZeroCount: %d", p.ZeroCount())
+
+		b.logExponentialHistogramBuckets("Positive", p.Positive())
+		b.logExponentialHistogramBuckets("Negative", p.Negative())
+	}
+}
+
+func (b *logDataBuffer) logExponentialHistogramBuckets(description string, buckets pdata.ExponentialHistogramBuckets) {
+	b.logEntry("%s Offset: %d", description, buckets.Offset())
+	for j, count := range buckets.BucketCounts() {
+		b.logEntry("%s Buckets #%d, Count: %d", description, j, count)
+	}
+}
+
 func (b *logDataBuffer) logDataPointLabels(labels pdata.StringMap) {
 	b.logStringMap("Data point labels", labels)
 }
@@ -36,3 +36,8 @@ type Config struct {
 	// The URL to send logs to. If omitted the Endpoint + "/v1/logs" will be used.
 	LogsEndpoint string `mapstructure:"logs_endpoint"`
 }
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	return exporterhelper.ValidateSettings(nil, &cfg.QueueSettings, &cfg.RetrySettings)
+}
@@ -35,4 +35,8 @@ type Config struct {
 
 	// SendTimestamps will send the underlying scrape timestamp with the export
 	SendTimestamps bool `mapstructure:"send_timestamps"`
+
+	// EnableOpenMetrics enables the OpenMetrics format when it is negotiated by the scraper,
+	// the exemplars of the counters and histograms are only exported in this format.
+	EnableOpenMetrics bool `mapstructure:"enable_open_metrics"`
 }
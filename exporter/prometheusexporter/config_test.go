@@ -53,6 +53,7 @@ func TestLoadConfig(t *testing.T) {
 				"label1":        "value1",
 				"another label": "spaced value",
 			},
-			SendTimestamps: true,
+			SendTimestamps:    true,
+			EnableOpenMetrics: true,
 		})
 }
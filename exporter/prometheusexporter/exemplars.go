@@ -0,0 +1,361 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// exemplarTTL is how long the exemplars of a time series are kept after its last
+// exported data point.
+const exemplarTTL = 5 * time.Minute
+
+// exemplarStore keeps the exemplars of the last exported data points of the counters
+// and histograms by time series. The underlying exporter drops the exemplars, they are
+// added back to the gathered metrics by the exemplarGatherer.
+type exemplarStore struct {
+	namespace   string
+	constLabels prometheus.Labels
+	// ttl is how long the exemplars of a time series are kept after it was last recorded.
+	ttl time.Duration
+
+	mu sync.Mutex
+	// exemplars has the exemplars of every time series by seriesKey.
+	exemplars map[string]*seriesExemplars
+}
+
+// seriesExemplars are the exemplars of the last recorded data point of a time series.
+type seriesExemplars struct {
+	exemplars []*dto.Exemplar
+	recorded  time.Time
+}
+
+func newExemplarStore(namespace string, constLabels prometheus.Labels, ttl time.Duration) *exemplarStore {
+	return &exemplarStore{
+		namespace:   namespace,
+		constLabels: constLabels,
+		ttl:         ttl,
+		exemplars:   make(map[string]*seriesExemplars),
+	}
+}
+
+// record replaces the exemplars of the time series of md, and drops the exemplars
+// of the time series that were not recorded during the ttl.
+func (s *exemplarStore) record(md pdata.Metrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if rm.IsNil() {
+			continue
+		}
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			if ilm.IsNil() {
+				continue
+			}
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if metric.IsNil() {
+					continue
+				}
+				s.recordMetric(metric)
+			}
+		}
+	}
+	s.expire(time.Now())
+}
+
+func (s *exemplarStore) recordMetric(metric pdata.Metric) {
+	name := metricName(s.namespace, metric.Name())
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		if data := metric.IntSum(); !data.IsNil() {
+			dps := data.DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				if dp := dps.At(i); !dp.IsNil() {
+					s.set(name, dp.LabelsMap(), intExemplarsToProm(dp.Exemplars()))
+				}
+			}
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		if data := metric.DoubleSum(); !data.IsNil() {
+			dps := data.DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				if dp := dps.At(i); !dp.IsNil() {
+					s.set(name, dp.LabelsMap(), doubleExemplarsToProm(dp.Exemplars()))
+				}
+			}
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		if data := metric.IntHistogram(); !data.IsNil() {
+			dps := data.DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				if dp := dps.At(i); !dp.IsNil() {
+					s.set(name, dp.LabelsMap(), intExemplarsToProm(dp.Exemplars()))
+				}
+			}
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		if data := metric.DoubleHistogram(); !data.IsNil() {
+			dps := data.DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				if dp := dps.At(i); !dp.IsNil() {
+					s.set(name, dp.LabelsMap(), doubleExemplarsToProm(dp.Exemplars()))
+				}
+			}
+		}
+	case pdata.MetricDataTypeExponentialHistogram:
+		if data := metric.ExponentialHistogram(); !data.IsNil() {
+			dps := data.DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				if dp := dps.At(i); !dp.IsNil() {
+					s.set(name, dp.LabelsMap(), doubleExemplarsToProm(dp.Exemplars()))
+				}
+			}
+		}
+	}
+}
+
+func (s *exemplarStore) set(name string, labels pdata.StringMap, exemplars []*dto.Exemplar) {
+	seriesLabels := make(map[string]string, labels.Len()+len(s.constLabels))
+	labels.ForEach(func(k string, v string) {
+		seriesLabels[sanitize(k)] = v
+	})
+	for k, v := range s.constLabels {
+		seriesLabels[k] = v
+	}
+	key := seriesKey(name, seriesLabels)
+	if len(exemplars) == 0 {
+		delete(s.exemplars, key)
+		return
+	}
+	s.exemplars[key] = &seriesExemplars{exemplars: exemplars, recorded: time.Now()}
+}
+
+// expire drops the exemplars of the time series that were not recorded during the ttl.
+func (s *exemplarStore) expire(now time.Time) {
+	for key, series := range s.exemplars {
+		if now.Sub(series.recorded) > s.ttl {
+			delete(s.exemplars, key)
+		}
+	}
+}
+
+// addTo adds the recorded exemplars to the counters and to the histogram buckets of mfs.
+// A counter gets its last exemplar, a bucket gets the last exemplar that falls in it.
+func (s *exemplarStore) addTo(mfs []*dto.MetricFamily) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(time.Now())
+	if len(s.exemplars) == 0 {
+		return
+	}
+	for _, mf := range mfs {
+		if mf.GetType() != dto.MetricType_COUNTER && mf.GetType() != dto.MetricType_HISTOGRAM {
+			continue
+		}
+		for _, m := range mf.Metric {
+			labels := make(map[string]string, len(m.Label))
+			for _, lp := range m.Label {
+				labels[lp.GetName()] = lp.GetValue()
+			}
+			series, ok := s.exemplars[seriesKey(mf.GetName(), labels)]
+			if !ok {
+				continue
+			}
+			exemplars := series.exemplars
+			switch {
+			case m.Counter != nil:
+				m.Counter.Exemplar = exemplars[len(exemplars)-1]
+			case m.Histogram != nil:
+				addExemplarsToBuckets(m.Histogram, exemplars)
+			}
+		}
+	}
+}
+
+func addExemplarsToBuckets(histogram *dto.Histogram, exemplars []*dto.Exemplar) {
+	for _, exemplar := range exemplars {
+		buckets := histogram.Bucket
+		pos := sort.Search(len(buckets), func(i int) bool {
+			return buckets[i].GetUpperBound() >= exemplar.GetValue()
+		})
+		if pos == len(buckets) {
+			// The +Inf bucket is implicit, it is added to hold the exemplar.
+			histogram.Bucket = append(histogram.Bucket, &dto.Bucket{
+				CumulativeCount: proto.Uint64(histogram.GetSampleCount()),
+				UpperBound:      proto.Float64(math.Inf(+1)),
+			})
+		}
+		histogram.Bucket[pos].Exemplar = exemplar
+	}
+}
+
+// exemplarGatherer adds the exemplars of store to the metrics gathered by gatherer.
+type exemplarGatherer struct {
+	gatherer prometheus.Gatherer
+	store    *exemplarStore
+}
+
+var _ prometheus.Gatherer = (*exemplarGatherer)(nil)
+
+func (g *exemplarGatherer) Gather() ([]*dto.MetricFamily, error) {
+	mfs, err := g.gatherer.Gather()
+	g.store.addTo(mfs)
+	return mfs, err
+}
+
+// newOpenMetricsHandler returns a handler serving the metrics of gatherer with the
+// exemplars of store, the OpenMetrics format is used when negotiated by the scraper.
+func newOpenMetricsHandler(gatherer prometheus.Gatherer, store *exemplarStore) http.Handler {
+	return promhttp.HandlerFor(&exemplarGatherer{gatherer: gatherer, store: store}, promhttp.HandlerOpts{
+		ErrorHandling:     promhttp.ContinueOnError,
+		EnableOpenMetrics: true,
+	})
+}
+
+func intExemplarsToProm(exemplars pdata.IntExemplarSlice) []*dto.Exemplar {
+	var promExemplars []*dto.Exemplar
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
+		if exemplar.IsNil() {
+			continue
+		}
+		promExemplars = append(promExemplars, exemplarToProm(float64(exemplar.Value()), exemplar.Timestamp(),
+			exemplar.FilteredLabels(), exemplar.TraceID(), exemplar.SpanID()))
+	}
+	return promExemplars
+}
+
+func doubleExemplarsToProm(exemplars pdata.DoubleExemplarSlice) []*dto.Exemplar {
+	var promExemplars []*dto.Exemplar
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
+		if exemplar.IsNil() {
+			continue
+		}
+		promExemplars = append(promExemplars, exemplarToProm(exemplar.Value(), exemplar.Timestamp(),
+			exemplar.FilteredLabels(), exemplar.TraceID(), exemplar.SpanID()))
+	}
+	return promExemplars
+}
+
+// exemplarToProm converts an exemplar to its Prometheus representation. The trace and
+// span IDs are the first labels, the filtered labels are added in order of their names
+// while the total number of runes stays within prometheus.ExemplarMaxRunes.
+func exemplarToProm(value float64, timestamp pdata.TimestampUnixNano, filteredLabels pdata.StringMap,
+	traceID pdata.TraceID, spanID pdata.SpanID) *dto.Exemplar {
+	exemplar := &dto.Exemplar{Value: proto.Float64(value)}
+	if timestamp != 0 {
+		exemplar.Timestamp = timestamppb.New(time.Unix(0, int64(timestamp)))
+	}
+
+	runes := 0
+	addLabel := func(name, value string) {
+		labelRunes := utf8.RuneCountInString(name) + utf8.RuneCountInString(value)
+		if runes+labelRunes > prometheus.ExemplarMaxRunes {
+			return
+		}
+		runes += labelRunes
+		exemplar.Label = append(exemplar.Label, &dto.LabelPair{Name: proto.String(name), Value: proto.String(value)})
+	}
+	if traceID.IsValid() {
+		addLabel(conventions.OCAttachmentExemplarTraceID, traceID.HexString())
+	}
+	if spanID.IsValid() {
+		addLabel(conventions.OCAttachmentExemplarSpanID, spanID.HexString())
+	}
+	filteredLabels.ForEachSorted(func(k string, v string) {
+		addLabel(sanitize(k), v)
+	})
+	return exemplar
+}
+
+// seriesKey returns the identifier of the time series of the metric name with labels, the
+// labels with an empty value are ignored as they are in Prometheus.
+func seriesKey(name string, labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for k, v := range labels {
+		if v != "" {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range names {
+		b.WriteByte(0xff)
+		b.WriteString(k)
+		b.WriteByte(0xfe)
+		b.WriteString(labels[k])
+	}
+	return b.String()
+}
+
+// metricName returns the name of the metric as exported by the underlying exporter.
+func metricName(namespace string, name string) string {
+	if namespace != "" {
+		return namespace + "_" + sanitize(name)
+	}
+	return sanitize(name)
+}
+
+// sanitize replaces non-alphanumeric characters with underscores in s, it is the same
+// sanitization as the one of the underlying exporter.
+func sanitize(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+
+	s = strings.Map(sanitizeRune, s)
+	if unicode.IsDigit(rune(s[0])) {
+		s = "key_" + s
+	}
+	if s[0] == '_' {
+		s = "key" + s
+	}
+	return s
+}
+
+// sanitizeRune converts anything that is not a letter or digit to an underscore.
+func sanitizeRune(r rune) rune {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return r
+	}
+	return '_'
+}
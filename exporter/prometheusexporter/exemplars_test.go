@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestExemplarToProm(t *testing.T) {
+	filteredLabels := pdata.NewStringMap().InitFromMap(map[string]string{
+		"b.key": "value",
+		"a":     "value",
+	})
+	exemplar := exemplarToProm(1.5, 0, filteredLabels, pdata.InvalidTraceID(), pdata.InvalidSpanID())
+	assert.Equal(t, 1.5, exemplar.GetValue())
+	assert.Nil(t, exemplar.Timestamp)
+	assert.Equal(t, []*dto.LabelPair{
+		{Name: proto.String("a"), Value: proto.String("value")},
+		{Name: proto.String("b_key"), Value: proto.String("value")},
+	}, exemplar.Label)
+
+	// The trace and span IDs fill almost all the runes allowed, the filtered labels are dropped.
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	spanID := pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	exemplar = exemplarToProm(1.5, 1581452773000000000, filteredLabels, traceID, spanID)
+	assert.EqualValues(t, 1581452773, exemplar.GetTimestamp().GetSeconds())
+	assert.Equal(t, []*dto.LabelPair{
+		{Name: proto.String("trace_id"), Value: proto.String("01020304050607080807060504030201")},
+		{Name: proto.String("span_id"), Value: proto.String("0102030405060708")},
+	}, exemplar.Label)
+	runes := 0
+	for _, lp := range exemplar.Label {
+		runes += len(lp.GetName()) + len(lp.GetValue())
+	}
+	assert.LessOrEqual(t, runes, prometheus.ExemplarMaxRunes)
+}
+
+func TestExemplarStore(t *testing.T) {
+	store := newExemplarStore("test", prometheus.Labels{"const": "value"}, exemplarTTL)
+	store.record(exemplarMetrics())
+
+	counterValue := 7.0
+	mfs := []*dto.MetricFamily{
+		{
+			Name: proto.String("test_requests"),
+			Type: dto.MetricType_COUNTER.Enum(),
+			Metric: []*dto.Metric{
+				{
+					Label: []*dto.LabelPair{
+						{Name: proto.String("const"), Value: proto.String("value")},
+						{Name: proto.String("method"), Value: proto.String("GET")},
+						{Name: proto.String("missing"), Value: proto.String("")},
+					},
+					Counter: &dto.Counter{Value: &counterValue},
+				},
+				{
+					Label: []*dto.LabelPair{
+						{Name: proto.String("const"), Value: proto.String("value")},
+						{Name: proto.String("method"), Value: proto.String("POST")},
+					},
+					Counter: &dto.Counter{Value: &counterValue},
+				},
+			},
+		},
+		{
+			Name: proto.String("test_latency"),
+			Type: dto.MetricType_HISTOGRAM.Enum(),
+			Metric: []*dto.Metric{
+				{
+					Label: []*dto.LabelPair{
+						{Name: proto.String("const"), Value: proto.String("value")},
+						{Name: proto.String("method"), Value: proto.String("GET")},
+					},
+					Histogram: &dto.Histogram{
+						SampleCount: proto.Uint64(4),
+						Bucket: []*dto.Bucket{
+							{UpperBound: proto.Float64(1), CumulativeCount: proto.Uint64(2)},
+							{UpperBound: proto.Float64(5), CumulativeCount: proto.Uint64(3)},
+						},
+					},
+				},
+			},
+		},
+	}
+	store.addTo(mfs)
+
+	counters := mfs[0].Metric
+	require.NotNil(t, counters[0].Counter.Exemplar)
+	assert.Equal(t, 1.0, counters[0].Counter.Exemplar.GetValue())
+	assert.Nil(t, counters[1].Counter.Exemplar)
+
+	buckets := mfs[1].Metric[0].Histogram.Bucket
+	require.Len(t, buckets, 3)
+	assert.Nil(t, buckets[0].Exemplar)
+	assert.Equal(t, 4.0, buckets[1].Exemplar.GetValue())
+	assert.Equal(t, math.Inf(+1), buckets[2].GetUpperBound())
+	assert.EqualValues(t, 4, buckets[2].GetCumulativeCount())
+	assert.Equal(t, 9.5, buckets[2].Exemplar.GetValue())
+
+	// Data points without exemplars remove the recorded ones.
+	md := exemplarMetrics()
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.At(0).IntSum().DataPoints().At(0).Exemplars().Resize(0)
+	metrics.At(1).DoubleHistogram().DataPoints().At(0).Exemplars().Resize(0)
+	store.record(md)
+	assert.Empty(t, store.exemplars)
+}
+
+func TestExemplarStoreExpiry(t *testing.T) {
+	store := newExemplarStore("test", nil, time.Minute)
+	store.record(exemplarMetrics())
+	require.Len(t, store.exemplars, 2)
+	for _, series := range store.exemplars {
+		series.recorded = series.recorded.Add(-2 * time.Minute)
+	}
+
+	// The time series recorded again keep their exemplars, the others are dropped.
+	md := exemplarMetrics()
+	md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().Resize(1)
+	store.record(md)
+	require.Len(t, store.exemplars, 1)
+	assert.Contains(t, store.exemplars, seriesKey("test_requests", map[string]string{"method": "GET"}))
+
+	// The expired exemplars are not added to the gathered metrics.
+	for _, series := range store.exemplars {
+		series.recorded = series.recorded.Add(-2 * time.Minute)
+	}
+	counterValue := 7.0
+	mfs := []*dto.MetricFamily{
+		{
+			Name: proto.String("test_requests"),
+			Type: dto.MetricType_COUNTER.Enum(),
+			Metric: []*dto.Metric{
+				{
+					Label:   []*dto.LabelPair{{Name: proto.String("method"), Value: proto.String("GET")}},
+					Counter: &dto.Counter{Value: &counterValue},
+				},
+			},
+		},
+	}
+	store.addTo(mfs)
+	assert.Nil(t, mfs[0].Metric[0].Counter.Exemplar)
+	assert.Empty(t, store.exemplars)
+}
@@ -16,11 +16,11 @@ package prometheusexporter
 
 import (
 	"context"
-	"net"
 	"net/http"
 	"strings"
 
 	"github.com/orijtech/prometheus-go-metrics-exporter"
+	promclient "github.com/prometheus/client_golang/prometheus"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -63,9 +63,11 @@ func createMetricsExporter(
 		return nil, errBlankPrometheusAddress
 	}
 
+	registry := promclient.NewRegistry()
 	opts := prometheus.Options{
 		Namespace:      pcfg.Namespace,
 		ConstLabels:    pcfg.ConstLabels,
+		Registry:       registry,
 		SendTimestamps: pcfg.SendTimestamps,
 	}
 	pe, err := prometheus.New(opts)
@@ -73,25 +75,23 @@ func createMetricsExporter(
 		return nil, err
 	}
 
-	ln, err := net.Listen("tcp", addr)
-	if err != nil {
-		return nil, err
-	}
-
 	// The Prometheus metrics exporter has to run on the provided address
-	// as a server that'll be scraped by Prometheus.
+	// as a server that'll be scraped by Prometheus, it is started with the exporter.
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", pe)
-
-	srv := &http.Server{Handler: mux}
-	go func() {
-		_ = srv.Serve(ln)
-	}()
+	var exemplars *exemplarStore
+	if pcfg.EnableOpenMetrics {
+		exemplars = newExemplarStore(pcfg.Namespace, pcfg.ConstLabels, exemplarTTL)
+		mux.Handle("/metrics", newOpenMetricsHandler(registry, exemplars))
+	} else {
+		mux.Handle("/metrics", pe)
+	}
 
 	pexp := &prometheusExporter{
-		name:         cfg.Name(),
-		exporter:     pe,
-		shutdownFunc: ln.Close,
+		name:      cfg.Name(),
+		addr:      addr,
+		server:    &http.Server{Handler: mux},
+		exporter:  pe,
+		exemplars: exemplars,
 	}
 
 	return pexp, nil
@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"net"
+	"net/http"
 
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	// TODO: once this repository has been transferred to the
@@ -32,16 +34,33 @@ import (
 var errBlankPrometheusAddress = errors.New("expecting a non-blank address to run the Prometheus metrics handler")
 
 type prometheusExporter struct {
-	name         string
-	exporter     *prometheus.Exporter
-	shutdownFunc func() error
+	name      string
+	addr      string
+	server    *http.Server
+	exporter  *prometheus.Exporter
+	exemplars *exemplarStore
+	listener  net.Listener
 }
 
+// Start listens on the address of the exporter and serves the metrics, the address is
+// only bound once the exporter starts so that it can be created while another exporter
+// still uses it, e.g. when the configuration is validated or reloaded.
 func (pe *prometheusExporter) Start(_ context.Context, _ component.Host) error {
+	ln, err := net.Listen("tcp", pe.addr)
+	if err != nil {
+		return err
+	}
+	pe.listener = ln
+	go func() {
+		_ = pe.server.Serve(ln)
+	}()
 	return nil
 }
 
 func (pe *prometheusExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if pe.exemplars != nil {
+		pe.exemplars.record(md)
+	}
 	ocmds := internaldata.MetricsToOC(md)
 	for _, ocmd := range ocmds {
 		merged := make(map[string]*metricspb.Metric)
@@ -81,5 +100,10 @@ func metricSignature(metric *metricspb.Metric) string {
 
 // Shutdown stops the exporter and is invoked during shutdown.
 func (pe *prometheusExporter) Shutdown(context.Context) error {
-	return pe.shutdownFunc()
+	if pe.listener == nil {
+		return nil
+	}
+	// The listener is closed here rather than by the server, so that the address
+	// is released even if the server did not start serving yet.
+	return pe.listener.Close()
 }
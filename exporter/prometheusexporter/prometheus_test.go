@@ -29,7 +29,9 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/internaldata"
 )
 
@@ -77,6 +79,28 @@ func TestPrometheusExporter(t *testing.T) {
 	}
 }
 
+func TestPrometheusExporter_BindOnStart(t *testing.T) {
+	config := &Config{Endpoint: "localhost:7779"}
+	factory := NewFactory()
+	creationParams := component.ExporterCreateParams{Logger: zap.NewNop()}
+	first, err := factory.CreateMetricsExporter(context.Background(), creationParams, config)
+	require.NoError(t, err)
+	require.NoError(t, first.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Another exporter with the same address can be created, but not started, while
+	// the first one runs.
+	second, err := factory.CreateMetricsExporter(context.Background(), creationParams, config)
+	require.NoError(t, err)
+	require.Error(t, second.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, second.Shutdown(context.Background()))
+
+	require.NoError(t, first.Shutdown(context.Background()))
+	second, err = factory.CreateMetricsExporter(context.Background(), creationParams, config)
+	require.NoError(t, err)
+	require.NoError(t, second.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, second.Shutdown(context.Background()))
+}
+
 func TestPrometheusExporter_endToEnd(t *testing.T) {
 	config := &Config{
 		Namespace: "test",
@@ -91,6 +115,7 @@ func TestPrometheusExporter_endToEnd(t *testing.T) {
 	creationParams := component.ExporterCreateParams{Logger: zap.NewNop()}
 	exp, err := factory.CreateMetricsExporter(context.Background(), creationParams, config)
 	assert.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
 
 	t.Cleanup(func() {
 		require.NoError(t, exp.Shutdown(context.Background()))
@@ -142,6 +167,7 @@ func TestPrometheusExporter_endToEndWithTimestamps(t *testing.T) {
 	creationParams := component.ExporterCreateParams{Logger: zap.NewNop()}
 	exp, err := factory.CreateMetricsExporter(context.Background(), creationParams, config)
 	assert.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
 
 	t.Cleanup(func() {
 		require.NoError(t, exp.Shutdown(context.Background()))
@@ -178,6 +204,107 @@ func TestPrometheusExporter_endToEndWithTimestamps(t *testing.T) {
 	}
 }
 
+func TestPrometheusExporter_endToEndWithExemplars(t *testing.T) {
+	config := &Config{
+		Namespace: "test",
+		ConstLabels: map[string]string{
+			"foo3": "bar3",
+		},
+		Endpoint:          ":7778",
+		EnableOpenMetrics: true,
+	}
+
+	factory := NewFactory()
+	creationParams := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exp, err := factory.CreateMetricsExporter(context.Background(), creationParams, config)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+		// trigger a get so that the server cleans up our keepalive socket
+		http.Get("http://localhost:7778/metrics")
+	})
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), exemplarMetrics()))
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost:7778/metrics", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/openmetrics-text; version=0.0.1")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Failed to perform a scrape")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	blob, _ := ioutil.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	want := []string{
+		`test_requests{foo3="bar3",method="GET"} 7.0 # {trace_id="01020304050607080807060504030201",span_id="0102030405060708"} 1.0 1.581452773e+09`,
+		`test_latency_bucket{foo3="bar3",method="GET",le="1.0"} 2`,
+		`test_latency_bucket{foo3="bar3",method="GET",le="5.0"} 3 # {trace_id="01020304050607080807060504030201",span_id="0102030405060708"} 4.0 1.581452773e+09`,
+		`test_latency_bucket{foo3="bar3",method="GET",le="+Inf"} 4 # {code="500"} 9.5 1.581452773e+09`,
+	}
+	for _, w := range want {
+		assert.Contains(t, string(blob), w)
+	}
+}
+
+// exemplarMetrics returns a counter and a histogram with exemplars.
+func exemplarMetrics() pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	metrics := ilms.At(0).Metrics()
+	metrics.Resize(2)
+
+	timestamp := pdata.TimestampUnixNano(1581452773000000000)
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	spanID := pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	counter := metrics.At(0)
+	counter.SetName("requests")
+	counter.SetDataType(pdata.MetricDataTypeIntSum)
+	counter.IntSum().InitEmpty()
+	counter.IntSum().SetIsMonotonic(true)
+	counter.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	counter.IntSum().DataPoints().Resize(1)
+	counterDp := counter.IntSum().DataPoints().At(0)
+	counterDp.LabelsMap().Insert("method", "GET")
+	counterDp.SetTimestamp(timestamp)
+	counterDp.SetValue(7)
+	counterDp.Exemplars().Resize(1)
+	counterExemplar := counterDp.Exemplars().At(0)
+	counterExemplar.SetTimestamp(timestamp)
+	counterExemplar.SetValue(1)
+	counterExemplar.SetTraceID(traceID)
+	counterExemplar.SetSpanID(spanID)
+
+	histogram := metrics.At(1)
+	histogram.SetName("latency")
+	histogram.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	histogram.DoubleHistogram().InitEmpty()
+	histogram.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	histogram.DoubleHistogram().DataPoints().Resize(1)
+	histogramDp := histogram.DoubleHistogram().DataPoints().At(0)
+	histogramDp.LabelsMap().Insert("method", "GET")
+	histogramDp.SetTimestamp(timestamp)
+	histogramDp.SetCount(4)
+	histogramDp.SetSum(15)
+	histogramDp.SetExplicitBounds([]float64{1, 5})
+	histogramDp.SetBucketCounts([]uint64{2, 1, 1})
+	histogramDp.Exemplars().Resize(2)
+	bucketExemplar := histogramDp.Exemplars().At(0)
+	bucketExemplar.SetTimestamp(timestamp)
+	bucketExemplar.SetValue(4)
+	bucketExemplar.SetTraceID(traceID)
+	bucketExemplar.SetSpanID(spanID)
+	infExemplar := histogramDp.Exemplars().At(1)
+	infExemplar.SetTimestamp(timestamp)
+	infExemplar.SetValue(9.5)
+	infExemplar.FilteredLabels().Insert("code", "500")
+	return md
+}
+
 func metricBuilder(delta int64) []*metricspb.Metric {
 	return []*metricspb.Metric{
 		{
@@ -37,3 +37,8 @@ type Config struct {
 
 	HTTPClientSettings confighttp.HTTPClientSettings `mapstructure:",squash"`
 }
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	return exporterhelper.ValidateSettings(&cfg.TimeoutSettings, &cfg.QueueSettings, &cfg.RetrySettings)
+}
@@ -125,7 +125,7 @@ func (prwe *PrwExporter) PushMetrics(ctx context.Context, md pdata.Metrics) (int
 							dropped++
 							errs = append(errs, consumererror.Permanent(err))
 						}
-					case *otlp.Metric_DoubleHistogram, *otlp.Metric_IntHistogram:
+					case *otlp.Metric_DoubleHistogram, *otlp.Metric_IntHistogram, *otlp.Metric_ExponentialHistogram:
 						if err := prwe.handleHistogramMetric(tsMap, metric); err != nil {
 							dropped++
 							errs = append(errs, consumererror.Permanent(err))
@@ -234,6 +234,15 @@ func (prwe *PrwExporter) handleHistogramMetric(tsMap map[string]*prompb.TimeSeri
 		for _, pt := range metric.GetDoubleHistogram().GetDataPoints() {
 			addSingleDoubleHistogramDataPoint(pt, metric, prwe.namespace, tsMap, prwe.externalLabels)
 		}
+	case *otlp.Metric_ExponentialHistogram:
+		if metric.GetExponentialHistogram().GetDataPoints() == nil {
+			return fmt.Errorf("nil data point. %s is dropped", metric.GetName())
+		}
+		// Remote write has no exponential histogram, the data points are converted to explicit buckets.
+		for _, pt := range metric.GetExponentialHistogram().GetDataPoints() {
+			addSingleDoubleHistogramDataPoint(exponentialHistogramToDoubleHistogram(pt), metric, prwe.namespace, tsMap,
+				prwe.externalLabels)
+		}
 	}
 	return nil
 }
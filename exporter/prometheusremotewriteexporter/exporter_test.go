@@ -361,6 +361,20 @@ func Test_PushMetrics(t *testing.T) {
 	}
 	doubleSummaryBatch := pdata.MetricsFromOtlp(doubleSummaryMetric)
 
+	exponentialHistogramMetric := []*otlp.ResourceMetrics{
+		{
+			InstrumentationLibraryMetrics: []*otlp.InstrumentationLibraryMetrics{
+				{
+					Metrics: []*otlp.Metric{
+						validMetrics1[validExponentialHistogram],
+						validMetrics2[validExponentialHistogram],
+					},
+				},
+			},
+		},
+	}
+	exponentialHistogramBatch := pdata.MetricsFromOtlp(exponentialHistogramMetric)
+
 	// len(BucketCount) > len(ExplicitBounds)
 	unmatchedBoundBucketIntHistMetric := []*otlp.ResourceMetrics{
 		{
@@ -607,6 +621,15 @@ func Test_PushMetrics(t *testing.T) {
 			0,
 			false,
 		},
+		{
+			"exponentialHistogram_case",
+			&exponentialHistogramBatch,
+			checkFunc,
+			12,
+			http.StatusAccepted,
+			0,
+			false,
+		},
 		{
 			"unmatchedBoundBucketIntHist_case",
 			&unmatchedBoundBucketIntHistBatch,
@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 	common "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/common/v1"
 	otlp "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/metrics/v1"
+	metricstranslator "go.opentelemetry.io/collector/translator/metrics"
 )
 
 const (
@@ -75,6 +76,9 @@ func validateMetrics(metric *otlp.Metric) bool {
 			otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
 	case *otlp.Metric_DoubleSummary:
 		return metric.GetDoubleSummary() != nil
+	case *otlp.Metric_ExponentialHistogram:
+		return metric.GetExponentialHistogram() != nil && metric.GetExponentialHistogram().GetAggregationTemporality() ==
+			otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
 	}
 	return false
 }
@@ -281,6 +285,8 @@ func getTypeString(metric *otlp.Metric) string {
 		return strconv.Itoa(int(pdata.MetricDataTypeDoubleHistogram))
 	case *otlp.Metric_IntHistogram:
 		return strconv.Itoa(int(pdata.MetricDataTypeIntHistogram))
+	case *otlp.Metric_ExponentialHistogram:
+		return strconv.Itoa(int(pdata.MetricDataTypeExponentialHistogram))
 	}
 	return ""
 }
@@ -429,6 +435,30 @@ func addSingleDoubleHistogramDataPoint(pt *otlp.DoubleHistogramDataPoint, metric
 	addSample(tsMap, infBucket, infLabels, metric)
 }
 
+// exponentialHistogramToDoubleHistogram converts pt to a histogram data point with explicit bounds, the
+// bucket boundaries of pt are used as explicit bounds. The conversion is lossy for the buckets that are merged
+// because their boundaries cannot be represented as increasing float64 values.
+func exponentialHistogramToDoubleHistogram(pt *otlp.ExponentialHistogramDataPoint) *otlp.DoubleHistogramDataPoint {
+	if pt == nil {
+		return nil
+	}
+	bounds, counts := metricstranslator.ExponentialToExplicitBuckets(
+		pt.GetScale(),
+		pt.GetZeroCount(),
+		metricstranslator.ExponentialBuckets{Offset: pt.Negative.Offset, BucketCounts: pt.Negative.BucketCounts},
+		metricstranslator.ExponentialBuckets{Offset: pt.Positive.Offset, BucketCounts: pt.Positive.BucketCounts},
+	)
+	return &otlp.DoubleHistogramDataPoint{
+		Labels:            pt.GetLabels(),
+		StartTimeUnixNano: pt.GetStartTimeUnixNano(),
+		TimeUnixNano:      pt.GetTimeUnixNano(),
+		Count:             pt.GetCount(),
+		Sum:               pt.GetSum(),
+		BucketCounts:      counts,
+		ExplicitBounds:    bounds,
+	}
+}
+
 // addSingleDoubleSummaryDataPoint converts pt to len(QuantileValues) + 2 samples.
 func addSingleDoubleSummaryDataPoint(pt *otlp.DoubleSummaryDataPoint, metric *otlp.Metric, namespace string,
 	tsMap map[string]*prompb.TimeSeries, externalLabels map[string]string) {
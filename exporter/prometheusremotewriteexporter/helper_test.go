@@ -72,6 +72,25 @@ func Test_validateMetrics(t *testing.T) {
 	}
 }
 
+// Test_exponentialHistogramToDoubleHistogram checks exponentialHistogramToDoubleHistogram uses the exponential
+// bucket boundaries as explicit bounds and keeps the labels, timestamps, count and sum of the data point.
+func Test_exponentialHistogramToDoubleHistogram(t *testing.T) {
+	assert.Nil(t, exponentialHistogramToDoubleHistogram(nil))
+
+	pt := getExponentialHistogramDataPoint(lbs1, time1, floatVal1, 4, 0, 1, 0, []uint64{1, 2})
+	pt.StartTimeUnixNano = time1 - 1
+	pt.Negative = otlp.ExponentialHistogramDataPoint_Buckets{Offset: 1, BucketCounts: []uint64{1}}
+
+	got := exponentialHistogramToDoubleHistogram(pt)
+	assert.Equal(t, lbs1, got.Labels)
+	assert.Equal(t, time1-1, got.StartTimeUnixNano)
+	assert.Equal(t, time1, got.TimeUnixNano)
+	assert.Equal(t, uint64(4), got.Count)
+	assert.Equal(t, floatVal1, got.Sum)
+	assert.Equal(t, []float64{-2, 0, 2, 4}, got.ExplicitBounds)
+	assert.Equal(t, []uint64{1, 1, 1, 2, 0}, got.BucketCounts)
+}
+
 // Test_addSample checks addSample updates the map it receives correctly based on the sample and Label
 // set it receives.
 // Test cases are two samples belonging to the same TimeSeries,  two samples belong to different TimeSeries, and nil
@@ -91,6 +91,8 @@ var (
 	validDoubleHistogram = "valid_DoubleHistogram"
 	validDoubleSummary   = "valid_DoubleSummary"
 
+	validExponentialHistogram = "valid_ExponentialHistogram"
+
 	validIntGaugeDirty = "*valid_IntGauge$"
 
 	unmatchedBoundBucketIntHist    = "unmatchedBoundBucketIntHist"
@@ -179,6 +181,18 @@ var (
 				},
 			},
 		},
+		validExponentialHistogram: {
+			Name: validExponentialHistogram,
+			Data: &otlp.Metric_ExponentialHistogram{
+				ExponentialHistogram: &otlp.ExponentialHistogram{
+					DataPoints: []*otlp.ExponentialHistogramDataPoint{
+						getExponentialHistogramDataPoint(lbs1, time1, floatVal1, uint64(intVal1), 0, 1, 0, buckets[:2]),
+						nil,
+					},
+					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				},
+			},
+		},
 	}
 	validMetrics2 = map[string]*otlp.Metric{
 		validIntGauge: {
@@ -256,6 +270,17 @@ var (
 				},
 			},
 		},
+		validExponentialHistogram: {
+			Name: validExponentialHistogram,
+			Data: &otlp.Metric_ExponentialHistogram{
+				ExponentialHistogram: &otlp.ExponentialHistogram{
+					DataPoints: []*otlp.ExponentialHistogramDataPoint{
+						getExponentialHistogramDataPoint(lbs2, time2, floatVal2, uint64(intVal2), 0, 1, 0, buckets[:2]),
+					},
+					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				},
+			},
+		},
 		validIntGaugeDirty: {
 			Name: validIntGaugeDirty,
 			Data: &otlp.Metric_IntGauge{
@@ -309,12 +334,16 @@ var (
 	notMatchDoubleHistogram = "notMatchDoubleHistogram"
 	notMatchDoubleSummary   = "notMatchDoubleSummary"
 
+	notMatchExponentialHistogram = "notMatchExponentialHistogram"
+
 	// Category 2: invalid type and temporality combination
 	invalidIntSum          = "invalidIntSum"
 	invalidDoubleSum       = "invalidDoubleSum"
 	invalidIntHistogram    = "invalidIntHistogram"
 	invalidDoubleHistogram = "invalidDoubleHistogram"
 
+	invalidExponentialHistogram = "invalidExponentialHistogram"
+
 	// Category 3: nil data points
 	nilDataPointIntGauge        = "nilDataPointIntGauge"
 	nilDataPointDoubleGauge     = "nilDataPointDoubleGauge"
@@ -358,6 +387,10 @@ var (
 			Name: notMatchDoubleSummary,
 			Data: &otlp.Metric_DoubleSummary{},
 		},
+		notMatchExponentialHistogram: {
+			Name: notMatchExponentialHistogram,
+			Data: &otlp.Metric_ExponentialHistogram{},
+		},
 		invalidIntSum: {
 			Name: invalidIntSum,
 			Data: &otlp.Metric_IntSum{
@@ -390,6 +423,14 @@ var (
 				},
 			},
 		},
+		invalidExponentialHistogram: {
+			Name: invalidExponentialHistogram,
+			Data: &otlp.Metric_ExponentialHistogram{
+				ExponentialHistogram: &otlp.ExponentialHistogram{
+					AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+				},
+			},
+		},
 	}
 
 	// different metrics that will cause the exporter to return an error
@@ -511,6 +552,22 @@ func getDoubleHistogramDataPoint(labels []commonpb.StringKeyValue, ts uint64, su
 	}
 }
 
+func getExponentialHistogramDataPoint(labels []commonpb.StringKeyValue, ts uint64, sum float64, count uint64,
+	scale int32, zeroCount uint64, offset int32, buckets []uint64) *otlp.ExponentialHistogramDataPoint {
+	return &otlp.ExponentialHistogramDataPoint{
+		Labels:       labels,
+		TimeUnixNano: ts,
+		Count:        count,
+		Sum:          sum,
+		Scale:        scale,
+		ZeroCount:    zeroCount,
+		Positive: otlp.ExponentialHistogramDataPoint_Buckets{
+			Offset:       offset,
+			BucketCounts: buckets,
+		},
+	}
+}
+
 func getDoubleSummaryDataPoint(labels []commonpb.StringKeyValue, ts uint64, sum float64, count uint64,
 	quantiles []*otlp.DoubleSummaryDataPoint_ValueAtQuantile) *otlp.DoubleSummaryDataPoint {
 	return &otlp.DoubleSummaryDataPoint{
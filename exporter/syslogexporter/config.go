@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// ProtocolRFC5424 formats messages as described in https://tools.ietf.org/html/rfc5424.
+	ProtocolRFC5424 = "rfc5424"
+	// ProtocolRFC3164 formats messages as described in https://tools.ietf.org/html/rfc3164.
+	ProtocolRFC3164 = "rfc3164"
+)
+
+// Config defines configuration for syslog exporter.
+type Config struct {
+	configmodels.ExporterSettings  `mapstructure:",squash"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// NetAddr configures the syslog server address and the transport, either "udp" or "tcp".
+	confignet.NetAddr `mapstructure:",squash"`
+
+	// TLSSetting configures TLS for the "tcp" transport. TLS is used when insecure is false.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// Protocol is the message format, either "rfc5424" or "rfc3164" (default "rfc5424").
+	Protocol string `mapstructure:"protocol"`
+
+	// Facility is the syslog facility name used to compute the message priority (default "user").
+	Facility string `mapstructure:"facility"`
+
+	// StructuredDataID is the SD-ID of the RFC 5424 structured data element that
+	// contains the log record attributes (default "attrs@32473").
+	StructuredDataID string `mapstructure:"structured_data_id"`
+}
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	return exporterhelper.ValidateSettings(&cfg.TimeoutSettings, &cfg.QueueSettings, &cfg.RetrySettings)
+}
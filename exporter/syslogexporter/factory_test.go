@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateLogsExporter(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		mustFail bool
+	}{
+		{
+			name:   "UDP",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "TCP",
+			modify: func(cfg *Config) { cfg.Transport = "tcp" },
+		},
+		{
+			name:     "NoEndpoint",
+			modify:   func(cfg *Config) { cfg.Endpoint = "" },
+			mustFail: true,
+		},
+		{
+			name:     "UnknownTransport",
+			modify:   func(cfg *Config) { cfg.Transport = "unix" },
+			mustFail: true,
+		},
+		{
+			name:     "UnknownProtocol",
+			modify:   func(cfg *Config) { cfg.Protocol = "rfc1234" },
+			mustFail: true,
+		},
+		{
+			name:     "UnknownFacility",
+			modify:   func(cfg *Config) { cfg.Facility = "local9" },
+			mustFail: true,
+		},
+		{
+			name:     "TLSWithUDP",
+			modify:   func(cfg *Config) { cfg.TLSSetting.Insecure = false },
+			mustFail: true,
+		},
+		{
+			name: "TLSMissingCA",
+			modify: func(cfg *Config) {
+				cfg.Transport = "tcp"
+				cfg.TLSSetting.CAFile = "nosuchfile"
+			},
+			mustFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:514"
+			tt.modify(cfg)
+
+			params := component.ExporterCreateParams{Logger: zap.NewNop()}
+			exp, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+			if tt.mustFail {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, exp)
+			assert.NoError(t, exp.Shutdown(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	nilValue = "-"
+	// defaultTag is used as RFC 3164 TAG when the resource has no service name.
+	defaultTag = "otelcol"
+
+	// Syslog severities as defined in https://tools.ietf.org/html/rfc5424#section-6.2.1.
+	severityEmergency     = 0
+	severityAlert         = 1
+	severityCritical      = 2
+	severityError         = 3
+	severityWarning       = 4
+	severityNotice        = 5
+	severityInformational = 6
+	severityDebug         = 7
+
+	// Maximum field lengths as defined in https://tools.ietf.org/html/rfc5424#section-6.
+	maxHostnameLen = 255
+	maxAppNameLen  = 48
+	maxProcIDLen   = 128
+	maxMsgIDLen    = 32
+	maxSDNameLen   = 32
+	// Maximum TAG length as defined in https://tools.ietf.org/html/rfc3164#section-4.1.3.
+	maxTagLen = 32
+)
+
+// facilities maps facility names to the facility codes defined in https://tools.ietf.org/html/rfc5424#section-6.2.1.
+var facilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"ntp":      12,
+	"security": 13,
+	"console":  14,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// message contains the fields of a syslog message independent of the format.
+type message struct {
+	priority   int
+	timestamp  time.Time
+	hostname   string
+	appName    string
+	procID     string
+	msgID      string
+	attributes pdata.AttributeMap
+	body       string
+}
+
+// syslogSeverity maps the OpenTelemetry severity number to a syslog severity. The mapping
+// is the reverse of the one suggested in the OpenTelemetry logs data model, see
+// https://github.com/open-telemetry/opentelemetry-specification/blob/master/specification/logs/data-model.md#appendix-b-severitynumber-example-mappings
+func syslogSeverity(sn pdata.SeverityNumber) int {
+	switch {
+	case sn == pdata.SeverityNumberUNDEFINED:
+		return severityInformational
+	case sn <= pdata.SeverityNumberDEBUG4:
+		return severityDebug
+	case sn == pdata.SeverityNumberINFO:
+		return severityInformational
+	case sn <= pdata.SeverityNumberINFO4:
+		return severityNotice
+	case sn <= pdata.SeverityNumberWARN4:
+		return severityWarning
+	case sn == pdata.SeverityNumberERROR:
+		return severityError
+	case sn == pdata.SeverityNumberERROR2:
+		return severityCritical
+	case sn <= pdata.SeverityNumberERROR4:
+		return severityAlert
+	default:
+		return severityEmergency
+	}
+}
+
+// formatRFC5424 formats the message as described in https://tools.ietf.org/html/rfc5424.
+// The attributes are written as parameters of a single structured data element with the given SD-ID.
+func formatRFC5424(m *message, sdID string) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<%d>1 %s %s %s %s %s ",
+		m.priority,
+		m.timestamp.Format("2006-01-02T15:04:05.000000Z07:00"),
+		headerField(m.hostname, maxHostnameLen),
+		headerField(m.appName, maxAppNameLen),
+		headerField(m.procID, maxProcIDLen),
+		headerField(m.msgID, maxMsgIDLen))
+
+	if m.attributes.Len() == 0 {
+		sb.WriteString(nilValue)
+	} else {
+		sb.WriteByte('[')
+		sb.WriteString(sdID)
+		for _, k := range sortedKeys(m.attributes) {
+			v, _ := m.attributes.Get(k)
+			sb.WriteByte(' ')
+			sb.WriteString(sdName(k))
+			sb.WriteString(`="`)
+			sb.WriteString(escapeParamValue(tracetranslator.AttributeValueToString(v, false)))
+			sb.WriteByte('"')
+		}
+		sb.WriteByte(']')
+	}
+
+	if m.body != "" {
+		sb.WriteByte(' ')
+		sb.WriteString(m.body)
+	}
+	return []byte(sb.String())
+}
+
+// formatRFC3164 formats the message as described in https://tools.ietf.org/html/rfc3164.
+// The format has no place for attributes so they are not exported.
+func formatRFC3164(m *message) []byte {
+	hostname := m.hostname
+	if hostname == "" {
+		hostname = nilValue
+	}
+	tag := truncate(tagField(m.appName), maxTagLen)
+	if tag == "" {
+		tag = defaultTag
+	}
+	if m.procID != "" {
+		tag += "[" + m.procID + "]"
+	}
+	return []byte(fmt.Sprintf("<%d>%s %s %s: %s",
+		m.priority,
+		m.timestamp.Format(time.Stamp),
+		headerField(hostname, maxHostnameLen),
+		tag,
+		m.body))
+}
+
+// headerField returns the value with all characters outside of PRINTUSASCII removed,
+// or NILVALUE for empty values.
+func headerField(v string, maxLen int) string {
+	v = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, v)
+	if v == "" {
+		return nilValue
+	}
+	return truncate(v, maxLen)
+}
+
+// tagField returns the value with all non alphanumeric characters removed.
+func tagField(v string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, v)
+}
+
+// sdName replaces all characters that are not allowed in a SD-NAME with '_'.
+func sdName(v string) string {
+	v = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, v)
+	return truncate(v, maxSDNameLen)
+}
+
+// escapeParamValue escapes '"', '\' and ']' as required for PARAM-VALUE.
+func escapeParamValue(v string) string {
+	var sb strings.Builder
+	for _, r := range v {
+		if r == '"' || r == '\\' || r == ']' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func truncate(v string, maxLen int) string {
+	if len(v) > maxLen {
+		return v[:maxLen]
+	}
+	return v
+}
+
+func sortedKeys(am pdata.AttributeMap) []string {
+	keys := make([]string, 0, am.Len())
+	am.ForEach(func(k string, _ pdata.AttributeValue) {
+		keys = append(keys, k)
+	})
+	sort.Strings(keys)
+	return keys
+}
+
+// frameOctetCounting prefixes the message with its length as described in
+// https://tools.ietf.org/html/rfc6587#section-3.4.1.
+func frameOctetCounting(msg []byte) []byte {
+	return append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+}
+
+// frameNonTransparent terminates the message with a LF as described in
+// https://tools.ietf.org/html/rfc6587#section-3.4.2. LFs in the message are
+// escaped as "\n" so that a multi-line body is not split into several messages.
+func frameNonTransparent(msg []byte) []byte {
+	return append(bytes.ReplaceAll(msg, []byte{'\n'}, []byte(`\n`)), '\n')
+}
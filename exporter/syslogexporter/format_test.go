@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestSyslogSeverity(t *testing.T) {
+	tests := []struct {
+		sn       pdata.SeverityNumber
+		expected int
+	}{
+		{pdata.SeverityNumberUNDEFINED, severityInformational},
+		{pdata.SeverityNumberTRACE, severityDebug},
+		{pdata.SeverityNumberDEBUG4, severityDebug},
+		{pdata.SeverityNumberINFO, severityInformational},
+		{pdata.SeverityNumberINFO2, severityNotice},
+		{pdata.SeverityNumberWARN, severityWarning},
+		{pdata.SeverityNumberWARN4, severityWarning},
+		{pdata.SeverityNumberERROR, severityError},
+		{pdata.SeverityNumberERROR2, severityCritical},
+		{pdata.SeverityNumberERROR3, severityAlert},
+		{pdata.SeverityNumberFATAL, severityEmergency},
+		{pdata.SeverityNumberFATAL4, severityEmergency},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, syslogSeverity(tt.sn), "severity number %d", tt.sn)
+	}
+}
+
+func newTestMessage() *message {
+	return &message{
+		priority:  1*8 + severityError,
+		timestamp: time.Date(2020, 2, 11, 20, 26, 13, 123456789, time.UTC),
+		hostname:  "host1",
+		appName:   "checkout",
+		procID:    "42",
+		msgID:     "logA",
+		attributes: pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+			"http.method": pdata.NewAttributeValueString("GET"),
+			"quote":       pdata.NewAttributeValueString(`a "b" [c]`),
+			"bad key=":    pdata.NewAttributeValueInt(1),
+		}),
+		body: "This is a log message",
+	}
+}
+
+func TestFormatRFC5424(t *testing.T) {
+	m := newTestMessage()
+	assert.Equal(t,
+		`<11>1 2020-02-11T20:26:13.123456Z host1 checkout 42 logA [attrs@32473 bad_key_="1" http.method="GET" quote="a \"b\" [c\]"] This is a log message`,
+		string(formatRFC5424(m, "attrs@32473")))
+
+	m.attributes = pdata.NewAttributeMap()
+	m.hostname = ""
+	m.procID = ""
+	m.msgID = "with space"
+	m.body = ""
+	assert.Equal(t,
+		`<11>1 2020-02-11T20:26:13.123456Z - checkout - withspace -`,
+		string(formatRFC5424(m, "attrs@32473")))
+}
+
+func TestFormatRFC3164(t *testing.T) {
+	m := newTestMessage()
+	assert.Equal(t,
+		`<11>Feb 11 20:26:13 host1 checkout[42]: This is a log message`,
+		string(formatRFC3164(m)))
+
+	m.appName = ""
+	m.procID = ""
+	assert.Equal(t,
+		`<11>Feb 11 20:26:13 host1 otelcol: This is a log message`,
+		string(formatRFC3164(m)))
+}
+
+func TestFraming(t *testing.T) {
+	assert.Equal(t, "5 hello", string(frameOctetCounting([]byte("hello"))))
+	assert.Equal(t, "hello\n", string(frameNonTransparent([]byte("hello"))))
+	assert.Equal(t, "11 hello\nworld", string(frameOctetCounting([]byte("hello\nworld"))))
+	assert.Equal(t, `hello\nworld`+"\n", string(frameNonTransparent([]byte("hello\nworld"))))
+}
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/internal/subset"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+type syslogExporter struct {
+	endpoint string
+	facility int
+	hostname string
+	format   func(*message) []byte
+	frame    func([]byte) []byte
+	dial     func(ctx context.Context) (net.Conn, error)
+	logger   *zap.Logger
+
+	// mu protects conn, only one push at a time can write to the connection.
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newExporter(cfg *Config, logger *zap.Logger) (*syslogExporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("endpoint must be specified")
+	}
+
+	isStream := strings.HasPrefix(cfg.Transport, "tcp")
+	if !isStream && !strings.HasPrefix(cfg.Transport, "udp") {
+		return nil, fmt.Errorf("unsupported transport %q, must be one of \"udp\" or \"tcp\"", cfg.Transport)
+	}
+
+	facility, ok := facilities[cfg.Facility]
+	if !ok {
+		return nil, fmt.Errorf("unknown facility %q", cfg.Facility)
+	}
+
+	tlsCfg, err := cfg.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil && !isStream {
+		return nil, errors.New("tls is only supported with the tcp transport")
+	}
+
+	e := &syslogExporter{
+		endpoint: cfg.Endpoint,
+		facility: facility,
+		logger:   logger,
+	}
+
+	switch cfg.Protocol {
+	case ProtocolRFC5424:
+		sdID := cfg.StructuredDataID
+		e.format = func(m *message) []byte { return formatRFC5424(m, sdID) }
+		if isStream {
+			e.frame = frameOctetCounting
+		}
+	case ProtocolRFC3164:
+		e.format = formatRFC3164
+		if isStream {
+			e.frame = frameNonTransparent
+		}
+	default:
+		return nil, fmt.Errorf("unknown protocol %q, must be one of %q or %q", cfg.Protocol, ProtocolRFC5424, ProtocolRFC3164)
+	}
+
+	e.hostname, err = os.Hostname()
+	if err != nil {
+		logger.Warn("Cannot determine hostname, only the resource host.name will be used.", zap.Error(err))
+	}
+
+	dialer := &net.Dialer{}
+	transport := cfg.Transport
+	e.dial = func(ctx context.Context) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, transport, e.endpoint)
+		if err != nil || tlsCfg == nil {
+			return conn, err
+		}
+		tlsConn := tls.Client(conn, tlsCfg)
+		if deadline, ok := ctx.Deadline(); ok {
+			tlsConn.SetDeadline(deadline)
+		}
+		if err = tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		tlsConn.SetDeadline(time.Time{})
+		return tlsConn, nil
+	}
+
+	return e, nil
+}
+
+func (e *syslogExporter) pushLogData(ctx context.Context, ld pdata.Logs) (int, error) {
+	var msgs [][]byte
+	var positions []subset.Position
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		hostname, appName, procID := e.resourceFields(rl.Resource())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+				m := &message{
+					priority:   e.facility*8 + syslogSeverity(lr.SeverityNumber()),
+					timestamp:  recordTime(lr.Timestamp()),
+					hostname:   hostname,
+					appName:    appName,
+					procID:     procID,
+					msgID:      lr.Name(),
+					attributes: lr.Attributes(),
+				}
+				if !lr.Body().IsNil() {
+					m.body = tracetranslator.AttributeValueToString(lr.Body(), false)
+				}
+				msg := e.format(m)
+				if e.frame != nil {
+					msg = e.frame(msg)
+				}
+				msgs = append(msgs, msg)
+				positions = append(positions, subset.Position{ResourceIndex: i, LibraryIndex: j, Index: k})
+			}
+		}
+	}
+
+	sent, err := e.send(ctx, msgs)
+	if err != nil {
+		if sent == 0 {
+			return ld.LogRecordCount(), err
+		}
+		return len(msgs) - sent, consumererror.PartialLogsError(err, subset.Logs(ld, positions[sent:]))
+	}
+	return 0, nil
+}
+
+// send writes all messages to the connection, connecting first if needed.
+// It returns the number of messages successfully written.
+func (e *syslogExporter) send(ctx context.Context, msgs [][]byte) (int, error) {
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		conn, err := e.dial(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to connect to %s: %w", e.endpoint, err)
+		}
+		e.conn = conn
+	}
+
+	// A zero deadline means no deadline.
+	deadline, _ := ctx.Deadline()
+	if err := e.conn.SetWriteDeadline(deadline); err != nil {
+		e.closeConn()
+		return 0, err
+	}
+
+	for i, msg := range msgs {
+		if _, err := e.conn.Write(msg); err != nil {
+			// Drop the connection, the next push reconnects.
+			e.closeConn()
+			return i, fmt.Errorf("failed to write to %s: %w", e.endpoint, err)
+		}
+	}
+	return len(msgs), nil
+}
+
+func (e *syslogExporter) shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.conn == nil {
+		return nil
+	}
+	err := e.conn.Close()
+	e.conn = nil
+	return err
+}
+
+// closeConn closes the connection ignoring errors. Must be called with mu held.
+func (e *syslogExporter) closeConn() {
+	if e.conn != nil {
+		_ = e.conn.Close()
+		e.conn = nil
+	}
+}
+
+// resourceFields returns the HOSTNAME, APP-NAME and PROCID for log records of the resource.
+func (e *syslogExporter) resourceFields(resource pdata.Resource) (hostname string, appName string, procID string) {
+	hostname = e.hostname
+	attrs := resource.Attributes()
+	if v, ok := attrs.Get(conventions.AttributeHostName); ok {
+		hostname = tracetranslator.AttributeValueToString(v, false)
+	}
+	if v, ok := attrs.Get(conventions.AttributeServiceName); ok {
+		appName = tracetranslator.AttributeValueToString(v, false)
+	}
+	if v, ok := attrs.Get(conventions.AttributeProcessID); ok {
+		procID = tracetranslator.AttributeValueToString(v, false)
+	}
+	return hostname, appName, procID
+}
+
+// recordTime returns the time of the log record, or the current time if not set.
+func recordTime(ts pdata.TimestampUnixNano) time.Time {
+	if ts == 0 {
+		return time.Now().UTC()
+	}
+	return pdata.UnixNanoToTime(ts).UTC()
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+func newTestExporter(t *testing.T, modify func(cfg *Config)) *syslogExporter {
+	cfg := createDefaultConfig().(*Config)
+	modify(cfg)
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	return exp
+}
+
+// readOctetCounted reads a single RFC 6587 octet counted frame.
+func readOctetCounted(t *testing.T, r *bufio.Reader) string {
+	lenStr, err := r.ReadString(' ')
+	require.NoError(t, err)
+	n, err := strconv.Atoi(strings.TrimSpace(lenStr))
+	require.NoError(t, err)
+	buf := make([]byte, n)
+	_, err = r.Read(buf)
+	require.NoError(t, err)
+	return string(buf)
+}
+
+func TestPushLogDataUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "localhost:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	exp := newTestExporter(t, func(cfg *Config) {
+		cfg.Endpoint = conn.LocalAddr().String()
+	})
+	defer exp.shutdown(context.Background())
+
+	dropped, err := exp.pushLogData(context.Background(), testdata.GenerateLogDataOneLog())
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	buf := make([]byte, 2048)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	msg := string(buf[:n])
+	assert.True(t, strings.HasPrefix(msg, "<14>1 2020-02-11T20:26:13.000000Z "), msg)
+	assert.True(t, strings.HasSuffix(msg, ` logA [attrs@32473 app="server" instance_num="1"] This is a log message`), msg)
+}
+
+func TestPushLogDataTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	exp := newTestExporter(t, func(cfg *Config) {
+		cfg.Endpoint = ln.Addr().String()
+		cfg.Transport = "tcp"
+	})
+	defer exp.shutdown(context.Background())
+
+	ld := testdata.GenerateLogDataTwoLogsSameResource()
+	dropped, err := exp.pushLogData(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	assert.Contains(t, readOctetCounted(t, r), "This is a log message")
+	assert.Contains(t, readOctetCounted(t, r), "something happened")
+}
+
+func TestPushLogDataTLS(t *testing.T) {
+	serverTLS := configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: path.Join(".", "testdata", "server.crt"),
+			KeyFile:  path.Join(".", "testdata", "server.key"),
+		},
+	}
+	tlsCfg, err := serverTLS.LoadTLSConfig()
+	require.NoError(t, err)
+	ln, err := tls.Listen("tcp", "localhost:0", tlsCfg)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+
+	exp := newTestExporter(t, func(cfg *Config) {
+		cfg.Endpoint = ln.Addr().String()
+		cfg.Transport = "tcp"
+		cfg.Protocol = ProtocolRFC3164
+		cfg.TLSSetting = configtls.TLSClientSetting{
+			TLSSetting: configtls.TLSSetting{
+				CAFile: path.Join(".", "testdata", "ca.crt"),
+			},
+			ServerName: "localhost",
+		}
+	})
+	defer exp.shutdown(context.Background())
+
+	_, err = exp.pushLogData(context.Background(), testdata.GenerateLogDataOneLog())
+	require.NoError(t, err)
+
+	select {
+	case line := <-received:
+		assert.True(t, strings.HasPrefix(line, "<14>Feb 11 20:26:13 "), line)
+		assert.True(t, strings.HasSuffix(line, " otelcol: This is a log message\n"), line)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not received")
+	}
+}
+
+// failingConn is a net.Conn that fails all writes after the first maxWrites.
+type failingConn struct {
+	net.Conn
+	writes    int
+	maxWrites int
+	closed    bool
+}
+
+func (c *failingConn) Write(b []byte) (int, error) {
+	if c.writes >= c.maxWrites {
+		return 0, errors.New("broken pipe")
+	}
+	c.writes++
+	return len(b), nil
+}
+
+func (c *failingConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+func (c *failingConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestPushLogDataReconnect(t *testing.T) {
+	exp := newTestExporter(t, func(cfg *Config) {
+		cfg.Endpoint = "localhost:514"
+		cfg.Transport = "tcp"
+	})
+
+	var conns []*failingConn
+	exp.dial = func(context.Context) (net.Conn, error) {
+		conn := &failingConn{maxWrites: 1}
+		conns = append(conns, conn)
+		return conn, nil
+	}
+
+	ld := testdata.GenerateLogDataTwoLogsSameResourceOneDifferent()
+	dropped, err := exp.pushLogData(context.Background(), ld)
+	require.Error(t, err)
+	assert.Equal(t, 2, dropped)
+	require.Len(t, conns, 1)
+	assert.True(t, conns[0].closed)
+
+	partialErr, ok := err.(consumererror.PartialError)
+	require.True(t, ok)
+	assert.Equal(t, 2, partialErr.GetLogs().LogRecordCount())
+
+	// The next push must use a new connection.
+	dropped, err = exp.pushLogData(context.Background(), testdata.GenerateLogDataOneLog())
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	assert.Len(t, conns, 2)
+}
+
+func TestLogsExporterNoServer(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Transport = "tcp"
+	cfg.QueueSettings.Enabled = false
+	cfg.RetrySettings.Enabled = false
+
+	exp, err := factory.CreateLogsExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer exp.Shutdown(context.Background())
+
+	assert.Error(t, exp.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+}
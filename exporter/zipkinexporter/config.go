@@ -34,3 +34,8 @@ type Config struct {
 
 	DefaultServiceName string `mapstructure:"default_service_name"`
 }
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	return exporterhelper.ValidateSettings(nil, &cfg.QueueSettings, &cfg.RetrySettings)
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorageextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"go.opentelemetry.io/collector/extension/storage"
+)
+
+var defaultBucket = []byte("default")
+
+// fileStorageClient is a storage client backed by a bbolt database file, which is
+// shared with the other clients of the same component.
+type fileStorageClient struct {
+	db      *bbolt.DB
+	file    string
+	release func(*fileStorageClient) error
+}
+
+var _ storage.Client = (*fileStorageClient)(nil)
+
+// openDB opens the database file, creating it and its bucket if needed.
+func openDB(file string, timeout time.Duration) (*bbolt.DB, error) {
+	db, err := bbolt.Open(file, 0600, &bbolt.Options{Timeout: timeout})
+	if err != nil {
+		return nil, fmt.Errorf("cannot open storage file %q: %w", file, err)
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(defaultBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("cannot open storage file %q: %w", file, err)
+	}
+	return db, nil
+}
+
+// Get returns a copy of the value, the values read from bbolt are only valid
+// during the transaction.
+func (c *fileStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	var value []byte
+	err := c.db.View(func(tx *bbolt.Tx) error {
+		value = copyValue(tx.Bucket(defaultBucket).Get([]byte(key)))
+		return nil
+	})
+	return value, err
+}
+
+func (c *fileStorageClient) Set(_ context.Context, key string, value []byte) error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(defaultBucket).Put([]byte(key), value)
+	})
+}
+
+func (c *fileStorageClient) Delete(_ context.Context, key string) error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(defaultBucket).Delete([]byte(key))
+	})
+}
+
+func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		for _, op := range ops {
+			var err error
+			switch op.Type {
+			case storage.Get:
+				op.Value = copyValue(bucket.Get([]byte(op.Key)))
+			case storage.Set:
+				err = bucket.Put([]byte(op.Key), op.Value)
+			case storage.Delete:
+				err = bucket.Delete([]byte(op.Key))
+			default:
+				err = errors.New("unknown operation type")
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Close releases the client, the database is closed with the last client of the component.
+func (c *fileStorageClient) Close(context.Context) error {
+	return c.release(c)
+}
+
+func copyValue(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+	copied := make([]byte, len(value))
+	copy(copied, value)
+	return copied
+}
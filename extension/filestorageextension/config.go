@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorageextension
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config has the configuration of the file storage extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// Directory is the directory the database files are created in, it must exist.
+	Directory string `mapstructure:"directory"`
+
+	// Timeout is how long to wait for the lock of a database file held by another
+	// process. Zero means waiting indefinitely.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks that the directory is set and the timeout is not negative.
+func (cfg *Config) Validate() error {
+	if cfg.Directory == "" {
+		return errors.New("directory must be set")
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorageextension
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/storage"
+)
+
+// fileStorage is a storage extension keeping the data of each component in its own
+// database file in the configured directory.
+type fileStorage struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	mu sync.Mutex
+	// dbs are the open database files, shared by the clients of the same component.
+	dbs map[string]*sharedDB
+	// clients are the open clients, their databases are closed on shutdown if the
+	// components did not close them.
+	clients map[*fileStorageClient]struct{}
+}
+
+// sharedDB is a database file open for the clients of a component, it is closed
+// when the last one is closed.
+type sharedDB struct {
+	db   *bbolt.DB
+	refs int
+}
+
+var _ storage.Extension = (*fileStorage)(nil)
+
+func newFileStorage(cfg *Config, logger *zap.Logger) *fileStorage {
+	return &fileStorage{
+		cfg:     cfg,
+		logger:  logger,
+		dbs:     make(map[string]*sharedDB),
+		clients: make(map[*fileStorageClient]struct{}),
+	}
+}
+
+// Start checks that the directory exists.
+func (fs *fileStorage) Start(context.Context, component.Host) error {
+	info, err := os.Stat(fs.cfg.Directory)
+	if err != nil {
+		return fmt.Errorf("cannot use directory %q: %w", fs.cfg.Directory, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("cannot use directory %q: not a directory", fs.cfg.Directory)
+	}
+	return nil
+}
+
+// Shutdown closes the databases of the clients not closed by their components.
+func (fs *fileStorage) Shutdown(ctx context.Context) error {
+	fs.mu.Lock()
+	dbs := fs.dbs
+	fs.dbs = make(map[string]*sharedDB)
+	fs.clients = make(map[*fileStorageClient]struct{})
+	fs.mu.Unlock()
+
+	var errs []error
+	for _, sdb := range dbs {
+		if err := sdb.db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("cannot close storage clients: %v", errs)
+	}
+	return nil
+}
+
+// GetClient returns a client of the database file of the component, e.g.
+// "receiver_filelog_2f1" for the receiver "filelog/1". The clients of the same
+// component share the database, bbolt does not allow opening a file twice.
+func (fs *fileStorage) GetClient(_ context.Context, kind component.Kind, componentName string) (storage.Client, error) {
+	file := filepath.Join(fs.cfg.Directory, kind.String()+"_"+escapeFileName(componentName))
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	sdb, ok := fs.dbs[file]
+	if !ok {
+		db, err := openDB(file, fs.cfg.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		sdb = &sharedDB{db: db}
+		fs.dbs[file] = sdb
+		fs.logger.Debug("Opened storage file", zap.String("file", file))
+	}
+	sdb.refs++
+
+	client := &fileStorageClient{db: sdb.db, file: file, release: fs.release}
+	fs.clients[client] = struct{}{}
+	return client, nil
+}
+
+// release forgets a client closed by its component, and closes its database if no
+// other client uses it. Releasing a client again does nothing.
+func (fs *fileStorage) release(client *fileStorageClient) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if _, ok := fs.clients[client]; !ok {
+		return nil
+	}
+	delete(fs.clients, client)
+
+	sdb := fs.dbs[client.file]
+	sdb.refs--
+	if sdb.refs > 0 {
+		return nil
+	}
+	delete(fs.dbs, client.file)
+	fs.logger.Debug("Closed storage file", zap.String("file", client.file))
+	return sdb.db.Close()
+}
+
+// escapeFileName returns the component name with the bytes that are not lower case
+// letters, digits, '-' or '.' replaced by '_' followed by their hexadecimal value,
+// e.g. "filelog_2f1" for "filelog/1". Different names always give different file
+// names, even on case-insensitive file systems.
+func escapeFileName(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '.' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "_%02x", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorageextension
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/storage"
+)
+
+func newTestExtension(t *testing.T) *fileStorage {
+	if raceEnabled {
+		t.Skip("bbolt fails the pointer checks of the race detector")
+	}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	fs := newFileStorage(cfg, zap.NewNop())
+	require.NoError(t, fs.Start(context.Background(), componenttest.NewNopHost()))
+	return fs
+}
+
+func TestFileStorage_Start(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = filepath.Join(t.TempDir(), "missing")
+	fs := newFileStorage(cfg, zap.NewNop())
+	assert.Error(t, fs.Start(context.Background(), componenttest.NewNopHost()))
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, ioutil.WriteFile(file, nil, 0600))
+	cfg.Directory = file
+	assert.EqualError(t, fs.Start(context.Background(), componenttest.NewNopHost()), `cannot use directory "`+file+`": not a directory`)
+}
+
+func TestFileStorage_Client(t *testing.T) {
+	ctx := context.Background()
+	fs := newTestExtension(t)
+	client, err := fs.GetClient(ctx, component.KindReceiver, "filelog/1")
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(fs.cfg.Directory, "receiver_filelog_2f1"))
+	require.NoError(t, err)
+
+	value, err := client.Get(ctx, "offset")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Set(ctx, "offset", []byte("10")))
+	value, err = client.Get(ctx, "offset")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("10"), value)
+
+	require.NoError(t, client.Delete(ctx, "offset"))
+	value, err = client.Get(ctx, "offset")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	require.NoError(t, client.Delete(ctx, "offset"))
+
+	require.NoError(t, client.Close(ctx))
+	require.NoError(t, fs.Shutdown(ctx))
+}
+
+func TestFileStorage_Batch(t *testing.T) {
+	ctx := context.Background()
+	fs := newTestExtension(t)
+	client, err := fs.GetClient(ctx, component.KindExporter, "otlp")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "head", []byte("1")))
+
+	head := storage.GetOperation("head")
+	tail := storage.GetOperation("tail")
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("tail", []byte("2")),
+		head,
+		storage.DeleteOperation("head"),
+		tail,
+	))
+	assert.Equal(t, []byte("1"), head.Value)
+	assert.Equal(t, []byte("2"), tail.Value)
+
+	value, err := client.Get(ctx, "head")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// Nothing is done if an operation fails.
+	err = client.Batch(ctx,
+		storage.SetOperation("head", []byte("3")),
+		&storage.Operation{Type: storage.OpType(10), Key: "head"},
+	)
+	assert.EqualError(t, err, "unknown operation type")
+	value, err = client.Get(ctx, "head")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, fs.Shutdown(ctx))
+}
+
+func TestFileStorage_ComponentsAreSeparated(t *testing.T) {
+	ctx := context.Background()
+	fs := newTestExtension(t)
+	receiver, err := fs.GetClient(ctx, component.KindReceiver, "kafka")
+	require.NoError(t, err)
+	exporter, err := fs.GetClient(ctx, component.KindExporter, "kafka")
+	require.NoError(t, err)
+
+	require.NoError(t, receiver.Set(ctx, "key", []byte("receiver")))
+	require.NoError(t, exporter.Set(ctx, "key", []byte("exporter")))
+
+	value, err := receiver.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("receiver"), value)
+	value, err = exporter.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("exporter"), value)
+
+	// The clients not closed by the components are closed on shutdown.
+	require.NoError(t, fs.Shutdown(ctx))
+	assert.Empty(t, fs.clients)
+}
+
+func TestFileStorage_SameComponentClients(t *testing.T) {
+	ctx := context.Background()
+	fs := newTestExtension(t)
+	first, err := fs.GetClient(ctx, component.KindReceiver, "filelog")
+	require.NoError(t, err)
+	second, err := fs.GetClient(ctx, component.KindReceiver, "filelog")
+	require.NoError(t, err)
+
+	require.NoError(t, first.Set(ctx, "key", []byte("first")))
+	value, err := second.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), value)
+
+	// The database is closed with the last client, closing a client again does nothing.
+	require.NoError(t, first.Close(ctx))
+	require.NoError(t, first.Close(ctx))
+	value, err = second.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), value)
+	require.NoError(t, second.Close(ctx))
+	assert.Empty(t, fs.dbs)
+
+	third, err := fs.GetClient(ctx, component.KindReceiver, "filelog")
+	require.NoError(t, err)
+	value, err = third.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), value)
+	require.NoError(t, fs.Shutdown(ctx))
+	assert.Empty(t, fs.dbs)
+	require.NoError(t, third.Close(ctx))
+}
+
+func TestEscapeFileName(t *testing.T) {
+	assert.Equal(t, "filelog_2f1", escapeFileName("filelog/1"))
+	assert.Equal(t, "otlp-2.0", escapeFileName("otlp-2.0"))
+	assert.Equal(t, "_c3_a9", escapeFileName("é"))
+
+	// Names that would be the same file once the unsupported characters are replaced.
+	names := []string{"a/b_c", "a/b/c", "a_b_c", "a_2fb_c", "A/b/c", "a/B/c"}
+	files := make(map[string]string)
+	for _, name := range names {
+		file := escapeFileName(name)
+		assert.NotContains(t, files, file, "%q and %q have the same file", name, files[file])
+		files[file] = name
+	}
+}
+
+func TestFileStorage_Persistence(t *testing.T) {
+	ctx := context.Background()
+	fs := newTestExtension(t)
+	client, err := fs.GetClient(ctx, component.KindReceiver, "filelog")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "offset", []byte("10")))
+	require.NoError(t, client.Close(ctx))
+	require.NoError(t, fs.Shutdown(ctx))
+
+	fs = newFileStorage(fs.cfg, zap.NewNop())
+	require.NoError(t, fs.Start(ctx, componenttest.NewNopHost()))
+	client, err = fs.GetClient(ctx, component.KindReceiver, "filelog")
+	require.NoError(t, err)
+	value, err := client.Get(ctx, "offset")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("10"), value)
+	require.NoError(t, client.Close(ctx))
+	require.NoError(t, fs.Shutdown(ctx))
+}
@@ -15,6 +15,9 @@
 package healthcheckextension
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 )
 
@@ -26,4 +29,17 @@ type Config struct {
 	// Port is the port used to publish the health check status.
 	// The default value is 13133.
 	Port uint16 `mapstructure:"port"`
+
+	// ExporterFailureThreshold is how long an exporter can fail to send data before the
+	// health check reports the collector as not available. Zero, the default, means that
+	// the failures of the exporters do not change the availability.
+	ExporterFailureThreshold time.Duration `mapstructure:"exporter_failure_threshold"`
+}
+
+// Validate checks that the exporter failure threshold is not negative.
+func (cfg *Config) Validate() error {
+	if cfg.ExporterFailureThreshold < 0 {
+		return errors.New("exporter_failure_threshold must not be negative")
+	}
+	return nil
 }
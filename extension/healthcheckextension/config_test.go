@@ -17,6 +17,7 @@ package healthcheckextension
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,10 +48,19 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "health_check",
 				NameVal: "health_check/1",
 			},
-			Port: 13,
+			Port:                     13,
+			ExporterFailureThreshold: 5 * time.Minute,
 		},
 		ext1)
 
 	assert.Equal(t, 1, len(cfg.Service.Extensions))
 	assert.Equal(t, "health_check/1", cfg.Service.Extensions[0])
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ExporterFailureThreshold = -time.Second
+	assert.EqualError(t, cfg.Validate(), "exporter_failure_threshold must not be negative")
+}
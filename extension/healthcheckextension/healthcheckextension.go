@@ -16,11 +16,13 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 
-	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
@@ -29,11 +31,56 @@ import (
 type healthCheckExtension struct {
 	config Config
 	logger *zap.Logger
-	state  *healthcheck.HealthCheck
 	server http.Server
+
+	mu sync.Mutex
+	// upSince is when the pipelines became ready, it is zero when they are not.
+	upSince  time.Time
+	statuses map[statusKey]*componentStatus
 }
 
 var _ component.PipelineWatcher = (*healthCheckExtension)(nil)
+var _ component.StatusWatcher = (*healthCheckExtension)(nil)
+
+// statusKey identifies a component in the status of the collector.
+type statusKey struct {
+	kind component.Kind
+	name string
+}
+
+// componentStatus is the last status reported by a component.
+type componentStatus struct {
+	event component.StatusEvent
+	// failingSince is when the component started failing, it is zero if the
+	// component is OK.
+	failingSince time.Time
+}
+
+// healthCheckResponse is the JSON document returned by the health check.
+type healthCheckResponse struct {
+	StatusMsg string                     `json:"status"`
+	UpSince   time.Time                  `json:"upSince"`
+	Uptime    string                     `json:"uptime"`
+	Pipelines map[string]*pipelineStatus `json:"pipelines,omitempty"`
+	Exporters map[string]*statusResponse `json:"exporters,omitempty"`
+}
+
+// pipelineStatus is the status of a pipeline, the worst status of its components, and
+// the status of each of its components keyed by kind/name.
+type pipelineStatus struct {
+	status     component.Status
+	Status     string                     `json:"status"`
+	Components map[string]*statusResponse `json:"components"`
+}
+
+type statusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Since is the time of the last change of the status.
+	Since time.Time `json:"since"`
+	// FailingSince is when the component started failing.
+	FailingSince *time.Time `json:"failingSince,omitempty"`
+}
 
 func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) error {
 
@@ -48,7 +95,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 	}
 
 	// Mount HC handler
-	hc.server.Handler = hc.state.Handler()
+	hc.server.Handler = http.HandlerFunc(hc.handleHealthCheck)
 
 	go func() {
 		// The listener ownership goes to the server.
@@ -65,24 +112,142 @@ func (hc *healthCheckExtension) Shutdown(context.Context) error {
 }
 
 func (hc *healthCheckExtension) Ready() error {
-	hc.state.Set(healthcheck.Ready)
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	if hc.upSince.IsZero() {
+		hc.upSince = time.Now()
+	}
+	hc.logger.Info("Health Check state change", zap.String("status", "ready"))
 	return nil
 }
 
 func (hc *healthCheckExtension) NotReady() error {
-	hc.state.Set(healthcheck.Unavailable)
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.upSince = time.Time{}
+	hc.logger.Info("Health Check state change", zap.String("status", "unavailable"))
 	return nil
 }
 
-func newServer(config Config, logger *zap.Logger) *healthCheckExtension {
-	hc := &healthCheckExtension{
-		config: config,
-		logger: logger,
-		state:  healthcheck.New(),
-		server: http.Server{},
+// ComponentStatusChanged records the status of the component, it is reported by the
+// health check.
+func (hc *healthCheckExtension) ComponentStatusChanged(event component.StatusEvent) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	key := statusKey{kind: event.Kind, name: event.Name}
+	cs := &componentStatus{event: event}
+	if event.Status != component.StatusOK {
+		cs.failingSince = event.Timestamp
+		if prev, ok := hc.statuses[key]; ok && !prev.failingSince.IsZero() {
+			cs.failingSince = prev.failingSince
+		}
+	}
+	hc.statuses[key] = cs
+}
+
+// ComponentsChanged replaces the components of the health check, the statuses of the
+// components that were removed are forgotten, the new components are OK.
+func (hc *healthCheckExtension) ComponentsChanged(components []component.StatusEvent) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	statuses := make(map[statusKey]*componentStatus, len(components))
+	for _, event := range components {
+		key := statusKey{kind: event.Kind, name: event.Name}
+		cs, ok := hc.statuses[key]
+		if ok {
+			cs.event.Pipelines = event.Pipelines
+		} else {
+			cs = &componentStatus{event: event}
+		}
+		statuses[key] = cs
 	}
+	hc.statuses = statuses
+}
 
-	hc.state.SetLogger(logger)
+func (hc *healthCheckExtension) handleHealthCheck(w http.ResponseWriter, _ *http.Request) {
+	resp, ready := hc.response(time.Now())
+	body, _ := json.Marshal(resp)
 
-	return hc
+	w.Header().Set("Content-Type", "application/json")
+	if ready {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = w.Write(body)
+}
+
+// response returns the status of the collector at the given time and if it is
+// available.
+func (hc *healthCheckExtension) response(now time.Time) (*healthCheckResponse, bool) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	ready := !hc.upSince.IsZero()
+	resp := &healthCheckResponse{}
+	if ready {
+		resp.UpSince = hc.upSince
+		resp.Uptime = now.Sub(hc.upSince).String()
+	}
+
+	for key, cs := range hc.statuses {
+		sr := &statusResponse{
+			Status: cs.event.Status.String(),
+			Since:  cs.event.Timestamp,
+		}
+		if cs.event.Err != nil {
+			sr.Error = cs.event.Err.Error()
+		}
+		if !cs.failingSince.IsZero() {
+			failingSince := cs.failingSince
+			sr.FailingSince = &failingSince
+		}
+
+		if key.kind == component.KindExporter {
+			if resp.Exporters == nil {
+				resp.Exporters = make(map[string]*statusResponse)
+			}
+			resp.Exporters[key.name] = sr
+			if hc.config.ExporterFailureThreshold > 0 && !cs.failingSince.IsZero() &&
+				now.Sub(cs.failingSince) > hc.config.ExporterFailureThreshold {
+				ready = false
+			}
+		}
+
+		for _, pipeline := range cs.event.Pipelines {
+			if resp.Pipelines == nil {
+				resp.Pipelines = make(map[string]*pipelineStatus)
+			}
+			ps, ok := resp.Pipelines[pipeline]
+			if !ok {
+				ps = &pipelineStatus{Components: make(map[string]*statusResponse)}
+				resp.Pipelines[pipeline] = ps
+			}
+			ps.Components[key.kind.String()+"/"+key.name] = sr
+			if cs.event.Status > ps.status {
+				ps.status = cs.event.Status
+			}
+		}
+	}
+	for _, ps := range resp.Pipelines {
+		ps.Status = ps.status.String()
+	}
+
+	if ready {
+		resp.StatusMsg = "Server available"
+	} else {
+		resp.StatusMsg = "Server not available"
+	}
+	return resp, ready
+}
+
+func newServer(config Config, logger *zap.Logger) *healthCheckExtension {
+	return &healthCheckExtension{
+		config:   config,
+		logger:   logger,
+		server:   http.Server{},
+		statuses: make(map[statusKey]*componentStatus),
+	}
 }
@@ -16,6 +16,8 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net"
 	"net/http"
 	"runtime"
@@ -23,9 +25,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/testutil"
 )
@@ -65,6 +69,120 @@ func TestHealthCheckExtensionUsage(t *testing.T) {
 	require.Equal(t, http.StatusServiceUnavailable, resp2.StatusCode)
 }
 
+func TestHealthCheckExtensionComponentStatus(t *testing.T) {
+	config := Config{
+		Port:                     testutil.GetAvailablePort(t),
+		ExporterFailureThreshold: time.Minute,
+	}
+
+	hcExt := newServer(config, zap.NewNop())
+	require.NoError(t, hcExt.Start(context.Background(), componenttest.NewNopHost()))
+	defer hcExt.Shutdown(context.Background())
+	require.NoError(t, hcExt.Ready())
+
+	start := time.Now()
+	hcExt.ComponentStatusChanged(component.StatusEvent{
+		Kind:      component.KindExporter,
+		Name:      "otlp",
+		Status:    component.StatusRecoverableError,
+		Err:       errors.New("connection refused"),
+		Timestamp: start,
+		Pipelines: []string{"traces", "metrics"},
+	})
+	hcExt.ComponentStatusChanged(component.StatusEvent{
+		Kind:      component.KindExporter,
+		Name:      "jaeger",
+		Status:    component.StatusOK,
+		Timestamp: start,
+		Pipelines: []string{"traces"},
+	})
+
+	client := &http.Client{}
+	url := "http://localhost:" + strconv.Itoa(int(config.Port))
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	// The exporter is not failing for longer than the threshold yet.
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body healthCheckResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Server available", body.StatusMsg)
+	require.Contains(t, body.Exporters, "otlp")
+	assert.Equal(t, "recoverable_error", body.Exporters["otlp"].Status)
+	assert.Equal(t, "connection refused", body.Exporters["otlp"].Error)
+	assert.Equal(t, "ok", body.Exporters["jaeger"].Status)
+	assert.Nil(t, body.Exporters["jaeger"].FailingSince)
+	require.Contains(t, body.Pipelines, "traces")
+	assert.Equal(t, "recoverable_error", body.Pipelines["traces"].Status)
+	assert.Len(t, body.Pipelines["traces"].Components, 2)
+	assert.Contains(t, body.Pipelines["metrics"].Components, "exporter/otlp")
+
+	// Another failure does not reset the time the exporter started failing.
+	hcExt.ComponentStatusChanged(component.StatusEvent{
+		Kind:      component.KindExporter,
+		Name:      "otlp",
+		Status:    component.StatusPermanentError,
+		Err:       errors.New("unauthorized"),
+		Timestamp: start.Add(time.Second),
+		Pipelines: []string{"traces", "metrics"},
+	})
+	status, ready := hcExt.response(start.Add(2 * time.Minute))
+	assert.False(t, ready)
+	assert.Equal(t, "Server not available", status.StatusMsg)
+	assert.Equal(t, "permanent_error", status.Pipelines["metrics"].Status)
+	assert.Equal(t, start, *status.Exporters["otlp"].FailingSince)
+
+	hcExt.ComponentStatusChanged(component.StatusEvent{
+		Kind:      component.KindExporter,
+		Name:      "otlp",
+		Status:    component.StatusOK,
+		Timestamp: start.Add(time.Minute),
+		Pipelines: []string{"traces", "metrics"},
+	})
+	status, ready = hcExt.response(start.Add(2 * time.Minute))
+	assert.True(t, ready)
+	assert.Equal(t, "ok", status.Pipelines["traces"].Status)
+}
+
+func TestHealthCheckExtensionComponentsChanged(t *testing.T) {
+	hcExt := newServer(Config{ExporterFailureThreshold: time.Minute}, zap.NewNop())
+	require.NoError(t, hcExt.Ready())
+
+	start := time.Now()
+	hcExt.ComponentsChanged([]component.StatusEvent{
+		{Kind: component.KindReceiver, Name: "otlp", Timestamp: start, Pipelines: []string{"traces"}},
+		{Kind: component.KindExporter, Name: "otlp", Timestamp: start, Pipelines: []string{"traces"}},
+		{Kind: component.KindExporter, Name: "jaeger", Timestamp: start, Pipelines: []string{"traces"}},
+	})
+	hcExt.ComponentStatusChanged(component.StatusEvent{
+		Kind:      component.KindExporter,
+		Name:      "jaeger",
+		Status:    component.StatusRecoverableError,
+		Err:       errors.New("connection refused"),
+		Timestamp: start,
+		Pipelines: []string{"traces"},
+	})
+
+	// The components that did not report a status are OK.
+	status, ready := hcExt.response(start.Add(2 * time.Minute))
+	assert.False(t, ready)
+	assert.Equal(t, "ok", status.Exporters["otlp"].Status)
+	assert.Len(t, status.Pipelines["traces"].Components, 3)
+	assert.Equal(t, "ok", status.Pipelines["traces"].Components["receiver/otlp"].Status)
+
+	// The failing exporter is removed from the configuration, the others keep their status.
+	hcExt.ComponentsChanged([]component.StatusEvent{
+		{Kind: component.KindReceiver, Name: "otlp", Timestamp: start.Add(time.Minute), Pipelines: []string{"traces", "metrics"}},
+		{Kind: component.KindExporter, Name: "otlp", Timestamp: start.Add(time.Minute), Pipelines: []string{"traces", "metrics"}},
+	})
+	status, ready = hcExt.response(start.Add(2 * time.Minute))
+	assert.True(t, ready)
+	assert.NotContains(t, status.Exporters, "jaeger")
+	assert.Equal(t, start, status.Exporters["otlp"].Since)
+	assert.Len(t, status.Pipelines["metrics"].Components, 2)
+}
+
 func TestHealthCheckExtensionPortAlreadyInUse(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 	_, portStr, err := net.SplitHostPort(endpoint)
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managementextension
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config has the configuration of the management extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// HTTPClientSettings configures the connection to the management server, Endpoint
+	// is the URL the reports are sent to, e.g. "https://manager:4320/v1/agents".
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// Interval is the interval at which the collector reports to the management server
+	// and gets the configuration to apply.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// AcceptRemoteConfig enables applying the configurations sent by the management
+	// server. If it is disabled the collector only reports its status.
+	AcceptRemoteConfig bool `mapstructure:"accept_remote_config"`
+}
+
+// Validate checks that the endpoint is set and the interval is positive.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managementextension
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	ext0 := cfg.Extensions["management"]
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "http://localhost:4320/v1/agents"
+	assert.Equal(t, defaultCfg, ext0)
+
+	ext1 := cfg.Extensions["management/1"]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: configmodels.ExtensionSettings{
+				TypeVal: "management",
+				NameVal: "management/1",
+			},
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: "https://manager:4320/v1/agents",
+				Headers:  map[string]string{"authorization": "Bearer token"},
+				Timeout:  defaultTimeout,
+			},
+			Interval:           time.Minute,
+			AcceptRemoteConfig: false,
+		},
+		ext1)
+
+	assert.Equal(t, 1, len(cfg.Service.Extensions))
+	assert.Equal(t, "management/1", cfg.Service.Extensions[0])
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "endpoint must be set")
+
+	cfg.Endpoint = "http://localhost:4320"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Interval = 0
+	assert.EqualError(t, cfg.Validate(), "interval must be positive")
+}
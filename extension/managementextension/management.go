@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managementextension
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// management reports the status of the collector to the management server and
+// applies the configurations it sends.
+type management struct {
+	cfg       *Config
+	logger    *zap.Logger
+	agent     agentDescription
+	startTime time.Time
+	client    *http.Client
+
+	// configManager applies the configurations, it is nil if the host does not
+	// implement component.ConfigManager.
+	configManager component.ConfigManager
+	// configStatus is the result of applying the last configuration sent by the server.
+	configStatus *remoteConfigStatus
+
+	mu       sync.Mutex
+	statuses map[string]component.StatusEvent
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ component.StatusWatcher = (*management)(nil)
+
+func newManagement(cfg *Config, logger *zap.Logger, info component.ApplicationStartInfo) (*management, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return &management{
+		cfg:    cfg,
+		logger: logger,
+		agent: agentDescription{
+			InstanceID: uuid.New().String(),
+			Name:       info.ExeName,
+			Version:    info.Version,
+			Hostname:   hostname,
+		},
+		statuses: make(map[string]component.StatusEvent),
+	}, nil
+}
+
+// Start starts reporting to the management server in the background.
+func (m *management) Start(_ context.Context, host component.Host) error {
+	client, err := m.cfg.ToClient()
+	if err != nil {
+		return err
+	}
+	m.client = client
+	m.startTime = time.Now()
+
+	if cm, ok := host.(component.ConfigManager); ok {
+		m.configManager = cm
+	} else {
+		m.logger.Warn("The host cannot apply configurations, only the status is reported")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done = make(chan struct{})
+	go m.run(ctx)
+	return nil
+}
+
+// Shutdown stops reporting, it waits for the configuration being applied if any.
+func (m *management) Shutdown(context.Context) error {
+	if m.cancel != nil {
+		m.cancel()
+		<-m.done
+	}
+	return nil
+}
+
+// ComponentStatusChanged implements component.StatusWatcher.
+func (m *management) ComponentStatusChanged(event component.StatusEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[event.Kind.String()+"/"+event.Name] = event
+}
+
+// ComponentsChanged implements component.StatusWatcher, it forgets the statuses of the
+// components that were removed.
+func (m *management) ComponentsChanged(components []component.StatusEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	statuses := make(map[string]component.StatusEvent, len(components))
+	for _, event := range components {
+		name := event.Kind.String() + "/" + event.Name
+		if prev, ok := m.statuses[name]; ok {
+			prev.Pipelines = event.Pipelines
+			event = prev
+		}
+		statuses[name] = event
+	}
+	m.statuses = statuses
+}
+
+func (m *management) run(ctx context.Context) {
+	defer close(m.done)
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		m.report(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// report sends the status to the management server and applies the configuration it
+// answers with. The result of applying a configuration is reported right away.
+func (m *management) report(ctx context.Context) {
+	for ctx.Err() == nil {
+		resp, err := m.send(ctx)
+		if err != nil {
+			m.logger.Warn("Failed to report to the management server", zap.Error(err))
+			return
+		}
+		if !m.applyRemoteConfig(ctx, resp.RemoteConfig) {
+			return
+		}
+	}
+}
+
+// send sends the report and returns the response of the management server.
+func (m *management) send(ctx context.Context) (*serverResponse, error) {
+	body, err := json.Marshal(m.buildReport(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		return nil, fmt.Errorf("management server responded with HTTP Status Code %d", httpResp.StatusCode)
+	}
+
+	resp := &serverResponse{}
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, resp); err != nil {
+			return nil, fmt.Errorf("invalid response of the management server: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+func (m *management) buildReport(now time.Time) *agentReport {
+	report := &agentReport{
+		Agent: m.agent,
+		Health: agentHealth{
+			Healthy:   true,
+			StartTime: m.startTime,
+		},
+		RemoteConfigStatus: m.configStatus,
+	}
+
+	if m.configManager != nil {
+		effective, err := m.effectiveConfig()
+		if err != nil {
+			m.logger.Debug("Cannot report the effective configuration", zap.Error(err))
+		}
+		report.EffectiveConfig = effective
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.statuses) > 0 {
+		report.Health.Components = make(map[string]componentHealth, len(m.statuses))
+	}
+	for name, event := range m.statuses {
+		ch := componentHealth{
+			Status: event.Status.String(),
+			Since:  event.Timestamp,
+		}
+		if event.Err != nil {
+			ch.Error = event.Err.Error()
+		}
+		if event.Status != component.StatusOK {
+			report.Health.Healthy = false
+		}
+		report.Health.Components[name] = ch
+	}
+	return report
+}
+
+func (m *management) effectiveConfig() (string, error) {
+	cfg, err := m.configManager.EffectiveConfig()
+	if err != nil {
+		return "", err
+	}
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// applyRemoteConfig applies the configuration sent by the server unless it is the
+// last one applied or failed. It returns true if the status of the remote
+// configuration changed.
+func (m *management) applyRemoteConfig(ctx context.Context, rc *remoteConfig) bool {
+	if rc == nil || (m.configStatus != nil && m.configStatus.Hash == rc.Hash) {
+		return false
+	}
+
+	var err error
+	switch {
+	case !m.cfg.AcceptRemoteConfig:
+		err = errors.New("remote configuration is disabled")
+	case m.configManager == nil:
+		err = errors.New("the host cannot apply configurations")
+	default:
+		m.logger.Info("Applying configuration sent by the management server", zap.String("hash", rc.Hash))
+		err = m.configManager.ApplyConfig(ctx, []byte(rc.Config))
+	}
+	if ctx.Err() != nil {
+		// Shutting down, the configuration is applied again on the next start.
+		return false
+	}
+
+	m.configStatus = &remoteConfigStatus{Hash: rc.Hash, Status: remoteConfigApplied}
+	if err != nil {
+		m.logger.Warn("Failed to apply configuration sent by the management server", zap.String("hash", rc.Hash), zap.Error(err))
+		m.configStatus.Status = remoteConfigFailed
+		m.configStatus.Error = err.Error()
+	}
+	return true
+}
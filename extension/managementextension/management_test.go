@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managementextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+// configManagerHost is a host applying the configurations with an error, if set.
+type configManagerHost struct {
+	componenttest.NopHost
+	mu      sync.Mutex
+	applied []string
+	err     error
+}
+
+func (h *configManagerHost) EffectiveConfig() (map[string]interface{}, error) {
+	return map[string]interface{}{"receivers": map[string]interface{}{"otlp": nil}}, nil
+}
+
+func (h *configManagerHost) ApplyConfig(_ context.Context, yamlConfig []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.applied = append(h.applied, string(yamlConfig))
+	return h.err
+}
+
+func (h *configManagerHost) appliedConfigs() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.applied...)
+}
+
+// managementServer is a stand-in for the management server, it records the reports
+// and answers with the response, if set.
+type managementServer struct {
+	*httptest.Server
+	reports  chan *agentReport
+	response *serverResponse
+	status   int
+}
+
+func newManagementServer(t *testing.T, response *serverResponse) *managementServer {
+	ms := &managementServer{
+		reports:  make(chan *agentReport, 10),
+		response: response,
+		status:   http.StatusOK,
+	}
+	ms.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "tenant1", r.Header.Get("x-tenant"))
+		report := &agentReport{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(report))
+		ms.reports <- report
+
+		w.WriteHeader(ms.status)
+		if ms.response != nil {
+			assert.NoError(t, json.NewEncoder(w).Encode(ms.response))
+		}
+	}))
+	t.Cleanup(ms.Close)
+	return ms
+}
+
+func (ms *managementServer) nextReport(t *testing.T) *agentReport {
+	select {
+	case report := <-ms.reports:
+		return report
+	case <-time.After(5 * time.Second):
+		t.Fatal("no report received")
+		return nil
+	}
+}
+
+func startManagement(t *testing.T, endpoint string, host component.Host, accept bool) *management {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Headers = map[string]string{"x-tenant": "tenant1"}
+	cfg.Interval = time.Hour
+	cfg.AcceptRemoteConfig = accept
+
+	m, err := newManagement(cfg, zap.NewNop(), componenttest.TestApplicationStartInfo())
+	require.NoError(t, err)
+	m.ComponentStatusChanged(component.StatusEvent{
+		Kind:      component.KindExporter,
+		Name:      "otlp",
+		Status:    component.StatusRecoverableError,
+		Err:       errors.New("connection refused"),
+		Timestamp: time.Unix(10, 0).UTC(),
+	})
+	require.NoError(t, m.Start(context.Background(), host))
+	t.Cleanup(func() { assert.NoError(t, m.Shutdown(context.Background())) })
+	return m
+}
+
+func TestManagement_Report(t *testing.T) {
+	server := newManagementServer(t, nil)
+	m := startManagement(t, server.URL, &configManagerHost{}, true)
+
+	report := server.nextReport(t)
+	info := componenttest.TestApplicationStartInfo()
+	assert.Equal(t, m.agent, report.Agent)
+	assert.NotEmpty(t, report.Agent.InstanceID)
+	assert.Equal(t, info.ExeName, report.Agent.Name)
+	assert.Equal(t, info.Version, report.Agent.Version)
+	assert.NotEmpty(t, report.Agent.Hostname)
+	assert.Equal(t, "receivers:\n  otlp: null\n", report.EffectiveConfig)
+	assert.False(t, report.Health.Healthy)
+	assert.True(t, m.startTime.Equal(report.Health.StartTime))
+	require.Len(t, report.Health.Components, 1)
+	otlp := report.Health.Components["exporter/otlp"]
+	assert.Equal(t, "recoverable_error", otlp.Status)
+	assert.Equal(t, "connection refused", otlp.Error)
+	assert.True(t, time.Unix(10, 0).Equal(otlp.Since))
+	assert.Nil(t, report.RemoteConfigStatus)
+}
+
+func TestManagement_RemoteConfig(t *testing.T) {
+	server := newManagementServer(t, &serverResponse{
+		RemoteConfig: &remoteConfig{Hash: "1", Config: "receivers:\n  otlp:\n"},
+	})
+	host := &configManagerHost{}
+	startManagement(t, server.URL, host, true)
+
+	assert.Nil(t, server.nextReport(t).RemoteConfigStatus)
+	// The result is reported right away, the configuration is not applied again.
+	assert.Equal(t, &remoteConfigStatus{Hash: "1", Status: "applied"}, server.nextReport(t).RemoteConfigStatus)
+	assert.Equal(t, []string{"receivers:\n  otlp:\n"}, host.appliedConfigs())
+}
+
+func TestManagement_RemoteConfigFailed(t *testing.T) {
+	server := newManagementServer(t, &serverResponse{
+		RemoteConfig: &remoteConfig{Hash: "2", Config: "receivers: ["},
+	})
+	host := &configManagerHost{err: errors.New("cannot parse configuration")}
+	startManagement(t, server.URL, host, true)
+
+	server.nextReport(t)
+	assert.Equal(t, &remoteConfigStatus{
+		Hash:   "2",
+		Status: "failed",
+		Error:  "cannot parse configuration",
+	}, server.nextReport(t).RemoteConfigStatus)
+	assert.Len(t, host.appliedConfigs(), 1)
+}
+
+func TestManagement_RemoteConfigDisabled(t *testing.T) {
+	server := newManagementServer(t, &serverResponse{
+		RemoteConfig: &remoteConfig{Hash: "3", Config: "receivers:\n  otlp:\n"},
+	})
+	host := &configManagerHost{}
+	startManagement(t, server.URL, host, false)
+
+	server.nextReport(t)
+	assert.Equal(t, &remoteConfigStatus{
+		Hash:   "3",
+		Status: "failed",
+		Error:  "remote configuration is disabled",
+	}, server.nextReport(t).RemoteConfigStatus)
+	assert.Empty(t, host.appliedConfigs())
+}
+
+func TestManagement_HostWithoutConfigManager(t *testing.T) {
+	server := newManagementServer(t, &serverResponse{
+		RemoteConfig: &remoteConfig{Hash: "4", Config: "receivers:\n  otlp:\n"},
+	})
+	startManagement(t, server.URL, componenttest.NewNopHost(), true)
+
+	assert.Empty(t, server.nextReport(t).EffectiveConfig)
+	assert.Equal(t, &remoteConfigStatus{
+		Hash:   "4",
+		Status: "failed",
+		Error:  "the host cannot apply configurations",
+	}, server.nextReport(t).RemoteConfigStatus)
+}
+
+func TestManagement_ServerError(t *testing.T) {
+	server := newManagementServer(t, &serverResponse{
+		RemoteConfig: &remoteConfig{Hash: "5", Config: "receivers:\n  otlp:\n"},
+	})
+	server.status = http.StatusServiceUnavailable
+	host := &configManagerHost{}
+	m := startManagement(t, server.URL, host, true)
+
+	server.nextReport(t)
+	require.NoError(t, m.Shutdown(context.Background()))
+	assert.Empty(t, host.appliedConfigs())
+	assert.Nil(t, m.configStatus)
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managementextension
+
+import "time"
+
+// The messages exchanged with the management server, in JSON. The collector sends an
+// agentReport in the body of a POST request to the endpoint at each interval, the
+// server answers with a serverResponse.
+
+// agentReport is the status of the collector reported to the management server.
+type agentReport struct {
+	Agent agentDescription `json:"agent"`
+	// EffectiveConfig is the configuration in effect in YAML, with the secrets redacted.
+	EffectiveConfig string      `json:"effective_config,omitempty"`
+	Health          agentHealth `json:"health"`
+	// RemoteConfigStatus is the result of applying the last configuration sent by the
+	// server, it is not set until the server sends one.
+	RemoteConfigStatus *remoteConfigStatus `json:"remote_config_status,omitempty"`
+}
+
+// agentDescription identifies the collector.
+type agentDescription struct {
+	// InstanceID is unique for each run of the collector.
+	InstanceID string `json:"instance_id"`
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Hostname   string `json:"hostname"`
+}
+
+// agentHealth is the health of the collector, from the status reported by the
+// components.
+type agentHealth struct {
+	// Healthy is false if any component reports an error.
+	Healthy   bool      `json:"healthy"`
+	StartTime time.Time `json:"start_time"`
+	// Components are the status of the components that reported one, by kind and
+	// name, e.g. "exporter/otlp".
+	Components map[string]componentHealth `json:"components,omitempty"`
+}
+
+// componentHealth is the status of a component.
+type componentHealth struct {
+	// Status is one of "ok", "recoverable_error" or "permanent_error".
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+	Since  time.Time `json:"since"`
+}
+
+const (
+	remoteConfigApplied = "applied"
+	remoteConfigFailed  = "failed"
+)
+
+// remoteConfigStatus is the result of applying a configuration sent by the server.
+type remoteConfigStatus struct {
+	Hash string `json:"hash"`
+	// Status is "applied" or "failed", the previous configuration stays in effect if
+	// the configuration failed to be applied.
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// serverResponse is the answer of the management server to an agentReport.
+type serverResponse struct {
+	// RemoteConfig is the configuration the collector must apply, if any.
+	RemoteConfig *remoteConfig `json:"remote_config,omitempty"`
+}
+
+// remoteConfig is a configuration sent by the management server.
+type remoteConfig struct {
+	// Hash identifies the configuration, a configuration with the hash of the last
+	// configuration applied or failed is ignored.
+	Hash string `json:"hash"`
+	// Config is the configuration in YAML.
+	Config string `json:"config"`
+}
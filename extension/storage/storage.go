@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storage defines the interface of the storage extensions, which provide
+// the components with a key-value store to persist their state, e.g. the offsets
+// of a receiver or the queue of an exporter.
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Extension is the interface of the storage extensions. The components find the
+// extension by name in component.Host.GetExtensions(), see GetClient.
+type Extension interface {
+	component.ServiceExtension
+
+	// GetClient returns a client of the storage of the component of the given kind
+	// and name. The data of a component is kept apart from the data of the other
+	// components. The client must be closed by the component once it is not used.
+	GetClient(ctx context.Context, kind component.Kind, componentName string) (Client, error)
+}
+
+// Client is a key-value store. It is safe for concurrent use.
+type Client interface {
+	// Get returns the value of the key, nil if the key is not set.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set sets the value of the key.
+	Set(ctx context.Context, key string, value []byte) error
+
+	// Delete deletes the key, it does nothing if the key is not set.
+	Delete(ctx context.Context, key string) error
+
+	// Batch executes the operations in order in a single transaction: either all the
+	// operations are executed or none. The values read by the Get operations are
+	// stored in their Value.
+	Batch(ctx context.Context, ops ...*Operation) error
+
+	// Close releases the client.
+	Close(ctx context.Context) error
+}
+
+// OpType is the type of an Operation.
+type OpType int
+
+const (
+	// Get reads the value of the key.
+	Get OpType = iota
+	// Set sets the value of the key.
+	Set
+	// Delete deletes the key.
+	Delete
+)
+
+// Operation is one of the operations of Client.Batch.
+type Operation struct {
+	Type  OpType
+	Key   string
+	Value []byte
+}
+
+// GetOperation returns an operation reading the value of the key.
+func GetOperation(key string) *Operation {
+	return &Operation{Type: Get, Key: key}
+}
+
+// SetOperation returns an operation setting the value of the key.
+func SetOperation(key string, value []byte) *Operation {
+	return &Operation{Type: Set, Key: key, Value: value}
+}
+
+// DeleteOperation returns an operation deleting the key.
+func DeleteOperation(key string) *Operation {
+	return &Operation{Type: Delete, Key: key}
+}
+
+// GetClient returns a client of the storage extension with the given name, e.g.
+// "file_storage/offsets", for the component of the given kind and name. It returns
+// an error if there is no such extension or if it is not a storage extension.
+func GetClient(ctx context.Context, host component.Host, extensionName string, kind component.Kind, componentName string) (Client, error) {
+	for cfg, ext := range host.GetExtensions() {
+		if cfg.Name() != extensionName {
+			continue
+		}
+		storageExt, ok := ext.(Extension)
+		if !ok {
+			return nil, fmt.Errorf("extension %q is not a storage extension", extensionName)
+		}
+		return storageExt.GetClient(ctx, kind, componentName)
+	}
+	return nil, fmt.Errorf("storage extension %q not found", extensionName)
+}
+
+// NewNopClient returns a client that stores nothing, for the components that can
+// run without storage.
+func NewNopClient() Client {
+	return nopClient{}
+}
+
+type nopClient struct{}
+
+func (nopClient) Get(context.Context, string) ([]byte, error) {
+	return nil, nil
+}
+
+func (nopClient) Set(context.Context, string, []byte) error {
+	return nil
+}
+
+func (nopClient) Delete(context.Context, string) error {
+	return nil
+}
+
+func (nopClient) Batch(context.Context, ...*Operation) error {
+	return nil
+}
+
+func (nopClient) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+type testExtension struct {
+	componenttest.ExampleExtension
+	kind          component.Kind
+	componentName string
+}
+
+func (e *testExtension) GetClient(_ context.Context, kind component.Kind, componentName string) (Client, error) {
+	e.kind = kind
+	e.componentName = componentName
+	return NewNopClient(), nil
+}
+
+type testHost struct {
+	componenttest.NopHost
+	extensions map[configmodels.Extension]component.ServiceExtension
+}
+
+func (h *testHost) GetExtensions() map[configmodels.Extension]component.ServiceExtension {
+	return h.extensions
+}
+
+func TestGetClient(t *testing.T) {
+	storageExt := &testExtension{}
+	host := &testHost{extensions: map[configmodels.Extension]component.ServiceExtension{
+		&configmodels.ExtensionSettings{TypeVal: "file_storage", NameVal: "file_storage/1"}: storageExt,
+		&configmodels.ExtensionSettings{TypeVal: "pprof", NameVal: "pprof"}:                 &componenttest.ExampleExtension{},
+	}}
+
+	client, err := GetClient(context.Background(), host, "file_storage/1", component.KindReceiver, "filelog")
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+	assert.Equal(t, component.KindReceiver, storageExt.kind)
+	assert.Equal(t, "filelog", storageExt.componentName)
+
+	_, err = GetClient(context.Background(), host, "pprof", component.KindReceiver, "filelog")
+	assert.EqualError(t, err, `extension "pprof" is not a storage extension`)
+
+	_, err = GetClient(context.Background(), host, "file_storage", component.KindReceiver, "filelog")
+	assert.EqualError(t, err, `storage extension "file_storage" not found`)
+}
+
+func TestNopClient(t *testing.T) {
+	ctx := context.Background()
+	client := NewNopClient()
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.NoError(t, client.Delete(ctx, "key"))
+	assert.NoError(t, client.Batch(ctx, GetOperation("key"), SetOperation("key", nil), DeleteOperation("key")))
+	assert.NoError(t, client.Close(ctx))
+}
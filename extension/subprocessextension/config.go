@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessextension
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config has the configuration of the subprocess extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// ExecutablePath is the path of the executable to run. Ideally it is an absolute
+	// path since the working directory of the collector is not guaranteed to be stable.
+	ExecutablePath string `mapstructure:"executable_path"`
+
+	// Args are the arguments passed to the executable.
+	Args []string `mapstructure:"args"`
+
+	// Environment are the environment variables of the process in addition to the ones
+	// of the collector, in the NAME=value form. They are not a map since the keys of the
+	// configuration are case insensitive.
+	Environment []string `mapstructure:"environment"`
+
+	// WorkingDirectory is the working directory of the process, the one of the
+	// collector if it is not set.
+	WorkingDirectory string `mapstructure:"working_directory"`
+
+	// LogsPipeline is the name of the logs pipeline each line the process writes to its
+	// stdout or stderr is sent to as a log record. If it is not set the lines are
+	// written to the collector's log at debug level.
+	LogsPipeline string `mapstructure:"logs_pipeline"`
+
+	// RestartDelay is how long to wait before restarting the process after it exited.
+	// The delay doubles after each restart of a process that exited before running
+	// for MaxRestartDelay, up to MaxRestartDelay.
+	RestartDelay time.Duration `mapstructure:"restart_delay"`
+
+	// MaxRestartDelay is the maximal delay before restarting the process.
+	MaxRestartDelay time.Duration `mapstructure:"max_restart_delay"`
+
+	// ShutdownTimeout is how long to wait for the process to exit after it was sent
+	// SIGTERM on shutdown before it is killed.
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+// Validate checks that the executable path is set, the environment variables are
+// well-formed and the delays are consistent.
+func (cfg *Config) Validate() error {
+	if cfg.ExecutablePath == "" {
+		return errors.New("executable_path must be set")
+	}
+	for _, env := range cfg.Environment {
+		if strings.IndexByte(env, '=') <= 0 {
+			return fmt.Errorf("environment variable %q is not in the NAME=value form", env)
+		}
+	}
+	if cfg.RestartDelay <= 0 {
+		return errors.New("restart_delay must be positive")
+	}
+	if cfg.MaxRestartDelay < cfg.RestartDelay {
+		return errors.New("max_restart_delay must not be less than restart_delay")
+	}
+	if cfg.ShutdownTimeout < 0 {
+		return errors.New("shutdown_timeout must not be negative")
+	}
+	return nil
+}
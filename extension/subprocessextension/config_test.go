@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessextension
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	ext0 := cfg.Extensions["subprocess"]
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.ExecutablePath = "/usr/bin/my-agent"
+	assert.Equal(t, defaultCfg, ext0)
+
+	ext1 := cfg.Extensions["subprocess/1"]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: configmodels.ExtensionSettings{
+				TypeVal: "subprocess",
+				NameVal: "subprocess/1",
+			},
+			ExecutablePath:   "/usr/bin/my-agent",
+			Args:             []string{"--config", "/etc/my-agent.conf"},
+			Environment:      []string{"LOG_LEVEL=info"},
+			WorkingDirectory: "/var/lib/my-agent",
+			LogsPipeline:     "logs/agent",
+			RestartDelay:     2 * time.Second,
+			MaxRestartDelay:  30 * time.Second,
+			ShutdownTimeout:  10 * time.Second,
+		},
+		ext1)
+
+	assert.Equal(t, 1, len(cfg.Service.Extensions))
+	assert.Equal(t, "subprocess/1", cfg.Service.Extensions[0])
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "executable_path must be set")
+
+	cfg.ExecutablePath = "/usr/bin/my-agent"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Environment = []string{"LOG_LEVEL=info", "=info"}
+	assert.EqualError(t, cfg.Validate(), `environment variable "=info" is not in the NAME=value form`)
+	cfg.Environment = []string{"LOG_LEVEL=info", "EMPTY="}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ShutdownTimeout = -time.Second
+	assert.EqualError(t, cfg.Validate(), "shutdown_timeout must not be negative")
+
+	cfg.MaxRestartDelay = time.Millisecond
+	assert.EqualError(t, cfg.Validate(), "max_restart_delay must not be less than restart_delay")
+
+	cfg.RestartDelay = 0
+	assert.EqualError(t, cfg.Validate(), "restart_delay must be positive")
+}
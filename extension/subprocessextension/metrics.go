@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessextension
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagExtensionName, _ = tag.NewKey("extension")
+
+	statRestarts = stats.Int64("subprocess_restarts", "Number of times the process was restarted", stats.UnitDimensionless)
+	statExitCode = stats.Int64("subprocess_exit_code", "Exit code of the last run of the process, -1 if it was killed by a signal", stats.UnitDimensionless)
+)
+
+// MetricViews return metric views for the subprocess extension.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagExtensionName}
+
+	countRestarts := &view.View{
+		Name:        statRestarts.Name(),
+		Measure:     statRestarts,
+		Description: statRestarts.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	lastValueExitCode := &view.View{
+		Name:        statExitCode.Name(),
+		Measure:     statExitCode,
+		Description: statExitCode.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.LastValue(),
+	}
+
+	return []*view.View{
+		countRestarts,
+		lastValueExitCode,
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessextension
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	// maxBatchSize is the maximal number of lines sent in one Logs.
+	maxBatchSize = 100
+	// maxLineSize is the maximal size of a line, the rest of the output of the stream
+	// is discarded after a longer line.
+	maxLineSize = 1024 * 1024
+	// outputDrainTimeout is how long the output is read after the process exited.
+	outputDrainTimeout = time.Second
+
+	attributeStream = "stream"
+)
+
+// outputLine is a line written by the process to its stdout or stderr.
+type outputLine struct {
+	text   string
+	stream string
+	time   time.Time
+}
+
+// supervisor runs the configured executable until it is shut down, restarting the
+// process with backoff when it exits.
+type supervisor struct {
+	conf   *Config
+	logger *zap.Logger
+	host   component.Host
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newSupervisor(conf *Config, logger *zap.Logger) *supervisor {
+	return &supervisor{
+		conf:   conf,
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+}
+
+// Start starts the process in the background.
+func (s *supervisor) Start(_ context.Context, host component.Host) error {
+	if s.conf.LogsPipeline != "" {
+		if _, ok := host.(component.PipelineConsumers); !ok {
+			return errors.New("the host does not let extensions send logs into the pipelines")
+		}
+	}
+	s.host = host
+
+	// The process outlives the context given to Start.
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go func() {
+		defer close(s.done)
+		s.run(ctx)
+	}()
+	return nil
+}
+
+// Shutdown terminates the process and waits until it exited or ctx is done.
+func (s *supervisor) Shutdown(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run starts the process and restarts it each time it exits until ctx is done.
+func (s *supervisor) run(ctx context.Context) {
+	statsCtx, _ := tag.New(context.Background(), tag.Upsert(tagExtensionName, s.conf.Name()))
+	delay := s.conf.RestartDelay
+	for {
+		started := time.Now()
+		err := s.runProcess(ctx, statsCtx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// A process that ran for a while is restarted quickly again.
+		if time.Since(started) >= s.conf.MaxRestartDelay {
+			delay = s.conf.RestartDelay
+		}
+		s.logger.Error("Subprocess exited, restarting it", zap.Error(err), zap.Duration("delay", delay))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		stats.Record(statsCtx, statRestarts.M(1))
+
+		delay *= 2
+		if delay > s.conf.MaxRestartDelay {
+			delay = s.conf.MaxRestartDelay
+		}
+	}
+}
+
+// runProcess runs the process until it exits, it is terminated when ctx is done. The
+// returned error is nil if the process exited with code 0.
+func (s *supervisor) runProcess(ctx context.Context, statsCtx context.Context) error {
+	cmd := exec.Command(s.conf.ExecutablePath, s.conf.Args...)
+	cmd.Dir = s.conf.WorkingDirectory
+	cmd.Env = append(os.Environ(), s.conf.Environment...)
+	applyOSSpecificCmdModifications(cmd)
+
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer stdoutReader.Close()
+	stderrReader, stderrWriter, err := os.Pipe()
+	if err != nil {
+		stdoutWriter.Close()
+		return err
+	}
+	defer stderrReader.Close()
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = stderrWriter
+	err = cmd.Start()
+	// The process has its own copies of the write ends.
+	stdoutWriter.Close()
+	stderrWriter.Close()
+	if err != nil {
+		return err
+	}
+	pid := cmd.Process.Pid
+	s.logger.Info("Subprocess started", zap.String("command", cmd.String()), zap.Int("pid", pid))
+
+	lines := make(chan outputLine, maxBatchSize)
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go readLines(stdoutReader, "stdout", lines, &readers)
+	go readLines(stderrReader, "stderr", lines, &readers)
+	readersDone := make(chan struct{})
+	go func() {
+		readers.Wait()
+		close(lines)
+		close(readersDone)
+	}()
+	forwarded := make(chan struct{})
+	go func() {
+		defer close(forwarded)
+		s.forwardLines(lines, pid)
+	}()
+
+	exited := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.terminate(cmd.Process, exited)
+		case <-exited:
+		}
+	}()
+	err = cmd.Wait()
+	close(exited)
+	if cmd.ProcessState != nil {
+		stats.Record(statsCtx, statExitCode.M(int64(cmd.ProcessState.ExitCode())))
+	}
+
+	// Children of the process may still hold the pipes, the rest of the output is only
+	// read for a short while.
+	timer := time.NewTimer(outputDrainTimeout)
+	defer timer.Stop()
+	select {
+	case <-readersDone:
+	case <-timer.C:
+		stdoutReader.Close()
+		stderrReader.Close()
+		<-readersDone
+	}
+	<-forwarded
+	return err
+}
+
+// terminate sends SIGTERM to the process and kills it if it did not exit after the
+// shutdown timeout.
+func (s *supervisor) terminate(process *os.Process, exited <-chan struct{}) {
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		// Signals other than kill are not supported on Windows.
+		_ = process.Kill()
+		return
+	}
+	timer := time.NewTimer(s.conf.ShutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-exited:
+	case <-timer.C:
+		s.logger.Warn("Subprocess did not exit after SIGTERM, killing it", zap.Int("pid", process.Pid))
+		_ = process.Kill()
+	}
+}
+
+// readLines sends the lines read from r to lines until r is closed.
+func readLines(r io.Reader, stream string, lines chan<- outputLine, wg *sync.WaitGroup) {
+	defer wg.Done()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		lines <- outputLine{text: scanner.Text(), stream: stream, time: time.Now()}
+	}
+	// Keep reading after a too long line so that the process does not block writing.
+	_, _ = io.Copy(ioutil.Discard, r)
+}
+
+// forwardLines sends the lines in batches of the lines available at once.
+func (s *supervisor) forwardLines(lines <-chan outputLine, pid int) {
+	for line := range lines {
+		batch := []outputLine{line}
+	drain:
+		for len(batch) < maxBatchSize {
+			select {
+			case l, ok := <-lines:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, l)
+			default:
+				break drain
+			}
+		}
+		s.sendLines(batch, pid)
+	}
+}
+
+// sendLines sends the lines to the logs pipeline, they are written to the log if the
+// pipeline is not set or not running.
+func (s *supervisor) sendLines(batch []outputLine, pid int) {
+	var lc consumer.LogsConsumer
+	if s.conf.LogsPipeline != "" {
+		lc = s.host.(component.PipelineConsumers).GetLogsConsumer(s.conf.LogsPipeline)
+	}
+	if lc == nil {
+		for _, line := range batch {
+			s.logger.Debug(line.text, zap.String(attributeStream, line.stream))
+		}
+		return
+	}
+	if err := lc.ConsumeLogs(context.Background(), s.linesToLogs(batch, pid)); err != nil {
+		s.logger.Debug("Failed to send the output of the subprocess", zap.Int("lines", len(batch)), zap.Error(err))
+	}
+}
+
+// linesToLogs converts the lines of the process with the given pid to a log record
+// per line.
+func (s *supervisor) linesToLogs(batch []outputLine, pid int) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	resource := rl.Resource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString(conventions.AttributeProcessExecutablePath, s.conf.ExecutablePath)
+	resource.Attributes().InsertInt(conventions.AttributeProcessID, int64(pid))
+
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(len(batch))
+	for i, line := range batch {
+		lr := logs.At(i)
+		lr.SetTimestamp(pdata.TimestampUnixNano(line.time.UnixNano()))
+		lr.Body().InitEmpty()
+		lr.Body().SetStringVal(line.text)
+		lr.Attributes().InsertString(attributeStream, line.stream)
+	}
+	return ld
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package subprocessextension
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// pipelineHost is a host with a single logs pipeline.
+type pipelineHost struct {
+	component.Host
+	name string
+	sink *consumertest.LogsSink
+}
+
+func (h *pipelineHost) GetLogsConsumer(pipelineName string) consumer.LogsConsumer {
+	if pipelineName != h.name {
+		return nil
+	}
+	return h.sink
+}
+
+func newTestConfig(t *testing.T, script string) *Config {
+	path := filepath.Join(t.TempDir(), "script.sh")
+	require.NoError(t, ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script), 0700))
+	cfg := createDefaultConfig().(*Config)
+	cfg.ExecutablePath = path
+	cfg.RestartDelay = 10 * time.Millisecond
+	cfg.MaxRestartDelay = 50 * time.Millisecond
+	return cfg
+}
+
+func TestSupervisor_SendsOutputToPipeline(t *testing.T) {
+	cfg := newTestConfig(t, `echo "$1 $GREETING"
+echo error 1>&2
+exec sleep 100
+`)
+	cfg.Args = []string{"hello"}
+	cfg.Environment = []string{"GREETING=world"}
+	cfg.LogsPipeline = "logs/subprocess"
+	host := &pipelineHost{Host: componenttest.NewNopHost(), name: "logs/subprocess", sink: new(consumertest.LogsSink)}
+
+	s := newSupervisor(cfg, zap.NewNop())
+	require.NoError(t, s.Start(context.Background(), host))
+	require.Eventually(t, func() bool {
+		return host.sink.LogRecordsCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, s.Shutdown(context.Background()))
+
+	streams := map[string]string{}
+	for _, ld := range host.sink.AllLogs() {
+		rl := ld.ResourceLogs().At(0)
+		path, ok := rl.Resource().Attributes().Get(conventions.AttributeProcessExecutablePath)
+		require.True(t, ok)
+		assert.Equal(t, cfg.ExecutablePath, path.StringVal())
+		_, ok = rl.Resource().Attributes().Get(conventions.AttributeProcessID)
+		assert.True(t, ok)
+
+		logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+		for i := 0; i < logs.Len(); i++ {
+			stream, ok := logs.At(i).Attributes().Get(attributeStream)
+			require.True(t, ok)
+			assert.NotZero(t, logs.At(i).Timestamp())
+			streams[stream.StringVal()] = logs.At(i).Body().StringVal()
+		}
+	}
+	assert.Equal(t, map[string]string{"stdout": "hello world", "stderr": "error"}, streams)
+}
+
+func TestSupervisor_RequiresPipelineConsumers(t *testing.T) {
+	cfg := newTestConfig(t, "exec sleep 100\n")
+	cfg.LogsPipeline = "logs"
+	s := newSupervisor(cfg, zap.NewNop())
+	require.Error(t, s.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, s.Shutdown(context.Background()))
+}
+
+func TestSupervisor_LogsOutputWithoutPipeline(t *testing.T) {
+	cfg := newTestConfig(t, "echo hello\nexec sleep 100\n")
+	core, logs := observer.New(zap.DebugLevel)
+
+	s := newSupervisor(cfg, zap.New(core))
+	require.NoError(t, s.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("hello").Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, s.Shutdown(context.Background()))
+}
+
+func TestSupervisor_Restart(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	cfg := newTestConfig(t, "echo run\nexit 3\n")
+	core, logs := observer.New(zap.InfoLevel)
+
+	s := newSupervisor(cfg, zap.New(core))
+	require.NoError(t, s.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("Subprocess exited, restarting it").Len() >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, s.Shutdown(context.Background()))
+
+	rows, err := view.RetrieveData(statRestarts.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, typeStr, rows[0].Tags[0].Value)
+	assert.GreaterOrEqual(t, rows[0].Data.(*view.SumData).Value, 2.0)
+
+	rows, err = view.RetrieveData(statExitCode.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 3.0, rows[0].Data.(*view.LastValueData).Value)
+}
+
+func TestSupervisor_BadExecutable(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ExecutablePath = "/does/not/exist"
+	cfg.RestartDelay = 10 * time.Millisecond
+	core, logs := observer.New(zap.InfoLevel)
+
+	s := newSupervisor(cfg, zap.New(core))
+	require.NoError(t, s.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("Subprocess exited, restarting it").Len() >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, s.Shutdown(context.Background()))
+}
+
+func TestSupervisor_ShutdownKillsProcess(t *testing.T) {
+	// The process ignores SIGTERM and its child keeps the output open.
+	cfg := newTestConfig(t, "trap '' TERM\nsleep 5\n")
+	cfg.ShutdownTimeout = 100 * time.Millisecond
+	core, logs := observer.New(zap.InfoLevel)
+
+	s := newSupervisor(cfg, zap.New(core))
+	require.NoError(t, s.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("Subprocess started").Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	start := time.Now()
+	require.NoError(t, s.Shutdown(context.Background()))
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+	assert.Equal(t, 1, logs.FilterMessage("Subprocess did not exit after SIGTERM, killing it").Len())
+	assert.Equal(t, 0, logs.FilterMessage("Subprocess exited, restarting it").Len())
+}
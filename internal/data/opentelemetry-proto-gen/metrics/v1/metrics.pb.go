@@ -323,6 +323,7 @@ type Metric struct {
 	//	*Metric_DoubleSum
 	//	*Metric_IntHistogram
 	//	*Metric_DoubleHistogram
+	//	*Metric_ExponentialHistogram
 	//	*Metric_DoubleSummary
 	Data isMetric_Data `protobuf_oneof:"data"`
 }
@@ -384,17 +385,21 @@ type Metric_IntHistogram struct {
 type Metric_DoubleHistogram struct {
 	DoubleHistogram *DoubleHistogram `protobuf:"bytes,9,opt,name=double_histogram,json=doubleHistogram,proto3,oneof" json:"double_histogram,omitempty"`
 }
+type Metric_ExponentialHistogram struct {
+	ExponentialHistogram *ExponentialHistogram `protobuf:"bytes,10,opt,name=exponential_histogram,json=exponentialHistogram,proto3,oneof" json:"exponential_histogram,omitempty"`
+}
 type Metric_DoubleSummary struct {
 	DoubleSummary *DoubleSummary `protobuf:"bytes,11,opt,name=double_summary,json=doubleSummary,proto3,oneof" json:"double_summary,omitempty"`
 }
 
-func (*Metric_IntGauge) isMetric_Data()        {}
-func (*Metric_DoubleGauge) isMetric_Data()     {}
-func (*Metric_IntSum) isMetric_Data()          {}
-func (*Metric_DoubleSum) isMetric_Data()       {}
-func (*Metric_IntHistogram) isMetric_Data()    {}
-func (*Metric_DoubleHistogram) isMetric_Data() {}
-func (*Metric_DoubleSummary) isMetric_Data()   {}
+func (*Metric_IntGauge) isMetric_Data()             {}
+func (*Metric_DoubleGauge) isMetric_Data()          {}
+func (*Metric_IntSum) isMetric_Data()               {}
+func (*Metric_DoubleSum) isMetric_Data()            {}
+func (*Metric_IntHistogram) isMetric_Data()         {}
+func (*Metric_DoubleHistogram) isMetric_Data()      {}
+func (*Metric_ExponentialHistogram) isMetric_Data() {}
+func (*Metric_DoubleSummary) isMetric_Data()        {}
 
 func (m *Metric) GetData() isMetric_Data {
 	if m != nil {
@@ -466,6 +471,13 @@ func (m *Metric) GetDoubleHistogram() *DoubleHistogram {
 	return nil
 }
 
+func (m *Metric) GetExponentialHistogram() *ExponentialHistogram {
+	if x, ok := m.GetData().(*Metric_ExponentialHistogram); ok {
+		return x.ExponentialHistogram
+	}
+	return nil
+}
+
 func (m *Metric) GetDoubleSummary() *DoubleSummary {
 	if x, ok := m.GetData().(*Metric_DoubleSummary); ok {
 		return x.DoubleSummary
@@ -482,6 +494,7 @@ func (*Metric) XXX_OneofWrappers() []interface{} {
 		(*Metric_DoubleSum)(nil),
 		(*Metric_IntHistogram)(nil),
 		(*Metric_DoubleHistogram)(nil),
+		(*Metric_ExponentialHistogram)(nil),
 		(*Metric_DoubleSummary)(nil),
 	}
 }
@@ -1713,6 +1726,261 @@ func (m *DoubleExemplar) GetValue() float64 {
 	return 0
 }
 
+// ExponentialHistogram represents the type of a metric that is calculated by aggregating
+// as an ExponentialHistogram of all reported double measurements over a time interval.
+type ExponentialHistogram struct {
+	DataPoints []*ExponentialHistogramDataPoint `protobuf:"bytes,1,rep,name=data_points,json=dataPoints,proto3" json:"data_points,omitempty"`
+	// aggregation_temporality describes if the aggregator reports delta changes
+	// since last report time, or cumulative changes since a fixed start time.
+	AggregationTemporality AggregationTemporality `protobuf:"varint,2,opt,name=aggregation_temporality,json=aggregationTemporality,proto3,enum=opentelemetry.proto.metrics.v1.AggregationTemporality" json:"aggregation_temporality,omitempty"`
+}
+
+func (m *ExponentialHistogram) Reset()         { *m = ExponentialHistogram{} }
+func (m *ExponentialHistogram) String() string { return proto.CompactTextString(m) }
+func (*ExponentialHistogram) ProtoMessage()    {}
+func (*ExponentialHistogram) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c3112f9fa006917, []int{17}
+}
+func (m *ExponentialHistogram) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExponentialHistogram) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExponentialHistogram.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExponentialHistogram) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExponentialHistogram.Merge(m, src)
+}
+func (m *ExponentialHistogram) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExponentialHistogram) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExponentialHistogram.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExponentialHistogram proto.InternalMessageInfo
+
+func (m *ExponentialHistogram) GetDataPoints() []*ExponentialHistogramDataPoint {
+	if m != nil {
+		return m.DataPoints
+	}
+	return nil
+}
+
+func (m *ExponentialHistogram) GetAggregationTemporality() AggregationTemporality {
+	if m != nil {
+		return m.AggregationTemporality
+	}
+	return AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED
+}
+
+// ExponentialHistogramDataPoint is a single data point in a timeseries that describes the
+// time-varying values of a distribution of double values. The buckets have exponentially
+// growing boundaries: for a scale s, base = 2^(2^-s) and the bucket with index i covers
+// values in (base^i, base^(i+1)].
+type ExponentialHistogramDataPoint struct {
+	// The set of labels that uniquely identify this timeseries.
+	Labels []v11.StringKeyValue `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels"`
+	// start_time_unix_nano is the last time when the aggregation value was reset
+	// to "zero". For some metric types this is ignored, see data types for more
+	// details.
+	//
+	// Value is UNIX Epoch time in nanoseconds since 00:00:00 UTC on 1 January 1970.
+	StartTimeUnixNano uint64 `protobuf:"fixed64,2,opt,name=start_time_unix_nano,json=startTimeUnixNano,proto3" json:"start_time_unix_nano,omitempty"`
+	// time_unix_nano is the moment when this aggregation value was reported.
+	//
+	// Value is UNIX Epoch time in nanoseconds since 00:00:00 UTC on 1 January 1970.
+	TimeUnixNano uint64 `protobuf:"fixed64,3,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	// count is the number of values in the population. Must be non-negative. This
+	// value must be equal to the sum of the "bucket_counts" values in the positive
+	// and negative buckets plus the "zero_count" field.
+	Count uint64 `protobuf:"fixed64,4,opt,name=count,proto3" json:"count,omitempty"`
+	// sum of the values in the population. If count is zero then this field
+	// must be zero.
+	Sum float64 `protobuf:"fixed64,5,opt,name=sum,proto3" json:"sum,omitempty"`
+	// scale describes the resolution of the histogram. Boundaries are located at
+	// powers of the base, where base = 2^(2^-scale). Larger scales have more
+	// buckets of narrower width.
+	Scale int32 `protobuf:"zigzag32,6,opt,name=scale,proto3" json:"scale,omitempty"`
+	// zero_count is the count of values that are exactly zero.
+	ZeroCount uint64 `protobuf:"fixed64,7,opt,name=zero_count,json=zeroCount,proto3" json:"zero_count,omitempty"`
+	// positive carries the positive range of exponential bucket counts.
+	Positive ExponentialHistogramDataPoint_Buckets `protobuf:"bytes,8,opt,name=positive,proto3" json:"positive"`
+	// negative carries the negative range of exponential bucket counts,
+	// indexed by the absolute value of the measurements.
+	Negative ExponentialHistogramDataPoint_Buckets `protobuf:"bytes,9,opt,name=negative,proto3" json:"negative"`
+	// (Optional) List of exemplars collected from
+	// measurements that were used to form the data point
+	Exemplars []*DoubleExemplar `protobuf:"bytes,10,rep,name=exemplars,proto3" json:"exemplars,omitempty"`
+}
+
+func (m *ExponentialHistogramDataPoint) Reset()         { *m = ExponentialHistogramDataPoint{} }
+func (m *ExponentialHistogramDataPoint) String() string { return proto.CompactTextString(m) }
+func (*ExponentialHistogramDataPoint) ProtoMessage()    {}
+func (*ExponentialHistogramDataPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c3112f9fa006917, []int{18}
+}
+func (m *ExponentialHistogramDataPoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExponentialHistogramDataPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExponentialHistogramDataPoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExponentialHistogramDataPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExponentialHistogramDataPoint.Merge(m, src)
+}
+func (m *ExponentialHistogramDataPoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExponentialHistogramDataPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExponentialHistogramDataPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExponentialHistogramDataPoint proto.InternalMessageInfo
+
+func (m *ExponentialHistogramDataPoint) GetLabels() []v11.StringKeyValue {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *ExponentialHistogramDataPoint) GetStartTimeUnixNano() uint64 {
+	if m != nil {
+		return m.StartTimeUnixNano
+	}
+	return 0
+}
+
+func (m *ExponentialHistogramDataPoint) GetTimeUnixNano() uint64 {
+	if m != nil {
+		return m.TimeUnixNano
+	}
+	return 0
+}
+
+func (m *ExponentialHistogramDataPoint) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ExponentialHistogramDataPoint) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *ExponentialHistogramDataPoint) GetScale() int32 {
+	if m != nil {
+		return m.Scale
+	}
+	return 0
+}
+
+func (m *ExponentialHistogramDataPoint) GetZeroCount() uint64 {
+	if m != nil {
+		return m.ZeroCount
+	}
+	return 0
+}
+
+func (m *ExponentialHistogramDataPoint) GetPositive() ExponentialHistogramDataPoint_Buckets {
+	if m != nil {
+		return m.Positive
+	}
+	return ExponentialHistogramDataPoint_Buckets{}
+}
+
+func (m *ExponentialHistogramDataPoint) GetNegative() ExponentialHistogramDataPoint_Buckets {
+	if m != nil {
+		return m.Negative
+	}
+	return ExponentialHistogramDataPoint_Buckets{}
+}
+
+func (m *ExponentialHistogramDataPoint) GetExemplars() []*DoubleExemplar {
+	if m != nil {
+		return m.Exemplars
+	}
+	return nil
+}
+
+// Buckets are a set of bucket counts, encoded in a contiguous array of counts.
+type ExponentialHistogramDataPoint_Buckets struct {
+	// offset is the bucket index of the first entry in the bucket_counts array.
+	Offset int32 `protobuf:"zigzag32,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// bucket_counts is an array of count values, where bucket_counts[i] carries
+	// the count of the bucket at index (offset+i).
+	BucketCounts []uint64 `protobuf:"varint,2,rep,packed,name=bucket_counts,json=bucketCounts,proto3" json:"bucket_counts,omitempty"`
+}
+
+func (m *ExponentialHistogramDataPoint_Buckets) Reset()         { *m = ExponentialHistogramDataPoint_Buckets{} }
+func (m *ExponentialHistogramDataPoint_Buckets) String() string { return proto.CompactTextString(m) }
+func (*ExponentialHistogramDataPoint_Buckets) ProtoMessage()    {}
+func (*ExponentialHistogramDataPoint_Buckets) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c3112f9fa006917, []int{18, 0}
+}
+func (m *ExponentialHistogramDataPoint_Buckets) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExponentialHistogramDataPoint_Buckets) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExponentialHistogramDataPoint_Buckets.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExponentialHistogramDataPoint_Buckets) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExponentialHistogramDataPoint_Buckets.Merge(m, src)
+}
+func (m *ExponentialHistogramDataPoint_Buckets) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExponentialHistogramDataPoint_Buckets) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExponentialHistogramDataPoint_Buckets.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExponentialHistogramDataPoint_Buckets proto.InternalMessageInfo
+
+func (m *ExponentialHistogramDataPoint_Buckets) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ExponentialHistogramDataPoint_Buckets) GetBucketCounts() []uint64 {
+	if m != nil {
+		return m.BucketCounts
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("opentelemetry.proto.metrics.v1.AggregationTemporality", AggregationTemporality_name, AggregationTemporality_value)
 	proto.RegisterType((*ResourceMetrics)(nil), "opentelemetry.proto.metrics.v1.ResourceMetrics")
@@ -1733,6 +2001,9 @@ func init() {
 	proto.RegisterType((*DoubleSummaryDataPoint_ValueAtQuantile)(nil), "opentelemetry.proto.metrics.v1.DoubleSummaryDataPoint.ValueAtQuantile")
 	proto.RegisterType((*IntExemplar)(nil), "opentelemetry.proto.metrics.v1.IntExemplar")
 	proto.RegisterType((*DoubleExemplar)(nil), "opentelemetry.proto.metrics.v1.DoubleExemplar")
+	proto.RegisterType((*ExponentialHistogram)(nil), "opentelemetry.proto.metrics.v1.ExponentialHistogram")
+	proto.RegisterType((*ExponentialHistogramDataPoint)(nil), "opentelemetry.proto.metrics.v1.ExponentialHistogramDataPoint")
+	proto.RegisterType((*ExponentialHistogramDataPoint_Buckets)(nil), "opentelemetry.proto.metrics.v1.ExponentialHistogramDataPoint.Buckets")
 }
 
 func init() {
@@ -1740,86 +2011,96 @@ func init() {
 }
 
 var fileDescriptor_3c3112f9fa006917 = []byte{
-	// 1261 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x58, 0xcf, 0x6f, 0x1b, 0xc5,
-	0x17, 0xf7, 0xda, 0x89, 0xe3, 0x3c, 0x3b, 0x76, 0xbe, 0xa3, 0x7e, 0xd3, 0x55, 0xa4, 0xba, 0xa9,
-	0x8b, 0xda, 0xd0, 0x36, 0xb6, 0x1a, 0xd4, 0x8a, 0x0b, 0x12, 0x4e, 0xe2, 0x26, 0xa6, 0x4e, 0x6a,
-	0x26, 0x4e, 0x50, 0x51, 0xd1, 0x6a, 0xe3, 0x1d, 0xdc, 0x11, 0xbb, 0x33, 0x66, 0x77, 0x36, 0x4a,
-	0xae, 0x48, 0xdc, 0x90, 0x40, 0xe2, 0x02, 0xf4, 0x1f, 0xea, 0xb1, 0x9c, 0x40, 0x48, 0x54, 0xa8,
-	0x95, 0xb8, 0x70, 0xe2, 0xce, 0x01, 0xcd, 0xec, 0x6e, 0x6c, 0x27, 0x9b, 0xd8, 0x25, 0x41, 0x4a,
-	0xcb, 0xed, 0xed, 0x9b, 0xf7, 0x3e, 0xef, 0xf3, 0x7e, 0xcc, 0xdb, 0xb5, 0xe1, 0x16, 0xef, 0x12,
-	0x26, 0x88, 0x4d, 0x1c, 0x22, 0xdc, 0xfd, 0x4a, 0xd7, 0xe5, 0x82, 0x57, 0xa4, 0x4c, 0xdb, 0x5e,
-	0x65, 0xf7, 0x76, 0x24, 0x96, 0xd5, 0x01, 0x2a, 0x0e, 0x58, 0x07, 0xca, 0x72, 0x64, 0xb2, 0x7b,
-	0x7b, 0xf6, 0x42, 0x87, 0x77, 0x78, 0x80, 0x21, 0xa5, 0xc0, 0x60, 0xf6, 0x46, 0x5c, 0x8c, 0x36,
-	0x77, 0x1c, 0xce, 0x64, 0x88, 0x40, 0x0a, 0x6d, 0xcb, 0x71, 0xb6, 0x2e, 0xf1, 0xb8, 0xef, 0xb6,
-	0x89, 0xb4, 0x8e, 0xe4, 0xc0, 0xbe, 0xf4, 0xbb, 0x06, 0x05, 0x1c, 0xaa, 0xd6, 0x03, 0x22, 0xe8,
-	0x3e, 0x64, 0x22, 0x2b, 0x5d, 0x9b, 0xd3, 0xe6, 0xb3, 0x8b, 0x6f, 0x97, 0xe3, 0x88, 0x1f, 0x40,
-	0xed, 0xde, 0x2e, 0x47, 0x18, 0x4b, 0x63, 0x4f, 0x9f, 0x5f, 0x4e, 0xe0, 0x03, 0x00, 0xf4, 0xa5,
-	0x06, 0x97, 0x29, 0xf3, 0x84, 0xeb, 0x3b, 0x84, 0x09, 0x53, 0x50, 0xce, 0x0c, 0x9b, 0xee, 0xb8,
-	0xa6, 0xbb, 0x6f, 0x84, 0x99, 0xeb, 0xc9, 0xb9, 0xd4, 0x7c, 0x76, 0xf1, 0xbd, 0xf2, 0xc9, 0xd5,
-	0x29, 0xd7, 0x07, 0x61, 0x1a, 0x01, 0x4a, 0xc8, 0x1a, 0x5f, 0xa2, 0x27, 0x1d, 0x97, 0x7e, 0xd4,
-	0xe0, 0xd2, 0x89, 0x00, 0x88, 0xc1, 0xc5, 0x63, 0x88, 0x86, 0x55, 0xb8, 0x13, 0x4b, 0x30, 0x2c,
-	0xff, 0xb1, 0xfc, 0xf0, 0x4c, 0x3c, 0x31, 0xf4, 0x3e, 0x4c, 0x0c, 0x16, 0xe0, 0xda, 0xb0, 0x02,
-	0x04, 0x4c, 0x71, 0xe4, 0x56, 0xfa, 0x7a, 0x1c, 0xd2, 0x81, 0x0e, 0x21, 0x18, 0x63, 0xa6, 0x13,
-	0xf4, 0x6b, 0x12, 0x2b, 0x19, 0xcd, 0x41, 0xd6, 0x22, 0x5e, 0xdb, 0xa5, 0x5d, 0x19, 0x56, 0x4f,
-	0xaa, 0xa3, 0x7e, 0x95, 0xf4, 0xf2, 0x19, 0x15, 0x7a, 0x2a, 0xf0, 0x92, 0x32, 0x5a, 0x85, 0x49,
-	0xca, 0x84, 0xd1, 0x31, 0xfd, 0x0e, 0xd1, 0xc7, 0x54, 0xe2, 0xf3, 0xc3, 0x3b, 0x23, 0x56, 0xa5,
-	0xfd, 0x5a, 0x02, 0x67, 0x68, 0x28, 0xa3, 0x26, 0xe4, 0x2c, 0xee, 0xef, 0xd8, 0x24, 0xc4, 0x1a,
-	0x57, 0x58, 0x37, 0x87, 0x61, 0xad, 0x28, 0x9f, 0x08, 0x2e, 0x6b, 0xf5, 0x1e, 0x51, 0x15, 0x26,
-	0x24, 0x35, 0xcf, 0x77, 0xf4, 0xb4, 0x02, 0xbb, 0x36, 0x02, 0xb1, 0x4d, 0xdf, 0x59, 0x4b, 0xe0,
-	0x34, 0x55, 0x12, 0xfa, 0x00, 0x20, 0x24, 0x25, 0x51, 0x26, 0x4e, 0x98, 0xee, 0x23, 0x94, 0x02,
-	0xa0, 0x49, 0x2b, 0x7a, 0x40, 0x9b, 0x30, 0x25, 0xe9, 0x3c, 0xa6, 0x9e, 0xe0, 0x1d, 0xd7, 0x74,
-	0xf4, 0x8c, 0x82, 0xbb, 0x35, 0x02, 0xa9, 0xb5, 0xc8, 0x67, 0x2d, 0x81, 0x73, 0xb4, 0xef, 0x19,
-	0x3d, 0x82, 0xe9, 0x90, 0x60, 0x0f, 0x77, 0x52, 0xe1, 0x56, 0x46, 0xa3, 0xd9, 0x0f, 0x5d, 0xb0,
-	0x06, 0x55, 0x68, 0x1b, 0xf2, 0xbd, 0xf4, 0x1d, 0x39, 0xda, 0x59, 0x85, 0xbd, 0x30, 0x72, 0x09,
-	0xa4, 0xd3, 0x5a, 0x02, 0x4f, 0x59, 0xfd, 0x8a, 0xa5, 0x34, 0x8c, 0x59, 0xa6, 0x30, 0x4b, 0x0f,
-	0x21, 0x13, 0xcd, 0x02, 0x5a, 0x87, 0xac, 0xd4, 0x19, 0x5d, 0x4e, 0x99, 0xf0, 0x74, 0x4d, 0xcd,
-	0xf8, 0x28, 0xc5, 0x59, 0x31, 0x85, 0xd9, 0x94, 0x4e, 0x18, 0xac, 0x48, 0xf4, 0x4a, 0x06, 0x64,
-	0xfb, 0x46, 0x03, 0x35, 0xe3, 0xd0, 0x47, 0x2c, 0x51, 0x7c, 0x80, 0x3f, 0x34, 0x48, 0x07, 0xf3,
-	0x72, 0xc6, 0xd4, 0x11, 0x87, 0x8b, 0x66, 0xa7, 0xe3, 0x92, 0x4e, 0xb0, 0x55, 0x04, 0x71, 0xba,
-	0xdc, 0x35, 0x6d, 0x2a, 0xf6, 0xd5, 0xa5, 0xcc, 0x2f, 0xde, 0x1d, 0x06, 0x5d, 0xed, 0xb9, 0xb7,
-	0x7a, 0xde, 0x78, 0xc6, 0x8c, 0xd5, 0xa3, 0x2b, 0x90, 0xa3, 0x9e, 0xe1, 0x70, 0xc6, 0x05, 0x67,
-	0xb4, 0xad, 0xee, 0x77, 0x06, 0x67, 0xa9, 0xb7, 0x1e, 0xa9, 0x4a, 0x7f, 0x6a, 0x30, 0x79, 0xd0,
-	0xd4, 0xb3, 0xaf, 0xe6, 0xb9, 0xcc, 0xf9, 0x27, 0x0d, 0x72, 0xfd, 0x97, 0x0f, 0x6d, 0xc7, 0xa5,
-	0x7d, 0xe7, 0x55, 0xee, 0xef, 0xf9, 0x48, 0xbe, 0xf4, 0xab, 0x06, 0x85, 0x43, 0xd7, 0x1f, 0x3d,
-	0x8c, 0x4b, 0xee, 0xdd, 0x57, 0x5c, 0x22, 0xe7, 0x24, 0xbf, 0xc7, 0x30, 0x35, 0xb0, 0x81, 0xd0,
-	0x47, 0x71, 0xc9, 0xdd, 0x7d, 0xa5, 0x2d, 0x16, 0xbf, 0x05, 0xbe, 0x4d, 0xaa, 0x19, 0x39, 0x38,
-	0x44, 0xf7, 0x21, 0x6d, 0x9b, 0x3b, 0xc4, 0x8e, 0x82, 0x2c, 0x0c, 0xf9, 0x0a, 0xd8, 0x14, 0x2e,
-	0x65, 0x9d, 0xfb, 0x64, 0x7f, 0xdb, 0xb4, 0xfd, 0xe8, 0x7b, 0x28, 0x84, 0x40, 0x15, 0xb8, 0xe0,
-	0x09, 0xd3, 0x15, 0x86, 0xa0, 0x0e, 0x31, 0x7c, 0x46, 0xf7, 0x0c, 0x66, 0x32, 0xae, 0xaa, 0x96,
-	0xc6, 0xff, 0x53, 0x67, 0x2d, 0xea, 0x90, 0x2d, 0x46, 0xf7, 0x36, 0x4c, 0xc6, 0xd1, 0x5b, 0x90,
-	0x3f, 0x64, 0x9a, 0x52, 0xa6, 0x39, 0xd1, 0x6f, 0x75, 0x01, 0xc6, 0x77, 0x65, 0x34, 0xf5, 0xbe,
-	0x9e, 0xc6, 0xc1, 0x03, 0xaa, 0xc3, 0x24, 0xd9, 0x23, 0x4e, 0xd7, 0x36, 0x5d, 0x4f, 0x1f, 0x57,
-	0xe4, 0x6f, 0x8e, 0x30, 0xdb, 0xb5, 0xd0, 0x07, 0xf7, 0xbc, 0x4b, 0xdf, 0x27, 0xa3, 0xf9, 0x7a,
-	0x8d, 0x0b, 0xa3, 0x45, 0x85, 0x69, 0x1c, 0x2d, 0x4c, 0x79, 0xb4, 0xd1, 0x89, 0xab, 0xcd, 0x5f,
-	0x49, 0xf8, 0x7f, 0xec, 0x4a, 0x78, 0x5d, 0x2a, 0xd4, 0xe6, 0x3e, 0x13, 0xaa, 0x42, 0x69, 0x1c,
-	0x3c, 0xa0, 0x69, 0x48, 0xc9, 0xef, 0xa3, 0x71, 0x35, 0x4e, 0x52, 0x44, 0x57, 0x61, 0x6a, 0xc7,
-	0x6f, 0x7f, 0x46, 0x84, 0xa1, 0x2c, 0x3c, 0x3d, 0x3d, 0x97, 0x92, 0x60, 0x81, 0x72, 0x59, 0xe9,
-	0xd0, 0x75, 0x28, 0x90, 0xbd, 0xae, 0x4d, 0xdb, 0x54, 0x18, 0x3b, 0xdc, 0x67, 0x96, 0xa7, 0x4f,
-	0xcc, 0xa5, 0xe6, 0x35, 0x9c, 0x8f, 0xd4, 0x4b, 0x4a, 0x3b, 0x38, 0x9a, 0x99, 0x53, 0x8d, 0xe6,
-	0x17, 0x29, 0xd0, 0x8f, 0x5b, 0x5a, 0x6f, 0x46, 0x07, 0xb4, 0x7f, 0xa3, 0x03, 0x8d, 0xa3, 0x1d,
-	0x38, 0xc5, 0x1d, 0xf8, 0x21, 0x05, 0x33, 0xf1, 0xcb, 0xf5, 0x8d, 0x6a, 0x01, 0x87, 0xc2, 0xe7,
-	0xbe, 0xc9, 0x04, 0xb5, 0x89, 0xa1, 0x56, 0x49, 0xd0, 0x84, 0xec, 0xe2, 0xbd, 0x7f, 0xf6, 0xe6,
-	0x29, 0xab, 0x1c, 0xab, 0xe2, 0xc3, 0x10, 0x14, 0xe7, 0x23, 0x78, 0x75, 0xe0, 0xcd, 0x2e, 0x43,
-	0xe1, 0x90, 0x09, 0x9a, 0x85, 0x4c, 0x64, 0xa4, 0x7e, 0xed, 0x69, 0xf8, 0xe0, 0xb9, 0xb7, 0xee,
-	0x92, 0x7d, 0xeb, 0xae, 0xf4, 0x5d, 0x0a, 0xb2, 0x7d, 0x97, 0x07, 0x3d, 0x82, 0xc2, 0xa7, 0xd4,
-	0x16, 0xc4, 0x25, 0x96, 0x71, 0xfa, 0xd6, 0xe4, 0x23, 0xac, 0x46, 0xd0, 0xa2, 0xa3, 0x15, 0x4f,
-	0x9e, 0xb4, 0x98, 0x53, 0xfd, 0x6f, 0x2c, 0x1f, 0x26, 0xbc, 0xae, 0xc9, 0x0c, 0x6a, 0xa9, 0x4e,
-	0xe4, 0x96, 0x1e, 0xc9, 0x10, 0xbf, 0x3c, 0xbf, 0xdc, 0xea, 0xf0, 0x43, 0xdc, 0x28, 0xaf, 0xb4,
-	0xb9, 0x6d, 0x93, 0xb6, 0xe0, 0x6e, 0x85, 0x32, 0x41, 0x5c, 0x66, 0xda, 0x15, 0xf9, 0x22, 0xaf,
-	0x0c, 0x18, 0x2e, 0xa8, 0x24, 0x16, 0x3a, 0x84, 0xf5, 0xfe, 0x32, 0x29, 0x6f, 0x76, 0x4d, 0x56,
-	0x5f, 0xc1, 0x69, 0x19, 0xac, 0x6e, 0xa1, 0x3d, 0xc8, 0x08, 0xd7, 0x6c, 0x13, 0x19, 0x77, 0x5c,
-	0xc5, 0xfd, 0x24, 0x8c, 0xbb, 0x75, 0xb6, 0x71, 0x5b, 0x32, 0x4a, 0x7d, 0x05, 0x4f, 0xa8, 0x70,
-	0x75, 0xab, 0xf4, 0x24, 0x05, 0xf9, 0xc1, 0x5b, 0x75, 0xfe, 0xba, 0xa3, 0xfd, 0x57, 0xbb, 0x73,
-	0xe3, 0x2b, 0x0d, 0x66, 0xe2, 0x3f, 0x54, 0xd1, 0x75, 0xb8, 0x5a, 0x5d, 0x5d, 0xc5, 0xb5, 0xd5,
-	0x6a, 0xab, 0xfe, 0x60, 0xc3, 0x68, 0xd5, 0xd6, 0x9b, 0x0f, 0x70, 0xb5, 0x51, 0x6f, 0x3d, 0x34,
-	0xb6, 0x36, 0x36, 0x9b, 0xb5, 0xe5, 0xfa, 0xbd, 0x7a, 0x6d, 0x65, 0x3a, 0x81, 0xae, 0xc0, 0xa5,
-	0xe3, 0x0c, 0x57, 0x6a, 0x8d, 0x56, 0x75, 0x5a, 0x43, 0xd7, 0xa0, 0x74, 0x9c, 0xc9, 0xf2, 0xd6,
-	0xfa, 0x56, 0xa3, 0xda, 0xaa, 0x6f, 0xd7, 0xa6, 0x93, 0x4b, 0x4f, 0xb4, 0xa7, 0x2f, 0x8a, 0xda,
-	0xb3, 0x17, 0x45, 0xed, 0xb7, 0x17, 0x45, 0xed, 0x9b, 0x97, 0xc5, 0xc4, 0xb3, 0x97, 0xc5, 0xc4,
-	0xcf, 0x2f, 0x8b, 0x09, 0xb8, 0x42, 0xf9, 0x90, 0x0d, 0xb4, 0x94, 0x0b, 0xff, 0xe7, 0x6a, 0xca,
-	0x83, 0xa6, 0xf6, 0xf1, 0xc6, 0x59, 0x14, 0xaf, 0xf7, 0x4f, 0xe7, 0x4e, 0x5a, 0x69, 0xdf, 0xf9,
-	0x3b, 0x00, 0x00, 0xff, 0xff, 0x82, 0x12, 0xf4, 0x2c, 0x12, 0x15, 0x00, 0x00,
+	// 1421 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x59, 0x41, 0x6f, 0x1b, 0x45,
+	0x14, 0xf6, 0xda, 0xb1, 0x63, 0x3f, 0x3b, 0x76, 0x3a, 0x4a, 0x53, 0x2b, 0x52, 0x5c, 0xd7, 0x45,
+	0x6d, 0x28, 0x8d, 0xad, 0x06, 0x5a, 0x71, 0xa9, 0x84, 0x9d, 0xb8, 0x89, 0xa9, 0x93, 0x9a, 0x8d,
+	0x13, 0x54, 0x54, 0x58, 0x6d, 0xec, 0xa9, 0x3b, 0xea, 0xee, 0x8c, 0xd9, 0x9d, 0x8d, 0x12, 0x8e,
+	0x48, 0x48, 0x1c, 0x38, 0x20, 0x71, 0x01, 0xfa, 0x87, 0x7a, 0x2c, 0x27, 0x10, 0x12, 0x15, 0x6a,
+	0x25, 0x38, 0x70, 0xe2, 0xde, 0x03, 0x9a, 0xd9, 0xdd, 0xd8, 0x4e, 0x36, 0xb1, 0xdb, 0xa4, 0x52,
+	0x5a, 0x6e, 0x33, 0x6f, 0xde, 0xfb, 0xbe, 0x37, 0xef, 0xbd, 0x7d, 0x33, 0x1e, 0xc3, 0x55, 0xd6,
+	0xc5, 0x94, 0x63, 0x03, 0x9b, 0x98, 0x5b, 0xbb, 0xa5, 0xae, 0xc5, 0x38, 0x2b, 0x89, 0x31, 0x69,
+	0xd9, 0xa5, 0xed, 0x6b, 0xfe, 0xb0, 0x28, 0x17, 0x50, 0x6e, 0x40, 0xdb, 0x15, 0x16, 0x7d, 0x95,
+	0xed, 0x6b, 0x33, 0x53, 0x1d, 0xd6, 0x61, 0x2e, 0x86, 0x18, 0xb9, 0x0a, 0x33, 0x57, 0x82, 0x38,
+	0x5a, 0xcc, 0x34, 0x19, 0x15, 0x14, 0xee, 0xc8, 0xd3, 0x2d, 0x06, 0xe9, 0x5a, 0xd8, 0x66, 0x8e,
+	0xd5, 0xc2, 0x42, 0xdb, 0x1f, 0xbb, 0xfa, 0x85, 0xbf, 0x14, 0xc8, 0xa8, 0x9e, 0x68, 0xd5, 0x75,
+	0x04, 0xdd, 0x86, 0xb8, 0xaf, 0x95, 0x55, 0xf2, 0xca, 0x5c, 0x72, 0xe1, 0xdd, 0x62, 0x90, 0xe3,
+	0x7b, 0x50, 0xdb, 0xd7, 0x8a, 0x3e, 0x46, 0x65, 0xec, 0xf1, 0xd3, 0xf3, 0x21, 0x75, 0x0f, 0x00,
+	0x7d, 0xa3, 0xc0, 0x79, 0x42, 0x6d, 0x6e, 0x39, 0x26, 0xa6, 0x5c, 0xe7, 0x84, 0x51, 0xcd, 0x20,
+	0x5b, 0x96, 0x6e, 0xed, 0x6a, 0xde, 0xce, 0xb3, 0xe1, 0x7c, 0x64, 0x2e, 0xb9, 0x70, 0xb3, 0x78,
+	0x74, 0x74, 0x8a, 0xb5, 0x41, 0x98, 0xba, 0x8b, 0xe2, 0x79, 0xad, 0xce, 0x92, 0xa3, 0x96, 0x0b,
+	0xbf, 0x28, 0x30, 0x7b, 0x24, 0x00, 0xa2, 0x70, 0xee, 0x10, 0x47, 0xbd, 0x28, 0x5c, 0x0f, 0x74,
+	0xd0, 0x0b, 0xff, 0xa1, 0xfe, 0xa9, 0xd3, 0xc1, 0x8e, 0xa1, 0x8f, 0x60, 0x7c, 0x30, 0x00, 0x97,
+	0x86, 0x05, 0xc0, 0xf5, 0x54, 0xf5, 0xcd, 0x0a, 0x2f, 0xa2, 0x10, 0x73, 0x65, 0x08, 0xc1, 0x18,
+	0xd5, 0x4d, 0x37, 0x5f, 0x09, 0x55, 0x8e, 0x51, 0x1e, 0x92, 0x6d, 0x6c, 0xb7, 0x2c, 0xd2, 0x15,
+	0xb4, 0xd9, 0xb0, 0x5c, 0xea, 0x17, 0x09, 0x2b, 0x87, 0x12, 0x9e, 0x8d, 0xb8, 0x56, 0x62, 0x8c,
+	0x96, 0x21, 0x41, 0x28, 0xd7, 0x3a, 0xba, 0xd3, 0xc1, 0xd9, 0x31, 0xb9, 0xf1, 0xb9, 0xe1, 0x99,
+	0xe1, 0xcb, 0x42, 0x7f, 0x25, 0xa4, 0xc6, 0x89, 0x37, 0x46, 0x0d, 0x48, 0xb5, 0x99, 0xb3, 0x65,
+	0x60, 0x0f, 0x2b, 0x2a, 0xb1, 0xde, 0x1b, 0x86, 0xb5, 0x24, 0x6d, 0x7c, 0xb8, 0x64, 0xbb, 0x37,
+	0x45, 0x65, 0x18, 0x17, 0xae, 0xd9, 0x8e, 0x99, 0x8d, 0xe5, 0x95, 0x51, 0x22, 0x56, 0xa3, 0x7c,
+	0xdd, 0x31, 0x57, 0x42, 0x6a, 0x8c, 0xc8, 0x11, 0xfa, 0x18, 0xc0, 0x73, 0x4a, 0xa0, 0x8c, 0x1f,
+	0x51, 0xdd, 0x07, 0x5c, 0x72, 0x81, 0x12, 0x6d, 0x7f, 0x82, 0xd6, 0x61, 0x42, 0xb8, 0xf3, 0x80,
+	0xd8, 0x9c, 0x75, 0x2c, 0xdd, 0xcc, 0xc6, 0x25, 0xdc, 0xd5, 0x11, 0x9c, 0x5a, 0xf1, 0x6d, 0x56,
+	0x42, 0x6a, 0x8a, 0xf4, 0xcd, 0xd1, 0x3d, 0x98, 0xf4, 0x1c, 0xec, 0xe1, 0x26, 0x24, 0x6e, 0x69,
+	0x34, 0x37, 0xfb, 0xa1, 0x33, 0xed, 0x41, 0x11, 0x7a, 0x08, 0x67, 0xf1, 0x4e, 0x97, 0x51, 0x4c,
+	0x39, 0xd1, 0x8d, 0x3e, 0x0a, 0x90, 0x14, 0x1f, 0x0c, 0xa3, 0xa8, 0xf6, 0x8c, 0xfb, 0x79, 0xa6,
+	0x70, 0x80, 0x1c, 0x6d, 0x42, 0xba, 0x17, 0x6b, 0x53, 0x7c, 0x47, 0x49, 0xc9, 0x32, 0x3f, 0x72,
+	0xbc, 0x85, 0xd1, 0x4a, 0x48, 0x9d, 0x68, 0xf7, 0x0b, 0x2a, 0x31, 0x18, 0x6b, 0xeb, 0x5c, 0x2f,
+	0xdc, 0x85, 0xb8, 0x5f, 0x78, 0x68, 0x15, 0x92, 0x42, 0xa6, 0x75, 0x19, 0xa1, 0xdc, 0xce, 0x2a,
+	0xf9, 0xc8, 0x88, 0x99, 0x58, 0xd2, 0xb9, 0xde, 0x10, 0x46, 0x2a, 0xb4, 0xfd, 0xa1, 0x5d, 0xd0,
+	0x20, 0xd9, 0x57, 0x87, 0xa8, 0x11, 0x84, 0x3e, 0x62, 0x3e, 0x82, 0x09, 0xfe, 0x51, 0x20, 0xe6,
+	0x16, 0xe7, 0x09, 0xbb, 0x8e, 0x18, 0x9c, 0xd3, 0x3b, 0x1d, 0x0b, 0x77, 0xdc, 0x16, 0xc6, 0xb1,
+	0xd9, 0x65, 0x96, 0x6e, 0x10, 0xbe, 0x2b, 0x3b, 0x40, 0x7a, 0xe1, 0xc6, 0x30, 0xe8, 0x72, 0xcf,
+	0xbc, 0xd9, 0xb3, 0x56, 0xa7, 0xf5, 0x40, 0x39, 0xba, 0x00, 0x29, 0x62, 0x6b, 0x26, 0xa3, 0x8c,
+	0x33, 0x4a, 0x5a, 0xb2, 0x99, 0xc4, 0xd5, 0x24, 0xb1, 0x57, 0x7d, 0x51, 0xe1, 0x5f, 0x05, 0x12,
+	0x7b, 0x49, 0x3d, 0xf9, 0x68, 0x9e, 0xca, 0x3d, 0xff, 0xaa, 0x40, 0xaa, 0xff, 0x4b, 0x47, 0x9b,
+	0x41, 0xdb, 0xbe, 0xfe, 0x32, 0xcd, 0xe2, 0x74, 0x6c, 0xbe, 0xf0, 0x87, 0x02, 0x99, 0x7d, 0xbd,
+	0x06, 0xdd, 0x0d, 0xda, 0xdc, 0x87, 0x2f, 0xd9, 0xb1, 0x4e, 0xc9, 0xfe, 0x1e, 0xc0, 0xc4, 0x40,
+	0x07, 0x42, 0x9f, 0x06, 0x6d, 0xee, 0xc6, 0x4b, 0x75, 0xb1, 0xe0, 0x2e, 0xf0, 0x43, 0x58, 0xd6,
+	0xc8, 0xde, 0x22, 0xba, 0x0d, 0x31, 0x43, 0xdf, 0xc2, 0x86, 0x4f, 0x32, 0x3f, 0xe4, 0xca, 0xb1,
+	0xce, 0x2d, 0x42, 0x3b, 0xb7, 0xf1, 0xee, 0xa6, 0x6e, 0x38, 0xfe, 0xe5, 0xcb, 0x83, 0x40, 0x25,
+	0x98, 0xb2, 0xb9, 0x6e, 0x71, 0x8d, 0x13, 0x13, 0x6b, 0x0e, 0x25, 0x3b, 0x1a, 0xd5, 0x29, 0x93,
+	0x51, 0x8b, 0xa9, 0x67, 0xe4, 0x5a, 0x93, 0x98, 0x78, 0x83, 0x92, 0x9d, 0x35, 0x9d, 0x32, 0xf4,
+	0x0e, 0xa4, 0xf7, 0xa9, 0x46, 0xa4, 0x6a, 0x8a, 0xf7, 0x6b, 0x4d, 0x41, 0x74, 0x5b, 0xb0, 0xc9,
+	0xcb, 0xc1, 0xa4, 0xea, 0x4e, 0x50, 0x0d, 0x12, 0x78, 0x07, 0x9b, 0x5d, 0x43, 0xb7, 0xec, 0x6c,
+	0x34, 0x1f, 0x19, 0xe5, 0xa8, 0xaf, 0x51, 0x5e, 0xf5, 0x6c, 0xd4, 0x9e, 0x75, 0xe1, 0xa7, 0xb0,
+	0x5f, 0x5f, 0x6f, 0x70, 0x60, 0x14, 0x3f, 0x30, 0xf5, 0x83, 0x81, 0x29, 0x8e, 0x56, 0x3a, 0x41,
+	0xb1, 0x79, 0x11, 0x86, 0xb3, 0x81, 0x2d, 0xe1, 0x4d, 0x89, 0x50, 0x8b, 0x39, 0x94, 0xcb, 0x08,
+	0xc5, 0x54, 0x77, 0x82, 0x26, 0x21, 0x22, 0x2e, 0x63, 0x51, 0x59, 0x4e, 0x62, 0x88, 0x2e, 0xc2,
+	0xc4, 0x96, 0xd3, 0x7a, 0x88, 0xb9, 0x26, 0x35, 0xec, 0x6c, 0x2c, 0x1f, 0x11, 0x60, 0xae, 0x70,
+	0x51, 0xca, 0xd0, 0x65, 0xc8, 0xe0, 0x9d, 0xae, 0x41, 0x5a, 0x84, 0x6b, 0x5b, 0xcc, 0xa1, 0x6d,
+	0x3b, 0x3b, 0x9e, 0x8f, 0xcc, 0x29, 0x6a, 0xda, 0x17, 0x57, 0xa4, 0x74, 0xb0, 0x34, 0xe3, 0xc7,
+	0x2a, 0xcd, 0xaf, 0x23, 0x90, 0x3d, 0xac, 0x69, 0xbd, 0x1d, 0x19, 0x50, 0x5e, 0x47, 0x06, 0xea,
+	0x07, 0x33, 0x70, 0x8c, 0x6f, 0xe0, 0xe7, 0x08, 0x4c, 0x07, 0x37, 0xd7, 0xb7, 0x2a, 0x05, 0x0c,
+	0x32, 0x5f, 0x3a, 0x3a, 0xe5, 0xc4, 0xc0, 0x9a, 0x6c, 0x25, 0x6e, 0x12, 0x92, 0x0b, 0xb7, 0x5e,
+	0xed, 0xe4, 0x29, 0xca, 0x3d, 0x96, 0xf9, 0x27, 0x1e, 0xa8, 0x9a, 0xf6, 0xe1, 0xe5, 0x82, 0x3d,
+	0xb3, 0x08, 0x99, 0x7d, 0x2a, 0x68, 0x06, 0xe2, 0xbe, 0x92, 0xfc, 0x69, 0xa9, 0xa8, 0x7b, 0xf3,
+	0x5e, 0xbb, 0x0b, 0xf7, 0xb5, 0xbb, 0xc2, 0x8f, 0x11, 0x48, 0xf6, 0x7d, 0x3c, 0xe8, 0x1e, 0x64,
+	0xee, 0x13, 0x83, 0x63, 0x0b, 0xb7, 0xb5, 0xe3, 0xa7, 0x26, 0xed, 0x63, 0xd5, 0xdd, 0x14, 0x1d,
+	0x8c, 0x78, 0xf8, 0xa8, 0xc6, 0x1c, 0xe9, 0x3f, 0xb1, 0x1c, 0x18, 0xb7, 0xbb, 0x3a, 0xd5, 0x48,
+	0x5b, 0x66, 0x22, 0x55, 0xb9, 0x27, 0x28, 0x7e, 0x7f, 0x7a, 0xbe, 0xd9, 0x61, 0xfb, 0x7c, 0x23,
+	0xe2, 0xbd, 0xc5, 0x30, 0x70, 0x8b, 0x33, 0xab, 0x44, 0x28, 0xc7, 0x16, 0xd5, 0x8d, 0x92, 0x38,
+	0xc8, 0x4b, 0x03, 0x8a, 0xf3, 0x72, 0x13, 0xf3, 0x1d, 0x4c, 0x7b, 0xef, 0x33, 0xc5, 0xf5, 0xae,
+	0x4e, 0x6b, 0x4b, 0x6a, 0x4c, 0x90, 0xd5, 0xda, 0x68, 0x07, 0xe2, 0xdc, 0xd2, 0x5b, 0x58, 0xf0,
+	0x46, 0x25, 0xef, 0xe7, 0x1e, 0xef, 0xc6, 0xc9, 0xf2, 0x36, 0x05, 0x4b, 0x6d, 0x49, 0x1d, 0x97,
+	0x74, 0xb5, 0x76, 0xe1, 0x51, 0x04, 0xd2, 0x83, 0x5f, 0xd5, 0xe9, 0xcb, 0x8e, 0xf2, 0xbf, 0xcd,
+	0xce, 0xdf, 0x0a, 0x4c, 0x05, 0xfd, 0xbc, 0x46, 0x5f, 0x04, 0xdd, 0x3e, 0x6f, 0xbe, 0xca, 0x2f,
+	0xf5, 0x53, 0x72, 0xbf, 0xfe, 0x36, 0x0a, 0xb3, 0x47, 0xba, 0xf7, 0x56, 0xb5, 0xf1, 0x29, 0x88,
+	0xda, 0x2d, 0xdd, 0xc0, 0xf2, 0xc9, 0xea, 0x8c, 0xea, 0x4e, 0xd0, 0x2c, 0xc0, 0x57, 0xd8, 0x62,
+	0xee, 0xe9, 0x2a, 0xdf, 0xa1, 0x62, 0x6a, 0x42, 0x48, 0xe4, 0xd1, 0x8a, 0x3a, 0x10, 0xef, 0x32,
+	0x9b, 0x70, 0xb2, 0x8d, 0xbd, 0x57, 0xa5, 0xea, 0xb1, 0x12, 0x5e, 0xac, 0xc8, 0x73, 0xdb, 0xf6,
+	0x9f, 0x67, 0x7d, 0x70, 0x41, 0x44, 0x65, 0x8a, 0xb6, 0x71, 0x36, 0xf1, 0x1a, 0x88, 0x7c, 0xf0,
+	0xc1, 0x2b, 0x00, 0x1c, 0xf3, 0x0a, 0x30, 0x73, 0x0b, 0xc6, 0x3d, 0x22, 0x34, 0x0d, 0x31, 0x76,
+	0xff, 0xbe, 0x8d, 0xb9, 0x3c, 0xa0, 0xce, 0xa8, 0xde, 0xec, 0xe0, 0x0d, 0x46, 0x3c, 0xb2, 0x8e,
+	0x0d, 0xde, 0x60, 0xae, 0x7c, 0xa7, 0xc0, 0x74, 0x70, 0xf5, 0xa2, 0xcb, 0x70, 0xb1, 0xbc, 0xbc,
+	0xac, 0x56, 0x97, 0xcb, 0xcd, 0xda, 0x9d, 0x35, 0xad, 0x59, 0x5d, 0x6d, 0xdc, 0x51, 0xcb, 0xf5,
+	0x5a, 0xf3, 0xae, 0xb6, 0xb1, 0xb6, 0xde, 0xa8, 0x2e, 0xd6, 0x6e, 0xd5, 0xaa, 0x4b, 0x93, 0x21,
+	0x74, 0x01, 0x66, 0x0f, 0x53, 0x5c, 0xaa, 0xd6, 0x9b, 0xe5, 0x49, 0x05, 0x5d, 0x82, 0xc2, 0x61,
+	0x2a, 0x8b, 0x1b, 0xab, 0x1b, 0xf5, 0x72, 0xb3, 0xb6, 0x59, 0x9d, 0x0c, 0x57, 0x1e, 0x29, 0x8f,
+	0x9f, 0xe5, 0x94, 0x27, 0xcf, 0x72, 0xca, 0x9f, 0xcf, 0x72, 0xca, 0xf7, 0xcf, 0x73, 0xa1, 0x27,
+	0xcf, 0x73, 0xa1, 0xdf, 0x9e, 0xe7, 0x42, 0x70, 0x81, 0xb0, 0x21, 0xe1, 0xaa, 0xa4, 0xbc, 0x97,
+	0xec, 0x86, 0x58, 0x68, 0x28, 0x9f, 0xad, 0x9d, 0x44, 0xc7, 0xea, 0xfd, 0x97, 0xb1, 0x15, 0x93,
+	0xd2, 0xf7, 0xff, 0x1b, 0x00, 0x98, 0xfa, 0x93, 0x8e, 0xf4, 0x18, 0x00, 0x00,
 }
 
 func (m *ResourceMetrics) Marshal() (dAtA []byte, err error) {
@@ -2097,6 +2378,27 @@ func (m *Metric_DoubleHistogram) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Metric_ExponentialHistogram) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Metric_ExponentialHistogram) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ExponentialHistogram != nil {
+		{
+			size, err := m.ExponentialHistogram.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintMetrics(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
 func (m *Metric_DoubleSummary) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
@@ -2591,9 +2893,9 @@ func (m *IntHistogramDataPoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	if len(m.ExplicitBounds) > 0 {
 		for iNdEx := len(m.ExplicitBounds) - 1; iNdEx >= 0; iNdEx-- {
-			f10 := math.Float64bits(float64(m.ExplicitBounds[iNdEx]))
+			f11 := math.Float64bits(float64(m.ExplicitBounds[iNdEx]))
 			i -= 8
-			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f10))
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f11))
 		}
 		i = encodeVarintMetrics(dAtA, i, uint64(len(m.ExplicitBounds)*8))
 		i--
@@ -2685,9 +2987,9 @@ func (m *DoubleHistogramDataPoint) MarshalToSizedBuffer(dAtA []byte) (int, error
 	}
 	if len(m.ExplicitBounds) > 0 {
 		for iNdEx := len(m.ExplicitBounds) - 1; iNdEx >= 0; iNdEx-- {
-			f11 := math.Float64bits(float64(m.ExplicitBounds[iNdEx]))
+			f12 := math.Float64bits(float64(m.ExplicitBounds[iNdEx]))
 			i -= 8
-			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f11))
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f12))
 		}
 		i = encodeVarintMetrics(dAtA, i, uint64(len(m.ExplicitBounds)*8))
 		i--
@@ -2991,60 +3293,254 @@ func (m *DoubleExemplar) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintMetrics(dAtA []byte, offset int, v uint64) int {
-	offset -= sovMetrics(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ExponentialHistogram) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *ResourceMetrics) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Resource.Size()
-	n += 1 + l + sovMetrics(uint64(l))
-	if len(m.InstrumentationLibraryMetrics) > 0 {
-		for _, e := range m.InstrumentationLibraryMetrics {
-			l = e.Size()
-			n += 1 + l + sovMetrics(uint64(l))
-		}
-	}
-	return n
+
+func (m *ExponentialHistogram) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *InstrumentationLibraryMetrics) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ExponentialHistogram) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.InstrumentationLibrary != nil {
-		l = m.InstrumentationLibrary.Size()
-		n += 1 + l + sovMetrics(uint64(l))
+	if m.AggregationTemporality != 0 {
+		i = encodeVarintMetrics(dAtA, i, uint64(m.AggregationTemporality))
+		i--
+		dAtA[i] = 0x10
 	}
-	if len(m.Metrics) > 0 {
-		for _, e := range m.Metrics {
-			l = e.Size()
-			n += 1 + l + sovMetrics(uint64(l))
+	if len(m.DataPoints) > 0 {
+		for iNdEx := len(m.DataPoints) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DataPoints[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintMetrics(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Metric) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Name)
+func (m *ExponentialHistogramDataPoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExponentialHistogramDataPoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExponentialHistogramDataPoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Exemplars) > 0 {
+		for iNdEx := len(m.Exemplars) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Exemplars[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintMetrics(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	{
+		size, err := m.Negative.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintMetrics(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x4a
+	{
+		size, err := m.Positive.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintMetrics(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x42
+	if m.ZeroCount != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.ZeroCount))
+		i--
+		dAtA[i] = 0x39
+	}
+	if m.Scale != 0 {
+		i = encodeVarintMetrics(dAtA, i, uint64((uint32(m.Scale)<<1)^uint32((m.Scale>>31))))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Sum != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Sum))))
+		i--
+		dAtA[i] = 0x29
+	}
+	if m.Count != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Count))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.TimeUnixNano != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.TimeUnixNano))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.StartTimeUnixNano != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.StartTimeUnixNano))
+		i--
+		dAtA[i] = 0x11
+	}
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Labels[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintMetrics(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExponentialHistogramDataPoint_Buckets) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExponentialHistogramDataPoint_Buckets) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExponentialHistogramDataPoint_Buckets) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.BucketCounts) > 0 {
+		dAtA16 := make([]byte, len(m.BucketCounts)*10)
+		var j15 int
+		for _, num := range m.BucketCounts {
+			for num >= 1<<7 {
+				dAtA16[j15] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j15++
+			}
+			dAtA16[j15] = uint8(num)
+			j15++
+		}
+		i -= j15
+		copy(dAtA[i:], dAtA16[:j15])
+		i = encodeVarintMetrics(dAtA, i, uint64(j15))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Offset != 0 {
+		i = encodeVarintMetrics(dAtA, i, uint64((uint32(m.Offset)<<1)^uint32((m.Offset>>31))))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintMetrics(dAtA []byte, offset int, v uint64) int {
+	offset -= sovMetrics(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ResourceMetrics) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Resource.Size()
+	n += 1 + l + sovMetrics(uint64(l))
+	if len(m.InstrumentationLibraryMetrics) > 0 {
+		for _, e := range m.InstrumentationLibraryMetrics {
+			l = e.Size()
+			n += 1 + l + sovMetrics(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *InstrumentationLibraryMetrics) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.InstrumentationLibrary != nil {
+		l = m.InstrumentationLibrary.Size()
+		n += 1 + l + sovMetrics(uint64(l))
+	}
+	if len(m.Metrics) > 0 {
+		for _, e := range m.Metrics {
+			l = e.Size()
+			n += 1 + l + sovMetrics(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Metric) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
 	if l > 0 {
 		n += 1 + l + sovMetrics(uint64(l))
 	}
@@ -3134,6 +3630,18 @@ func (m *Metric_DoubleHistogram) Size() (n int) {
 	}
 	return n
 }
+func (m *Metric_ExponentialHistogram) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ExponentialHistogram != nil {
+		l = m.ExponentialHistogram.Size()
+		n += 1 + l + sovMetrics(uint64(l))
+	}
+	return n
+}
 func (m *Metric_DoubleSummary) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3505,6 +4013,86 @@ func (m *DoubleExemplar) Size() (n int) {
 	return n
 }
 
+func (m *ExponentialHistogram) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.DataPoints) > 0 {
+		for _, e := range m.DataPoints {
+			l = e.Size()
+			n += 1 + l + sovMetrics(uint64(l))
+		}
+	}
+	if m.AggregationTemporality != 0 {
+		n += 1 + sovMetrics(uint64(m.AggregationTemporality))
+	}
+	return n
+}
+
+func (m *ExponentialHistogramDataPoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovMetrics(uint64(l))
+		}
+	}
+	if m.StartTimeUnixNano != 0 {
+		n += 9
+	}
+	if m.TimeUnixNano != 0 {
+		n += 9
+	}
+	if m.Count != 0 {
+		n += 9
+	}
+	if m.Sum != 0 {
+		n += 9
+	}
+	if m.Scale != 0 {
+		n += 1 + sozMetrics(uint64(m.Scale))
+	}
+	if m.ZeroCount != 0 {
+		n += 9
+	}
+	l = m.Positive.Size()
+	n += 1 + l + sovMetrics(uint64(l))
+	l = m.Negative.Size()
+	n += 1 + l + sovMetrics(uint64(l))
+	if len(m.Exemplars) > 0 {
+		for _, e := range m.Exemplars {
+			l = e.Size()
+			n += 1 + l + sovMetrics(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ExponentialHistogramDataPoint_Buckets) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Offset != 0 {
+		n += 1 + sozMetrics(uint64(m.Offset))
+	}
+	if len(m.BucketCounts) > 0 {
+		l = 0
+		for _, e := range m.BucketCounts {
+			l += sovMetrics(uint64(e))
+		}
+		n += 1 + sovMetrics(uint64(l)) + l
+	}
+	return n
+}
+
 func sovMetrics(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -4089,9 +4677,9 @@ func (m *Metric) Unmarshal(dAtA []byte) error {
 			}
 			m.Data = &Metric_DoubleHistogram{v}
 			iNdEx = postIndex
-		case 11:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DoubleSummary", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExponentialHistogram", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4118,17 +4706,52 @@ func (m *Metric) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &DoubleSummary{}
+			v := &ExponentialHistogram{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Data = &Metric_DoubleSummary{v}
+			m.Data = &Metric_ExponentialHistogram{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipMetrics(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DoubleSummary", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DoubleSummary{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Data = &Metric_DoubleSummary{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetrics(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
 			if skippy < 0 {
 				return ErrInvalidLengthMetrics
@@ -6295,6 +6918,521 @@ func (m *DoubleExemplar) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ExponentialHistogram) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetrics
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExponentialHistogram: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExponentialHistogram: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataPoints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DataPoints = append(m.DataPoints, &ExponentialHistogramDataPoint{})
+			if err := m.DataPoints[len(m.DataPoints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregationTemporality", wireType)
+			}
+			m.AggregationTemporality = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AggregationTemporality |= AggregationTemporality(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetrics(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExponentialHistogramDataPoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetrics
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExponentialHistogramDataPoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExponentialHistogramDataPoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, v11.StringKeyValue{})
+			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimeUnixNano", wireType)
+			}
+			m.StartTimeUnixNano = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StartTimeUnixNano = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeUnixNano", wireType)
+			}
+			m.TimeUnixNano = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TimeUnixNano = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Count = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Sum = float64(math.Float64frombits(v))
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scale", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			v = int32((uint32(v) >> 1) ^ uint32(((v&1)<<31)>>31))
+			m.Scale = v
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ZeroCount", wireType)
+			}
+			m.ZeroCount = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ZeroCount = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Positive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Positive.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Negative", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Negative.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exemplars", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Exemplars = append(m.Exemplars, &DoubleExemplar{})
+			if err := m.Exemplars[len(m.Exemplars)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetrics(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExponentialHistogramDataPoint_Buckets) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetrics
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Buckets: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Buckets: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetrics
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			v = int32((uint32(v) >> 1) ^ uint32(((v&1)<<31)>>31))
+			m.Offset = v
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMetrics
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.BucketCounts = append(m.BucketCounts, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMetrics
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthMetrics
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthMetrics
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.BucketCounts) == 0 {
+					m.BucketCounts = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMetrics
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.BucketCounts = append(m.BucketCounts, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field BucketCounts", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetrics(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetrics
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipMetrics(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -33,14 +33,15 @@ var (
 )
 
 const (
-	TestGaugeDoubleMetricName     = "gauge-double"
-	TestGaugeIntMetricName        = "gauge-int"
-	TestCounterDoubleMetricName   = "counter-double"
-	TestCounterIntMetricName      = "counter-int"
-	TestDoubleHistogramMetricName = "double-histogram"
-	TestIntHistogramMetricName    = "int-histogram"
-	TestDoubleSummaryMetricName   = "double-summary"
-	NumMetricTests                = 14
+	TestGaugeDoubleMetricName          = "gauge-double"
+	TestGaugeIntMetricName             = "gauge-int"
+	TestCounterDoubleMetricName        = "counter-double"
+	TestCounterIntMetricName           = "counter-int"
+	TestDoubleHistogramMetricName      = "double-histogram"
+	TestIntHistogramMetricName         = "int-histogram"
+	TestDoubleSummaryMetricName        = "double-summary"
+	TestExponentialHistogramMetricName = "exponential-histogram"
+	NumMetricTests                     = 15
 )
 
 func GenerateMetricsEmpty() pdata.Metrics {
@@ -213,6 +214,29 @@ func GenerateMetricsOtlpTwoMetrics() []*otlpmetrics.ResourceMetrics {
 	}
 }
 
+func GenerateMetricsOneExponentialHistogramMetric() pdata.Metrics {
+	md := GenerateMetricsOneEmptyInstrumentationLibrary()
+	rm0ils0 := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0)
+	rm0ils0.Metrics().Resize(1)
+	initExponentialHistogramMetric(rm0ils0.Metrics().At(0))
+	return md
+}
+
+func generateMetricsOtlpOneExponentialHistogramMetric() []*otlpmetrics.ResourceMetrics {
+	return []*otlpmetrics.ResourceMetrics{
+		{
+			Resource: generateOtlpResource1(),
+			InstrumentationLibraryMetrics: []*otlpmetrics.InstrumentationLibraryMetrics{
+				{
+					Metrics: []*otlpmetrics.Metric{
+						generateOtlpExponentialHistogramMetric(),
+					},
+				},
+			},
+		},
+	}
+}
+
 func GenerateMetricsOneMetricOneNil() pdata.Metrics {
 	return pdata.MetricsFromOtlp(generateMetricsOtlpOneMetricOneNil())
 }
@@ -537,6 +561,76 @@ func generateOtlpDoubleHistogramMetric() *otlpmetrics.Metric {
 	return m
 }
 
+func initExponentialHistogramMetric(hm pdata.Metric) {
+	initMetric(hm, TestExponentialHistogramMetricName, pdata.MetricDataTypeExponentialHistogram)
+
+	hdps := hm.ExponentialHistogram().DataPoints()
+	hdps.Resize(2)
+	hdp0 := hdps.At(0)
+	initMetricLabels13(hdp0.LabelsMap())
+	hdp0.SetStartTime(TestMetricStartTimestamp)
+	hdp0.SetTimestamp(TestMetricTimestamp)
+	hdp0.SetCount(1)
+	hdp0.SetSum(0)
+	hdp0.SetZeroCount(1)
+	hdp1 := hdps.At(1)
+	initMetricLabels2(hdp1.LabelsMap())
+	hdp1.SetStartTime(TestMetricStartTimestamp)
+	hdp1.SetTimestamp(TestMetricTimestamp)
+	hdp1.SetCount(4)
+	hdp1.SetSum(11.5)
+	hdp1.SetScale(1)
+	hdp1.Positive().SetOffset(2)
+	hdp1.Positive().SetBucketCounts([]uint64{1, 0, 2})
+	hdp1.Negative().SetOffset(-1)
+	hdp1.Negative().SetBucketCounts([]uint64{1})
+	exemplars := hdp1.Exemplars()
+	exemplars.Resize(1)
+	exemplar := exemplars.At(0)
+	exemplar.SetTimestamp(TestMetricExemplarTimestamp)
+	exemplar.SetValue(5)
+	initMetricAttachment(exemplar.FilteredLabels())
+}
+
+func generateOtlpExponentialHistogramMetric() *otlpmetrics.Metric {
+	m := generateOtlpMetric(TestExponentialHistogramMetricName, pdata.MetricDataTypeExponentialHistogram)
+	m.Data.(*otlpmetrics.Metric_ExponentialHistogram).ExponentialHistogram.DataPoints =
+		[]*otlpmetrics.ExponentialHistogramDataPoint{
+			{
+				Labels:            generateOtlpMetricLabels13(),
+				StartTimeUnixNano: uint64(TestMetricStartTimestamp),
+				TimeUnixNano:      uint64(TestMetricTimestamp),
+				Count:             1,
+				Sum:               0,
+				ZeroCount:         1,
+			},
+			{
+				Labels:            generateOtlpMetricLabels2(),
+				StartTimeUnixNano: uint64(TestMetricStartTimestamp),
+				TimeUnixNano:      uint64(TestMetricTimestamp),
+				Count:             4,
+				Sum:               11.5,
+				Scale:             1,
+				Positive: otlpmetrics.ExponentialHistogramDataPoint_Buckets{
+					Offset:       2,
+					BucketCounts: []uint64{1, 0, 2},
+				},
+				Negative: otlpmetrics.ExponentialHistogramDataPoint_Buckets{
+					Offset:       -1,
+					BucketCounts: []uint64{1},
+				},
+				Exemplars: []*otlpmetrics.DoubleExemplar{
+					{
+						FilteredLabels: generateOtlpMetricAttachment(),
+						TimeUnixNano:   uint64(TestMetricExemplarTimestamp),
+						Value:          5,
+					},
+				},
+			},
+		}
+	return m
+}
+
 func initIntHistogramMetric(hm pdata.Metric) {
 	initMetric(hm, TestIntHistogramMetricName, pdata.MetricDataTypeIntHistogram)
 
@@ -681,6 +775,10 @@ func initMetric(m pdata.Metric, name string, ty pdata.MetricDataType) {
 	case pdata.MetricDataTypeDoubleSummary:
 		summary := m.DoubleSummary()
 		summary.InitEmpty()
+	case pdata.MetricDataTypeExponentialHistogram:
+		histo := m.ExponentialHistogram()
+		histo.InitEmpty()
+		histo.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 	}
 }
 
@@ -715,6 +813,10 @@ func generateOtlpMetric(name string, ty pdata.MetricDataType) *otlpmetrics.Metri
 		}}
 	case pdata.MetricDataTypeDoubleSummary:
 		m.Data = &otlpmetrics.Metric_DoubleSummary{DoubleSummary: &otlpmetrics.DoubleSummary{}}
+	case pdata.MetricDataTypeExponentialHistogram:
+		m.Data = &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: &otlpmetrics.ExponentialHistogram{
+			AggregationTemporality: otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}}
 	}
 	return m
 }
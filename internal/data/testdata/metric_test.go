@@ -76,6 +76,11 @@ func generateAllMetricsTestCases() []traceMetricsCase {
 			td:   GenerateMetricsTwoMetrics(),
 			otlp: GenerateMetricsOtlpTwoMetrics(),
 		},
+		{
+			name: "one-exponential-histogram-metric",
+			td:   GenerateMetricsOneExponentialHistogramMetric(),
+			otlp: generateMetricsOtlpOneExponentialHistogramMetric(),
+		},
 		{
 			name: "one-metric-one-nil",
 			td:   GenerateMetricsOneMetricOneNil(),
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreport
+
+import (
+	"strings"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Counter is the current value of one of the counters recorded by obsreport for a
+// component.
+type Counter struct {
+	// Name is the name of the counter without the kind of the component, e.g.
+	// "accepted_spans".
+	Name  string
+	Value int64
+}
+
+// ReceiverCounters returns the current values of the counters of the receiver, summed
+// over the transports. The counters are only available if the views of obsreport
+// are registered.
+func ReceiverCounters(receiver string) []Counter {
+	return readCounters(receiverPrefix, tagKeyReceiver, receiver,
+		mReceiverAcceptedSpans,
+		mReceiverRefusedSpans,
+		mReceiverAcceptedMetricPoints,
+		mReceiverRefusedMetricPoints,
+		mReceiverAcceptedLogRecords,
+		mReceiverRefusedLogRecords)
+}
+
+// ProcessorCounters returns the current values of the counters of the processor. The
+// counters are only available if the views of obsreport are registered.
+func ProcessorCounters(processor string) []Counter {
+	return readCounters(processorPrefix, tagKeyProcessor, processor,
+		mProcessorAcceptedSpans,
+		mProcessorRefusedSpans,
+		mProcessorDroppedSpans,
+		mProcessorAcceptedMetricPoints,
+		mProcessorRefusedMetricPoints,
+		mProcessorDroppedMetricPoints,
+		mProcessorAcceptedLogRecords,
+		mProcessorRefusedLogRecords,
+		mProcessorDroppedLogRecords)
+}
+
+// ExporterCounters returns the current values of the counters of the exporter. The
+// counters are only available if the views of obsreport are registered.
+func ExporterCounters(exporter string) []Counter {
+	return readCounters(exporterPrefix, tagKeyExporter, exporter,
+		mExporterSentSpans,
+		mExporterFailedToSendSpans,
+		mExporterSentMetricPoints,
+		mExporterFailedToSendMetricPoints,
+		mExporterSentLogRecords,
+		mExporterFailedToSendLogRecords)
+}
+
+// readCounters returns the values of the views of the measures for the rows where the
+// key has the given value.
+func readCounters(prefix string, key tag.Key, value string, measures ...*stats.Int64Measure) []Counter {
+	var counters []Counter
+	for _, measure := range measures {
+		rows, err := view.RetrieveData(measure.Name())
+		if err != nil {
+			// The view is not registered.
+			continue
+		}
+		counter := Counter{Name: strings.TrimPrefix(measure.Name(), prefix)}
+		for _, row := range rows {
+			if !hasTag(row.Tags, key, value) {
+				continue
+			}
+			if sum, ok := row.Data.(*view.SumData); ok {
+				counter.Value += int64(sum.Value)
+			}
+		}
+		counters = append(counters, counter)
+	}
+	return counters
+}
+
+func hasTag(tags []tag.Tag, key tag.Key, value string) bool {
+	for _, t := range tags {
+		if t.Key == key {
+			return t.Value == value
+		}
+	}
+	return false
+}
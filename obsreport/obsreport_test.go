@@ -559,6 +559,32 @@ func TestProcessorTraceData(t *testing.T) {
 	obsreporttest.CheckProcessorTracesViews(t, processor, acceptedSpans, refusedSpans, droppedSpans)
 }
 
+func TestCounters(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	obsrep := obsreport.NewProcessorObsReport(configtelemetry.LevelNormal, processor)
+	obsrep.TracesAccepted(context.Background(), 27)
+	obsrep.TracesDropped(context.Background(), 13)
+	obsreport.NewProcessorObsReport(configtelemetry.LevelNormal, "otherProcessor").TracesAccepted(context.Background(), 5)
+
+	counters := obsreport.ProcessorCounters(processor)
+	require.Len(t, counters, 9)
+	assert.Equal(t, obsreport.Counter{Name: obsreport.AcceptedSpansKey, Value: 27}, counters[0])
+	assert.Equal(t, obsreport.Counter{Name: obsreport.RefusedSpansKey, Value: 0}, counters[1])
+	assert.Equal(t, obsreport.Counter{Name: obsreport.DroppedSpansKey, Value: 13}, counters[2])
+
+	receiverCtx := obsreport.ReceiverContext(context.Background(), receiver, transport)
+	ctx := obsreport.StartTraceDataReceiveOp(receiverCtx, receiver, transport)
+	obsreport.EndTraceDataReceiveOp(ctx, format, 7, nil)
+	counters = obsreport.ReceiverCounters(receiver)
+	require.Len(t, counters, 6)
+	assert.Equal(t, obsreport.Counter{Name: obsreport.AcceptedSpansKey, Value: 7}, counters[0])
+
+	assert.Len(t, obsreport.ExporterCounters(exporter), 6)
+}
+
 func TestProcessorMetricsData(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	require.NoError(t, err)
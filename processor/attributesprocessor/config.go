@@ -15,8 +15,13 @@
 package attributesprocessor
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filtermatcher"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 )
 
@@ -37,3 +42,40 @@ type Config struct {
 	// This is a required field.
 	processorhelper.Settings `mapstructure:",squash"`
 }
+
+// Validate checks that the actions are set and valid and that the filters of the
+// include and exclude properties can be created. The checks that depend on the type
+// of the data processed, traces or logs, are done when the processor is created.
+func (cfg *Config) Validate() error {
+	if len(cfg.Actions) == 0 {
+		return errors.New("missing required field \"actions\"")
+	}
+	if _, err := processorhelper.NewAttrProc(&cfg.Settings); err != nil {
+		return err
+	}
+	if err := validateMatchProperties(cfg.Include); err != nil {
+		return fmt.Errorf("invalid include properties: %w", err)
+	}
+	if err := validateMatchProperties(cfg.Exclude); err != nil {
+		return fmt.Errorf("invalid exclude properties: %w", err)
+	}
+	return nil
+}
+
+func validateMatchProperties(mp *filterconfig.MatchProperties) error {
+	if mp == nil {
+		return nil
+	}
+	if _, err := filtermatcher.NewMatcher(mp); err != nil {
+		return err
+	}
+	for _, names := range [][]string{mp.Services, mp.SpanNames, mp.LogNames} {
+		if len(names) == 0 {
+			continue
+		}
+		if _, err := filterset.CreateFilterSet(names, &mp.Config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -235,3 +235,30 @@ func TestLoadingConfig(t *testing.T) {
 	})
 
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), `missing required field "actions"`)
+
+	cfg.Actions = []processorhelper.ActionKeyValue{{Key: "attribute1", Value: 123, Action: processorhelper.INSERT}}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Actions = []processorhelper.ActionKeyValue{{Key: "attribute1", Action: "unknown"}}
+	assert.Error(t, cfg.Validate())
+
+	cfg.Actions = []processorhelper.ActionKeyValue{{Key: "attribute1", Action: processorhelper.DELETE}}
+	cfg.Include = &filterconfig.MatchProperties{
+		Config:   filterset.Config{MatchType: filterset.Regexp},
+		Services: []string{"svc("},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid include properties")
+
+	cfg.Include = nil
+	cfg.Exclude = &filterconfig.MatchProperties{
+		Config:    filterset.Config{MatchType: filterset.Strict},
+		SpanNames: []string{"span"},
+	}
+	assert.NoError(t, cfg.Validate())
+}
@@ -16,6 +16,7 @@ package batchprocessor
 
 import (
 	"context"
+	"fmt"
 	"runtime"
 	"time"
 
@@ -46,6 +47,7 @@ type batchProcessor struct {
 	sendBatchSize    uint32
 	timeout          time.Duration
 	sendBatchMaxSize uint32
+	mergeResources   bool
 
 	timer   *time.Timer
 	done    chan struct{}
@@ -71,6 +73,10 @@ type batch interface {
 
 	// add item to the current batch
 	add(item interface{})
+
+	// normalize groups the data of identical resources and instrumentation libraries
+	// of the current batch.
+	normalize()
 }
 
 var _ consumer.TracesConsumer = (*batchProcessor)(nil)
@@ -86,6 +92,7 @@ func newBatchProcessor(params component.ProcessorCreateParams, cfg *Config, batc
 
 		sendBatchSize:    cfg.SendBatchSize,
 		sendBatchMaxSize: cfg.SendBatchMaxSize,
+		mergeResources:   cfg.MergeResources,
 		timeout:          cfg.Timeout,
 		done:             make(chan struct{}, 1),
 		newItem:          make(chan interface{}, runtime.NumCPU()),
@@ -96,7 +103,7 @@ func newBatchProcessor(params component.ProcessorCreateParams, cfg *Config, batc
 }
 
 func (bp *batchProcessor) GetCapabilities() component.ProcessorCapabilities {
-	return component.ProcessorCapabilities{MutatesConsumedData: true}
+	return component.ProcessorCapabilities{MutatesConsumedData: true, SupportsSharedData: true}
 }
 
 // Start is invoked during service startup.
@@ -105,11 +112,16 @@ func (bp *batchProcessor) Start(context.Context, component.Host) error {
 	return nil
 }
 
-// Shutdown is invoked during service shutdown.
-func (bp *batchProcessor) Shutdown(context.Context) error {
+// Shutdown is invoked during service shutdown. The pending batch is flushed, Shutdown
+// returns an error if the flush does not complete before ctx is done.
+func (bp *batchProcessor) Shutdown(ctx context.Context) error {
 	bp.cancel()
-	<-bp.done
-	return nil
+	select {
+	case <-bp.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("batch processor %q did not flush before the shutdown deadline: %w", bp.name, ctx.Err())
+	}
 }
 
 func (bp *batchProcessor) startProcessingCycle() {
@@ -153,6 +165,8 @@ func (bp *batchProcessor) processItem(item interface{}) {
 		if td, ok := item.(pdata.Traces); ok {
 			itemCount := bp.batch.itemCount()
 			if itemCount+uint32(td.SpanCount()) > bp.sendBatchMaxSize {
+				// Splitting removes spans from td.
+				td = td.Writable()
 				tdRemainSize := splitTrace(int(bp.sendBatchSize-itemCount), td)
 				item = tdRemainSize
 				go func() {
@@ -175,6 +189,10 @@ func (bp *batchProcessor) resetTimer() {
 }
 
 func (bp *batchProcessor) sendItems(measure *stats.Int64Measure) {
+	if bp.mergeResources {
+		bp.batch.normalize()
+	}
+
 	// Add that it came form the trace pipeline?
 	statsTags := []tag.Mutator{tag.Insert(processor.TagProcessorNameKey, bp.name)}
 	_ = stats.RecordWithTags(context.Background(), statsTags, measure.M(1), statBatchSendSize.M(int64(bp.batch.itemCount())))
@@ -227,6 +245,8 @@ type batchTraces struct {
 	nextConsumer consumer.TracesConsumer
 	traceData    pdata.Traces
 	spanCount    uint32
+	// shared is true if the batch contains data shared with other users.
+	shared bool
 }
 
 func newBatchTraces(nextConsumer consumer.TracesConsumer) *batchTraces {
@@ -244,10 +264,20 @@ func (bt *batchTraces) add(item interface{}) {
 	}
 
 	bt.spanCount += uint32(newSpanCount)
+	// Only the ResourceSpans are moved to the batch, the data below them is still shared
+	// with the other users of td if it is shared.
+	bt.shared = bt.shared || td.IsShared()
 	td.ResourceSpans().MoveAndAppendTo(bt.traceData.ResourceSpans())
 }
 
+func (bt *batchTraces) normalize() {
+	bt.traceData = pdata.NormalizeTraces(bt.traceData)
+}
+
 func (bt *batchTraces) export(ctx context.Context) error {
+	if bt.shared {
+		return bt.nextConsumer.ConsumeTraces(ctx, bt.traceData.MarkShared())
+	}
 	return bt.nextConsumer.ConsumeTraces(ctx, bt.traceData)
 }
 
@@ -263,12 +293,15 @@ func (bt *batchTraces) size() int {
 func (bt *batchTraces) reset() {
 	bt.traceData = pdata.NewTraces()
 	bt.spanCount = 0
+	bt.shared = false
 }
 
 type batchMetrics struct {
 	nextConsumer consumer.MetricsConsumer
 	metricData   pdata.Metrics
 	metricCount  uint32
+	// shared is true if the batch contains data shared with other users.
+	shared bool
 }
 
 func newBatchMetrics(nextConsumer consumer.MetricsConsumer) *batchMetrics {
@@ -277,7 +310,14 @@ func newBatchMetrics(nextConsumer consumer.MetricsConsumer) *batchMetrics {
 	return b
 }
 
+func (bm *batchMetrics) normalize() {
+	bm.metricData = pdata.NormalizeMetrics(bm.metricData)
+}
+
 func (bm *batchMetrics) export(ctx context.Context) error {
+	if bm.shared {
+		return bm.nextConsumer.ConsumeMetrics(ctx, bm.metricData.MarkShared())
+	}
 	return bm.nextConsumer.ConsumeMetrics(ctx, bm.metricData)
 }
 
@@ -293,6 +333,7 @@ func (bm *batchMetrics) size() int {
 func (bm *batchMetrics) reset() {
 	bm.metricData = pdata.NewMetrics()
 	bm.metricCount = 0
+	bm.shared = false
 }
 
 func (bm *batchMetrics) add(item interface{}) {
@@ -303,6 +344,9 @@ func (bm *batchMetrics) add(item interface{}) {
 		return
 	}
 	bm.metricCount += uint32(newMetricsCount)
+	// Only the ResourceMetrics are moved to the batch, the data below them is still shared
+	// with the other users of md if it is shared.
+	bm.shared = bm.shared || md.IsShared()
 	md.ResourceMetrics().MoveAndAppendTo(bm.metricData.ResourceMetrics())
 }
 
@@ -310,6 +354,8 @@ type batchLogs struct {
 	nextConsumer consumer.LogsConsumer
 	logData      pdata.Logs
 	logCount     uint32
+	// shared is true if the batch contains data shared with other users.
+	shared bool
 }
 
 func newBatchLogs(nextConsumer consumer.LogsConsumer) *batchLogs {
@@ -318,7 +364,14 @@ func newBatchLogs(nextConsumer consumer.LogsConsumer) *batchLogs {
 	return b
 }
 
+func (bm *batchLogs) normalize() {
+	bm.logData = pdata.NormalizeLogs(bm.logData)
+}
+
 func (bm *batchLogs) export(ctx context.Context) error {
+	if bm.shared {
+		return bm.nextConsumer.ConsumeLogs(ctx, bm.logData.MarkShared())
+	}
 	return bm.nextConsumer.ConsumeLogs(ctx, bm.logData)
 }
 
@@ -334,6 +387,7 @@ func (bm *batchLogs) size() int {
 func (bm *batchLogs) reset() {
 	bm.logData = pdata.NewLogs()
 	bm.logCount = 0
+	bm.shared = false
 }
 
 func (bm *batchLogs) add(item interface{}) {
@@ -344,5 +398,8 @@ func (bm *batchLogs) add(item interface{}) {
 		return
 	}
 	bm.logCount += uint32(newLogsCount)
+	// Only the ResourceLogs are moved to the batch, the data below them is still shared
+	// with the other users of ld if it is shared.
+	bm.shared = bm.shared || ld.IsShared()
 	ld.ResourceLogs().MoveAndAppendTo(bm.logData.ResourceLogs())
 }
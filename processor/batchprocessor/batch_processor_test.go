@@ -16,6 +16,7 @@ package batchprocessor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -225,6 +226,51 @@ func TestBatchProcessorSentByTimeout(t *testing.T) {
 	}
 }
 
+func TestBatchProcessorSharedData(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 20
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The batches with shared data are not copied but still share it.
+	td := testdata.GenerateTraceDataManySpansSameResource(10)
+	for _, shared := range td.Share(2) {
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), shared))
+	}
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(20)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	receivedTraces := sink.AllTraces()
+	require.Len(t, receivedTraces, 2)
+	assert.True(t, receivedTraces[0].IsShared())
+	assert.True(t, receivedTraces[0].ResourceSpans().At(0).Resource() == td.ResourceSpans().At(0).Resource())
+	assert.False(t, receivedTraces[1].IsShared())
+}
+
+func TestBatchProcessorMergeResources(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 50
+	cfg.MergeResources = true
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for requestNum := 0; requestNum < 5; requestNum++ {
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(10)))
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	receivedTraces := sink.AllTraces()
+	require.Len(t, receivedTraces, 1)
+	rss := receivedTraces[0].ResourceSpans()
+	require.Equal(t, 1, rss.Len())
+	require.Equal(t, 1, rss.At(0).InstrumentationLibrarySpans().Len())
+	assert.Equal(t, 50, rss.At(0).InstrumentationLibrarySpans().At(0).Spans().Len())
+}
+
 func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	cfg := Config{
 		Timeout:       3 * time.Second,
@@ -249,6 +295,37 @@ func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	require.Equal(t, 1, len(sink.AllTraces()))
 }
 
+// blockingTracesConsumer blocks until unblock is closed.
+type blockingTracesConsumer struct {
+	unblock chan struct{}
+}
+
+func (bc *blockingTracesConsumer) ConsumeTraces(context.Context, pdata.Traces) error {
+	<-bc.unblock
+	return nil
+}
+
+func TestBatchProcessorShutdownDeadline(t *testing.T) {
+	cfg := Config{
+		Timeout:       3 * time.Second,
+		SendBatchSize: 1000,
+	}
+	next := &blockingTracesConsumer{unblock: make(chan struct{})}
+	defer close(next.unblock)
+
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, next, &cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(10)))
+
+	// The flush blocks, Shutdown returns when the deadline expires.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := batcher.Shutdown(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
 func TestBatchMetricProcessor_ReceivingData(t *testing.T) {
 	// Instantiate the batch processor with low config values to test data
 	// gets sent through the processor.
@@ -15,6 +15,7 @@
 package batchprocessor
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -33,4 +34,20 @@ type Config struct {
 	// SendBatchMaxSize is the maximum size of a batch. Larger batches are split into smaller units.
 	// Default value is 0, that means no maximum size.
 	SendBatchMaxSize uint32 `mapstructure:"send_batch_max_size,omitempty"`
+
+	// MergeResources enables grouping the data of identical resources and instrumentation
+	// libraries of a batch under a single one before it is sent, see pdata.NormalizeTraces.
+	MergeResources bool `mapstructure:"merge_resources,omitempty"`
+}
+
+// Validate checks that the timeout is positive and that the maximum size of the batches,
+// if set, is not smaller than the size that triggers sending them.
+func (cfg *Config) Validate() error {
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	if cfg.SendBatchMaxSize > 0 && cfg.SendBatchMaxSize < cfg.SendBatchSize {
+		return errors.New("send_batch_max_size must be greater than or equal to send_batch_size")
+	}
+	return nil
 }
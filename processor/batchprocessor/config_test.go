@@ -56,5 +56,22 @@ func TestLoadConfig(t *testing.T) {
 			SendBatchSize:    sendBatchSize,
 			SendBatchMaxSize: sendBatchMaxSize,
 			Timeout:          timeout,
+			MergeResources:   true,
 		})
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Timeout = 0
+	assert.EqualError(t, cfg.Validate(), "timeout must be positive")
+
+	cfg.Timeout = time.Second
+	cfg.SendBatchSize = 100
+	cfg.SendBatchMaxSize = 50
+	assert.EqualError(t, cfg.Validate(), "send_batch_max_size must be greater than or equal to send_batch_size")
+
+	cfg.SendBatchMaxSize = 100
+	assert.NoError(t, cfg.Validate())
+}
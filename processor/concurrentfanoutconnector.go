@@ -0,0 +1,419 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+// This file contains implementations of Trace/Metrics/Logs connectors that fan out
+// the data to multiple other consumers concurrently. Each consumer has its own
+// goroutine and bounded buffer, so that a slow consumer does not delay the others
+// nor the caller, unless its buffer is full and the policy is to block.
+
+// defaultFanOutBufferSize is the number of batches buffered for each consumer if
+// the buffer size is not configured.
+const defaultFanOutBufferSize = 100
+
+var errFanOutStopped = errors.New("fan-out connector is shut down")
+
+// Keys and stats for the telemetry of the concurrent fan-out connectors.
+var (
+	tagPipelineKey, _ = tag.NewKey("pipeline")
+	tagExporterKey, _ = tag.NewKey(obsreport.ExporterKey)
+
+	statFanOutLatency = stats.Float64(
+		"fanout_latency",
+		"time from the reception of the data by the fan-out to the end of its export",
+		stats.UnitMilliseconds)
+	statFanOutDroppedBatches = stats.Int64(
+		"fanout_dropped_batches",
+		"number of batches dropped because the fan-out buffer of the exporter was full or the fan-out was shut down",
+		stats.UnitDimensionless)
+)
+
+// FanOutMetricViews returns the views of the metrics of the concurrent fan-out connectors.
+func FanOutMetricViews() []*view.View {
+	tagKeys := []tag.Key{tagPipelineKey, tagExporterKey}
+	return []*view.View{
+		{
+			Name:        statFanOutLatency.Name(),
+			Measure:     statFanOutLatency,
+			Description: "The time from the reception of the data by the fan-out to the end of its export, per exporter.",
+			TagKeys:     tagKeys,
+			Aggregation: view.Distribution(1, 5, 10, 50, 100, 500, 1000, 5000, 10000, 30000),
+		},
+		{
+			Name:        statFanOutDroppedBatches.Name(),
+			Measure:     statFanOutDroppedBatches,
+			Description: "The number of batches dropped because the fan-out buffer of the exporter was full or the fan-out was shut down.",
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+// ConcurrentFanOutParams are the parameters of the concurrent fan-out connectors.
+type ConcurrentFanOutParams struct {
+	Logger *zap.Logger
+	// PipelineName is the name of the pipeline of the connector, used in its metrics.
+	PipelineName string
+	// ConsumerNames are the names of the consumers, in the same order as the consumers,
+	// used in the metrics of the connector.
+	ConsumerNames []string
+	Settings      configmodels.FanOutSettings
+}
+
+// ConcurrentTracesFanOutConnector is a traces consumer that fans out the data to
+// multiple consumers concurrently. It must be started before consuming data and
+// shut down to stop sending data to the consumers.
+type ConcurrentTracesFanOutConnector interface {
+	consumer.TracesConsumer
+	component.Component
+}
+
+// ConcurrentMetricsFanOutConnector is a metrics consumer that fans out the data to
+// multiple consumers concurrently. It must be started before consuming data and
+// shut down to stop sending data to the consumers.
+type ConcurrentMetricsFanOutConnector interface {
+	consumer.MetricsConsumer
+	component.Component
+}
+
+// ConcurrentLogsFanOutConnector is a logs consumer that fans out the data to
+// multiple consumers concurrently. It must be started before consuming data and
+// shut down to stop sending data to the consumers.
+type ConcurrentLogsFanOutConnector interface {
+	consumer.LogsConsumer
+	component.Component
+}
+
+// NewTracesConcurrentFanOutConnector wraps multiple traces consumers in a single one
+// that sends the data to each of them from its own goroutine.
+func NewTracesConcurrentFanOutConnector(params ConcurrentFanOutParams, tcs []consumer.TracesConsumer) ConcurrentTracesFanOutConnector {
+	return &tracesConcurrentFanOutConnector{
+		concurrentFanOut: newConcurrentFanOut(params, len(tcs)),
+		tcs:              tcs,
+	}
+}
+
+type tracesConcurrentFanOutConnector struct {
+	*concurrentFanOut
+	tcs []consumer.TracesConsumer
+}
+
+// ConsumeTraces queues the span data for all the trace consumers wrapped by the current one.
+func (tfc *tracesConcurrentFanOutConnector) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	return tfc.dispatch(ctx, func(i int) consumeFunc {
+		return func(ctx context.Context) error {
+			return tfc.tcs[i].ConsumeTraces(ctx, td)
+		}
+	})
+}
+
+// NewMetricsConcurrentFanOutConnector wraps multiple metrics consumers in a single one
+// that sends the data to each of them from its own goroutine.
+func NewMetricsConcurrentFanOutConnector(params ConcurrentFanOutParams, mcs []consumer.MetricsConsumer) ConcurrentMetricsFanOutConnector {
+	return &metricsConcurrentFanOutConnector{
+		concurrentFanOut: newConcurrentFanOut(params, len(mcs)),
+		mcs:              mcs,
+	}
+}
+
+type metricsConcurrentFanOutConnector struct {
+	*concurrentFanOut
+	mcs []consumer.MetricsConsumer
+}
+
+// ConsumeMetrics queues the metrics data for all the metrics consumers wrapped by the current one.
+func (mfc *metricsConcurrentFanOutConnector) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return mfc.dispatch(ctx, func(i int) consumeFunc {
+		return func(ctx context.Context) error {
+			return mfc.mcs[i].ConsumeMetrics(ctx, md)
+		}
+	})
+}
+
+// NewLogsConcurrentFanOutConnector wraps multiple logs consumers in a single one
+// that sends the data to each of them from its own goroutine.
+func NewLogsConcurrentFanOutConnector(params ConcurrentFanOutParams, lcs []consumer.LogsConsumer) ConcurrentLogsFanOutConnector {
+	return &logsConcurrentFanOutConnector{
+		concurrentFanOut: newConcurrentFanOut(params, len(lcs)),
+		lcs:              lcs,
+	}
+}
+
+type logsConcurrentFanOutConnector struct {
+	*concurrentFanOut
+	lcs []consumer.LogsConsumer
+}
+
+// ConsumeLogs queues the log data for all the log consumers wrapped by the current one.
+func (lfc *logsConcurrentFanOutConnector) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return lfc.dispatch(ctx, func(i int) consumeFunc {
+		return func(ctx context.Context) error {
+			return lfc.lcs[i].ConsumeLogs(ctx, ld)
+		}
+	})
+}
+
+// consumeFunc sends data to a consumer.
+type consumeFunc func(ctx context.Context) error
+
+type fanOutItem struct {
+	ctx      context.Context
+	received time.Time
+	consume  consumeFunc
+}
+
+// fanOutBranch sends the data to a consumer from its own goroutine.
+type fanOutBranch struct {
+	logger *zap.Logger
+	// metricsCtx has the tags of the metrics of the branch.
+	metricsCtx context.Context
+	buffer     chan fanOutItem
+}
+
+// run sends the buffered data to the consumer until no more data can be queued and
+// the buffer is empty, or until the fan-out is aborted.
+func (b *fanOutBranch) run(flushed, aborted <-chan struct{}) {
+	for {
+		// Check first if the fan-out is aborted, so that no data is sent to the
+		// consumer once the shutdown returned.
+		select {
+		case <-aborted:
+			b.dropBuffered(flushed)
+			return
+		default:
+		}
+
+		select {
+		case item := <-b.buffer:
+			b.send(item)
+		case <-flushed:
+			select {
+			case item := <-b.buffer:
+				b.send(item)
+			default:
+				return
+			}
+		case <-aborted:
+		}
+	}
+}
+
+func (b *fanOutBranch) send(item fanOutItem) {
+	err := item.consume(item.ctx)
+	stats.Record(b.metricsCtx, statFanOutLatency.M(float64(time.Since(item.received))/float64(time.Millisecond)))
+	if err != nil {
+		b.logger.Error("Exporting failed. Dropping data.", zap.Error(err))
+	}
+}
+
+// dropBuffered drops the data left in the buffer once no more data can be queued.
+func (b *fanOutBranch) dropBuffered(flushed <-chan struct{}) {
+	<-flushed
+	dropped := len(b.buffer)
+	if dropped == 0 {
+		return
+	}
+	for i := 0; i < dropped; i++ {
+		<-b.buffer
+	}
+	stats.Record(b.metricsCtx, statFanOutDroppedBatches.M(int64(dropped)))
+	b.logger.Warn("Dropping data because the fan-out is shut down.", zap.Int("batches", dropped))
+}
+
+// concurrentFanOut implements the dispatching of the data and the lifecycle of the
+// concurrent fan-out connectors, the data specific connectors only provide the
+// functions that send the data to each consumer.
+type concurrentFanOut struct {
+	policy   configmodels.FanOutPolicy
+	branches []*fanOutBranch
+	wg       sync.WaitGroup
+
+	// mu protects stopped, dispatch registers itself in dispatching while holding a
+	// read lock so that Shutdown knows when no more data can be queued.
+	mu          sync.RWMutex
+	stopped     bool
+	dispatching sync.WaitGroup
+	// stopping is closed when the shutdown starts, it unblocks the dispatches waiting
+	// for space in a buffer.
+	stopping chan struct{}
+	// flushed is closed once the dispatches in progress when the shutdown started
+	// returned, the data in the buffers is then the last to send.
+	flushed chan struct{}
+	// aborted is closed when the shutdown deadline expires, the branches then stop
+	// and drop their buffered data.
+	aborted   chan struct{}
+	abortOnce sync.Once
+}
+
+func newConcurrentFanOut(params ConcurrentFanOutParams, numConsumers int) *concurrentFanOut {
+	bufferSize := params.Settings.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultFanOutBufferSize
+	}
+	logger := params.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	cf := &concurrentFanOut{
+		policy:   params.Settings.Policy,
+		branches: make([]*fanOutBranch, numConsumers),
+		stopping: make(chan struct{}),
+		flushed:  make(chan struct{}),
+		aborted:  make(chan struct{}),
+	}
+	for i := range cf.branches {
+		name := ""
+		if i < len(params.ConsumerNames) {
+			name = params.ConsumerNames[i]
+		}
+		metricsCtx, _ := tag.New(context.Background(),
+			tag.Upsert(tagPipelineKey, params.PipelineName),
+			tag.Upsert(tagExporterKey, name))
+		cf.branches[i] = &fanOutBranch{
+			logger:     logger.With(zap.String("exporter", name)),
+			metricsCtx: metricsCtx,
+			buffer:     make(chan fanOutItem, bufferSize),
+		}
+	}
+	return cf
+}
+
+// Start starts the goroutines sending the data to the consumers.
+func (cf *concurrentFanOut) Start(context.Context, component.Host) error {
+	for _, b := range cf.branches {
+		cf.wg.Add(1)
+		go func(b *fanOutBranch) {
+			defer cf.wg.Done()
+			b.run(cf.flushed, cf.aborted)
+		}(b)
+	}
+	return nil
+}
+
+// Shutdown stops accepting data and waits until the buffered data is sent to the
+// consumers or ctx is done. If ctx is done first, the consumers do not get any more
+// data, the context of the data being sent is canceled and the buffered data is
+// dropped.
+func (cf *concurrentFanOut) Shutdown(ctx context.Context) error {
+	cf.mu.Lock()
+	if !cf.stopped {
+		cf.stopped = true
+		close(cf.stopping)
+		go func() {
+			cf.dispatching.Wait()
+			close(cf.flushed)
+		}()
+	}
+	cf.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		cf.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		cf.abortOnce.Do(func() { close(cf.aborted) })
+		return ctx.Err()
+	}
+}
+
+// dispatch queues the data for each consumer, consumeFn returns the function sending
+// the data to the i-th consumer.
+func (cf *concurrentFanOut) dispatch(ctx context.Context, consumeFn func(i int) consumeFunc) error {
+	cf.mu.RLock()
+	if cf.stopped {
+		cf.mu.RUnlock()
+		return errFanOutStopped
+	}
+	// The lock is not held while waiting for space in the buffers, so that Shutdown
+	// is never blocked by a slow consumer.
+	cf.dispatching.Add(1)
+	cf.mu.RUnlock()
+	defer cf.dispatching.Done()
+
+	received := time.Now()
+	// The data is sent after the caller returns, the cancellation of the caller's
+	// context must not propagate to it.
+	itemCtx := fanOutContext{Context: ctx, aborted: cf.aborted}
+	var errs []error
+	for i, b := range cf.branches {
+		item := fanOutItem{ctx: itemCtx, received: received, consume: consumeFn(i)}
+		if cf.policy == configmodels.FanOutDrop {
+			select {
+			case b.buffer <- item:
+			default:
+				stats.Record(b.metricsCtx, statFanOutDroppedBatches.M(1))
+				b.logger.Warn("Dropping data because the fan-out buffer is full. Try increasing buffer_size.")
+			}
+			continue
+		}
+
+		select {
+		case b.buffer <- item:
+		case <-ctx.Done():
+			errs = append(errs, ctx.Err())
+		case <-cf.stopping:
+			errs = append(errs, errFanOutStopped)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// fanOutContext is a context that keeps the values of its parent but is only done
+// when the shutdown of the fan-out is aborted.
+type fanOutContext struct {
+	context.Context
+	aborted <-chan struct{}
+}
+
+func (fanOutContext) Deadline() (deadline time.Time, ok bool) {
+	return
+}
+
+func (c fanOutContext) Done() <-chan struct{} {
+	return c.aborted
+}
+
+func (c fanOutContext) Err() error {
+	select {
+	case <-c.aborted:
+		return context.Canceled
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+)
+
+// blockingTracesConsumer blocks in ConsumeTraces until unblock is closed.
+type blockingTracesConsumer struct {
+	consumertest.TracesSink
+	started chan struct{}
+	unblock chan struct{}
+}
+
+func newBlockingTracesConsumer() *blockingTracesConsumer {
+	return &blockingTracesConsumer{
+		started: make(chan struct{}, 100),
+		unblock: make(chan struct{}),
+	}
+}
+
+func (bc *blockingTracesConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	bc.started <- struct{}{}
+	<-bc.unblock
+	return bc.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestTracesConcurrentFanOut(t *testing.T) {
+	sinks := []*consumertest.TracesSink{new(consumertest.TracesSink), new(consumertest.TracesSink)}
+	sinks[1].SetConsumeError(errors.New("my_error"))
+	tfc := NewTracesConcurrentFanOutConnector(
+		ConcurrentFanOutParams{ConsumerNames: []string{"exp1", "exp2"}},
+		[]consumer.TracesConsumer{sinks[0], sinks[1]})
+	require.NoError(t, tfc.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraceDataOneSpan()
+	for i := 0; i < 2; i++ {
+		// The errors of the consumers are not returned.
+		assert.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	}
+
+	require.NoError(t, tfc.Shutdown(context.Background()))
+	assert.Equal(t, 2, sinks[0].SpansCount())
+	assert.EqualValues(t, td, sinks[0].AllTraces()[0])
+	assert.Equal(t, 0, sinks[1].SpansCount())
+
+	assert.Equal(t, errFanOutStopped, tfc.ConsumeTraces(context.Background(), td))
+}
+
+func TestTracesConcurrentFanOut_SlowConsumer(t *testing.T) {
+	slow := newBlockingTracesConsumer()
+	fast := new(consumertest.TracesSink)
+	tfc := NewTracesConcurrentFanOutConnector(
+		ConcurrentFanOutParams{ConsumerNames: []string{"slow", "fast"}},
+		[]consumer.TracesConsumer{slow, fast})
+	require.NoError(t, tfc.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraceDataOneSpan()
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	<-slow.started
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// The fast consumer gets the data while the slow one is blocked.
+	assert.Eventually(t, func() bool { return fast.SpansCount() == 2 }, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, 0, slow.SpansCount())
+
+	close(slow.unblock)
+	require.NoError(t, tfc.Shutdown(context.Background()))
+	assert.Equal(t, 2, slow.SpansCount())
+}
+
+func TestTracesConcurrentFanOut_DropPolicy(t *testing.T) {
+	slow := newBlockingTracesConsumer()
+	tfc := NewTracesConcurrentFanOutConnector(
+		ConcurrentFanOutParams{
+			PipelineName:  "traces/drop",
+			ConsumerNames: []string{"slow"},
+			Settings:      configmodels.FanOutSettings{Concurrent: true, BufferSize: 1, Policy: configmodels.FanOutDrop},
+		},
+		[]consumer.TracesConsumer{slow})
+	require.NoError(t, view.Register(FanOutMetricViews()...))
+	defer view.Unregister(FanOutMetricViews()...)
+	require.NoError(t, tfc.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraceDataOneSpan()
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	<-slow.started
+	// The first one fills the buffer, the others are dropped.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	}
+
+	close(slow.unblock)
+	require.NoError(t, tfc.Shutdown(context.Background()))
+	assert.Equal(t, 2, slow.SpansCount())
+
+	rows, err := view.RetrieveData(statFanOutDroppedBatches.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, float64(2), rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(statFanOutLatency.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(2), rows[0].Data.(*view.DistributionData).Count)
+}
+
+func TestTracesConcurrentFanOut_BlockPolicy(t *testing.T) {
+	slow := newBlockingTracesConsumer()
+	tfc := NewTracesConcurrentFanOutConnector(
+		ConcurrentFanOutParams{Settings: configmodels.FanOutSettings{Concurrent: true, BufferSize: 1}},
+		[]consumer.TracesConsumer{slow})
+	require.NoError(t, tfc.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraceDataOneSpan()
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	<-slow.started
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// The buffer is full, it blocks until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, tfc.ConsumeTraces(ctx, td))
+
+	close(slow.unblock)
+	require.NoError(t, tfc.Shutdown(context.Background()))
+	assert.Equal(t, 2, slow.SpansCount())
+}
+
+func TestMetricsConcurrentFanOut(t *testing.T) {
+	sinks := []*consumertest.MetricsSink{new(consumertest.MetricsSink), new(consumertest.MetricsSink)}
+	mfc := NewMetricsConcurrentFanOutConnector(ConcurrentFanOutParams{}, []consumer.MetricsConsumer{sinks[0], sinks[1]})
+	require.NoError(t, mfc.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := testdata.GenerateMetricsOneMetric()
+	require.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
+
+	require.NoError(t, mfc.Shutdown(context.Background()))
+	for _, sink := range sinks {
+		assert.Equal(t, 1, sink.MetricsCount())
+		assert.EqualValues(t, md, sink.AllMetrics()[0])
+	}
+}
+
+func TestLogsConcurrentFanOut(t *testing.T) {
+	sinks := []*consumertest.LogsSink{new(consumertest.LogsSink), new(consumertest.LogsSink)}
+	lfc := NewLogsConcurrentFanOutConnector(ConcurrentFanOutParams{}, []consumer.LogsConsumer{sinks[0], sinks[1]})
+	require.NoError(t, lfc.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := testdata.GenerateLogDataOneLog()
+	require.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
+
+	require.NoError(t, lfc.Shutdown(context.Background()))
+	for _, sink := range sinks {
+		assert.Equal(t, 1, sink.LogRecordsCount())
+		assert.EqualValues(t, ld, sink.AllLogs()[0])
+	}
+}
+
+func TestConcurrentFanOut_ShutdownTimeout(t *testing.T) {
+	slow := newBlockingTracesConsumer()
+	defer close(slow.unblock)
+	tfc := NewTracesConcurrentFanOutConnector(ConcurrentFanOutParams{}, []consumer.TracesConsumer{slow})
+	require.NoError(t, tfc.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	<-slow.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, tfc.Shutdown(ctx))
+}
+
+func TestConcurrentFanOut_ShutdownTimeoutStopsConsumers(t *testing.T) {
+	slow := newBlockingTracesConsumer()
+	tfc := NewTracesConcurrentFanOutConnector(
+		ConcurrentFanOutParams{PipelineName: "traces/abort", ConsumerNames: []string{"slow"}},
+		[]consumer.TracesConsumer{slow})
+	require.NoError(t, view.Register(FanOutMetricViews()...))
+	defer view.Unregister(FanOutMetricViews()...)
+	require.NoError(t, tfc.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraceDataOneSpan()
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	<-slow.started
+	for i := 0; i < 3; i++ {
+		require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, tfc.Shutdown(ctx))
+
+	// The consumer is not called anymore once the shutdown returned, the buffered
+	// data is dropped.
+	close(slow.unblock)
+	assert.Eventually(t, func() bool {
+		rows, err := view.RetrieveData(statFanOutDroppedBatches.Name())
+		return err == nil && len(rows) == 1 && rows[0].Data.(*view.SumData).Value == 3
+	}, 5*time.Second, 5*time.Millisecond)
+	assert.Len(t, slow.started, 0)
+	assert.Equal(t, 1, slow.SpansCount())
+}
+
+func TestConcurrentFanOut_ShutdownWhileDispatchBlocked(t *testing.T) {
+	slow := newBlockingTracesConsumer()
+	defer close(slow.unblock)
+	tfc := NewTracesConcurrentFanOutConnector(
+		ConcurrentFanOutParams{Settings: configmodels.FanOutSettings{Concurrent: true, BufferSize: 1}},
+		[]consumer.TracesConsumer{slow})
+	require.NoError(t, tfc.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraceDataOneSpan()
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	<-slow.started
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// The buffer is full and the dispatch blocks without a deadline, the shutdown
+	// still returns at its deadline and unblocks the dispatch.
+	dispatched := make(chan error)
+	go func() {
+		dispatched <- tfc.ConsumeTraces(context.Background(), td)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, tfc.Shutdown(ctx))
+	assert.Equal(t, errFanOutStopped, <-dispatched)
+}
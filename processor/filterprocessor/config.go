@@ -15,6 +15,8 @@
 package filterprocessor
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/internal/processor/filtermetric"
 )
@@ -37,3 +39,14 @@ type MetricFilters struct {
 	// If both Include and Exclude are specified, Include filtering occurs first.
 	Exclude *filtermetric.MatchProperties `mapstructure:"exclude"`
 }
+
+// Validate checks that the matchers of the include and exclude properties can be created.
+func (cfg *Config) Validate() error {
+	if _, err := createMatcher(cfg.Metrics.Include); err != nil {
+		return fmt.Errorf("invalid include properties: %w", err)
+	}
+	if _, err := createMatcher(cfg.Metrics.Exclude); err != nil {
+		return fmt.Errorf("invalid exclude properties: %w", err)
+	}
+	return nil
+}
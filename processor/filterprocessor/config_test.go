@@ -311,3 +311,13 @@ func TestLoadingConfigExpr(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadingConfigInvalid(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	factories.Processors[typeStr] = NewFactory()
+
+	_, err = configtest.LoadConfigFile(t, path.Join(".", "testdata", "config_invalid.yaml"), factories)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid configuration of processors "filter/include": invalid include properties`)
+}
@@ -52,5 +52,29 @@ type Config struct {
 	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
 }
 
+// Validate checks that the check interval and either the fixed or the percentage limits
+// are set, and that the spike limit is smaller than the limit.
+func (cfg *Config) Validate() error {
+	if cfg.CheckInterval <= 0 {
+		return errCheckIntervalOutOfRange
+	}
+	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
+		return errLimitOutOfRange
+	}
+	if cfg.MemoryLimitMiB != 0 {
+		if cfg.MemorySpikeLimitMiB >= cfg.MemoryLimitMiB {
+			return errMemSpikeLimitOutOfRange
+		}
+		return nil
+	}
+	if cfg.MemoryLimitPercentage > 100 || cfg.MemorySpikePercentage == 0 || cfg.MemorySpikePercentage > 100 {
+		return errPercentageLimitOutOfRange
+	}
+	if cfg.MemorySpikePercentage >= cfg.MemoryLimitPercentage {
+		return errMemSpikeLimitOutOfRange
+	}
+	return nil
+}
+
 // Name of BallastSizeMiB config option.
 const ballastSizeMibKey = "ballast_size_mib"
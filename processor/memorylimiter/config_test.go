@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
 )
@@ -34,10 +35,9 @@ func TestLoadConfig(t *testing.T) {
 	factories.Processors[typeStr] = factory
 	require.NoError(t, err)
 
-	cfg, err := configtest.LoadConfigFile(
-		t,
-		path.Join(".", "testdata", "config.yaml"),
-		factories)
+	// The empty configuration is not valid, it is loaded without validating it.
+	v := configtest.NewViperFromYamlFile(t, path.Join(".", "testdata", "config.yaml"))
+	cfg, err := config.Load(v, factories)
 
 	require.Nil(t, err)
 	require.NotNil(t, cfg)
@@ -64,3 +64,55 @@ func TestLoadConfig(t *testing.T) {
 			BallastSizeMiB:      2000,
 		})
 }
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name:    "zero_checkInterval",
+			cfg:     Config{MemoryLimitMiB: 100},
+			wantErr: errCheckIntervalOutOfRange,
+		},
+		{
+			name:    "zero_memAllocLimit",
+			cfg:     Config{CheckInterval: time.Second},
+			wantErr: errLimitOutOfRange,
+		},
+		{
+			name:    "memSpikeLimit_gt_memAllocLimit",
+			cfg:     Config{CheckInterval: time.Second, MemoryLimitMiB: 1, MemorySpikeLimitMiB: 2},
+			wantErr: errMemSpikeLimitOutOfRange,
+		},
+		{
+			name:    "percentage_gt_hundred",
+			cfg:     Config{CheckInterval: time.Second, MemoryLimitPercentage: 101, MemorySpikePercentage: 10},
+			wantErr: errPercentageLimitOutOfRange,
+		},
+		{
+			name:    "zero_spikePercentage",
+			cfg:     Config{CheckInterval: time.Second, MemoryLimitPercentage: 50},
+			wantErr: errPercentageLimitOutOfRange,
+		},
+		{
+			name:    "spikePercentage_gt_percentage",
+			cfg:     Config{CheckInterval: time.Second, MemoryLimitPercentage: 50, MemorySpikePercentage: 60},
+			wantErr: errMemSpikeLimitOutOfRange,
+		},
+		{
+			name: "fixed",
+			cfg:  Config{CheckInterval: time.Second, MemoryLimitMiB: 4000, MemorySpikeLimitMiB: 500},
+		},
+		{
+			name: "percentage",
+			cfg:  Config{CheckInterval: time.Second, MemoryLimitPercentage: 50, MemorySpikePercentage: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.cfg.Validate())
+		})
+	}
+}
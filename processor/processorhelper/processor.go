@@ -40,6 +40,11 @@ type Shutdown func(context.Context) error
 type TProcessor interface {
 	// ProcessTraces is a helper function that processes the incoming data and returns the data to be sent to the next component.
 	// If error is returned then returned data are ignored. It MUST not call the next component.
+	//
+	// Unless the processor is created with capabilities not mutating the data, the input
+	// is made writable with pdata.Traces.Writable, which copies all the resources if the data
+	// is shared with other pipelines. Processors modifying only some of the resources can
+	// implement the consumer directly and copy them with pdata.Traces.WritableResourceSpans.
 	ProcessTraces(context.Context, pdata.Traces) (pdata.Traces, error)
 }
 
@@ -47,6 +52,11 @@ type TProcessor interface {
 type MProcessor interface {
 	// ProcessMetrics is a helper function that processes the incoming data and returns the data to be sent to the next component.
 	// If error is returned then returned data are ignored. It MUST not call the next component.
+	//
+	// Unless the processor is created with capabilities not mutating the data, the input
+	// is made writable with pdata.Metrics.Writable, which copies all the resources if the data
+	// is shared with other pipelines. Processors modifying only some of the resources can
+	// implement the consumer directly and copy them with pdata.Metrics.WritableResourceMetrics.
 	ProcessMetrics(context.Context, pdata.Metrics) (pdata.Metrics, error)
 }
 
@@ -54,6 +64,11 @@ type MProcessor interface {
 type LProcessor interface {
 	// ProcessLogs is a helper function that processes the incoming data and returns the data to be sent to the next component.
 	// If error is returned then returned data are ignored. It MUST not call the next component.
+	//
+	// Unless the processor is created with capabilities not mutating the data, the input
+	// is made writable with pdata.Logs.Writable, which copies all the resources if the data
+	// is shared with other pipelines. Processors modifying only some of the resources can
+	// implement the consumer directly and copy them with pdata.Logs.WritableResourceLogs.
 	ProcessLogs(context.Context, pdata.Logs) (pdata.Logs, error)
 }
 
@@ -102,6 +117,9 @@ func newBaseProcessor(fullName string, options ...Option) baseProcessor {
 	for _, op := range options {
 		op(&be)
 	}
+	// The helper makes the input writable before processing it, or marks the output
+	// shared if the input is, see processTraces.
+	be.capabilities.SupportsSharedData = true
 
 	return be
 }
@@ -132,9 +150,21 @@ type tracesProcessor struct {
 	nextConsumer consumer.TracesConsumer
 }
 
+func (mp *tracesProcessor) processTraces(ctx context.Context, td pdata.Traces) (pdata.Traces, error) {
+	if !mp.capabilities.MutatesConsumedData {
+		out, err := mp.processor.ProcessTraces(ctx, td)
+		if td.IsShared() && !out.IsShared() {
+			// The output may reference the shared data of the input.
+			out = out.MarkShared()
+		}
+		return out, err
+	}
+	return mp.processor.ProcessTraces(ctx, td.Writable())
+}
+
 func (mp *tracesProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
 	var err error
-	td, err = mp.processor.ProcessTraces(ctx, td)
+	td, err = mp.processTraces(ctx, td)
 	if err != nil {
 		return err
 	}
@@ -170,9 +200,21 @@ type metricsProcessor struct {
 	nextConsumer consumer.MetricsConsumer
 }
 
+func (mp *metricsProcessor) processMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	if !mp.capabilities.MutatesConsumedData {
+		out, err := mp.processor.ProcessMetrics(ctx, md)
+		if md.IsShared() && !out.IsShared() {
+			// The output may reference the shared data of the input.
+			out = out.MarkShared()
+		}
+		return out, err
+	}
+	return mp.processor.ProcessMetrics(ctx, md.Writable())
+}
+
 func (mp *metricsProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
 	var err error
-	md, err = mp.processor.ProcessMetrics(ctx, md)
+	md, err = mp.processMetrics(ctx, md)
 	if err != nil {
 		if err == ErrSkipProcessingData {
 			return nil
@@ -211,9 +253,21 @@ type logProcessor struct {
 	nextConsumer consumer.LogsConsumer
 }
 
+func (lp *logProcessor) processLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	if !lp.capabilities.MutatesConsumedData {
+		out, err := lp.processor.ProcessLogs(ctx, ld)
+		if ld.IsShared() && !out.IsShared() {
+			// The output may reference the shared data of the input.
+			out = out.MarkShared()
+		}
+		return out, err
+	}
+	return lp.processor.ProcessLogs(ctx, ld.Writable())
+}
+
 func (lp *logProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
 	var err error
-	ld, err = lp.processor.ProcessLogs(ctx, ld)
+	ld, err = lp.processLogs(ctx, ld)
 	if err != nil {
 		return err
 	}
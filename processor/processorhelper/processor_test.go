@@ -75,9 +75,11 @@ func TestWithShutdown_ReturnError(t *testing.T) {
 func TestWithCapabilities(t *testing.T) {
 	bp := newBaseProcessor(testFullName)
 	assert.True(t, bp.GetCapabilities().MutatesConsumedData)
+	assert.True(t, bp.GetCapabilities().SupportsSharedData)
 
 	bp = newBaseProcessor(testFullName, WithCapabilities(component.ProcessorCapabilities{MutatesConsumedData: false}))
 	assert.False(t, bp.GetCapabilities().MutatesConsumedData)
+	assert.True(t, bp.GetCapabilities().SupportsSharedData)
 }
 
 func TestNewTraceExporter(t *testing.T) {
@@ -104,6 +106,27 @@ func TestNewTraceExporter_ProcessTraceError(t *testing.T) {
 	assert.Equal(t, want, me.ConsumeTraces(context.Background(), testdata.GenerateTraceDataEmpty()))
 }
 
+func TestNewTraceExporter_SharedData(t *testing.T) {
+	tp := &sharedTProcessor{}
+	sink := new(consumertest.TracesSink)
+	me, err := NewTraceProcessor(testCfg, sink, tp)
+	require.NoError(t, err)
+
+	// The data is made writable for the processors mutating it.
+	shares := testdata.GenerateTraceDataOneSpan().Share(2)
+	assert.NoError(t, me.ConsumeTraces(context.Background(), shares[0]))
+	assert.False(t, tp.gotShared)
+
+	// The output of other processors may share the data of their input.
+	me, err = NewTraceProcessor(testCfg, sink, tp, WithCapabilities(component.ProcessorCapabilities{MutatesConsumedData: false}))
+	require.NoError(t, err)
+	assert.NoError(t, me.ConsumeTraces(context.Background(), shares[1]))
+	assert.True(t, tp.gotShared)
+	require.Len(t, sink.AllTraces(), 2)
+	assert.False(t, sink.AllTraces()[0].IsShared())
+	assert.True(t, sink.AllTraces()[1].IsShared())
+}
+
 func TestNewMetricsExporter(t *testing.T) {
 	me, err := NewMetricsProcessor(testCfg, consumertest.NewMetricsNop(), newTestMProcessor(nil))
 	require.NoError(t, err)
@@ -170,6 +193,18 @@ func (ttp *testTProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pd
 	return td, ttp.retError
 }
 
+// sharedTProcessor returns new traces referencing the spans of its input.
+type sharedTProcessor struct {
+	gotShared bool
+}
+
+func (stp *sharedTProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	stp.gotShared = td.IsShared()
+	out := pdata.NewTraces()
+	out.ResourceSpans().Append(td.ResourceSpans().At(0))
+	return out, nil
+}
+
 type testMProcessor struct {
 	retError error
 }
@@ -272,7 +272,7 @@ func (sp *queuedProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics)
 }
 
 func (sp *queuedProcessor) GetCapabilities() component.ProcessorCapabilities {
-	return component.ProcessorCapabilities{MutatesConsumedData: false}
+	return component.ProcessorCapabilities{MutatesConsumedData: false, SupportsSharedData: true}
 }
 
 // Shutdown is invoked during service shutdown.
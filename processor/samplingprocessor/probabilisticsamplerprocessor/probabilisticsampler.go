@@ -79,6 +79,10 @@ func (tsp *tracesamplerprocessor) ConsumeTraces(ctx context.Context, td pdata.Tr
 		}
 		tsp.processTraces(rspan, sampledTraceData)
 	}
+	if td.IsShared() {
+		// The sampled spans are shared with the other users of td.
+		sampledTraceData = sampledTraceData.MarkShared()
+	}
 	return tsp.nextConsumer.ConsumeTraces(ctx, sampledTraceData)
 }
 
@@ -122,7 +126,7 @@ func (tsp *tracesamplerprocessor) processTraces(resourceSpans pdata.ResourceSpan
 }
 
 func (tsp *tracesamplerprocessor) GetCapabilities() component.ProcessorCapabilities {
-	return component.ProcessorCapabilities{MutatesConsumedData: false}
+	return component.ProcessorCapabilities{MutatesConsumedData: false, SupportsSharedData: true}
 }
 
 // Start is invoked during service startup.
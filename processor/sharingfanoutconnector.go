@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// This file contains implementations of sharing Trace/Metrics connectors
+// that fan out the data to multiple other consumers. Sharing connectors share
+// the data between the consumers with copy-on-write semantics, see
+// pdata.Traces.Share, which ensures each consumer is free to modify it after
+// making it writable: the data is only copied for the consumers that do it.
+
+// NewMetricsSharingFanOutConnector wraps multiple metrics consumers in a single one and shares the data
+// between them.
+func NewMetricsSharingFanOutConnector(mcs []consumer.MetricsConsumer) consumer.MetricsConsumer {
+	if len(mcs) == 1 {
+		// Don't wrap if no need to do it.
+		return mcs[0]
+	}
+	return metricsSharingFanOutConnector(mcs)
+}
+
+type metricsSharingFanOutConnector []consumer.MetricsConsumer
+
+var _ consumer.MetricsConsumer = (*metricsSharingFanOutConnector)(nil)
+
+// ConsumeMetrics exports the MetricsData to all consumers wrapped by the current one.
+func (mfc metricsSharingFanOutConnector) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	var errs []error
+	for i, shared := range md.Share(len(mfc)) {
+		if err := mfc[i].ConsumeMetrics(ctx, shared); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// NewTracesSharingFanOutConnector wraps multiple traces consumers in a single one and shares the data
+// between them.
+func NewTracesSharingFanOutConnector(tcs []consumer.TracesConsumer) consumer.TracesConsumer {
+	if len(tcs) == 1 {
+		// Don't wrap if no need to do it.
+		return tcs[0]
+	}
+	return tracesSharingFanOutConnector(tcs)
+}
+
+type tracesSharingFanOutConnector []consumer.TracesConsumer
+
+var _ consumer.TracesConsumer = (*tracesSharingFanOutConnector)(nil)
+
+// ConsumeTraceData exports the span data to all trace consumers wrapped by the current one.
+func (tfc tracesSharingFanOutConnector) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	var errs []error
+	for i, shared := range td.Share(len(tfc)) {
+		if err := tfc[i].ConsumeTraces(ctx, shared); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// NewLogsSharingFanOutConnector wraps multiple logs consumers in a single one and shares the data
+// between them.
+func NewLogsSharingFanOutConnector(lcs []consumer.LogsConsumer) consumer.LogsConsumer {
+	if len(lcs) == 1 {
+		// Don't wrap if no need to do it.
+		return lcs[0]
+	}
+	return logsSharingFanOutConnector(lcs)
+}
+
+type logsSharingFanOutConnector []consumer.LogsConsumer
+
+var _ consumer.LogsConsumer = (*logsSharingFanOutConnector)(nil)
+
+// ConsumeLogs exports the log data to all consumers wrapped by the current one.
+func (lfc logsSharingFanOutConnector) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	var errs []error
+	for i, shared := range ld.Share(len(lfc)) {
+		if err := lfc[i].ConsumeLogs(ctx, shared); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
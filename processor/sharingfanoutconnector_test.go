@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func TestTraceProcessorSharingNotMultiplexing(t *testing.T) {
+	nop := consumertest.NewTracesNop()
+	tfc := NewTracesSharingFanOutConnector([]consumer.TracesConsumer{nop})
+	assert.Same(t, nop, tfc)
+}
+
+func TestTraceProcessorSharingMultiplexing(t *testing.T) {
+	processors := make([]consumer.TracesConsumer, 3)
+	for i := range processors {
+		processors[i] = new(consumertest.TracesSink)
+	}
+
+	tfc := NewTracesSharingFanOutConnector(processors)
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+
+	var wantSpansCount = 0
+	for i := 0; i < 2; i++ {
+		wantSpansCount += td.SpanCount()
+		err := tfc.ConsumeTraces(context.Background(), td)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	for i, p := range processors {
+		m := p.(*consumertest.TracesSink)
+		assert.Equal(t, wantSpansCount, m.SpansCount())
+		spanOrig := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+		allTraces := m.AllTraces()
+		// The consumers share the data until they make it writable.
+		assert.True(t, allTraces[0].IsShared())
+		spanClone := allTraces[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+		assert.True(t, spanOrig == spanClone)
+
+		// All the consumers but the last one get a copy when making it writable.
+		writable := allTraces[0].Writable()
+		assert.False(t, writable.IsShared())
+		spanClone = writable.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+		if i < len(processors)-1 {
+			assert.True(t, td.ResourceSpans().At(0).Resource() != writable.ResourceSpans().At(0).Resource())
+			assert.True(t, spanOrig != spanClone)
+		} else {
+			assert.True(t, td.ResourceSpans().At(0).Resource() == writable.ResourceSpans().At(0).Resource())
+			assert.True(t, spanOrig == spanClone)
+		}
+		assert.EqualValues(t, td.ResourceSpans().At(0).Resource(), writable.ResourceSpans().At(0).Resource())
+		assert.EqualValues(t, spanOrig, spanClone)
+	}
+}
+
+func TestMetricsProcessorSharingNotMultiplexing(t *testing.T) {
+	nop := consumertest.NewMetricsNop()
+	mfc := NewMetricsSharingFanOutConnector([]consumer.MetricsConsumer{nop})
+	assert.Same(t, nop, mfc)
+}
+
+func TestMetricsProcessorSharingMultiplexing(t *testing.T) {
+	processors := make([]consumer.MetricsConsumer, 3)
+	for i := range processors {
+		processors[i] = new(consumertest.MetricsSink)
+	}
+
+	mfc := NewMetricsSharingFanOutConnector(processors)
+	md := testdata.GeneratMetricsAllTypesWithSampleDatapoints()
+
+	var wantMetricsCount = 0
+	for i := 0; i < 2; i++ {
+		wantMetricsCount += md.MetricCount()
+		err := mfc.ConsumeMetrics(context.Background(), md)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	for i, p := range processors {
+		m := p.(*consumertest.MetricsSink)
+		assert.Equal(t, wantMetricsCount, m.MetricsCount())
+		metricOrig := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+		allMetrics := m.AllMetrics()
+		// The consumers share the data until they make it writable.
+		assert.True(t, allMetrics[0].IsShared())
+		metricClone := allMetrics[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+		assert.True(t, metricOrig == metricClone)
+
+		// All the consumers but the last one get a copy when making it writable.
+		writable := allMetrics[0].Writable()
+		assert.False(t, writable.IsShared())
+		metricClone = writable.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+		if i < len(processors)-1 {
+			assert.True(t, md.ResourceMetrics().At(0).Resource() != writable.ResourceMetrics().At(0).Resource())
+			assert.True(t, metricOrig != metricClone)
+		} else {
+			assert.True(t, md.ResourceMetrics().At(0).Resource() == writable.ResourceMetrics().At(0).Resource())
+			assert.True(t, metricOrig == metricClone)
+		}
+		assert.EqualValues(t, md.ResourceMetrics().At(0).Resource(), writable.ResourceMetrics().At(0).Resource())
+		assert.EqualValues(t, metricOrig, metricClone)
+	}
+}
+
+func TestLogsProcessorSharingNotMultiplexing(t *testing.T) {
+	nop := consumertest.NewLogsNop()
+	lfc := NewLogsSharingFanOutConnector([]consumer.LogsConsumer{nop})
+	assert.Same(t, nop, lfc)
+}
+
+func TestLogsProcessorSharingMultiplexing(t *testing.T) {
+	processors := make([]consumer.LogsConsumer, 3)
+	for i := range processors {
+		processors[i] = new(consumertest.LogsSink)
+	}
+
+	lfc := NewLogsSharingFanOutConnector(processors)
+	ld := testdata.GenerateLogDataOneLog()
+
+	var wantLogsCount = 0
+	for i := 0; i < 2; i++ {
+		wantLogsCount += ld.LogRecordCount()
+		err := lfc.ConsumeLogs(context.Background(), ld)
+		if err != nil {
+			t.Errorf("Wanted nil got error")
+			return
+		}
+	}
+
+	for i, p := range processors {
+		m := p.(*consumertest.LogsSink)
+		assert.Equal(t, wantLogsCount, m.LogRecordsCount())
+		logOrig := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+		allLogs := m.AllLogs()
+		// The consumers share the data until they make it writable.
+		assert.True(t, allLogs[0].IsShared())
+		logClone := allLogs[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+		assert.True(t, logOrig == logClone)
+
+		// All the consumers but the last one get a copy when making it writable.
+		writable := allLogs[0].Writable()
+		assert.False(t, writable.IsShared())
+		logClone = writable.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+		if i < len(processors)-1 {
+			assert.True(t, ld.ResourceLogs().At(0).Resource() != writable.ResourceLogs().At(0).Resource())
+			assert.True(t, logOrig != logClone)
+		} else {
+			assert.True(t, ld.ResourceLogs().At(0).Resource() == writable.ResourceLogs().At(0).Resource())
+			assert.True(t, logOrig == logClone)
+		}
+		assert.EqualValues(t, ld.ResourceLogs().At(0).Resource(), writable.ResourceLogs().At(0).Resource())
+		assert.EqualValues(t, logOrig, logClone)
+	}
+}
+
+// writableTracesConsumer makes the data writable like the processors that mutate it.
+type writableTracesConsumer struct{}
+
+func (writableTracesConsumer) ConsumeTraces(_ context.Context, td pdata.Traces) error {
+	td.Writable().ResourceSpans().At(0).Resource().Attributes().UpsertString("key", "value")
+	return nil
+}
+
+func BenchmarkTracesSharingFanOut(b *testing.B) {
+	for _, mutating := range []int{0, 1, 3} {
+		tcs := make([]consumer.TracesConsumer, 3)
+		for i := range tcs {
+			tcs[i] = consumertest.NewTracesNop()
+			if i < mutating {
+				tcs[i] = writableTracesConsumer{}
+			}
+		}
+		cloning := NewTracesCloningFanOutConnector(tcs)
+		sharing := NewTracesSharingFanOutConnector(tcs)
+
+		b.Run(fmt.Sprintf("clone/mutating=%d", mutating), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_ = cloning.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(100))
+			}
+		})
+		b.Run(fmt.Sprintf("share/mutating=%d", mutating), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_ = sharing.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(100))
+			}
+		})
+	}
+}
+
+// mutatingTracesProcessor modifies the first resource, with processorhelper it gets data
+// made writable with Writable.
+type mutatingTracesProcessor struct{}
+
+func (mutatingTracesProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	td.ResourceSpans().At(0).Resource().Attributes().UpsertString("key", "value")
+	return td, nil
+}
+
+// writableResourceTracesConsumer modifies the first resource after making only this
+// resource writable.
+type writableResourceTracesConsumer struct{}
+
+func (writableResourceTracesConsumer) ConsumeTraces(_ context.Context, td pdata.Traces) error {
+	td.WritableResourceSpans(0).Resource().Attributes().UpsertString("key", "value")
+	return nil
+}
+
+func generateTraceDataManyResources(resourceCount int) pdata.Traces {
+	td := pdata.NewTraces()
+	for i := 0; i < resourceCount; i++ {
+		testdata.GenerateTraceDataManySpansSameResource(10).ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+	return td
+}
+
+// BenchmarkTracesSharingFanOutProcessor measures the fan out to pipelines modifying one
+// resource of the data, either with a processor created by processorhelper, which copies
+// all the resources of the shared data, or with a consumer copying only that resource.
+func BenchmarkTracesSharingFanOutProcessor(b *testing.B) {
+	processors := make([]consumer.TracesConsumer, 3)
+	perResource := make([]consumer.TracesConsumer, 3)
+	for i := range processors {
+		cfg := &configmodels.ProcessorSettings{TypeVal: "mutating", NameVal: fmt.Sprintf("mutating/%d", i)}
+		tp, err := processorhelper.NewTraceProcessor(cfg, consumertest.NewTracesNop(), mutatingTracesProcessor{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		processors[i] = tp
+		perResource[i] = writableResourceTracesConsumer{}
+	}
+	fanOuts := []struct {
+		name string
+		tc   consumer.TracesConsumer
+	}{
+		{name: "clone/processorhelper", tc: NewTracesCloningFanOutConnector(processors)},
+		{name: "share/processorhelper", tc: NewTracesSharingFanOutConnector(processors)},
+		{name: "share/per_resource", tc: NewTracesSharingFanOutConnector(perResource)},
+	}
+
+	for _, fo := range fanOuts {
+		b.Run(fo.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_ = fo.tc.ConsumeTraces(context.Background(), generateTraceDataManyResources(10))
+			}
+		})
+	}
+}
@@ -74,6 +74,9 @@ func newOtlpReceiver(cfg *Config, logger *zap.Logger) (*otlpReceiver, error) {
 		// Use our custom JSON marshaler instead of default Protobuf JSON marshaler.
 		// This is needed because OTLP spec defines encoding for trace and span id
 		// and it is only possible to do using Gogoproto-compatible JSONPb marshaler.
+		// The gateway needs a marshaler for all the messages it handles, not only the
+		// requests decoded by pdata's FromOtlpJSONBytes, but both use gogo jsonpb
+		// ignoring unknown fields and accept the same requests.
 		jsonpb := &JSONPb{
 			EmitDefaults: true,
 			Indent:       "  ",
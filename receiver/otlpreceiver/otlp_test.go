@@ -238,6 +238,26 @@ func testHTTPJSONRequest(t *testing.T, url string, sink *consumertest.TracesSink
 
 }
 
+func TestJsonHttpPdataEncoding(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := new(consumertest.TracesSink)
+	ocr := newHTTPReceiver(t, addr, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	// The requests encoded by pdata are received unchanged.
+	body, err := traceOtlp.ToOtlpJSONBytes()
+	require.NoError(t, err)
+	resp, err := http.Post(fmt.Sprintf("http://%s/v1/traces", addr), "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	allTraces := sink.AllTraces()
+	require.Len(t, allTraces, 1)
+	assert.EqualValues(t, traceOtlp, allTraces[0])
+}
+
 func TestJsonMarshaling(t *testing.T) {
 	m := jsonpb.Marshaler{}
 	json, err := m.MarshalToString(&resourceSpansOtlp)
@@ -17,41 +17,94 @@ package builder
 import (
 	"flag"
 	"fmt"
+	"strings"
+	"time"
 )
 
 const (
 	// flags
-	configCfg      = "config"
-	memBallastFlag = "mem-ballast-size-mib"
+	configCfg       = "config"
+	printConfigFlag = "print-config"
+	memBallastFlag  = "mem-ballast-size-mib"
+	drainFlag       = "shutdown-drain-timeout"
+
+	defaultDrainTimeout = 10 * time.Second
 
 	kindLogKey        = "component_kind"
 	kindLogsReceiver  = "receiver"
 	kindLogsProcessor = "processor"
 	kindLogsExporter  = "exporter"
 	kindLogExtension  = "extension"
+	kindLogsConnector = "connector"
 	typeLogKey        = "component_type"
 	nameLogKey        = "component_name"
 )
 
 var (
-	configFile     *string
+	configFiles    *stringArrayValue
+	printConfig    *bool
 	memBallastSize *uint
+	drainTimeout   *time.Duration
 )
 
+// stringArrayValue is a flag.Value that accumulates the values of a repeated flag.
+type stringArrayValue []string
+
+func (s *stringArrayValue) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringArrayValue) Set(val string) error {
+	*s = append(*s, val)
+	return nil
+}
+
 // Flags adds flags related to basic building of the collector application to the given flagset.
 func Flags(flags *flag.FlagSet) {
-	configFile = flags.String(configCfg, "", "Path to the config file")
+	configFiles = new(stringArrayValue)
+	flags.Var(configFiles, configCfg, "Path to a config file or to a directory of config files. "+
+		"Can be repeated, the files are merged in the given order. Files in a directory are merged in lexical order.")
+	printConfig = flags.Bool(printConfigFlag, false, "Print the merged configuration with secrets redacted and exit.")
 	memBallastSize = flags.Uint(memBallastFlag, 0,
 		fmt.Sprintf("Flag to specify size of memory (MiB) ballast to set. Ballast is not used when this is not specified. "+
 			"default settings: 0"))
+	drainTimeout = flags.Duration(drainFlag, defaultDrainTimeout,
+		"Maximum time to wait on shutdown for the processors and the exporters to send the data they hold. "+
+			"The data that is not sent when it expires is dropped.")
 }
 
-// GetConfigFile gets the config file from the config file flag.
+// GetConfigFile gets the first config file from the config file flag.
+//
+// Deprecated: use GetConfigFiles, the config flag can be repeated.
 func GetConfigFile() string {
-	return *configFile
+	if len(*configFiles) == 0 {
+		return ""
+	}
+	return (*configFiles)[0]
+}
+
+// GetConfigFiles gets the config files and directories from the config file flags,
+// in the order they were given.
+func GetConfigFiles() []string {
+	return *configFiles
+}
+
+// PrintConfig returns true if the merged configuration must be printed instead of
+// running the collector.
+func PrintConfig() bool {
+	return *printConfig
 }
 
 // MemBallastSize returns the size of memory ballast to use in MBs
 func MemBallastSize() int {
 	return int(*memBallastSize)
 }
+
+// ShutdownDrainTimeout returns the maximum time to wait on shutdown for the pipelines
+// to send the data they hold. The default is returned if the flags were not added.
+func ShutdownDrainTimeout() time.Duration {
+	if drainTimeout == nil {
+		return defaultDrainTimeout
+	}
+	return *drainTimeout
+}
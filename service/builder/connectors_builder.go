@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// connectorKey identifies a connector built for a data type: a connector that is an
+// exporter of pipelines of multiple data types is built once for each of them.
+type connectorKey struct {
+	name     string
+	dataType configmodels.DataType
+}
+
+// builtConnector is a connector that is built based on a config for a data type.
+type builtConnector struct {
+	connector component.Connector
+
+	// owned is true once a pipeline sending data to the connector manages its lifecycle.
+	owned bool
+
+	// depth is the maximal depth of the pipelines the connector sends data to.
+	depth int
+}
+
+// hasConnectors returns true if the pipeline receives data from or sends data to connectors.
+func (pb *PipelinesBuilder) hasConnectors(pipelineCfg *configmodels.Pipeline) bool {
+	for _, name := range pipelineCfg.Receivers {
+		if pb.config.Connectors[name] != nil {
+			return true
+		}
+	}
+	for _, name := range pipelineCfg.Exporters {
+		if pb.config.Connectors[name] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getOrBuildConnector returns the connector with the given name consuming the data
+// type of the pipeline, it builds the connector and the pipelines it sends data to
+// if they are not built yet.
+func (pb *PipelinesBuilder) getOrBuildConnector(ctx context.Context, name string, pipelineCfg *configmodels.Pipeline) (*builtConnector, error) {
+	key := connectorKey{name: name, dataType: pipelineCfg.InputType}
+	if bc := pb.connectors[key]; bc != nil {
+		return bc, nil
+	}
+
+	cfg := pb.config.Connectors[name]
+	factory := pb.connectorFactories[cfg.Type()]
+	if factory == nil {
+		return nil, fmt.Errorf("connector factory not found for type: %s", cfg.Type())
+	}
+
+	// Build the pipelines the connector is a receiver of, in a deterministic order.
+	names := make([]string, 0, len(pb.config.Service.Pipelines))
+	for pipelineName, pipeline := range pb.config.Service.Pipelines {
+		if hasReceiver(pipeline, name) {
+			names = append(names, pipelineName)
+		}
+	}
+	sort.Strings(names)
+
+	bc := &builtConnector{}
+	pipelinesByType := make(map[configmodels.DataType][]*builtPipeline)
+	for _, pipelineName := range names {
+		pipeline := pb.config.Service.Pipelines[pipelineName]
+		bp, err := pb.getOrBuildPipeline(ctx, pipeline)
+		if err != nil {
+			return nil, err
+		}
+		pipelinesByType[pipeline.InputType] = append(pipelinesByType[pipeline.InputType], bp)
+		if bp.depth > bc.depth {
+			bc.depth = bp.depth
+		}
+	}
+
+	var nextConsumers component.ConnectorConsumers
+	if pipelines := pipelinesByType[configmodels.TracesDataType]; len(pipelines) != 0 {
+		nextConsumers.Traces = buildFanoutTraceConsumer(pipelines)
+		if anyMutatesConsumedData(pipelines) {
+			if allSupportSharedData(pipelines) {
+				nextConsumers.Traces = sharedTracesConsumer{nextConsumers.Traces}
+			} else {
+				nextConsumers.Traces = cloningTracesConsumer{nextConsumers.Traces}
+			}
+		}
+	}
+	if pipelines := pipelinesByType[configmodels.MetricsDataType]; len(pipelines) != 0 {
+		nextConsumers.Metrics = buildFanoutMetricConsumer(pipelines)
+		if anyMutatesConsumedData(pipelines) {
+			if allSupportSharedData(pipelines) {
+				nextConsumers.Metrics = sharedMetricsConsumer{nextConsumers.Metrics}
+			} else {
+				nextConsumers.Metrics = cloningMetricsConsumer{nextConsumers.Metrics}
+			}
+		}
+	}
+	if pipelines := pipelinesByType[configmodels.LogsDataType]; len(pipelines) != 0 {
+		nextConsumers.Logs = buildFanoutLogConsumer(pipelines)
+		if anyMutatesConsumedData(pipelines) {
+			if allSupportSharedData(pipelines) {
+				nextConsumers.Logs = sharedLogsConsumer{nextConsumers.Logs}
+			} else {
+				nextConsumers.Logs = cloningLogsConsumer{nextConsumers.Logs}
+			}
+		}
+	}
+
+	creationParams := component.ConnectorCreateParams{
+		Logger: pb.logger.With(
+			zap.String(kindLogKey, kindLogsConnector),
+			zap.String(typeLogKey, string(cfg.Type())),
+			zap.String(nameLogKey, cfg.Name())),
+		ApplicationStartInfo: pb.appInfo,
+	}
+
+	var err error
+	switch pipelineCfg.InputType {
+	case configmodels.TracesDataType:
+		var conn component.TracesConnector
+		conn, err = factory.CreateTracesConnector(ctx, creationParams, cfg, nextConsumers)
+		if conn != nil {
+			bc.connector = conn
+		}
+	case configmodels.MetricsDataType:
+		var conn component.MetricsConnector
+		conn, err = factory.CreateMetricsConnector(ctx, creationParams, cfg, nextConsumers)
+		if conn != nil {
+			bc.connector = conn
+		}
+	case configmodels.LogsDataType:
+		var conn component.LogsConnector
+		conn, err = factory.CreateLogsConnector(ctx, creationParams, cfg, nextConsumers)
+		if conn != nil {
+			bc.connector = conn
+		}
+	}
+
+	if err != nil {
+		if err == configerror.ErrDataTypeIsNotSupported {
+			return nil, fmt.Errorf("pipeline %q of data type %q has a connector %q, which does not support "+
+				"sending that data type to the pipelines it is a receiver of", pipelineCfg.Name, pipelineCfg.InputType, name)
+		}
+		return nil, fmt.Errorf("error creating %s connector: %v", name, err)
+	}
+
+	// Check if the factory really created the connector.
+	if bc.connector == nil {
+		return nil, fmt.Errorf("factory for %q produced a nil connector", name)
+	}
+
+	pb.connectors[key] = bc
+	return bc, nil
+}
+
+func anyMutatesConsumedData(pipelines []*builtPipeline) bool {
+	for _, pipeline := range pipelines {
+		if pipeline.MutatesConsumedData {
+			return true
+		}
+	}
+	return false
+}
+
+func allSupportSharedData(pipelines []*builtPipeline) bool {
+	for _, pipeline := range pipelines {
+		if !pipeline.SupportsSharedData {
+			return false
+		}
+	}
+	return true
+}
+
+// The data a connector receives is also sent to the other exporters of the pipeline,
+// the following consumers mark it as shared before it reaches pipelines that modify
+// it, so that it is copied when they make it writable. The data is cloned instead if
+// a pipeline has processors that do not support shared data.
+
+type sharedTracesConsumer struct {
+	next consumer.TracesConsumer
+}
+
+func (sc sharedTracesConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	return sc.next.ConsumeTraces(ctx, td.MarkShared())
+}
+
+type sharedMetricsConsumer struct {
+	next consumer.MetricsConsumer
+}
+
+func (sc sharedMetricsConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return sc.next.ConsumeMetrics(ctx, md.MarkShared())
+}
+
+type sharedLogsConsumer struct {
+	next consumer.LogsConsumer
+}
+
+func (sc sharedLogsConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return sc.next.ConsumeLogs(ctx, ld.MarkShared())
+}
+
+type cloningTracesConsumer struct {
+	next consumer.TracesConsumer
+}
+
+func (cc cloningTracesConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	return cc.next.ConsumeTraces(ctx, td.Clone())
+}
+
+type cloningMetricsConsumer struct {
+	next consumer.MetricsConsumer
+}
+
+func (cc cloningMetricsConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return cc.next.ConsumeMetrics(ctx, md.Clone())
+}
+
+type cloningLogsConsumer struct {
+	next consumer.LogsConsumer
+}
+
+func (cc cloningLogsConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return cc.next.ConsumeLogs(ctx, ld.Clone())
+}
@@ -17,6 +17,7 @@ package builder
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"go.uber.org/zap"
 
@@ -112,6 +113,22 @@ func (exps Exporters) ShutdownAll(ctx context.Context) error {
 	return componenterror.CombineErrors(errs)
 }
 
+// Without returns the exporters that are not present in other. Exporters reused
+// by a builder for a new configuration are present in both.
+func (exps Exporters) Without(other Exporters) Exporters {
+	existing := make(map[*builtExporter]bool, len(other))
+	for _, exp := range other {
+		existing[exp] = true
+	}
+	result := make(Exporters)
+	for cfg, exp := range exps {
+		if !existing[exp] {
+			result[cfg] = exp
+		}
+	}
+	return result
+}
+
 func (exps Exporters) ToMapByDataType() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
 
 	exportersMap := make(map[configmodels.DataType]map[configmodels.Exporter]component.Exporter)
@@ -146,6 +163,9 @@ type ExportersBuilder struct {
 	appInfo   component.ApplicationStartInfo
 	config    *configmodels.Config
 	factories map[configmodels.Type]component.ExporterFactory
+
+	prevConfig    *configmodels.Config
+	prevExporters Exporters
 }
 
 // NewExportersBuilder creates a new ExportersBuilder. Call BuildExporters() on the returned value.
@@ -155,7 +175,21 @@ func NewExportersBuilder(
 	config *configmodels.Config,
 	factories map[configmodels.Type]component.ExporterFactory,
 ) *ExportersBuilder {
-	return &ExportersBuilder{logger.With(zap.String(kindLogKey, kindLogsExporter)), appInfo, config, factories}
+	return &ExportersBuilder{
+		logger:    logger.With(zap.String(kindLogKey, kindLogsExporter)),
+		appInfo:   appInfo,
+		config:    config,
+		factories: factories,
+	}
+}
+
+// ReuseFrom makes Build reuse the exporters built for a previous configuration instead
+// of creating new ones if neither their configuration nor the data types they are
+// required for changed. Reused exporters are already running and must not be started again.
+func (eb *ExportersBuilder) ReuseFrom(prevConfig *configmodels.Config, prevExporters Exporters) *ExportersBuilder {
+	eb.prevConfig = prevConfig
+	eb.prevExporters = prevExporters
+	return eb
 }
 
 // BuildExporters exporters from config.
@@ -168,6 +202,11 @@ func (eb *ExportersBuilder) Build() (Exporters, error) {
 
 	// BuildExporters exporters based on configuration and required input data types.
 	for _, cfg := range eb.config.Exporters {
+		if exp := eb.findReusableExporter(cfg, exporterInputDataTypes[cfg]); exp != nil {
+			exporters[cfg] = exp
+			continue
+		}
+
 		componentLogger := eb.logger.With(zap.String(typeLogKey, string(cfg.Type())), zap.String(nameLogKey, cfg.Name()))
 		exp, err := eb.buildExporter(context.Background(), componentLogger, eb.appInfo, cfg, exporterInputDataTypes)
 		if err != nil {
@@ -180,6 +219,28 @@ func (eb *ExportersBuilder) Build() (Exporters, error) {
 	return exporters, nil
 }
 
+// findReusableExporter returns the exporter built for the previous configuration if
+// it can be used as is for the given config, or nil otherwise.
+func (eb *ExportersBuilder) findReusableExporter(config configmodels.Exporter, inputDataTypes dataTypeRequirements) *builtExporter {
+	if eb.prevConfig == nil {
+		return nil
+	}
+	prevCfg, ok := eb.prevConfig.Exporters[config.Name()]
+	if !ok || !reflect.DeepEqual(prevCfg, config) {
+		return nil
+	}
+	exp := eb.prevExporters[prevCfg]
+	if exp == nil || len(exp.expByDataType) != len(inputDataTypes) {
+		return nil
+	}
+	for dataType := range inputDataTypes {
+		if _, ok := exp.expByDataType[dataType]; !ok {
+			return nil
+		}
+	}
+	return exp
+}
+
 func (eb *ExportersBuilder) calcExportersRequiredDataTypes() exportersRequiredDataTypes {
 
 	// Go over all pipelines. The data type of the pipeline defines what data type
@@ -197,8 +258,11 @@ func (eb *ExportersBuilder) calcExportersRequiredDataTypes() exportersRequiredDa
 	for _, pipeline := range eb.config.Service.Pipelines {
 		// Iterate over all exporters for this pipeline.
 		for _, expName := range pipeline.Exporters {
-			// Find the exporter config by name.
+			// Find the exporter config by name, connectors are built with the pipelines.
 			exporter := eb.config.Exporters[expName]
+			if exporter == nil {
+				continue
+			}
 
 			// Create the data type requirement for the exporter if it does not exist.
 			if result[exporter] == nil {
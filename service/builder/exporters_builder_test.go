@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/opencensusexporter"
 )
@@ -272,3 +273,40 @@ func newBadExporterFactory() component.ExporterFactory {
 		return &configmodels.ExporterSettings{}
 	})
 }
+
+func TestExportersBuilder_ReuseFrom(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	prevCfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	prevExporters, err := NewExportersBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), prevCfg, factories.Exporters).Build()
+	require.NoError(t, err)
+
+	cfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	cfg.Exporters["exampleexporter/2"].(*componenttest.ExampleExporter).ExtraSetting = "changed"
+
+	exporters, err := NewExportersBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, factories.Exporters).
+		ReuseFrom(prevCfg, prevExporters).
+		Build()
+	require.NoError(t, err)
+
+	// Unchanged exporter is reused, changed exporter is rebuilt.
+	assert.Same(t, prevExporters[prevCfg.Exporters["exampleexporter"]], exporters[cfg.Exporters["exampleexporter"]])
+	assert.NotSame(t, prevExporters[prevCfg.Exporters["exampleexporter/2"]], exporters[cfg.Exporters["exampleexporter/2"]])
+
+	newExporters := exporters.Without(prevExporters)
+	require.Len(t, newExporters, 1)
+	assert.NotNil(t, newExporters[cfg.Exporters["exampleexporter/2"]])
+
+	// An exporter whose required data types change is rebuilt.
+	delete(cfg.Service.Pipelines, "metrics")
+	delete(cfg.Service.Pipelines, "metrics/2")
+	exporters, err = NewExportersBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, factories.Exporters).
+		ReuseFrom(prevCfg, prevExporters).
+		Build()
+	require.NoError(t, err)
+	assert.NotSame(t, prevExporters[prevCfg.Exporters["exampleexporter"]], exporters[cfg.Exporters["exampleexporter"]])
+	assert.Nil(t, exporters[cfg.Exporters["exampleexporter"]].getMetricExporter())
+}
@@ -102,6 +102,26 @@ func (exts Extensions) NotifyPipelineNotReady() error {
 	return componenterror.CombineErrors(errs)
 }
 
+// NotifyComponentStatus forwards the status event to the extensions implementing
+// component.StatusWatcher.
+func (exts Extensions) NotifyComponentStatus(event component.StatusEvent) {
+	for _, ext := range exts {
+		if sw, ok := ext.extension.(component.StatusWatcher); ok {
+			sw.ComponentStatusChanged(event)
+		}
+	}
+}
+
+// NotifyComponentsChanged forwards the components of the applied configuration to the
+// extensions implementing component.StatusWatcher.
+func (exts Extensions) NotifyComponentsChanged(components []component.StatusEvent) {
+	for _, ext := range exts {
+		if sw, ok := ext.extension.(component.StatusWatcher); ok {
+			sw.ComponentsChanged(components)
+		}
+	}
+}
+
 func (exts Extensions) ToMap() map[configmodels.Extension]component.ServiceExtension {
 	result := make(map[configmodels.Extension]component.ServiceExtension, len(exts))
 	for k, v := range exts {
@@ -17,6 +17,8 @@ package builder
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 
 	"go.uber.org/zap"
 
@@ -40,15 +42,86 @@ type builtPipeline struct {
 	// can mutate the TraceData or MetricsData input argument.
 	MutatesConsumedData bool
 
+	// SupportsSharedData is set to true if all processors in the pipeline
+	// support data shared with other pipelines with copy-on-write semantics.
+	SupportsSharedData bool
+
 	processors []component.Processor
+
+	// fanOut is the connector sending the data to the exporters if it must be started
+	// and shut down, i.e. if it sends the data concurrently, nil otherwise.
+	fanOut component.Component
+
+	// exporters are the exporters the pipeline fans out to.
+	exporters []*builtExporter
+
+	// connectors are the connectors the pipeline fans out to that were created for it,
+	// they are started and shut down with the pipeline.
+	connectors []component.Connector
+
+	// depth is 0 if the pipeline does not fan out to connectors, otherwise it is one more
+	// than the maximal depth of the pipelines the connectors send data to.
+	depth int
+
+	// taps record the data sent by the receivers and by each processor, in the order
+	// of the pipeline.
+	taps []*Tap
+}
+
+// Taps returns the taps of the pipeline, the tap of the receivers first, then the tap
+// of each processor in the order of the pipeline.
+func (bp *builtPipeline) Taps() []*Tap {
+	return bp.taps
+}
+
+// TracesConsumer returns the consumer the receivers of the pipeline send traces to, nil
+// if the pipeline is not a traces pipeline.
+func (bp *builtPipeline) TracesConsumer() consumer.TracesConsumer {
+	return bp.firstTC
+}
+
+// MetricsConsumer returns the consumer the receivers of the pipeline send metrics to, nil
+// if the pipeline is not a metrics pipeline.
+func (bp *builtPipeline) MetricsConsumer() consumer.MetricsConsumer {
+	return bp.firstMC
+}
+
+// LogsConsumer returns the consumer the receivers of the pipeline send logs to, nil
+// if the pipeline is not a logs pipeline.
+func (bp *builtPipeline) LogsConsumer() consumer.LogsConsumer {
+	return bp.firstLC
 }
 
 // BuiltPipelines is a map of build pipelines created from pipeline configs.
 type BuiltPipelines map[*configmodels.Pipeline]*builtPipeline
 
-func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Host) error {
+// byDepth returns the pipelines sorted by depth: the pipelines receiving data from
+// connectors are before the pipelines sending data to them.
+func (bps BuiltPipelines) byDepth() []*builtPipeline {
+	result := make([]*builtPipeline, 0, len(bps))
 	for _, bp := range bps {
+		result = append(result, bp)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].depth < result[j].depth
+	})
+	return result
+}
+
+func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Host) error {
+	// Start the pipelines receiving data from connectors first.
+	for _, bp := range bps.byDepth() {
 		bp.logger.Info("Pipeline is starting...")
+		for _, conn := range bp.connectors {
+			if err := conn.Start(ctx, host); err != nil {
+				return err
+			}
+		}
+		if bp.fanOut != nil {
+			if err := bp.fanOut.Start(ctx, host); err != nil {
+				return err
+			}
+		}
 		// Start in reverse order, starting from the back of processors pipeline.
 		// This is important so that processors that are earlier in the pipeline and
 		// reference processors that are later in the pipeline do not start sending
@@ -63,15 +136,46 @@ func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Ho
 	return nil
 }
 
+// Without returns the pipelines that are not present in other. Pipelines reused
+// by a builder for a new configuration are present in both.
+func (bps BuiltPipelines) Without(other BuiltPipelines) BuiltPipelines {
+	existing := make(map[*builtPipeline]bool, len(other))
+	for _, bp := range other {
+		existing[bp] = true
+	}
+	result := make(BuiltPipelines)
+	for cfg, bp := range bps {
+		if !existing[bp] {
+			result[cfg] = bp
+		}
+	}
+	return result
+}
+
 func (bps BuiltPipelines) ShutdownProcessors(ctx context.Context) error {
 	var errs []error
-	for _, bp := range bps {
+	// Shut down the pipelines sending data to connectors first, so that the data they
+	// flush goes through the pipelines receiving it.
+	pipelines := bps.byDepth()
+	for i := len(pipelines) - 1; i >= 0; i-- {
+		bp := pipelines[i]
 		bp.logger.Info("Pipeline is shutting down...")
 		for _, p := range bp.processors {
 			if err := p.Shutdown(ctx); err != nil {
 				errs = append(errs, err)
 			}
 		}
+		// Shut down after the processors, so that the data they flush is sent to the exporters.
+		if bp.fanOut != nil {
+			if err := bp.fanOut.Shutdown(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		for _, conn := range bp.connectors {
+			if err := conn.Shutdown(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
 		bp.logger.Info("Pipeline is shutdown.")
 	}
 
@@ -85,6 +189,17 @@ type PipelinesBuilder struct {
 	config    *configmodels.Config
 	exporters Exporters
 	factories map[configmodels.Type]component.ProcessorFactory
+
+	connectorFactories map[configmodels.Type]component.ConnectorFactory
+
+	prevConfig    *configmodels.Config
+	prevPipelines BuiltPipelines
+
+	// built are the pipelines built by Build so far, building those being built, and
+	// connectors the connectors built so far.
+	built      BuiltPipelines
+	building   map[*configmodels.Pipeline]bool
+	connectors map[connectorKey]*builtConnector
 }
 
 // NewPipelinesBuilder creates a new PipelinesBuilder. Requires exporters to be already
@@ -96,22 +211,107 @@ func NewPipelinesBuilder(
 	exporters Exporters,
 	factories map[configmodels.Type]component.ProcessorFactory,
 ) *PipelinesBuilder {
-	return &PipelinesBuilder{logger, appInfo, config, exporters, factories}
+	return &PipelinesBuilder{
+		logger:    logger,
+		appInfo:   appInfo,
+		config:    config,
+		exporters: exporters,
+		factories: factories,
+	}
+}
+
+// ReuseFrom makes Build reuse the pipelines built for a previous configuration instead
+// of creating new ones if the pipeline, its processors configuration and its exporters
+// did not change. Reused pipelines are already running and must not be started again.
+func (pb *PipelinesBuilder) ReuseFrom(prevConfig *configmodels.Config, prevPipelines BuiltPipelines) *PipelinesBuilder {
+	pb.prevConfig = prevConfig
+	pb.prevPipelines = prevPipelines
+	return pb
+}
+
+// WithConnectors sets the factories of the connectors of the configuration, which
+// are built with the pipelines they connect.
+func (pb *PipelinesBuilder) WithConnectors(factories map[configmodels.Type]component.ConnectorFactory) *PipelinesBuilder {
+	pb.connectorFactories = factories
+	return pb
 }
 
 // BuildProcessors pipeline processors from config.
 func (pb *PipelinesBuilder) Build() (BuiltPipelines, error) {
-	pipelineProcessors := make(BuiltPipelines)
+	pb.built = make(BuiltPipelines)
+	pb.building = make(map[*configmodels.Pipeline]bool)
+	pb.connectors = make(map[connectorKey]*builtConnector)
 
 	for _, pipeline := range pb.config.Service.Pipelines {
-		firstProcessor, err := pb.buildPipeline(context.Background(), pipeline)
+		if _, err := pb.getOrBuildPipeline(context.Background(), pipeline); err != nil {
+			return nil, err
+		}
+	}
+
+	return pb.built, nil
+}
+
+// getOrBuildPipeline returns the pipeline if it was already built, otherwise it builds
+// it, after the pipelines it sends data to through connectors.
+func (pb *PipelinesBuilder) getOrBuildPipeline(ctx context.Context, pipelineCfg *configmodels.Pipeline) (*builtPipeline, error) {
+	if bp := pb.built[pipelineCfg]; bp != nil {
+		return bp, nil
+	}
+	if pb.building[pipelineCfg] {
+		return nil, fmt.Errorf("pipeline %q sends data to itself through connectors", pipelineCfg.Name)
+	}
+
+	bp := pb.findReusablePipeline(pipelineCfg)
+	if bp == nil {
+		pb.building[pipelineCfg] = true
+		var err error
+		bp, err = pb.buildPipeline(ctx, pipelineCfg)
+		delete(pb.building, pipelineCfg)
 		if err != nil {
 			return nil, err
 		}
-		pipelineProcessors[pipeline] = firstProcessor
 	}
 
-	return pipelineProcessors, nil
+	pb.built[pipelineCfg] = bp
+	return bp, nil
+}
+
+// findReusablePipeline returns the pipeline built for the previous configuration if
+// it can be used as is for the given pipeline config, or nil otherwise.
+func (pb *PipelinesBuilder) findReusablePipeline(pipelineCfg *configmodels.Pipeline) *builtPipeline {
+	if pb.prevConfig == nil {
+		return nil
+	}
+	prevCfg, ok := pb.prevConfig.Service.Pipelines[pipelineCfg.Name]
+	if !ok || !reflect.DeepEqual(prevCfg, pipelineCfg) {
+		return nil
+	}
+	// The pipelines connected by connectors are always rebuilt with them.
+	if pb.hasConnectors(pipelineCfg) {
+		return nil
+	}
+	bp := pb.prevPipelines[prevCfg]
+	if bp == nil {
+		return nil
+	}
+
+	for _, procName := range pipelineCfg.Processors {
+		if !reflect.DeepEqual(pb.prevConfig.Processors[procName], pb.config.Processors[procName]) {
+			return nil
+		}
+	}
+
+	// The exporters must be the same instances, otherwise the fan out must be rebuilt.
+	exporters := pb.getBuiltExportersByNames(pipelineCfg.Exporters)
+	if len(exporters) != len(bp.exporters) {
+		return nil
+	}
+	for i := range exporters {
+		if exporters[i] != bp.exporters[i] {
+			return nil
+		}
+	}
+	return bp
 }
 
 // Builds a pipeline of processors. Returns the first processor in the pipeline.
@@ -119,25 +319,53 @@ func (pb *PipelinesBuilder) Build() (BuiltPipelines, error) {
 // that are configured for this pipeline.
 func (pb *PipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *configmodels.Pipeline) (*builtPipeline, error) {
 
+	pipelineLogger := pb.logger.With(zap.String("pipeline_name", pipelineCfg.Name),
+		zap.String("pipeline_datatype", string(pipelineCfg.InputType)))
+
 	// BuildProcessors the pipeline backwards.
 
-	// First create a consumer junction point that fans out the data to all exporters.
+	// First build the connectors the pipeline sends data to, if any.
+	conns := make(map[string]component.Connector)
+	var ownedConns []component.Connector
+	depth := 0
+	for _, name := range pipelineCfg.Exporters {
+		if pb.config.Connectors[name] == nil {
+			continue
+		}
+		bc, err := pb.getOrBuildConnector(ctx, name, pipelineCfg)
+		if err != nil {
+			return nil, err
+		}
+		if !bc.owned {
+			bc.owned = true
+			ownedConns = append(ownedConns, bc.connector)
+		}
+		conns[name] = bc.connector
+		if bc.depth+1 > depth {
+			depth = bc.depth + 1
+		}
+	}
+
+	// Then create a consumer junction point that fans out the data to all exporters.
 	var tc consumer.TracesConsumer
 	var mc consumer.MetricsConsumer
 	var lc consumer.LogsConsumer
+	var fanOut component.Component
 
 	switch pipelineCfg.InputType {
 	case configmodels.TracesDataType:
-		tc = pb.buildFanoutExportersTraceConsumer(pipelineCfg.Exporters)
+		tc, fanOut = pb.buildFanoutExportersTraceConsumer(pipelineLogger, pipelineCfg, conns)
 	case configmodels.MetricsDataType:
-		mc = pb.buildFanoutExportersMetricsConsumer(pipelineCfg.Exporters)
+		mc, fanOut = pb.buildFanoutExportersMetricsConsumer(pipelineLogger, pipelineCfg, conns)
 	case configmodels.LogsDataType:
-		lc = pb.buildFanoutExportersLogConsumer(pipelineCfg.Exporters)
+		lc, fanOut = pb.buildFanoutExportersLogConsumer(pipelineLogger, pipelineCfg, conns)
 	}
 
 	mutatesConsumedData := false
+	supportsSharedData := true
 
 	processors := make([]component.Processor, len(pipelineCfg.Processors))
+	taps := make([]*Tap, len(pipelineCfg.Processors)+1)
 
 	// Now build the processors backwards, starting from the last one.
 	// The last processor points to consumer which fans out to exporters, then
@@ -152,6 +380,8 @@ func (pb *PipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 		// This processor must point to the next consumer and then
 		// it becomes the next for the previous one (previous in the pipeline,
 		// which we will build in the next loop iteration).
+		taps[i+1] = &Tap{Stage: procName}
+		tc, mc, lc = wrapWithTap(taps[i+1], tc, mc, lc)
 		var err error
 		componentLogger := pb.logger.With(zap.String(kindLogKey, kindLogsProcessor), zap.String(typeLogKey, string(procCfg.Type())), zap.String(nameLogKey, procCfg.Name()))
 		creationParams := component.ProcessorCreateParams{
@@ -165,6 +395,7 @@ func (pb *PipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 			proc, err = factory.CreateTracesProcessor(ctx, creationParams, procCfg, tc)
 			if proc != nil {
 				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumedData
+				supportsSharedData = supportsSharedData && proc.GetCapabilities().SupportsSharedData
 			}
 			processors[i] = proc
 			tc = proc
@@ -173,6 +404,7 @@ func (pb *PipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 			proc, err = factory.CreateMetricsProcessor(ctx, creationParams, procCfg, mc)
 			if proc != nil {
 				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumedData
+				supportsSharedData = supportsSharedData && proc.GetCapabilities().SupportsSharedData
 			}
 			processors[i] = proc
 			mc = proc
@@ -182,6 +414,7 @@ func (pb *PipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 			proc, err = factory.CreateLogsProcessor(ctx, creationParams, procCfg, lc)
 			if proc != nil {
 				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumedData
+				supportsSharedData = supportsSharedData && proc.GetCapabilities().SupportsSharedData
 			}
 			processors[i] = proc
 			lc = proc
@@ -202,65 +435,120 @@ func (pb *PipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 		}
 	}
 
-	pipelineLogger := pb.logger.With(zap.String("pipeline_name", pipelineCfg.Name),
-		zap.String("pipeline_datatype", string(pipelineCfg.InputType)))
+	taps[0] = &Tap{Stage: TapStageReceivers}
+	tc, mc, lc = wrapWithTap(taps[0], tc, mc, lc)
+
 	pipelineLogger.Info("Pipeline is enabled.")
 
 	bp := &builtPipeline{
-		pipelineLogger,
-		tc,
-		mc,
-		lc,
-		mutatesConsumedData,
-		processors,
+		logger:              pipelineLogger,
+		firstTC:             tc,
+		firstMC:             mc,
+		firstLC:             lc,
+		MutatesConsumedData: mutatesConsumedData,
+		SupportsSharedData:  supportsSharedData,
+		processors:          processors,
+		fanOut:              fanOut,
+		exporters:           pb.getBuiltExportersByNames(pipelineCfg.Exporters),
+		connectors:          ownedConns,
+		depth:               depth,
+		taps:                taps,
 	}
 
 	return bp, nil
 }
 
 // Converts the list of exporter names to a list of corresponding builtExporters.
+// The names of connectors are ignored.
 func (pb *PipelinesBuilder) getBuiltExportersByNames(exporterNames []string) []*builtExporter {
 	var result []*builtExporter
 	for _, name := range exporterNames {
-		exporter := pb.exporters[pb.config.Exporters[name]]
+		cfg := pb.config.Exporters[name]
+		if cfg == nil {
+			continue
+		}
+		exporter := pb.exporters[cfg]
 		result = append(result, exporter)
 	}
 
 	return result
 }
 
-func (pb *PipelinesBuilder) buildFanoutExportersTraceConsumer(exporterNames []string) consumer.TracesConsumer {
-	builtExporters := pb.getBuiltExportersByNames(exporterNames)
+// concurrentFanOutParams returns the parameters of the concurrent fan-out of the pipeline.
+func concurrentFanOutParams(logger *zap.Logger, pipelineCfg *configmodels.Pipeline) processor.ConcurrentFanOutParams {
+	return processor.ConcurrentFanOutParams{
+		Logger:        logger,
+		PipelineName:  pipelineCfg.Name,
+		ConsumerNames: pipelineCfg.Exporters,
+		Settings:      pipelineCfg.FanOut,
+	}
+}
+
+func (pb *PipelinesBuilder) buildFanoutExportersTraceConsumer(
+	logger *zap.Logger,
+	pipelineCfg *configmodels.Pipeline,
+	conns map[string]component.Connector,
+) (consumer.TracesConsumer, component.Component) {
+	exporters := make([]consumer.TracesConsumer, len(pipelineCfg.Exporters))
+	for i, name := range pipelineCfg.Exporters {
+		if conn, ok := conns[name]; ok {
+			exporters[i] = conn.(consumer.TracesConsumer)
+			continue
+		}
+		exporters[i] = pb.exporters[pb.config.Exporters[name]].getTraceExporter()
+	}
 
-	var exporters []consumer.TracesConsumer
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.getTraceExporter())
+	if pipelineCfg.FanOut.Concurrent {
+		fanOut := processor.NewTracesConcurrentFanOutConnector(concurrentFanOutParams(logger, pipelineCfg), exporters)
+		return fanOut, fanOut
 	}
 
 	// Create a junction point that fans out to all exporters.
-	return processor.NewTracesFanOutConnector(exporters)
+	return processor.NewTracesFanOutConnector(exporters), nil
 }
 
-func (pb *PipelinesBuilder) buildFanoutExportersMetricsConsumer(exporterNames []string) consumer.MetricsConsumer {
-	builtExporters := pb.getBuiltExportersByNames(exporterNames)
+func (pb *PipelinesBuilder) buildFanoutExportersMetricsConsumer(
+	logger *zap.Logger,
+	pipelineCfg *configmodels.Pipeline,
+	conns map[string]component.Connector,
+) (consumer.MetricsConsumer, component.Component) {
+	exporters := make([]consumer.MetricsConsumer, len(pipelineCfg.Exporters))
+	for i, name := range pipelineCfg.Exporters {
+		if conn, ok := conns[name]; ok {
+			exporters[i] = conn.(consumer.MetricsConsumer)
+			continue
+		}
+		exporters[i] = pb.exporters[pb.config.Exporters[name]].getMetricExporter()
+	}
 
-	var exporters []consumer.MetricsConsumer
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.getMetricExporter())
+	if pipelineCfg.FanOut.Concurrent {
+		fanOut := processor.NewMetricsConcurrentFanOutConnector(concurrentFanOutParams(logger, pipelineCfg), exporters)
+		return fanOut, fanOut
 	}
 
 	// Create a junction point that fans out to all exporters.
-	return processor.NewMetricsFanOutConnector(exporters)
+	return processor.NewMetricsFanOutConnector(exporters), nil
 }
 
-func (pb *PipelinesBuilder) buildFanoutExportersLogConsumer(exporterNames []string) consumer.LogsConsumer {
-	builtExporters := pb.getBuiltExportersByNames(exporterNames)
+func (pb *PipelinesBuilder) buildFanoutExportersLogConsumer(
+	logger *zap.Logger,
+	pipelineCfg *configmodels.Pipeline,
+	conns map[string]component.Connector,
+) (consumer.LogsConsumer, component.Component) {
+	exporters := make([]consumer.LogsConsumer, len(pipelineCfg.Exporters))
+	for i, name := range pipelineCfg.Exporters {
+		if conn, ok := conns[name]; ok {
+			exporters[i] = conn.(consumer.LogsConsumer)
+			continue
+		}
+		exporters[i] = pb.exporters[pb.config.Exporters[name]].getLogExporter()
+	}
 
-	exporters := make([]consumer.LogsConsumer, len(builtExporters))
-	for i, builtExp := range builtExporters {
-		exporters[i] = builtExp.getLogExporter()
+	if pipelineCfg.FanOut.Concurrent {
+		fanOut := processor.NewLogsConcurrentFanOutConnector(concurrentFanOutParams(logger, pipelineCfg), exporters)
+		return fanOut, fanOut
 	}
 
 	// Create a junction point that fans out to all exporters.
-	return processor.NewLogsFanOutConnector(exporters)
+	return processor.NewLogsFanOutConnector(exporters), nil
 }
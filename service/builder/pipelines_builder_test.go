@@ -256,6 +256,77 @@ func testPipeline(t *testing.T, pipelineName string, exporterNames []string) {
 	assert.NoError(t, err)
 }
 
+func TestPipelinesBuilder_BuildConcurrentFanOut(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	pipelineCfg := cfg.Service.Pipelines["traces/2"]
+	pipelineCfg.FanOut = configmodels.FanOutSettings{Concurrent: true}
+
+	allExporters, err := NewExportersBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, factories.Exporters).Build()
+	require.NoError(t, err)
+	pipelines, err := NewPipelinesBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, allExporters, factories.Processors).Build()
+	require.NoError(t, err)
+
+	bp := pipelines[pipelineCfg]
+	require.NotNil(t, bp.fanOut)
+	assert.Nil(t, pipelines[cfg.Service.Pipelines["traces"]].fanOut)
+
+	require.NoError(t, pipelines.StartProcessors(context.Background(), componenttest.NewNopHost()))
+	td := testdata.GenerateTraceDataOneSpan()
+	require.NoError(t, bp.firstTC.ConsumeTraces(context.Background(), td))
+
+	// Shutting down the pipeline sends the buffered data to the exporters.
+	require.NoError(t, pipelines.ShutdownProcessors(context.Background()))
+	for _, name := range pipelineCfg.Exporters {
+		consumer := allExporters[cfg.Exporters[name]].getTraceExporter().(*componenttest.ExampleExporterConsumer)
+		require.Len(t, consumer.Traces, 1, name)
+		assert.EqualValues(t, td, consumer.Traces[0])
+	}
+}
+
+func TestPipelinesBuilder_BuildConnectors(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_connectors.yaml", factories)
+	require.NoError(t, err)
+
+	allExporters, err := NewExportersBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, factories.Exporters).Build()
+	require.NoError(t, err)
+	// The connector is not built as an exporter.
+	assert.Len(t, allExporters, 2)
+	pipelines, err := NewPipelinesBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, allExporters, factories.Processors).
+		WithConnectors(factories.Connectors).
+		Build()
+	require.NoError(t, err)
+	require.Len(t, pipelines, 3)
+
+	in := pipelines[cfg.Service.Pipelines["traces/in"]]
+	require.NotNil(t, in)
+	require.Len(t, in.connectors, 1)
+	assert.Greater(t, in.depth, pipelines[cfg.Service.Pipelines["traces/out"]].depth)
+
+	require.NoError(t, pipelines.StartProcessors(context.Background(), componenttest.NewNopHost()))
+	td := testdata.GenerateTraceDataOneSpan()
+	require.NoError(t, in.firstTC.ConsumeTraces(context.Background(), td))
+	require.NoError(t, pipelines.ShutdownProcessors(context.Background()))
+
+	// The data goes to the exporter of the first pipeline and, through the connector,
+	// to the traces and metrics pipelines.
+	first := allExporters[cfg.Exporters["exampleexporter"]].getTraceExporter().(*componenttest.ExampleExporterConsumer)
+	require.Len(t, first.Traces, 1)
+	assert.EqualValues(t, td, first.Traces[0])
+
+	second := allExporters[cfg.Exporters["exampleexporter/2"]].getTraceExporter().(*componenttest.ExampleExporterConsumer)
+	require.Len(t, second.Traces, 1)
+	assert.EqualValues(t, td, second.Traces[0])
+	secondMetrics := allExporters[cfg.Exporters["exampleexporter/2"]].getMetricExporter().(*componenttest.ExampleExporterConsumer)
+	require.Len(t, secondMetrics.Metrics, 1)
+	metric := secondMetrics.Metrics[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "span_count", metric.Name())
+}
+
 func TestProcessorsBuilder_ErrorOnUnsupportedProcessor(t *testing.T) {
 	factories, err := componenttest.ExampleComponents()
 	assert.NoError(t, err)
@@ -307,3 +378,48 @@ func newBadProcessorFactory() component.ProcessorFactory {
 		}
 	})
 }
+
+func TestPipelinesBuilder_ReuseFrom(t *testing.T) {
+	factories := createExampleFactories()
+
+	prevCfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	prevExporters, err := NewExportersBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), prevCfg, factories.Exporters).Build()
+	require.NoError(t, err)
+	prevPipelines, err := NewPipelinesBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), prevCfg, prevExporters, factories.Processors).Build()
+	require.NoError(t, err)
+
+	cfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	cfg.Exporters["exampleexporter/2"].(*componenttest.ExampleExporter).ExtraSetting = "changed"
+	cfg.Service.Pipelines["metrics"].Exporters = []string{"exampleexporter", "exampleexporter"}
+
+	exporters, err := NewExportersBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, factories.Exporters).
+		ReuseFrom(prevCfg, prevExporters).
+		Build()
+	require.NoError(t, err)
+	pipelines, err := NewPipelinesBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, exporters, factories.Processors).
+		ReuseFrom(prevCfg, prevPipelines).
+		Build()
+	require.NoError(t, err)
+
+	// Pipelines that only use unchanged components are reused.
+	for _, name := range []string{"traces", "metrics/2"} {
+		assert.Same(t, prevPipelines[prevCfg.Service.Pipelines[name]], pipelines[cfg.Service.Pipelines[name]], name)
+	}
+	// Pipelines that changed or use a changed exporter are rebuilt.
+	for _, name := range []string{"traces/2", "metrics", "metrics/3", "logs"} {
+		assert.NotSame(t, prevPipelines[prevCfg.Service.Pipelines[name]], pipelines[cfg.Service.Pipelines[name]], name)
+	}
+	assert.Len(t, pipelines.Without(prevPipelines), 4)
+	assert.Len(t, prevPipelines.Without(pipelines), 4)
+
+	// Changing a processor rebuilds the pipelines that use it.
+	cfg.Processors["exampleprocessor"].(*componenttest.ExampleProcessorCfg).ExtraSetting = "changed"
+	pipelines, err = NewPipelinesBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, exporters, factories.Processors).
+		ReuseFrom(prevCfg, prevPipelines).
+		Build()
+	require.NoError(t, err)
+	assert.NotSame(t, prevPipelines[prevCfg.Service.Pipelines["traces"]], pipelines[cfg.Service.Pipelines["traces"]])
+	assert.Same(t, prevPipelines[prevCfg.Service.Pipelines["metrics/2"]], pipelines[cfg.Service.Pipelines["metrics/2"]])
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"go.uber.org/zap"
 
@@ -36,6 +37,9 @@ var errUnusedReceiver = errors.New("receiver defined but not used by any pipelin
 type builtReceiver struct {
 	logger   *zap.Logger
 	receiver component.Receiver
+
+	// pipelines are the pipelines the receiver is attached to.
+	pipelines []*builtPipeline
 }
 
 // Start the receiver.
@@ -64,6 +68,22 @@ func (rcvs Receivers) ShutdownAll(ctx context.Context) error {
 	return componenterror.CombineErrors(errs)
 }
 
+// Without returns the receivers that are not present in other. Receivers reused
+// by a builder for a new configuration are present in both.
+func (rcvs Receivers) Without(other Receivers) Receivers {
+	existing := make(map[*builtReceiver]bool, len(other))
+	for _, rcv := range other {
+		existing[rcv] = true
+	}
+	result := make(Receivers)
+	for cfg, rcv := range rcvs {
+		if !existing[rcv] {
+			result[cfg] = rcv
+		}
+	}
+	return result
+}
+
 // StartAll starts all receivers.
 func (rcvs Receivers) StartAll(ctx context.Context, host component.Host) error {
 	for _, rcv := range rcvs {
@@ -84,6 +104,9 @@ type ReceiversBuilder struct {
 	config         *configmodels.Config
 	builtPipelines BuiltPipelines
 	factories      map[configmodels.Type]component.ReceiverFactory
+
+	prevConfig    *configmodels.Config
+	prevReceivers Receivers
 }
 
 // NewReceiversBuilder creates a new ReceiversBuilder. Call BuildProcessors() on the returned value.
@@ -94,7 +117,23 @@ func NewReceiversBuilder(
 	builtPipelines BuiltPipelines,
 	factories map[configmodels.Type]component.ReceiverFactory,
 ) *ReceiversBuilder {
-	return &ReceiversBuilder{logger.With(zap.String(kindLogKey, kindLogsReceiver)), appInfo, config, builtPipelines, factories}
+	return &ReceiversBuilder{
+		logger:         logger.With(zap.String(kindLogKey, kindLogsReceiver)),
+		appInfo:        appInfo,
+		config:         config,
+		builtPipelines: builtPipelines,
+		factories:      factories,
+	}
+}
+
+// ReuseFrom makes Build reuse the receivers built for a previous configuration instead
+// of creating new ones if their configuration did not change and they are attached to
+// the same pipeline instances. Reused receivers are already running and must not be
+// started again.
+func (rb *ReceiversBuilder) ReuseFrom(prevConfig *configmodels.Config, prevReceivers Receivers) *ReceiversBuilder {
+	rb.prevConfig = prevConfig
+	rb.prevReceivers = prevReceivers
+	return rb
 }
 
 // BuildProcessors receivers from config.
@@ -103,8 +142,17 @@ func (rb *ReceiversBuilder) Build() (Receivers, error) {
 
 	// BuildProcessors receivers based on configuration.
 	for _, cfg := range rb.config.Receivers {
+		rcv, err := rb.findReusableReceiver(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if rcv != nil {
+			receivers[cfg] = rcv
+			continue
+		}
+
 		logger := rb.logger.With(zap.String(typeLogKey, string(cfg.Type())), zap.String(nameLogKey, cfg.Name()))
-		rcv, err := rb.buildReceiver(context.Background(), logger, rb.appInfo, cfg)
+		rcv, err = rb.buildReceiver(context.Background(), logger, rb.appInfo, cfg)
 		if err != nil {
 			if err == errUnusedReceiver {
 				logger.Info("Ignoring receiver as it is not used by any pipeline", zap.String("receiver", cfg.Name()))
@@ -118,6 +166,44 @@ func (rb *ReceiversBuilder) Build() (Receivers, error) {
 	return receivers, nil
 }
 
+// findReusableReceiver returns the receiver built for the previous configuration if
+// it can be used as is for the given config, or nil otherwise.
+func (rb *ReceiversBuilder) findReusableReceiver(config configmodels.Receiver) (*builtReceiver, error) {
+	if rb.prevConfig == nil {
+		return nil, nil
+	}
+	prevCfg, ok := rb.prevConfig.Receivers[config.Name()]
+	if !ok || !reflect.DeepEqual(prevCfg, config) {
+		return nil, nil
+	}
+	rcv := rb.prevReceivers[prevCfg]
+	if rcv == nil {
+		return nil, nil
+	}
+
+	pipelinesToAttach, err := rb.findPipelinesToAttach(config)
+	if err != nil {
+		return nil, err
+	}
+	pipelines := make(map[*builtPipeline]bool, len(rcv.pipelines))
+	for _, bp := range rcv.pipelines {
+		pipelines[bp] = true
+	}
+	count := 0
+	for _, bps := range pipelinesToAttach {
+		for _, bp := range bps {
+			if !pipelines[bp] {
+				return nil, nil
+			}
+			count++
+		}
+	}
+	if count != len(rcv.pipelines) {
+		return nil, nil
+	}
+	return rcv, nil
+}
+
 // hasReceiver returns true if the pipeline is attached to specified receiver.
 func hasReceiver(pipeline *configmodels.Pipeline, receiverName string) bool {
 	for _, name := range pipeline.Receivers {
@@ -267,6 +353,7 @@ func (rb *ReceiversBuilder) buildReceiver(ctx context.Context, logger *zap.Logge
 		if err != nil {
 			return nil, err
 		}
+		rcv.pipelines = append(rcv.pipelines, pipelines...)
 	}
 
 	if rcv.receiver == nil {
@@ -284,18 +371,21 @@ func buildFanoutTraceConsumer(pipelines []*builtPipeline) consumer.TracesConsume
 
 	var pipelineConsumers []consumer.TracesConsumer
 	anyPipelineMutatesData := false
+	allPipelinesSupportSharedData := true
 	for _, pipeline := range pipelines {
 		pipelineConsumers = append(pipelineConsumers, pipeline.firstTC)
 		anyPipelineMutatesData = anyPipelineMutatesData || pipeline.MutatesConsumedData
+		allPipelinesSupportSharedData = allPipelinesSupportSharedData && pipeline.SupportsSharedData
 	}
 
 	// Create a junction point that fans out to all pipelines.
 	if anyPipelineMutatesData {
-		// If any pipeline mutates data use a cloning fan out connector
-		// so that it is safe to modify fanned out data.
-		// TODO: if there are more than 2 pipelines only clone data for pipelines that
-		// declare the intent to mutate the data. Pipelines that do not mutate the data
-		// can consume shared data.
+		// If any pipeline mutates data share it with copy-on-write semantics, or clone
+		// it if a pipeline has processors that do not support shared data, so that it
+		// is safe to modify fanned out data.
+		if allPipelinesSupportSharedData {
+			return processor.NewTracesSharingFanOutConnector(pipelineConsumers)
+		}
 		return processor.NewTracesCloningFanOutConnector(pipelineConsumers)
 	}
 	return processor.NewTracesFanOutConnector(pipelineConsumers)
@@ -309,18 +399,21 @@ func buildFanoutMetricConsumer(pipelines []*builtPipeline) consumer.MetricsConsu
 
 	var pipelineConsumers []consumer.MetricsConsumer
 	anyPipelineMutatesData := false
+	allPipelinesSupportSharedData := true
 	for _, pipeline := range pipelines {
 		pipelineConsumers = append(pipelineConsumers, pipeline.firstMC)
 		anyPipelineMutatesData = anyPipelineMutatesData || pipeline.MutatesConsumedData
+		allPipelinesSupportSharedData = allPipelinesSupportSharedData && pipeline.SupportsSharedData
 	}
 
 	// Create a junction point that fans out to all pipelines.
 	if anyPipelineMutatesData {
-		// If any pipeline mutates data use a cloning fan out connector
-		// so that it is safe to modify fanned out data.
-		// TODO: if there are more than 2 pipelines only clone data for pipelines that
-		// declare the intent to mutate the data. Pipelines that do not mutate the data
-		// can consume shared data.
+		// If any pipeline mutates data share it with copy-on-write semantics, or clone
+		// it if a pipeline has processors that do not support shared data, so that it
+		// is safe to modify fanned out data.
+		if allPipelinesSupportSharedData {
+			return processor.NewMetricsSharingFanOutConnector(pipelineConsumers)
+		}
 		return processor.NewMetricsCloningFanOutConnector(pipelineConsumers)
 	}
 	return processor.NewMetricsFanOutConnector(pipelineConsumers)
@@ -334,18 +427,21 @@ func buildFanoutLogConsumer(pipelines []*builtPipeline) consumer.LogsConsumer {
 
 	var pipelineConsumers []consumer.LogsConsumer
 	anyPipelineMutatesData := false
+	allPipelinesSupportSharedData := true
 	for _, pipeline := range pipelines {
 		pipelineConsumers = append(pipelineConsumers, pipeline.firstLC)
 		anyPipelineMutatesData = anyPipelineMutatesData || pipeline.MutatesConsumedData
+		allPipelinesSupportSharedData = allPipelinesSupportSharedData && pipeline.SupportsSharedData
 	}
 
 	// Create a junction point that fans out to all pipelines.
 	if anyPipelineMutatesData {
-		// If any pipeline mutates data use a cloning fan out connector
-		// so that it is safe to modify fanned out data.
-		// TODO: if there are more than 2 pipelines only clone data for pipelines that
-		// declare the intent to mutate the data. Pipelines that do not mutate the data
-		// can consume shared data.
+		// If any pipeline mutates data share it with copy-on-write semantics, or clone
+		// it if a pipeline has processors that do not support shared data, so that it
+		// is safe to modify fanned out data.
+		if allPipelinesSupportSharedData {
+			return processor.NewLogsSharingFanOutConnector(pipelineConsumers)
+		}
 		return processor.NewLogsCloningFanOutConnector(pipelineConsumers)
 	}
 	return processor.NewLogsFanOutConnector(pipelineConsumers)
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/data/testdata"
 	"go.opentelemetry.io/collector/processor/attributesprocessor"
@@ -394,3 +395,94 @@ func newBadReceiverFactory() component.ReceiverFactory {
 		}
 	})
 }
+
+func TestReceiversBuilder_ReuseFrom(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	type built struct {
+		cfg       *configmodels.Config
+		exporters Exporters
+		pipelines BuiltPipelines
+		receivers Receivers
+	}
+	build := func(cfg *configmodels.Config, prev built) built {
+		exporters, err := NewExportersBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, factories.Exporters).
+			ReuseFrom(prev.cfg, prev.exporters).
+			Build()
+		require.NoError(t, err)
+		pipelines, err := NewPipelinesBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, exporters, factories.Processors).
+			ReuseFrom(prev.cfg, prev.pipelines).
+			Build()
+		require.NoError(t, err)
+		receivers, err := NewReceiversBuilder(zap.NewNop(), componenttest.TestApplicationStartInfo(), cfg, pipelines, factories.Receivers).
+			ReuseFrom(prev.cfg, prev.receivers).
+			Build()
+		require.NoError(t, err)
+		return built{cfg, exporters, pipelines, receivers}
+	}
+
+	prevCfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	prev := build(prevCfg, built{})
+
+	cfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	// Rebuild the "traces/2" pipeline, examplereceiver/2 and examplereceiver/multi are attached to it.
+	cfg.Service.Pipelines["traces/2"].Processors = nil
+	// Change the config of examplereceiver/3.
+	cfg.Receivers["examplereceiver/3"].(*componenttest.ExampleReceiver).ExtraSetting = "changed"
+
+	receivers := build(cfg, prev).receivers
+	assert.Same(t, prev.receivers[prevCfg.Receivers["examplereceiver"]], receivers[cfg.Receivers["examplereceiver"]])
+	for _, name := range []string{"examplereceiver/2", "examplereceiver/3", "examplereceiver/multi"} {
+		assert.NotSame(t, prev.receivers[prevCfg.Receivers[name]], receivers[cfg.Receivers[name]], name)
+	}
+	assert.Len(t, receivers.Without(prev.receivers), 3)
+	assert.Len(t, prev.receivers.Without(receivers), 3)
+
+	// Attaching a receiver to another pipeline rebuilds it.
+	cfg, err = configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	cfg.Service.Pipelines["metrics/2"].Receivers = []string{"examplereceiver", "examplereceiver/3"}
+
+	receivers = build(cfg, prev).receivers
+	assert.NotSame(t, prev.receivers[prevCfg.Receivers["examplereceiver"]], receivers[cfg.Receivers["examplereceiver"]])
+	assert.Same(t, prev.receivers[prevCfg.Receivers["examplereceiver/2"]], receivers[cfg.Receivers["examplereceiver/2"]])
+}
+
+func TestBuildFanoutTraceConsumer_SharedData(t *testing.T) {
+	tests := []struct {
+		name               string
+		supportsSharedData bool
+		wantShared         bool
+	}{
+		{
+			name:               "processors_support_shared_data",
+			supportsSharedData: true,
+			wantShared:         true,
+		},
+		{
+			name:               "processors_do_not_support_shared_data",
+			supportsSharedData: false,
+			wantShared:         false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sinks := []*consumertest.TracesSink{new(consumertest.TracesSink), new(consumertest.TracesSink)}
+			pipelines := []*builtPipeline{
+				{firstTC: sinks[0], MutatesConsumedData: true, SupportsSharedData: test.supportsSharedData},
+				{firstTC: sinks[1], SupportsSharedData: true},
+			}
+			tc := buildFanoutTraceConsumer(pipelines)
+			require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+
+			for _, sink := range sinks {
+				require.Len(t, sink.AllTraces(), 1)
+				assert.Equal(t, test.wantShared, sink.AllTraces()[0].IsShared())
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	// TapStageReceivers is the stage of the taps recording the data sent by the
+	// receivers of a pipeline, the other stages are the names of the processors.
+	TapStageReceivers = "receivers"
+
+	// maxTapSampleItems is the maximum number of spans, metrics or log records
+	// rendered in a sample.
+	maxTapSampleItems = 100
+)
+
+// Tap records samples of the data passing through a stage of a pipeline, for
+// debugging. It is disabled until Enable is called, while disabled it only costs an
+// atomic load per batch of data.
+type Tap struct {
+	// Stage is TapStageReceivers for the data sent by the receivers, otherwise the
+	// name of the processor sending the data.
+	Stage string
+
+	enabled int32
+
+	mu      sync.Mutex
+	every   int
+	seen    int
+	size    int
+	samples []TapSample
+}
+
+// TapSample is a batch of data recorded by a tap.
+type TapSample struct {
+	Time time.Time
+	// Text is a human readable rendering of the data.
+	Text string
+}
+
+// Enable starts recording one batch of data in every `every` batches and keeps the
+// most recent size ones. The samples recorded previously are discarded.
+func (t *Tap) Enable(size, every int) {
+	if size <= 0 {
+		size = 1
+	}
+	if every <= 0 {
+		every = 1
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.size = size
+	t.every = every
+	t.seen = 0
+	t.samples = nil
+	atomic.StoreInt32(&t.enabled, 1)
+}
+
+// Disable stops recording data, the recorded samples are kept.
+func (t *Tap) Disable() {
+	atomic.StoreInt32(&t.enabled, 0)
+}
+
+// Enabled returns true if the tap records data.
+func (t *Tap) Enabled() bool {
+	return atomic.LoadInt32(&t.enabled) == 1
+}
+
+// Samples returns the recorded samples, the most recent first.
+func (t *Tap) Samples() []TapSample {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	samples := make([]TapSample, len(t.samples))
+	for i, s := range t.samples {
+		samples[len(samples)-1-i] = s
+	}
+	return samples
+}
+
+// record records the data rendered by render if the batch is sampled.
+func (t *Tap) record(render func(sb *strings.Builder)) {
+	if !t.Enabled() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seen++
+	if (t.seen-1)%t.every != 0 {
+		return
+	}
+	var sb strings.Builder
+	render(&sb)
+	if len(t.samples) == t.size {
+		t.samples = append(t.samples[:0], t.samples[1:]...)
+	}
+	t.samples = append(t.samples, TapSample{Time: time.Now(), Text: sb.String()})
+}
+
+// wrapWithTap returns the consumers wrapped by consumers recording the data with the
+// tap, the nil consumers stay nil.
+func wrapWithTap(tap *Tap, tc consumer.TracesConsumer, mc consumer.MetricsConsumer, lc consumer.LogsConsumer) (
+	consumer.TracesConsumer, consumer.MetricsConsumer, consumer.LogsConsumer) {
+	if tc != nil {
+		tc = &tracesTap{Tap: tap, next: tc}
+	}
+	if mc != nil {
+		mc = &metricsTap{Tap: tap, next: mc}
+	}
+	if lc != nil {
+		lc = &logsTap{Tap: tap, next: lc}
+	}
+	return tc, mc, lc
+}
+
+type tracesTap struct {
+	*Tap
+	next consumer.TracesConsumer
+}
+
+func (tt *tracesTap) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	tt.record(func(sb *strings.Builder) { renderTraces(sb, td) })
+	return tt.next.ConsumeTraces(ctx, td)
+}
+
+type metricsTap struct {
+	*Tap
+	next consumer.MetricsConsumer
+}
+
+func (mt *metricsTap) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	mt.record(func(sb *strings.Builder) { renderMetrics(sb, md) })
+	return mt.next.ConsumeMetrics(ctx, md)
+}
+
+type logsTap struct {
+	*Tap
+	next consumer.LogsConsumer
+}
+
+func (lt *logsTap) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	lt.record(func(sb *strings.Builder) { renderLogs(sb, ld) })
+	return lt.next.ConsumeLogs(ctx, ld)
+}
+
+// tapRenderer renders the items of a batch of data, up to maxTapSampleItems.
+type tapRenderer struct {
+	sb    *strings.Builder
+	items int
+}
+
+// item renders an item, it returns false if the maximum number of items is reached.
+func (r *tapRenderer) item(format string, a ...interface{}) bool {
+	r.items++
+	if r.items > maxTapSampleItems {
+		return false
+	}
+	fmt.Fprintf(r.sb, format, a...)
+	r.sb.WriteByte('\n')
+	return true
+}
+
+func (r *tapRenderer) resource(attrs pdata.AttributeMap) {
+	fmt.Fprintf(r.sb, "Resource %s\n", attributesToString(attrs))
+}
+
+func (r *tapRenderer) end(total int) {
+	if r.items > maxTapSampleItems {
+		fmt.Fprintf(r.sb, "... %d more\n", total-maxTapSampleItems)
+	}
+}
+
+func renderTraces(sb *strings.Builder, td pdata.Traces) {
+	r := &tapRenderer{sb: sb}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		r.resource(rs.Resource().Attributes())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				duration := time.Duration(span.EndTime() - span.StartTime())
+				if !r.item("  Span %q trace_id=%s span_id=%s kind=%s duration=%s %s",
+					span.Name(), span.TraceID().HexString(), span.SpanID().HexString(), span.Kind(),
+					duration, attributesToString(span.Attributes())) {
+					r.end(td.SpanCount())
+					return
+				}
+			}
+		}
+	}
+}
+
+func renderMetrics(sb *strings.Builder, md pdata.Metrics) {
+	r := &tapRenderer{sb: sb}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if rm.IsNil() {
+			continue
+		}
+		r.resource(rm.Resource().Attributes())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			if ilm.IsNil() {
+				continue
+			}
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if metric.IsNil() {
+					continue
+				}
+				if !r.item("  Metric %q type=%s unit=%q", metric.Name(), metric.DataType(), metric.Unit()) {
+					r.end(md.MetricCount())
+					return
+				}
+			}
+		}
+	}
+}
+
+func renderLogs(sb *strings.Builder, ld pdata.Logs) {
+	r := &tapRenderer{sb: sb}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		r.resource(rl.Resource().Attributes())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+				if !r.item("  Log %s severity=%s body=%s %s", lr.Timestamp(), lr.SeverityText(),
+					tracetranslator.AttributeValueToString(lr.Body(), true), attributesToString(lr.Attributes())) {
+					r.end(ld.LogRecordCount())
+					return
+				}
+			}
+		}
+	}
+}
+
+// attributesToString renders the attributes in the order of the map.
+func attributesToString(attrs pdata.AttributeMap) string {
+	parts := make([]string, 0, attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		parts = append(parts, k+"="+tracetranslator.AttributeValueToString(v, true))
+	})
+	return "{" + strings.Join(parts, ", ") + "}"
+}
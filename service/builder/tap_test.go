@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+)
+
+func TestTap(t *testing.T) {
+	tap := &Tap{Stage: TapStageReceivers}
+	sink := new(consumertest.TracesSink)
+	tc, mc, lc := wrapWithTap(tap, sink, nil, nil)
+	require.NotNil(t, tc)
+	assert.Nil(t, mc)
+	assert.Nil(t, lc)
+
+	// The data is not recorded while the tap is disabled.
+	td := testdata.GenerateTraceDataOneSpan()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Empty(t, tap.Samples())
+	assert.Equal(t, 1, sink.SpansCount())
+
+	// One batch in every 2 is recorded and the 2 most recent ones are kept.
+	tap.Enable(2, 2)
+	assert.True(t, tap.Enabled())
+	for i := 1; i <= 6; i++ {
+		require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(i)))
+	}
+	samples := tap.Samples()
+	require.Len(t, samples, 2)
+	// The most recent sample is first, it has 5 spans.
+	assert.Equal(t, 5, strings.Count(samples[0].Text, "Span "))
+	assert.Equal(t, 3, strings.Count(samples[1].Text, "Span "))
+	assert.Equal(t, 22, sink.SpansCount())
+
+	// The samples are kept when the tap is disabled.
+	tap.Disable()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Len(t, tap.Samples(), 2)
+}
+
+func TestTapRender(t *testing.T) {
+	var sb strings.Builder
+	renderTraces(&sb, testdata.GenerateTraceDataOneSpan())
+	assert.Contains(t, sb.String(), `Resource {resource-attr="resource-attr-val-1"}`)
+	assert.Contains(t, sb.String(), `Span "operationA"`)
+
+	sb.Reset()
+	renderTraces(&sb, testdata.GenerateTraceDataManySpansSameResource(maxTapSampleItems+5))
+	assert.Equal(t, maxTapSampleItems, strings.Count(sb.String(), "Span "))
+	assert.Contains(t, sb.String(), "... 5 more")
+
+	sb.Reset()
+	renderMetrics(&sb, testdata.GenerateMetricsOneMetric())
+	assert.Contains(t, sb.String(), `Metric "counter-int" type=IntSum`)
+
+	sb.Reset()
+	renderLogs(&sb, testdata.GenerateLogDataOneLog())
+	assert.Contains(t, sb.String(), "severity=Info")
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/service/builder"
+)
+
+// GetLogsConsumer returns the consumer of the running logs pipeline with the given name,
+// nil if there is no such pipeline. It implements component.PipelineConsumers.
+func (app *Application) GetLogsConsumer(pipelineName string) consumer.LogsConsumer {
+	consumers, _ := app.logsConsumers.Load().(map[string]consumer.LogsConsumer)
+	return consumers[pipelineName]
+}
+
+// setLogsConsumers makes the logs pipelines available to GetLogsConsumer, the pipelines
+// must be started.
+func (app *Application) setLogsConsumers(pipelines builder.BuiltPipelines) {
+	consumers := make(map[string]consumer.LogsConsumer)
+	for cfg, bp := range pipelines {
+		if lc := bp.LogsConsumer(); lc != nil {
+			consumers[cfg.Name] = lc
+		}
+	}
+	app.logsConsumers.Store(consumers)
+}
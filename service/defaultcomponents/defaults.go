@@ -18,6 +18,8 @@ package defaultcomponents
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/connector/forwardconnector"
+	"go.opentelemetry.io/collector/exporter/elasticsearchexporter"
 	"go.opentelemetry.io/collector/exporter/fileexporter"
 	"go.opentelemetry.io/collector/exporter/jaegerexporter"
 	"go.opentelemetry.io/collector/exporter/kafkaexporter"
@@ -27,10 +29,14 @@ import (
 	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.opentelemetry.io/collector/exporter/prometheusexporter"
 	"go.opentelemetry.io/collector/exporter/prometheusremotewriteexporter"
+	"go.opentelemetry.io/collector/exporter/syslogexporter"
 	"go.opentelemetry.io/collector/exporter/zipkinexporter"
+	"go.opentelemetry.io/collector/extension/filestorageextension"
 	"go.opentelemetry.io/collector/extension/fluentbitextension"
 	"go.opentelemetry.io/collector/extension/healthcheckextension"
+	"go.opentelemetry.io/collector/extension/managementextension"
 	"go.opentelemetry.io/collector/extension/pprofextension"
+	"go.opentelemetry.io/collector/extension/subprocessextension"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/processor/attributesprocessor"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
@@ -63,6 +69,9 @@ func Components() (
 		pprofextension.NewFactory(),
 		zpagesextension.NewFactory(),
 		fluentbitextension.NewFactory(),
+		filestorageextension.NewFactory(),
+		managementextension.NewFactory(),
+		subprocessextension.NewFactory(),
 	)
 	if err != nil {
 		errs = append(errs, err)
@@ -93,6 +102,8 @@ func Components() (
 		otlpexporter.NewFactory(),
 		otlphttpexporter.NewFactory(),
 		kafkaexporter.NewFactory(),
+		elasticsearchexporter.NewFactory(),
+		syslogexporter.NewFactory(),
 	)
 	if err != nil {
 		errs = append(errs, err)
@@ -112,11 +123,19 @@ func Components() (
 		errs = append(errs, err)
 	}
 
+	connectors, err := component.MakeConnectorFactoryMap(
+		forwardconnector.NewFactory(),
+	)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
 	factories := component.Factories{
 		Extensions: extensions,
 		Receivers:  receivers,
 		Processors: processors,
 		Exporters:  exporters,
+		Connectors: connectors,
 	}
 
 	return factories, componenterror.CombineErrors(errs)
@@ -31,6 +31,9 @@ func TestDefaultComponents(t *testing.T) {
 		"pprof",
 		"zpages",
 		"fluentbit",
+		"file_storage",
+		"management",
+		"subprocess",
 	}
 	expectedReceivers := []configmodels.Type{
 		"jaeger",
@@ -63,6 +66,11 @@ func TestDefaultComponents(t *testing.T) {
 		"otlp",
 		"otlphttp",
 		"kafka",
+		"elasticsearch",
+		"syslog",
+	}
+	expectedConnectors := []configmodels.Type{
+		"forward",
 	}
 
 	factories, err := Components()
@@ -102,4 +110,13 @@ func TestDefaultComponents(t *testing.T) {
 		assert.Equal(t, k, v.Type())
 		assert.Equal(t, k, v.CreateDefaultConfig().Type())
 	}
+
+	conns := factories.Connectors
+	assert.Equal(t, len(expectedConnectors), len(conns))
+	for _, k := range expectedConnectors {
+		v, ok := conns[k]
+		require.True(t, ok)
+		assert.Equal(t, k, v.Type())
+		assert.Equal(t, k, v.CreateDefaultConfig().Type())
+	}
 }
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// MetricsToPdata converts metrics read from OpenCensus to metrics with the given resource
+// attributes. The summaries are not converted, OpenCensus views do not produce them.
+func MetricsToPdata(resource map[string]string, metrics []*metricdata.Metric) pdata.Metrics {
+	md := pdata.NewMetrics()
+	if len(metrics) == 0 {
+		return md
+	}
+
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().InitEmpty()
+	for k, v := range resource {
+		rm.Resource().Attributes().InsertString(k, v)
+	}
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	dest := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	for _, m := range metrics {
+		if m.Descriptor.Type == metricdata.TypeSummary {
+			continue
+		}
+		metric := pdata.NewMetric()
+		metric.InitEmpty()
+		metricToPdata(m, metric)
+		dest.Append(metric)
+	}
+	return md
+}
+
+func metricToPdata(m *metricdata.Metric, metric pdata.Metric) {
+	metric.SetName(m.Descriptor.Name)
+	metric.SetDescription(m.Descriptor.Description)
+	metric.SetUnit(string(m.Descriptor.Unit))
+
+	switch m.Descriptor.Type {
+	case metricdata.TypeGaugeInt64:
+		metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		metric.IntGauge().InitEmpty()
+		intPointsToPdata(m, metric.IntGauge().DataPoints())
+	case metricdata.TypeCumulativeInt64:
+		metric.SetDataType(pdata.MetricDataTypeIntSum)
+		metric.IntSum().InitEmpty()
+		metric.IntSum().SetIsMonotonic(true)
+		metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		intPointsToPdata(m, metric.IntSum().DataPoints())
+	case metricdata.TypeGaugeFloat64:
+		metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		metric.DoubleGauge().InitEmpty()
+		doublePointsToPdata(m, metric.DoubleGauge().DataPoints())
+	case metricdata.TypeCumulativeFloat64:
+		metric.SetDataType(pdata.MetricDataTypeDoubleSum)
+		metric.DoubleSum().InitEmpty()
+		metric.DoubleSum().SetIsMonotonic(true)
+		metric.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		doublePointsToPdata(m, metric.DoubleSum().DataPoints())
+	case metricdata.TypeGaugeDistribution, metricdata.TypeCumulativeDistribution:
+		metric.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+		metric.DoubleHistogram().InitEmpty()
+		temporality := pdata.AggregationTemporalityCumulative
+		if m.Descriptor.Type == metricdata.TypeGaugeDistribution {
+			temporality = pdata.AggregationTemporalityDelta
+		}
+		metric.DoubleHistogram().SetAggregationTemporality(temporality)
+		histogramPointsToPdata(m, metric.DoubleHistogram().DataPoints())
+	}
+}
+
+// forEachPoint calls f for each point of m with the labels and the start time of its time series.
+func forEachPoint(m *metricdata.Metric, f func(labels pdata.StringMap, start pdata.TimestampUnixNano, p metricdata.Point)) {
+	for _, ts := range m.TimeSeries {
+		labels := pdata.NewStringMap()
+		for i, v := range ts.LabelValues {
+			if v.Present && i < len(m.Descriptor.LabelKeys) {
+				labels.Insert(m.Descriptor.LabelKeys[i].Key, v.Value)
+			}
+		}
+		start := pdata.TimeToUnixNano(ts.StartTime)
+		for _, p := range ts.Points {
+			f(labels, start, p)
+		}
+	}
+}
+
+func intPointsToPdata(m *metricdata.Metric, dest pdata.IntDataPointSlice) {
+	forEachPoint(m, func(labels pdata.StringMap, start pdata.TimestampUnixNano, p metricdata.Point) {
+		v, ok := p.Value.(int64)
+		if !ok {
+			return
+		}
+		dp := pdata.NewIntDataPoint()
+		dp.InitEmpty()
+		labels.CopyTo(dp.LabelsMap())
+		dp.SetStartTime(start)
+		dp.SetTimestamp(pdata.TimeToUnixNano(p.Time))
+		dp.SetValue(v)
+		dest.Append(dp)
+	})
+}
+
+func doublePointsToPdata(m *metricdata.Metric, dest pdata.DoubleDataPointSlice) {
+	forEachPoint(m, func(labels pdata.StringMap, start pdata.TimestampUnixNano, p metricdata.Point) {
+		v, ok := p.Value.(float64)
+		if !ok {
+			return
+		}
+		dp := pdata.NewDoubleDataPoint()
+		dp.InitEmpty()
+		labels.CopyTo(dp.LabelsMap())
+		dp.SetStartTime(start)
+		dp.SetTimestamp(pdata.TimeToUnixNano(p.Time))
+		dp.SetValue(v)
+		dest.Append(dp)
+	})
+}
+
+func histogramPointsToPdata(m *metricdata.Metric, dest pdata.DoubleHistogramDataPointSlice) {
+	forEachPoint(m, func(labels pdata.StringMap, start pdata.TimestampUnixNano, p metricdata.Point) {
+		d, ok := p.Value.(*metricdata.Distribution)
+		if !ok {
+			return
+		}
+		dp := pdata.NewDoubleHistogramDataPoint()
+		dp.InitEmpty()
+		labels.CopyTo(dp.LabelsMap())
+		dp.SetStartTime(start)
+		dp.SetTimestamp(pdata.TimeToUnixNano(p.Time))
+		dp.SetCount(uint64(d.Count))
+		dp.SetSum(d.Sum)
+		if d.BucketOptions != nil {
+			dp.SetExplicitBounds(d.BucketOptions.Bounds)
+		}
+		counts := make([]uint64, len(d.Buckets))
+		for i, b := range d.Buckets {
+			counts[i] = uint64(b.Count)
+		}
+		dp.SetBucketCounts(counts)
+		dest.Append(dp)
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestMetricsToPdata(t *testing.T) {
+	assert.Equal(t, 0, MetricsToPdata(nil, nil).MetricCount())
+
+	start := time.Unix(10, 0)
+	now := start.Add(time.Minute)
+	labelKeys := []metricdata.LabelKey{{Key: "receiver"}, {Key: "transport"}}
+	series := func(v interface{}) []*metricdata.TimeSeries {
+		return []*metricdata.TimeSeries{{
+			LabelValues: []metricdata.LabelValue{metricdata.NewLabelValue("otlp"), {}},
+			Points:      []metricdata.Point{{Time: now, Value: v}},
+			StartTime:   start,
+		}}
+	}
+	metrics := []*metricdata.Metric{
+		{
+			Descriptor: metricdata.Descriptor{Name: "accepted_spans", Type: metricdata.TypeCumulativeInt64, LabelKeys: labelKeys},
+			TimeSeries: series(int64(5)),
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "uptime", Unit: metricdata.UnitDimensionless, Type: metricdata.TypeCumulativeFloat64},
+			TimeSeries: series(1.5),
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "queue_size", Type: metricdata.TypeGaugeInt64},
+			TimeSeries: series(int64(3)),
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "batch_size", Type: metricdata.TypeCumulativeDistribution},
+			TimeSeries: series(&metricdata.Distribution{
+				Count:         3,
+				Sum:           30,
+				BucketOptions: &metricdata.BucketOptions{Bounds: []float64{10}},
+				Buckets:       []metricdata.Bucket{{Count: 1}, {Count: 2}},
+			}),
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "summary", Type: metricdata.TypeSummary},
+		},
+	}
+
+	md := MetricsToPdata(map[string]string{"service.name": "otelcol"}, metrics)
+	rm := md.ResourceMetrics().At(0)
+	name, ok := rm.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "otelcol", name.StringVal())
+
+	// The summary is not converted.
+	ms := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 4, ms.Len())
+
+	sum := ms.At(0)
+	assert.Equal(t, "accepted_spans", sum.Name())
+	require.Equal(t, pdata.MetricDataTypeIntSum, sum.DataType())
+	assert.True(t, sum.IntSum().IsMonotonic())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, sum.IntSum().AggregationTemporality())
+	dp := sum.IntSum().DataPoints().At(0)
+	assert.EqualValues(t, 5, dp.Value())
+	assert.Equal(t, pdata.TimeToUnixNano(start), dp.StartTime())
+	assert.Equal(t, pdata.TimeToUnixNano(now), dp.Timestamp())
+	// The missing label value is not converted.
+	assert.Equal(t, 1, dp.LabelsMap().Len())
+	receiver, ok := dp.LabelsMap().Get("receiver")
+	require.True(t, ok)
+	assert.Equal(t, "otlp", receiver)
+
+	assert.Equal(t, pdata.MetricDataTypeDoubleSum, ms.At(1).DataType())
+	assert.Equal(t, "1", ms.At(1).Unit())
+	assert.Equal(t, 1.5, ms.At(1).DoubleSum().DataPoints().At(0).Value())
+	assert.Equal(t, pdata.MetricDataTypeIntGauge, ms.At(2).DataType())
+	assert.EqualValues(t, 3, ms.At(2).IntGauge().DataPoints().At(0).Value())
+
+	require.Equal(t, pdata.MetricDataTypeDoubleHistogram, ms.At(3).DataType())
+	hdp := ms.At(3).DoubleHistogram().DataPoints().At(0)
+	assert.EqualValues(t, 3, hdp.Count())
+	assert.EqualValues(t, 30, hdp.Sum())
+	assert.Equal(t, []float64{10}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{1, 2}, hdp.BucketCounts())
+}
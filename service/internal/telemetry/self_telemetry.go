@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// spanBufferSize is the number of spans buffered before they are sent, the spans
+	// recorded while the buffer is full are dropped.
+	spanBufferSize = 2048
+	// maxSpanBatchSize is the maximum number of spans sent in one batch.
+	maxSpanBatchSize = 512
+	// spanBatchTimeout is the maximum time a span is buffered.
+	spanBatchTimeout = 5 * time.Second
+)
+
+// unsampledContext returns a context with an unsampled span, so that sending the
+// collector's own telemetry does not record spans, which would be sent in turn.
+func unsampledContext() (context.Context, *trace.Span) {
+	return trace.StartSpan(context.Background(), "telemetry", trace.WithSampler(trace.NeverSample()))
+}
+
+// tracesConsumerHolder holds the consumer of a SpanExporter, atomic.Value cannot hold nil.
+type tracesConsumerHolder struct {
+	consumer consumer.TracesConsumer
+}
+
+// SpanExporter is an OpenCensus trace exporter that sends the collector's own spans to a
+// traces consumer in batches.
+type SpanExporter struct {
+	logger   *zap.Logger
+	resource map[string]string
+	consumer atomic.Value
+	spans    chan *trace.SpanData
+	dropped  int64
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewSpanExporter returns a SpanExporter that sends spans with the given resource attributes.
+// The spans are dropped until a consumer is set.
+func NewSpanExporter(logger *zap.Logger, resource map[string]string) *SpanExporter {
+	e := &SpanExporter{
+		logger:   logger,
+		resource: resource,
+		spans:    make(chan *trace.SpanData, spanBufferSize),
+		stopCh:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	e.consumer.Store(tracesConsumerHolder{})
+	return e
+}
+
+// SetConsumer sets the consumer the spans are sent to, nil drops the spans.
+func (e *SpanExporter) SetConsumer(tc consumer.TracesConsumer) {
+	e.consumer.Store(tracesConsumerHolder{consumer: tc})
+}
+
+// ExportSpan implements trace.Exporter. It does not block, the span is dropped if the
+// buffer is full.
+func (e *SpanExporter) ExportSpan(sd *trace.SpanData) {
+	select {
+	case e.spans <- sd:
+	default:
+		atomic.AddInt64(&e.dropped, 1)
+	}
+}
+
+// Start starts sending the buffered spans.
+func (e *SpanExporter) Start() {
+	go e.run()
+}
+
+// Shutdown sends the buffered spans and stops the exporter.
+func (e *SpanExporter) Shutdown() {
+	e.stopOnce.Do(func() { close(e.stopCh) })
+	<-e.done
+}
+
+func (e *SpanExporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(spanBatchTimeout)
+	defer ticker.Stop()
+
+	batch := make([]*trace.SpanData, 0, maxSpanBatchSize)
+	for {
+		select {
+		case sd := <-e.spans:
+			batch = append(batch, sd)
+			if len(batch) >= maxSpanBatchSize {
+				batch = e.send(batch)
+			}
+		case <-ticker.C:
+			batch = e.send(batch)
+		case <-e.stopCh:
+			for {
+				select {
+				case sd := <-e.spans:
+					batch = append(batch, sd)
+					if len(batch) >= maxSpanBatchSize {
+						batch = e.send(batch)
+					}
+				default:
+					e.send(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// send sends the batch and returns it emptied.
+func (e *SpanExporter) send(batch []*trace.SpanData) []*trace.SpanData {
+	if dropped := atomic.SwapInt64(&e.dropped, 0); dropped > 0 {
+		e.logger.Warn("Dropped own spans, the buffer is full", zap.Int64("dropped_spans", dropped))
+	}
+	tc := e.consumer.Load().(tracesConsumerHolder).consumer
+	if len(batch) == 0 || tc == nil {
+		return batch[:0]
+	}
+
+	ctx, span := unsampledContext()
+	defer span.End()
+	if err := tc.ConsumeTraces(ctx, SpansToTraces(e.resource, batch)); err != nil {
+		e.logger.Warn("Failed to send own spans", zap.Error(err))
+	}
+	return batch[:0]
+}
+
+// metricsConsumerHolder holds the consumer of a MetricsExporter, atomic.Value cannot hold nil.
+type metricsConsumerHolder struct {
+	consumer consumer.MetricsConsumer
+}
+
+// MetricsExporter is an OpenCensus metrics exporter that sends the collector's own metrics
+// to a metrics consumer.
+type MetricsExporter struct {
+	logger   *zap.Logger
+	resource map[string]string
+	consumer atomic.Value
+}
+
+// NewMetricsExporter returns a MetricsExporter that sends metrics with the given resource
+// attributes. The metrics are dropped until a consumer is set.
+func NewMetricsExporter(logger *zap.Logger, resource map[string]string) *MetricsExporter {
+	e := &MetricsExporter{
+		logger:   logger,
+		resource: resource,
+	}
+	e.consumer.Store(metricsConsumerHolder{})
+	return e
+}
+
+// SetConsumer sets the consumer the metrics are sent to, nil drops the metrics.
+func (e *MetricsExporter) SetConsumer(mc consumer.MetricsConsumer) {
+	e.consumer.Store(metricsConsumerHolder{consumer: mc})
+}
+
+// ExportMetrics implements metricexport.Exporter.
+func (e *MetricsExporter) ExportMetrics(_ context.Context, metrics []*metricdata.Metric) error {
+	mc := e.consumer.Load().(metricsConsumerHolder).consumer
+	if len(metrics) == 0 || mc == nil {
+		return nil
+	}
+
+	ctx, span := unsampledContext()
+	defer span.End()
+	err := mc.ConsumeMetrics(ctx, MetricsToPdata(e.resource, metrics))
+	if err != nil {
+		e.logger.Warn("Failed to send own metrics", zap.Error(err))
+	}
+	return err
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// contextCheckingSink records if the traces were sent with a sampled span.
+type contextCheckingSink struct {
+	consumertest.TracesSink
+	sampled bool
+}
+
+func (s *contextCheckingSink) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if span := trace.FromContext(ctx); span == nil || span.SpanContext().IsSampled() {
+		s.sampled = true
+	}
+	return s.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestSpanExporter(t *testing.T) {
+	exp := NewSpanExporter(zap.NewNop(), map[string]string{"service.name": "otelcol"})
+	sink := new(contextCheckingSink)
+	exp.SetConsumer(sink)
+	exp.Start()
+	for i := 0; i < maxSpanBatchSize+1; i++ {
+		exp.ExportSpan(&trace.SpanData{Name: "span"})
+	}
+	exp.Shutdown()
+
+	// One full batch is sent right away, the other span on shutdown.
+	assert.Equal(t, maxSpanBatchSize+1, sink.SpansCount())
+	assert.Len(t, sink.AllTraces(), 2)
+	assert.False(t, sink.sampled)
+
+	// The spans are dropped when there is no consumer.
+	exp = NewSpanExporter(zap.NewNop(), nil)
+	exp.Start()
+	exp.ExportSpan(&trace.SpanData{Name: "dropped"})
+	exp.Shutdown()
+}
+
+func TestSpanExporter_BufferFull(t *testing.T) {
+	exp := NewSpanExporter(zap.NewNop(), nil)
+	// The exporter is not started, the spans are buffered until the buffer is full.
+	for i := 0; i < spanBufferSize+10; i++ {
+		exp.ExportSpan(&trace.SpanData{Name: "span"})
+	}
+	assert.EqualValues(t, 10, exp.dropped)
+}
+
+func TestMetricsExporter(t *testing.T) {
+	exp := NewMetricsExporter(zap.NewNop(), nil)
+	metrics := []*metricdata.Metric{{
+		Descriptor: metricdata.Descriptor{Name: "accepted_spans", Type: metricdata.TypeCumulativeInt64},
+	}}
+
+	// The metrics are dropped while there is no consumer.
+	require.NoError(t, exp.ExportMetrics(context.Background(), metrics))
+
+	sink := new(consumertest.MetricsSink)
+	exp.SetConsumer(sink)
+	require.NoError(t, exp.ExportMetrics(context.Background(), metrics))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 1, sink.AllMetrics()[0].MetricCount())
+
+	sink.SetConsumeError(errors.New("my error"))
+	assert.EqualError(t, exp.ExportMetrics(context.Background(), metrics), "my error")
+}
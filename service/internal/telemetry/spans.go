@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"go.opencensus.io/trace"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// SpansToTraces converts spans recorded with OpenCensus to traces with the given resource
+// attributes.
+func SpansToTraces(resource map[string]string, spans []*trace.SpanData) pdata.Traces {
+	td := pdata.NewTraces()
+	if len(spans) == 0 {
+		return td
+	}
+
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().InitEmpty()
+	for k, v := range resource {
+		rs.Resource().Attributes().InsertString(k, v)
+	}
+	rs.InstrumentationLibrarySpans().Resize(1)
+	dest := rs.InstrumentationLibrarySpans().At(0).Spans()
+	dest.Resize(len(spans))
+	for i, sd := range spans {
+		spanToPdata(sd, dest.At(i))
+	}
+	return td
+}
+
+func spanToPdata(sd *trace.SpanData, span pdata.Span) {
+	span.SetTraceID(pdata.NewTraceID(sd.TraceID))
+	span.SetSpanID(pdata.NewSpanID(sd.SpanID))
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		span.SetParentSpanID(pdata.NewSpanID(sd.ParentSpanID))
+	}
+	span.SetName(sd.Name)
+	span.SetKind(spanKindToPdata(sd.SpanKind))
+	span.SetStartTime(pdata.TimeToUnixNano(sd.StartTime))
+	span.SetEndTime(pdata.TimeToUnixNano(sd.EndTime))
+	attributesToPdata(sd.Attributes, span.Attributes())
+	span.SetDroppedAttributesCount(uint32(sd.DroppedAttributeCount))
+
+	span.Status().InitEmpty()
+	if sd.Code != trace.StatusCodeOK {
+		span.Status().SetCode(pdata.StatusCodeError)
+		span.Status().SetMessage(sd.Message)
+	}
+
+	events := span.Events()
+	events.Resize(len(sd.Annotations) + len(sd.MessageEvents))
+	for i, a := range sd.Annotations {
+		event := events.At(i)
+		event.SetTimestamp(pdata.TimeToUnixNano(a.Time))
+		event.SetName(a.Message)
+		attributesToPdata(a.Attributes, event.Attributes())
+	}
+	for i, me := range sd.MessageEvents {
+		event := events.At(len(sd.Annotations) + i)
+		event.SetTimestamp(pdata.TimeToUnixNano(me.Time))
+		if me.EventType == trace.MessageEventTypeSent {
+			event.SetName("message sent")
+		} else {
+			event.SetName("message received")
+		}
+		event.Attributes().InsertInt("message.id", me.MessageID)
+		event.Attributes().InsertInt("message.uncompressed_size", me.UncompressedByteSize)
+		event.Attributes().InsertInt("message.compressed_size", me.CompressedByteSize)
+	}
+	span.SetDroppedEventsCount(uint32(sd.DroppedAnnotationCount + sd.DroppedMessageEventCount))
+
+	links := span.Links()
+	links.Resize(len(sd.Links))
+	for i, l := range sd.Links {
+		link := links.At(i)
+		link.SetTraceID(pdata.NewTraceID(l.TraceID))
+		link.SetSpanID(pdata.NewSpanID(l.SpanID))
+		attributesToPdata(l.Attributes, link.Attributes())
+	}
+	span.SetDroppedLinksCount(uint32(sd.DroppedLinkCount))
+}
+
+func spanKindToPdata(kind int) pdata.SpanKind {
+	switch kind {
+	case trace.SpanKindServer:
+		return pdata.SpanKindSERVER
+	case trace.SpanKindClient:
+		return pdata.SpanKindCLIENT
+	default:
+		return pdata.SpanKindINTERNAL
+	}
+}
+
+func attributesToPdata(attrs map[string]interface{}, dest pdata.AttributeMap) {
+	for k, v := range attrs {
+		switch val := v.(type) {
+		case string:
+			dest.InsertString(k, val)
+		case bool:
+			dest.InsertBool(k, val)
+		case int64:
+			dest.InsertInt(k, val)
+		case float64:
+			dest.InsertDouble(k, val)
+		}
+	}
+}
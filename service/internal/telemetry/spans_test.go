@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestSpansToTraces(t *testing.T) {
+	assert.Equal(t, 0, SpansToTraces(nil, nil).SpanCount())
+
+	start := time.Unix(10, 0)
+	sd := &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1, 2, 3},
+			SpanID:  trace.SpanID{4, 5, 6},
+		},
+		ParentSpanID: trace.SpanID{7, 8, 9},
+		SpanKind:     trace.SpanKindServer,
+		Name:         "receiver/otlp/TraceDataReceived",
+		StartTime:    start,
+		EndTime:      start.Add(time.Second),
+		Attributes:   map[string]interface{}{"str": "val", "int": int64(1), "bool": true, "double": 1.5},
+		Annotations: []trace.Annotation{
+			{Time: start, Message: "Sending request", Attributes: map[string]interface{}{"retry_num": int64(2)}},
+		},
+		MessageEvents: []trace.MessageEvent{{Time: start, EventType: trace.MessageEventTypeSent, MessageID: 1}},
+		Links:         []trace.Link{{TraceID: trace.TraceID{10}, SpanID: trace.SpanID{11}}},
+		Status:        trace.Status{Code: trace.StatusCodeUnavailable, Message: "unavailable"},
+	}
+
+	td := SpansToTraces(map[string]string{"service.name": "otelcol"}, []*trace.SpanData{sd})
+	require.Equal(t, 1, td.SpanCount())
+	rs := td.ResourceSpans().At(0)
+	name, ok := rs.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "otelcol", name.StringVal())
+
+	span := rs.InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.NewTraceID([16]byte{1, 2, 3}), span.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{4, 5, 6}), span.SpanID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{7, 8, 9}), span.ParentSpanID())
+	assert.Equal(t, pdata.SpanKindSERVER, span.Kind())
+	assert.Equal(t, "receiver/otlp/TraceDataReceived", span.Name())
+	assert.Equal(t, pdata.TimeToUnixNano(start), span.StartTime())
+	assert.Equal(t, pdata.TimeToUnixNano(start.Add(time.Second)), span.EndTime())
+	assert.Equal(t, 4, span.Attributes().Len())
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "unavailable", span.Status().Message())
+	require.Equal(t, 2, span.Events().Len())
+	assert.Equal(t, "Sending request", span.Events().At(0).Name())
+	assert.Equal(t, "message sent", span.Events().At(1).Name())
+	require.Equal(t, 1, span.Links().Len())
+	assert.Equal(t, pdata.NewSpanID([8]byte{11}), span.Links().At(0).SpanID())
+}
@@ -34,6 +34,7 @@ var (
 	footerTemplate          = parseTemplate("footer")
 	pipelinesTableTemplate  = parseTemplate("pipelines_table")
 	propertiesTableTemplate = parseTemplate("properties_table")
+	tapTableTemplate        = parseTemplate("tap_table")
 )
 
 func parseTemplate(name string) *template.Template {
@@ -132,6 +133,36 @@ func WriteHTMLPropertiesTable(w io.Writer, chd PropertiesTableData) {
 	}
 }
 
+// TapTableData contains data for the tap table template.
+type TapTableData struct {
+	ComponentEndpoint string
+	Pipeline          string
+	Rows              []TapTableRowData
+	// Samples are the samples recorded by the selected stage, most recent first.
+	Samples []TapSampleData
+}
+
+// TapTableRowData contains data for one row in the tap table template.
+type TapTableRowData struct {
+	Stage   string
+	Enabled bool
+	Samples int
+}
+
+// TapSampleData contains data for one sample recorded by a tap.
+type TapSampleData struct {
+	Time string
+	Text string
+}
+
+// WriteHTMLTapTable writes the table of the taps of one pipeline followed by the samples
+// of the selected stage.
+func WriteHTMLTapTable(w io.Writer, ttd TapTableData) {
+	if err := tapTableTemplate.Execute(w, ttd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
 // WriteHTMLFooter writes the footer.
 func WriteHTMLFooter(w io.Writer) {
 	if err := footerTemplate.Execute(w, nil); err != nil {
@@ -82,6 +82,14 @@ func TestNoCrash(t *testing.T) {
 	assert.NotPanics(t, func() {
 		WriteHTMLPropertiesTable(buf, PropertiesTableData{Name: "Bar", Properties: [][2]string{{"key", "value"}}})
 	})
+	assert.NotPanics(t, func() {
+		WriteHTMLTapTable(buf, TapTableData{
+			ComponentEndpoint: "pagez",
+			Pipeline:          "traces",
+			Rows:              []TapTableRowData{{Stage: "receivers", Enabled: true, Samples: 1}, {Stage: "batch"}},
+			Samples:           []TapSampleData{{Time: "now", Text: "Span \"foo\""}},
+		})
+	})
 	assert.NotPanics(t, func() { WriteHTMLFooter(buf) })
 	assert.NotPanics(t, func() { WriteHTMLFooter(buf) })
 }
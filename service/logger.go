@@ -20,6 +20,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/internal/version"
 )
 
@@ -44,9 +45,15 @@ func loggerFlags(flags *flag.FlagSet) {
 	loggerFormatPtr = flags.String(logFormatCfg, "console", "Format of logs to use (json, console)")
 }
 
-func newLogger(options []zap.Option) (*zap.Logger, error) {
+// newLogger creates the logger from the command line flags, the settings of the
+// service::telemetry::logs configuration override the flags.
+func newLogger(options []zap.Option, cfg configmodels.LogsTelemetry) (*zap.Logger, error) {
+	levelStr := *loggerLevelPtr
+	if cfg.Level != "" {
+		levelStr = cfg.Level
+	}
 	var level zapcore.Level
-	err := (&level).UnmarshalText([]byte(*loggerLevelPtr))
+	err := (&level).UnmarshalText([]byte(levelStr))
 	if err != nil {
 		return nil, err
 	}
@@ -67,11 +74,27 @@ func newLogger(options []zap.Option) (*zap.Logger, error) {
 	}
 
 	conf.Encoding = *loggerFormatPtr
+	if cfg.Encoding != "" {
+		conf.Encoding = cfg.Encoding
+	}
 	if conf.Encoding == "console" {
 		// Human-readable timestamps for console format of logs.
 		conf.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
 
+	if cfg.Sampling != nil {
+		conf.Sampling = &zap.SamplingConfig{
+			Initial:    cfg.Sampling.Initial,
+			Thereafter: cfg.Sampling.Thereafter,
+		}
+	}
+	if len(cfg.OutputPaths) > 0 {
+		conf.OutputPaths = cfg.OutputPaths
+	}
+	if len(cfg.ErrorOutputPaths) > 0 {
+		conf.ErrorOutputPaths = cfg.ErrorOutputPaths
+	}
+
 	conf.Level.SetLevel(level)
 	return conf.Build(options...)
 }
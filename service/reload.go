@@ -0,0 +1,520 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configsource"
+	"go.opentelemetry.io/collector/service/builder"
+)
+
+// reloadConfiguration loads the configuration again and applies it to the running
+// pipelines. If the new configuration is invalid or cannot be applied the previous
+// configuration stays in effect.
+func (app *Application) reloadConfiguration(ctx context.Context) error {
+	return app.reloadConfigurationFrom(ctx, app.configFactory)
+}
+
+// reloadConfigurationFrom creates the configuration using the given factory and
+// applies it like reloadConfiguration.
+func (app *Application) reloadConfigurationFrom(ctx context.Context, factory ConfigFactory) error {
+	app.logger.Info("Reloading configuration...")
+	cfg, raw, sources, err := app.loadConfig(factory)
+	if err != nil {
+		return err
+	}
+	prevRaw, _ := app.rawConfig.Load().(map[string]interface{})
+
+	// Extensions are started before and stopped after the pipelines and other
+	// components may hold references to them, so they are never rebuilt.
+	if !reflect.DeepEqual(cfg.Extensions, app.config.Extensions) ||
+		!reflect.DeepEqual(cfg.Service.Extensions, app.config.Service.Extensions) {
+		app.logger.Warn("Changes to extensions require a restart and are ignored.")
+	}
+	cfg.Extensions = app.config.Extensions
+	cfg.Service.Extensions = app.config.Service.Extensions
+	keepRawValue(raw, prevRaw, "extensions")
+	keepRawValue(raw, prevRaw, "service", "extensions")
+
+	// The logger and the metrics endpoint are set up once at startup.
+	if !reflect.DeepEqual(cfg.Service.Telemetry.Logs, app.config.Service.Telemetry.Logs) ||
+		!reflect.DeepEqual(cfg.Service.Telemetry.Metrics, app.config.Service.Telemetry.Metrics) {
+		app.logger.Warn("Changes to telemetry logs and metrics require a restart and are ignored.")
+	}
+	cfg.Service.Telemetry.Logs = app.config.Service.Telemetry.Logs
+	cfg.Service.Telemetry.Metrics = app.config.Service.Telemetry.Metrics
+	keepRawValue(raw, prevRaw, "service", "telemetry", "logs")
+	keepRawValue(raw, prevRaw, "service", "telemetry", "metrics")
+
+	if err = app.applyConfig(ctx, cfg); err != nil {
+		if sources != nil {
+			sources.Close()
+		}
+		return err
+	}
+
+	if app.configSources != nil {
+		app.configSources.Close()
+	}
+	app.configSources = sources
+	app.rawConfig.Store(raw)
+	app.logger.Info("Configuration reloaded.")
+	return nil
+}
+
+// keepRawValue sets the value at the given path of the raw configuration dst to the
+// value at the same path in src, or removes it if src has no such value. It is used
+// for the settings whose changes are ignored on reload.
+func keepRawValue(dst, src map[string]interface{}, path ...string) {
+	for _, key := range path[:len(path)-1] {
+		src, _ = src[key].(map[string]interface{})
+		next, ok := dst[key].(map[string]interface{})
+		if !ok {
+			if src == nil {
+				return
+			}
+			next = make(map[string]interface{})
+			dst[key] = next
+		}
+		dst = next
+	}
+	last := path[len(path)-1]
+	if v, ok := src[last]; ok {
+		dst[last] = v
+	} else {
+		delete(dst, last)
+	}
+}
+
+// configPush is a configuration pushed with ApplyConfig, applied by the main loop so
+// that it does not run concurrently with the reloads.
+type configPush struct {
+	cfg    map[string]interface{}
+	result chan error
+}
+
+var _ component.ConfigManager = (*Application)(nil)
+
+// EffectiveConfig implements component.ConfigManager. It returns the configuration
+// read by the ConfigFactory when the configuration in effect was loaded.
+func (app *Application) EffectiveConfig() (map[string]interface{}, error) {
+	raw, ok := app.rawConfig.Load().(map[string]interface{})
+	if !ok || len(raw) == 0 {
+		return nil, errors.New("the configuration in effect was not read through viper")
+	}
+	return config.RedactSecrets(raw), nil
+}
+
+// ApplyConfig implements component.ConfigManager. It waits until the main loop of the
+// application applies the configuration, or until ctx is done if the loop does not
+// run.
+func (app *Application) ApplyConfig(ctx context.Context, yamlConfig []byte) error {
+	cfg, err := config.ParseConfig(yamlConfig)
+	if err != nil {
+		return fmt.Errorf("cannot parse configuration: %w", err)
+	}
+
+	push := configPush{cfg: cfg, result: make(chan error, 1)}
+	select {
+	case app.pushChannel <- push:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return <-push.result
+}
+
+// applyPushedConfig applies a configuration pushed with ApplyConfig. Once applied it
+// is also used by the following reloads instead of the configuration files.
+func (app *Application) applyPushedConfig(ctx context.Context, pushed map[string]interface{}) error {
+	factory := app.pushedConfigFactory(pushed)
+	if err := app.reloadConfigurationFrom(ctx, factory); err != nil {
+		return err
+	}
+	app.configFactory = factory
+	return nil
+}
+
+// pushedConfigFactory returns a ConfigFactory creating the configuration from a
+// pushed configuration. Like for the configuration files, the config sources it
+// references are watched.
+func (app *Application) pushedConfigFactory(pushed map[string]interface{}) ConfigFactory {
+	return func(v *viper.Viper, factories component.Factories) (*configmodels.Config, error) {
+		out, err := yaml.Marshal(pushed)
+		if err != nil {
+			return nil, err
+		}
+		v.SetConfigType("yaml")
+		if err = v.ReadConfig(bytes.NewReader(out)); err != nil {
+			return nil, err
+		}
+
+		sources := configsource.NewManager(app.sources)
+		cfg, err := config.LoadWithSources(v, factories, sources)
+		if err != nil {
+			sources.Close()
+			return nil, err
+		}
+		app.loadedSources = sources
+		return cfg, nil
+	}
+}
+
+// applyConfig switches the running pipelines to the given configuration. Only the
+// receivers, pipelines and exporters affected by the changes are rebuilt, all other
+// components keep running. Changing an exporter rebuilds the pipelines that use it and
+// changing a pipeline rebuilds the receivers attached to it.
+//
+// New components are built and started before the replaced ones are stopped, except
+// for the receivers and for the exporters replacing an exporter with the same name:
+// the replaced ones are stopped first since the new ones may listen on the same
+// endpoints, e.g. the Prometheus exporter. The replaced pipelines are stopped with
+// these exporters, once the receivers sending data to them are stopped. If anything
+// fails the new components are stopped and the previous configuration stays in effect.
+func (app *Application) applyConfig(ctx context.Context, cfg *configmodels.Config) error {
+	exporters, err := builder.NewExportersBuilder(app.logger, app.info, cfg, app.factories.Exporters).
+		ReuseFrom(app.config, app.builtExporters).
+		Build()
+	if err != nil {
+		return fmt.Errorf("cannot build exporters: %w", err)
+	}
+	newExporters := exporters.Without(app.builtExporters)
+	replacedExporters := app.builtExporters.Without(exporters)
+	renewedExporters := exportersWithNamesOf(newExporters, replacedExporters)
+	app.logger.Info("Starting new exporters...", zap.Int("count", len(newExporters)-len(renewedExporters)))
+	if err = newExporters.Without(renewedExporters).StartAll(ctx, app); err != nil {
+		return app.discardComponents(ctx, fmt.Errorf("cannot start exporters: %w", err), nil, nil, newExporters)
+	}
+
+	pipelines, err := builder.NewPipelinesBuilder(app.logger, app.info, cfg, exporters, app.factories.Processors).
+		WithConnectors(app.factories.Connectors).
+		ReuseFrom(app.config, app.builtPipelines).
+		Build()
+	if err != nil {
+		return app.discardComponents(ctx, fmt.Errorf("cannot build pipelines: %w", err), nil, nil, newExporters)
+	}
+	newPipelines := pipelines.Without(app.builtPipelines)
+	app.logger.Info("Starting new processors...", zap.Int("pipelines", len(newPipelines)))
+	if err = newPipelines.StartProcessors(ctx, app); err != nil {
+		return app.discardComponents(ctx, fmt.Errorf("cannot start processors: %w", err), nil, newPipelines, newExporters)
+	}
+
+	receivers, err := builder.NewReceiversBuilder(app.logger, app.info, cfg, pipelines, app.factories.Receivers).
+		ReuseFrom(app.config, app.builtReceivers).
+		Build()
+	if err != nil {
+		return app.discardComponents(ctx, fmt.Errorf("cannot build receivers: %w", err), nil, newPipelines, newExporters)
+	}
+	newReceivers := receivers.Without(app.builtReceivers)
+	oldReceivers := app.builtReceivers.Without(receivers)
+	replacedPipelines := app.builtPipelines.Without(pipelines)
+
+	app.logger.Info("Stopping replaced receivers...", zap.Int("count", len(oldReceivers)))
+	if err = oldReceivers.ShutdownAll(ctx); err != nil {
+		app.logger.Warn("Failed to stop replaced receivers", zap.Error(err))
+	}
+
+	// The replaced pipelines and exporters stopped at this point, restored if the new
+	// configuration cannot be applied.
+	var stoppedPipelines builder.BuiltPipelines
+	var stoppedExporters builder.Exporters
+	if len(renewedExporters) != 0 {
+		stoppedPipelines = replacedPipelines
+		stoppedExporters = exportersWithNamesOf(replacedExporters, renewedExporters)
+		app.logger.Info("Stopping replaced exporters...", zap.Int("count", len(stoppedExporters)))
+		app.drainComponents(ctx, stoppedPipelines, stoppedExporters)
+
+		app.logger.Info("Starting renewed exporters...", zap.Int("count", len(renewedExporters)))
+		if err = renewedExporters.StartAll(ctx, app); err != nil {
+			err = fmt.Errorf("cannot start exporters: %w", err)
+			if restoreErr := app.restorePipelines(ctx, cfg, stoppedPipelines, stoppedExporters, receivers.Without(newReceivers)); restoreErr != nil {
+				err = componenterror.CombineErrors([]error{err, restoreErr})
+			}
+			return app.discardComponents(ctx, err, nil, newPipelines, newExporters)
+		}
+	}
+
+	app.logger.Info("Starting new receivers...", zap.Int("count", len(newReceivers)))
+	if err = newReceivers.StartAll(ctx, app); err != nil {
+		err = fmt.Errorf("cannot start receivers: %w", err)
+		if restoreErr := app.restorePipelines(ctx, cfg, stoppedPipelines, stoppedExporters, receivers.Without(newReceivers)); restoreErr != nil {
+			err = componenterror.CombineErrors([]error{err, restoreErr})
+		}
+		return app.discardComponents(ctx, err, newReceivers, newPipelines, newExporters)
+	}
+
+	// Send the own telemetry and the logs of the extensions to the new pipelines before
+	// the replaced ones are stopped.
+	if app.selfTelemetry != nil {
+		if err = app.selfTelemetry.apply(ctx, cfg, pipelines, app); err != nil {
+			app.logger.Warn("Failed to apply the telemetry configuration", zap.Error(err))
+		}
+	}
+	app.setLogsConsumers(pipelines)
+
+	// The new configuration is in effect, stop everything else that was replaced.
+	app.drainComponents(ctx, replacedPipelines.Without(stoppedPipelines), replacedExporters.Without(stoppedExporters))
+
+	app.config = cfg
+	app.setComponentPipelines(cfg)
+	app.builtExporters = exporters
+	app.builtPipelines = pipelines
+	app.builtReceivers = receivers
+	app.setRunningState(cfg, exporters, pipelines)
+	return nil
+}
+
+// drainComponents stops the given replaced pipelines and exporters. They are given the
+// same time to send the data they hold as on shutdown.
+func (app *Application) drainComponents(ctx context.Context, pipelines builder.BuiltPipelines, exporters builder.Exporters) {
+	drainCtx, cancel := context.WithTimeout(ctx, builder.ShutdownDrainTimeout())
+	defer cancel()
+	var errs []error
+	if err := pipelines.ShutdownProcessors(drainCtx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to shutdown processors: %w", err))
+	}
+	if err := exporters.ShutdownAll(drainCtx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to shutdown exporters: %w", err))
+	}
+	app.logDrainedItems(exporters, drainCtx.Err() != nil)
+	if len(errs) != 0 {
+		app.logger.Warn("Failed to stop replaced components", zap.Error(componenterror.CombineErrors(errs)))
+	}
+}
+
+// exportersWithNamesOf returns the exporters having the same name as one of other.
+func exportersWithNamesOf(exporters, other builder.Exporters) builder.Exporters {
+	names := make(map[string]bool, len(other))
+	for cfg := range other {
+		names[cfg.Name()] = true
+	}
+	result := make(builder.Exporters)
+	for cfg, exp := range exporters {
+		if names[cfg.Name()] {
+			result[cfg] = exp
+		}
+	}
+	return result
+}
+
+// restorePipelines rebuilds and starts the exporters, pipelines and receivers of the
+// current configuration that were stopped while applying cfg. The components that were
+// kept running are reused.
+func (app *Application) restorePipelines(
+	ctx context.Context,
+	cfg *configmodels.Config,
+	stoppedPipelines builder.BuiltPipelines,
+	stoppedExporters builder.Exporters,
+	keptReceivers builder.Receivers,
+) error {
+	if len(stoppedExporters) != 0 {
+		exporters, err := builder.NewExportersBuilder(app.logger, app.info, app.config, app.factories.Exporters).
+			ReuseFrom(app.config, app.builtExporters.Without(stoppedExporters)).
+			Build()
+		if err != nil {
+			return fmt.Errorf("cannot rebuild previous exporters: %w", err)
+		}
+		if err = exporters.Without(app.builtExporters).StartAll(ctx, app); err != nil {
+			return fmt.Errorf("cannot restart previous exporters: %w", err)
+		}
+		app.builtExporters = exporters
+	}
+	if len(stoppedPipelines) != 0 {
+		pipelines, err := builder.NewPipelinesBuilder(app.logger, app.info, app.config, app.builtExporters, app.factories.Processors).
+			WithConnectors(app.factories.Connectors).
+			ReuseFrom(app.config, app.builtPipelines.Without(stoppedPipelines)).
+			Build()
+		if err != nil {
+			return fmt.Errorf("cannot rebuild previous pipelines: %w", err)
+		}
+		if err = pipelines.Without(app.builtPipelines).StartProcessors(ctx, app); err != nil {
+			return fmt.Errorf("cannot restart previous processors: %w", err)
+		}
+		app.builtPipelines = pipelines
+		app.setRunningState(app.config, app.builtExporters, pipelines)
+		if app.selfTelemetry != nil {
+			if err = app.selfTelemetry.apply(ctx, app.config, pipelines, app); err != nil {
+				app.logger.Warn("Failed to apply the telemetry configuration", zap.Error(err))
+			}
+		}
+		app.setLogsConsumers(pipelines)
+	}
+	return app.restoreReceivers(ctx, cfg, keptReceivers)
+}
+
+// restoreReceivers rebuilds and starts the receivers of the current configuration
+// that were stopped while applying cfg. The receivers that were kept running are reused.
+func (app *Application) restoreReceivers(ctx context.Context, cfg *configmodels.Config, kept builder.Receivers) error {
+	receivers, err := builder.NewReceiversBuilder(app.logger, app.info, app.config, app.builtPipelines, app.factories.Receivers).
+		ReuseFrom(cfg, kept).
+		Build()
+	if err != nil {
+		return fmt.Errorf("cannot rebuild previous receivers: %w", err)
+	}
+	if err = receivers.Without(kept).StartAll(ctx, app); err != nil {
+		return fmt.Errorf("cannot restart previous receivers: %w", err)
+	}
+	app.builtReceivers = receivers
+	return nil
+}
+
+// discardComponents stops components built for a configuration that could not be
+// applied and returns cause.
+func (app *Application) discardComponents(
+	ctx context.Context,
+	cause error,
+	receivers builder.Receivers,
+	pipelines builder.BuiltPipelines,
+	exporters builder.Exporters,
+) error {
+	var errs []error
+	if err := receivers.ShutdownAll(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pipelines.ShutdownProcessors(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := exporters.ShutdownAll(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) != 0 {
+		app.logger.Warn("Failed to stop discarded components", zap.Error(componenterror.CombineErrors(errs)))
+	}
+	return cause
+}
+
+// configWatcher notifies when any of the configuration files changes.
+type configWatcher struct {
+	watcher *fsnotify.Watcher
+	changed chan struct{}
+	done    chan struct{}
+}
+
+// watchedPath is a configuration file or directory given on the command line.
+type watchedPath struct {
+	path     string
+	isDir    bool
+	realPath string
+}
+
+// newConfigWatcher starts watching the given configuration files and directories. The
+// directory of a file is watched instead of the file itself so that replacing the file,
+// as done by most editors and by Kubernetes when a ConfigMap is updated, is detected too.
+// Only changes to .yaml and .yml files are considered in directories.
+func newConfigWatcher(paths []string, logger *zap.Logger) (*configWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	watched := make([]*watchedPath, 0, len(paths))
+	for _, path := range paths {
+		wp := &watchedPath{path: filepath.Clean(path)}
+		wp.realPath, _ = filepath.EvalSymlinks(wp.path)
+		dir := filepath.Dir(wp.path)
+		if info, err := os.Stat(wp.path); err == nil && info.IsDir() {
+			wp.isDir = true
+			dir = wp.path
+		}
+		if err = watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+		watched = append(watched, wp)
+	}
+
+	cw := &configWatcher{
+		watcher: watcher,
+		// Buffered so that a burst of events results in a single reload.
+		changed: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(cw.done)
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if isConfigChange(watched, event) {
+					select {
+					case cw.changed <- struct{}{}:
+					default:
+					}
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warn("Error watching configuration files", zap.Error(err))
+			}
+		}
+	}()
+
+	return cw, nil
+}
+
+// isConfigChange returns true if the event changes one of the watched configurations.
+func isConfigChange(watched []*watchedPath, event fsnotify.Event) bool {
+	name := filepath.Clean(event.Name)
+	changed := false
+	for _, wp := range watched {
+		if wp.isDir {
+			if filepath.Dir(name) == wp.path && config.IsConfigFileName(name) {
+				changed = true
+			}
+			continue
+		}
+
+		// Either the file itself was written or created, or the target of
+		// the symlink changed.
+		realPath, _ := filepath.EvalSymlinks(wp.path)
+		if (name == wp.path && event.Op&(fsnotify.Write|fsnotify.Create) != 0) ||
+			(realPath != "" && realPath != wp.realPath) {
+			wp.realPath = realPath
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Changed returns a channel that receives a value when a configuration file changed.
+func (cw *configWatcher) Changed() <-chan struct{} {
+	return cw.changed
+}
+
+// Close stops watching the configuration files.
+func (cw *configWatcher) Close() error {
+	err := cw.watcher.Close()
+	<-cw.done
+	return err
+}
@@ -0,0 +1,589 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configsource"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/prometheusexporter"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+func TestApplication_ReloadConfiguration(t *testing.T) {
+	app, configs := newReloadTestApplication(t)
+	prevCfg := app.config
+	prevExporter := tracesExporter(app, prevCfg, "exampleexporter")
+	prevExporter2 := tracesExporter(app, prevCfg, "exampleexporter/2")
+	prevReceivers := app.builtReceivers
+
+	// Change exampleexporter/2, only the "traces/2" pipeline uses it.
+	cfg := createReloadTestConfig()
+	cfg.Exporters["exampleexporter/2"].(*componenttest.ExampleExporter).ExtraSetting = "changed"
+	configs <- cfg
+	require.NoError(t, app.reloadConfiguration(context.Background()))
+
+	assert.Same(t, cfg, app.config)
+	assert.Same(t, cfg, app.getRunningState().config)
+	assert.Equal(t, app.builtPipelines, app.getRunningState().pipelines)
+	assert.Same(t, prevExporter, tracesExporter(app, cfg, "exampleexporter"))
+	assert.False(t, prevExporter.ExporterShutdown)
+
+	exporter2 := tracesExporter(app, cfg, "exampleexporter/2")
+	assert.NotSame(t, prevExporter2, exporter2)
+	assert.True(t, exporter2.ExporterStarted)
+	assert.True(t, prevExporter2.ExporterShutdown)
+
+	// Only the receiver attached to "traces/2" is rebuilt.
+	assert.Same(t, prevReceivers[prevCfg.Receivers["examplereceiver"]], app.builtReceivers[cfg.Receivers["examplereceiver"]])
+	assert.NotSame(t, prevReceivers[prevCfg.Receivers["examplereceiver/2"]], app.builtReceivers[cfg.Receivers["examplereceiver/2"]])
+
+	// Reloading the same configuration keeps everything running.
+	prevReceivers = app.builtReceivers
+	cfg = createReloadTestConfig()
+	cfg.Exporters["exampleexporter/2"].(*componenttest.ExampleExporter).ExtraSetting = "changed"
+	configs <- cfg
+	require.NoError(t, app.reloadConfiguration(context.Background()))
+	assert.Len(t, app.builtReceivers.Without(prevReceivers), 0)
+	assert.Same(t, exporter2, tracesExporter(app, cfg, "exampleexporter/2"))
+
+	require.NoError(t, app.shutdownPipelines(context.Background()))
+}
+
+func TestApplication_ReloadConfigurationInvalid(t *testing.T) {
+	app, configs := newReloadTestApplication(t)
+	prevCfg := app.config
+	prevExporters := app.builtExporters
+
+	// The config fails validation, the pipeline uses an exporter that does not exist.
+	cfg := createReloadTestConfig()
+	cfg.Service.Pipelines["traces"].Exporters = []string{"nonexistent"}
+	configs <- cfg
+	assert.Error(t, app.reloadConfiguration(context.Background()))
+
+	assert.Same(t, prevCfg, app.config)
+	assert.Same(t, prevCfg, app.getRunningState().config)
+	assert.Equal(t, prevExporters, app.builtExporters)
+	assert.False(t, tracesExporter(app, prevCfg, "exampleexporter").ExporterShutdown)
+
+	require.NoError(t, app.shutdownPipelines(context.Background()))
+}
+
+func TestApplication_ReloadConfigurationZPages(t *testing.T) {
+	app, configs := newReloadTestApplication(t)
+	mux := http.NewServeMux()
+	app.RegisterZPages(mux, "/debug")
+
+	// The zPages are served while the configuration is reloaded, run with -race. The
+	// status codes are checked at the end, calling t would synchronize the goroutines.
+	urls := []string{"/debug/pipelinez", "/debug/componentz", "/debug/tapz?zpipelinename=traces"}
+	codes := make([]int, len(urls))
+	done := make(chan struct{})
+	var started, wg sync.WaitGroup
+	for i, url := range urls {
+		started.Add(1)
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			for n := 0; ; n++ {
+				if n == 1 {
+					started.Done()
+				}
+				select {
+				case <-done:
+					return
+				default:
+				}
+				rr := httptest.NewRecorder()
+				mux.ServeHTTP(rr, httptest.NewRequest("GET", url, nil))
+				codes[i] = rr.Code
+				app.GetExporters()
+			}
+		}(i, url)
+	}
+	started.Wait()
+
+	for i := 0; i < 20; i++ {
+		cfg := createReloadTestConfig()
+		cfg.Exporters["exampleexporter/2"].(*componenttest.ExampleExporter).ExtraSetting = strconv.Itoa(i)
+		configs <- cfg
+		require.NoError(t, app.reloadConfiguration(context.Background()))
+	}
+	close(done)
+	wg.Wait()
+	for i, url := range urls {
+		assert.Equal(t, http.StatusOK, codes[i], url)
+	}
+
+	require.NoError(t, app.shutdownPipelines(context.Background()))
+}
+
+func TestApplication_ReloadConfigurationRollback(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(cfg *configmodels.Config)
+		// restarted is set when the previous exporter is stopped before the new
+		// configuration fails, it is rebuilt and started again.
+		restarted bool
+	}{
+		{
+			name: "exporter_start_error",
+			configure: func(cfg *configmodels.Config) {
+				cfg.Exporters["failing"] = &configmodels.ExporterSettings{TypeVal: "failing", NameVal: "failing"}
+				cfg.Service.Pipelines["traces"].Exporters = []string{"exampleexporter", "failing"}
+			},
+		},
+		{
+			name: "renewed_exporter_start_error",
+			configure: func(cfg *configmodels.Config) {
+				cfg.Exporters["exampleexporter"] = &configmodels.ExporterSettings{TypeVal: "failing", NameVal: "exampleexporter"}
+			},
+			restarted: true,
+		},
+		{
+			name: "receiver_start_error",
+			configure: func(cfg *configmodels.Config) {
+				cfg.Exporters["exampleexporter/2"].(*componenttest.ExampleExporter).ExtraSetting = "changed"
+				cfg.Receivers["failing"] = &configmodels.ReceiverSettings{TypeVal: "failing", NameVal: "failing"}
+				cfg.Service.Pipelines["traces"].Receivers = []string{"failing"}
+			},
+		},
+		{
+			name: "receiver_start_error_renewed_exporter",
+			configure: func(cfg *configmodels.Config) {
+				cfg.Exporters["exampleexporter"].(*componenttest.ExampleExporter).ExtraSetting = "changed"
+				cfg.Receivers["failing"] = &configmodels.ReceiverSettings{TypeVal: "failing", NameVal: "failing"}
+				cfg.Service.Pipelines["traces"].Receivers = []string{"failing"}
+			},
+			restarted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, configs := newReloadTestApplication(t)
+			prevCfg := app.config
+			prevExporter := tracesExporter(app, prevCfg, "exampleexporter")
+
+			cfg := createReloadTestConfig()
+			tt.configure(cfg)
+			configs <- cfg
+			assert.Error(t, app.reloadConfiguration(context.Background()))
+
+			// The previous configuration stays in effect.
+			assert.Same(t, prevCfg, app.config)
+			exporter := tracesExporter(app, prevCfg, "exampleexporter")
+			if tt.restarted {
+				assert.NotSame(t, prevExporter, exporter)
+				assert.True(t, prevExporter.ExporterShutdown)
+				assert.True(t, exporter.ExporterStarted)
+			} else {
+				assert.Same(t, prevExporter, exporter)
+			}
+			assert.False(t, exporter.ExporterShutdown)
+			assert.Equal(t, app.builtPipelines, app.getRunningState().pipelines)
+			assert.Len(t, app.builtReceivers, 2)
+			for _, rcvCfg := range prevCfg.Receivers {
+				assert.NotNil(t, app.builtReceivers[rcvCfg])
+			}
+
+			require.NoError(t, app.shutdownPipelines(context.Background()))
+		})
+	}
+}
+
+func TestApplication_ReloadConfigurationPortBindingExporter(t *testing.T) {
+	app, configs := newReloadTestApplication(t)
+	app.factories.Exporters["prometheus"] = prometheusexporter.NewFactory()
+	endpoint := testutil.GetAvailableLocalAddress(t)
+
+	createConfig := func(namespace string) *configmodels.Config {
+		cfg := createReloadTestConfig()
+		promCfg := app.factories.Exporters["prometheus"].CreateDefaultConfig().(*prometheusexporter.Config)
+		promCfg.Endpoint = endpoint
+		promCfg.Namespace = namespace
+		cfg.Exporters["prometheus"] = promCfg
+		cfg.Service.Pipelines["metrics"] = &configmodels.Pipeline{
+			Name:      "metrics",
+			InputType: configmodels.MetricsDataType,
+			Receivers: []string{"examplereceiver"},
+			Exporters: []string{"prometheus"},
+		}
+		return cfg
+	}
+	configs <- createConfig("first")
+	require.NoError(t, app.reloadConfiguration(context.Background()))
+
+	// The new exporter listens on the same endpoint, the replaced one must be stopped
+	// before it starts.
+	cfg := createConfig("second")
+	configs <- cfg
+	require.NoError(t, app.reloadConfiguration(context.Background()))
+	assert.Same(t, cfg, app.config)
+
+	resp, err := http.Get("http://" + endpoint + "/metrics")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+
+	require.NoError(t, app.shutdownPipelines(context.Background()))
+}
+
+func TestApplication_ReloadConfigSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configsources")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	secret := filepath.Join(dir, "secret")
+	require.NoError(t, ioutil.WriteFile(secret, []byte("first\n"), 0600))
+	file := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`
+receivers:
+  examplereceiver:
+exporters:
+  exampleexporter:
+    extra: "${file:`+secret+`}"
+service:
+  pipelines:
+    traces:
+      receivers: [examplereceiver]
+      exporters: [exampleexporter]
+`), 0600))
+
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	app, err := New(Parameters{Factories: factories, ApplicationStartInfo: componenttest.TestApplicationStartInfo()})
+	require.NoError(t, err)
+	app.logger = zap.NewNop()
+	app.sources = map[string]configsource.Source{"file": &configsource.FileSource{PollInterval: 10 * time.Millisecond}}
+	require.NoError(t, app.rootCmd.ParseFlags([]string{"--config=" + file}))
+
+	require.NoError(t, app.setupConfiguration(app.configFactory))
+	require.NoError(t, app.setupConfigurationComponents(context.Background()))
+	assert.Equal(t, "first", app.config.Exporters["exampleexporter"].(*componenttest.ExampleExporter).ExtraSetting)
+	prevSources := app.configSources
+	require.NotNil(t, prevSources)
+
+	require.NoError(t, ioutil.WriteFile(secret, []byte("second\n"), 0600))
+	select {
+	case <-prevSources.Changed():
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not notified")
+	}
+
+	require.NoError(t, app.reloadConfiguration(context.Background()))
+	assert.Equal(t, "second", app.config.Exporters["exampleexporter"].(*componenttest.ExampleExporter).ExtraSetting)
+	assert.NotSame(t, prevSources, app.configSources)
+
+	// The effective configuration has the references to the config sources, and it
+	// is not changed by a file that fails to reload.
+	effective, err := app.EffectiveConfig()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"extra": "${file:" + secret + "}"},
+		effective["exporters"].(map[string]interface{})["exampleexporter"])
+	require.NoError(t, ioutil.WriteFile(file, []byte("receivers: {}\n"), 0600))
+	assert.Error(t, app.reloadConfiguration(context.Background()))
+	afterInvalid, err := app.EffectiveConfig()
+	require.NoError(t, err)
+	assert.Equal(t, effective, afterInvalid)
+
+	require.NoError(t, app.shutdownPipelines(context.Background()))
+	require.NoError(t, app.shutdownExtensions(context.Background()))
+	require.NoError(t, app.configSources.Close())
+}
+
+func TestApplication_ApplyConfig(t *testing.T) {
+	app, configs := newReloadTestApplication(t)
+	prevExporter := tracesExporter(app, app.config, "exampleexporter")
+
+	// Apply the pushed configurations like the main loop.
+	pushes := make(chan configPush)
+	app.pushChannel = pushes
+	done := make(chan struct{})
+	defer func() {
+		close(pushes)
+		<-done
+	}()
+	go func() {
+		defer close(done)
+		for push := range pushes {
+			push.result <- app.applyPushedConfig(context.Background(), push.cfg)
+		}
+	}()
+
+	pushed := `
+receivers:
+  examplereceiver:
+exporters:
+  exampleexporter:
+  exampleexporter/2:
+    extra: "changed"
+    extra_map:
+      token: "secret"
+service:
+  pipelines:
+    traces:
+      receivers: [examplereceiver]
+      exporters: [exampleexporter, exampleexporter/2]
+`
+	// The test ConfigFactory does not read the configuration through viper.
+	_, err := app.EffectiveConfig()
+	assert.Error(t, err)
+
+	require.NoError(t, app.ApplyConfig(context.Background(), []byte(pushed)))
+	assert.Len(t, app.config.Service.Pipelines, 1)
+	assert.Same(t, prevExporter, tracesExporter(app, app.config, "exampleexporter"))
+	assert.Equal(t, "changed", app.config.Exporters["exampleexporter/2"].(*componenttest.ExampleExporter).ExtraSetting)
+
+	effective, err := app.EffectiveConfig()
+	require.NoError(t, err)
+	exporters := effective["exporters"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"extra":     "changed",
+		"extra_map": map[string]interface{}{"token": "[REDACTED]"},
+	}, exporters["exampleexporter/2"])
+
+	// An invalid configuration is not applied.
+	cfg := app.config
+	assert.Error(t, app.ApplyConfig(context.Background(), []byte("exporters: [")))
+	assert.Error(t, app.ApplyConfig(context.Background(), []byte(strings.Replace(pushed, "exampleexporter/2]", "nonexistent]", 1))))
+	assert.Same(t, cfg, app.config)
+	afterInvalid, err := app.EffectiveConfig()
+	require.NoError(t, err)
+	assert.Equal(t, effective, afterInvalid)
+
+	// The pushed configuration replaces the configuration files on reload.
+	configs <- createReloadTestConfig()
+	require.NoError(t, app.reloadConfiguration(context.Background()))
+	assert.Len(t, app.config.Service.Pipelines, 1)
+	assert.Len(t, configs, 1)
+
+	// ApplyConfig does not block if the configuration cannot be applied.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	app.pushChannel = nil
+	assert.Equal(t, context.Canceled, app.ApplyConfig(ctx, []byte(pushed)))
+
+	require.NoError(t, app.shutdownPipelines(context.Background()))
+}
+
+func TestKeepRawValue(t *testing.T) {
+	prev := map[string]interface{}{
+		"extensions": map[string]interface{}{"a": nil},
+		"service": map[string]interface{}{
+			"extensions": []interface{}{"a"},
+		},
+	}
+	raw := map[string]interface{}{
+		"extensions": map[string]interface{}{"b": nil},
+		"service": map[string]interface{}{
+			"extensions": []interface{}{"b"},
+			"telemetry":  map[string]interface{}{"logs": map[string]interface{}{"level": "debug"}},
+			"pipelines":  map[string]interface{}{},
+		},
+	}
+	keepRawValue(raw, prev, "extensions")
+	keepRawValue(raw, prev, "service", "extensions")
+	keepRawValue(raw, prev, "service", "telemetry", "logs")
+	keepRawValue(raw, prev, "service", "telemetry", "metrics")
+	assert.Equal(t, map[string]interface{}{
+		"extensions": map[string]interface{}{"a": nil},
+		"service": map[string]interface{}{
+			"extensions": []interface{}{"a"},
+			"telemetry":  map[string]interface{}{},
+			"pipelines":  map[string]interface{}{},
+		},
+	}, raw)
+}
+
+func TestConfigWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configwatcher")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(file, []byte("receivers:"), 0600))
+
+	confDir := filepath.Join(dir, "conf.d")
+	require.NoError(t, os.Mkdir(confDir, 0700))
+
+	watcher, err := newConfigWatcher([]string{file, confDir}, zap.NewNop())
+	require.NoError(t, err)
+
+	// Changes to other files in the directory are ignored.
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other.yaml"), []byte("receivers:"), 0600))
+	select {
+	case <-watcher.Changed():
+		t.Fatal("unexpected change notification")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("exporters:"), 0600))
+	select {
+	case <-watcher.Changed():
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not notified")
+	}
+
+	// Any config file added to the directory is a change.
+	require.NoError(t, ioutil.WriteFile(filepath.Join(confDir, "override.yml"), []byte("exporters:"), 0600))
+	select {
+	case <-watcher.Changed():
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not notified")
+	}
+
+	require.NoError(t, watcher.Close())
+}
+
+// newReloadTestApplication creates an application running the configuration returned by
+// createReloadTestConfig. Every reload uses the next configuration sent to the returned channel.
+func newReloadTestApplication(t *testing.T) (*Application, chan<- *configmodels.Config) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	factories.Receivers["failing"] = &failingReceiverFactory{}
+	factories.Exporters["failing"] = &failingExporterFactory{}
+
+	configs := make(chan *configmodels.Config, 1)
+	app := &Application{
+		info:      componenttest.TestApplicationStartInfo(),
+		v:         viper.New(),
+		logger:    zap.NewNop(),
+		factories: factories,
+		config:    createReloadTestConfig(),
+		configFactory: func(*viper.Viper, component.Factories) (*configmodels.Config, error) {
+			select {
+			case cfg := <-configs:
+				return cfg, nil
+			default:
+				return nil, errors.New("no configuration")
+			}
+		},
+	}
+	require.NoError(t, app.setupPipelines(context.Background()))
+	return app, configs
+}
+
+func createReloadTestConfig() *configmodels.Config {
+	exampleReceiverFactory := &componenttest.ExampleReceiverFactory{}
+	exampleExporterFactory := &componenttest.ExampleExporterFactory{}
+
+	receiver2 := exampleReceiverFactory.CreateDefaultConfig()
+	receiver2.SetName("examplereceiver/2")
+	exporter2 := exampleExporterFactory.CreateDefaultConfig()
+	exporter2.SetName("exampleexporter/2")
+
+	return &configmodels.Config{
+		Receivers: map[string]configmodels.Receiver{
+			"examplereceiver":   exampleReceiverFactory.CreateDefaultConfig(),
+			"examplereceiver/2": receiver2,
+		},
+		Exporters: map[string]configmodels.Exporter{
+			"exampleexporter":   exampleExporterFactory.CreateDefaultConfig(),
+			"exampleexporter/2": exporter2,
+		},
+		Service: configmodels.Service{
+			Pipelines: map[string]*configmodels.Pipeline{
+				"traces": {
+					Name:      "traces",
+					InputType: configmodels.TracesDataType,
+					Receivers: []string{"examplereceiver"},
+					Exporters: []string{"exampleexporter"},
+				},
+				"traces/2": {
+					Name:      "traces/2",
+					InputType: configmodels.TracesDataType,
+					Receivers: []string{"examplereceiver/2"},
+					Exporters: []string{"exampleexporter", "exampleexporter/2"},
+				},
+			},
+		},
+	}
+}
+
+func tracesExporter(app *Application, cfg *configmodels.Config, name string) *componenttest.ExampleExporterConsumer {
+	return app.GetExporters()[configmodels.TracesDataType][cfg.Exporters[name]].(*componenttest.ExampleExporterConsumer)
+}
+
+var errStart = errors.New("start error")
+
+// failingComponent is a receiver and exporter that fails to start.
+type failingComponent struct{}
+
+func (f *failingComponent) Start(context.Context, component.Host) error {
+	return errStart
+}
+
+func (f *failingComponent) Shutdown(context.Context) error {
+	return nil
+}
+
+func (f *failingComponent) ConsumeTraces(context.Context, pdata.Traces) error {
+	return nil
+}
+
+type failingReceiverFactory struct {
+	componenttest.ExampleReceiverFactory
+}
+
+func (f *failingReceiverFactory) Type() configmodels.Type {
+	return "failing"
+}
+
+func (f *failingReceiverFactory) CreateTracesReceiver(
+	context.Context,
+	component.ReceiverCreateParams,
+	configmodels.Receiver,
+	consumer.TracesConsumer,
+) (component.TracesReceiver, error) {
+	return &failingComponent{}, nil
+}
+
+type failingExporterFactory struct {
+	componenttest.ExampleExporterFactory
+}
+
+func (f *failingExporterFactory) Type() configmodels.Type {
+	return "failing"
+}
+
+func (f *failingExporterFactory) CreateTracesExporter(
+	context.Context,
+	component.ExporterCreateParams,
+	configmodels.Exporter,
+) (component.TracesExporter, error) {
+	return &failingComponent{}, nil
+}
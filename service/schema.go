@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/config/configschema"
+)
+
+// newSchemaCommand creates the command that prints the JSON Schema of the configuration
+// of the collector with the components of the application.
+func (app *Application) newSchemaCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Prints the JSON Schema of the configuration",
+		Long: "Prints the JSON Schema of the configuration files, to validate and autocomplete " +
+			"them in editors. The descriptions of the settings are only included for the " +
+			"components of the collector module.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			return enc.Encode(configschema.Generate(app.factories))
+		},
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/service/defaultcomponents"
+)
+
+func TestApplication_Schema(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+	app, err := New(Parameters{Factories: factories, ApplicationStartInfo: componenttest.TestApplicationStartInfo()})
+	require.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	app.Command().SetOut(out)
+	app.Command().SetArgs([]string{"schema"})
+	require.NoError(t, app.Run())
+
+	var schema struct {
+		Properties map[string]struct {
+			PatternProperties map[string]struct {
+				AdditionalProperties interface{} `json:"additionalProperties"`
+			} `json:"patternProperties"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &schema))
+	assert.Contains(t, schema.Properties["receivers"].PatternProperties, "^otlp(/.+)?$")
+	assert.Contains(t, schema.Properties["processors"].PatternProperties, "^batch(/.+)?$")
+	assert.Contains(t, schema.Properties["exporters"].PatternProperties, "^logging(/.+)?$")
+	assert.Contains(t, schema.Properties["extensions"].PatternProperties, "^health_check(/.+)?$")
+
+	// The otlp receiver has a custom unmarshaler, the batch processor does not.
+	assert.Equal(t, true, schema.Properties["receivers"].PatternProperties["^otlp(/.+)?$"].AdditionalProperties)
+	assert.Equal(t, false, schema.Properties["processors"].PatternProperties["^batch(/.+)?$"].AdditionalProperties)
+}
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opencensus.io/metric/metricexport"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/service/builder"
+	"go.opentelemetry.io/collector/service/internal/telemetry"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	// defaultSamplingRatio is the sampling ratio of OpenCensus when none is configured.
+	defaultSamplingRatio = 1e-4
+	// defaultTelemetryMetricsInterval is the interval at which the own metrics are sent
+	// when none is configured.
+	defaultTelemetryMetricsInterval = 10 * time.Second
+	// telemetryExporterName is the name of the OTLP exporter sending the own telemetry.
+	telemetryExporterName = "otlp/telemetry"
+)
+
+// selfTelemetry sends the collector's own spans and metrics, recorded with OpenCensus,
+// to the OTLP endpoint and to the pipelines configured in service::telemetry::export.
+type selfTelemetry struct {
+	logger  *zap.Logger
+	info    component.ApplicationStartInfo
+	spans   *telemetry.SpanExporter
+	metrics *telemetry.MetricsExporter
+
+	// reader reads the metrics periodically, it is nil if no metrics are sent.
+	reader         *metricexport.IntervalReader
+	readerInterval time.Duration
+
+	// exportSettings are the settings the OTLP exporters were created with.
+	exportSettings  configmodels.TelemetryExport
+	tracesExporter  component.TracesExporter
+	metricsExporter component.MetricsExporter
+}
+
+// newSelfTelemetry returns the own telemetry, the spans and metrics are sent with the
+// service attributes and the given resource labels as resource attributes.
+func newSelfTelemetry(logger *zap.Logger, info component.ApplicationStartInfo, instanceID string, resourceLabels map[string]string) *selfTelemetry {
+	resource := map[string]string{
+		conventions.AttributeServiceName:    info.ExeName,
+		conventions.AttributeServiceVersion: info.Version,
+	}
+	if instanceID != "" {
+		resource[conventions.AttributeServiceInstance] = instanceID
+	}
+	for k, v := range resourceLabels {
+		resource[k] = v
+	}
+	st := &selfTelemetry{
+		logger:  logger,
+		info:    info,
+		spans:   telemetry.NewSpanExporter(logger, resource),
+		metrics: telemetry.NewMetricsExporter(logger, resource),
+	}
+	st.spans.Start()
+	trace.RegisterExporter(st.spans)
+	return st
+}
+
+// apply applies the telemetry settings of cfg, the own telemetry is sent to the given
+// pipelines. The pipelines must be started.
+func (st *selfTelemetry) apply(ctx context.Context, cfg *configmodels.Config, pipelines builder.BuiltPipelines, host component.Host) error {
+	settings := cfg.Service.Telemetry
+
+	ratio := settings.Traces.SamplingRatio
+	if ratio == 0 {
+		ratio = defaultSamplingRatio
+	}
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(ratio)})
+
+	if err := st.updateExporters(ctx, settings.Export, host); err != nil {
+		return err
+	}
+
+	var tcs []consumer.TracesConsumer
+	var mcs []consumer.MetricsConsumer
+	if st.tracesExporter != nil {
+		tcs = append(tcs, st.tracesExporter)
+		mcs = append(mcs, st.metricsExporter)
+	}
+	for pipelineCfg, bp := range pipelines {
+		if pipelineCfg.Name == settings.Export.TracesPipeline && bp.TracesConsumer() != nil {
+			tcs = append(tcs, bp.TracesConsumer())
+		}
+		if pipelineCfg.Name == settings.Export.MetricsPipeline && bp.MetricsConsumer() != nil {
+			mcs = append(mcs, bp.MetricsConsumer())
+		}
+	}
+
+	switch len(tcs) {
+	case 0:
+		st.spans.SetConsumer(nil)
+	case 1:
+		st.spans.SetConsumer(tcs[0])
+	default:
+		st.spans.SetConsumer(processor.NewTracesCloningFanOutConnector(tcs))
+	}
+
+	interval := settings.Export.MetricsInterval
+	if interval == 0 {
+		interval = defaultTelemetryMetricsInterval
+	}
+	switch len(mcs) {
+	case 0:
+		st.stopReader()
+		st.metrics.SetConsumer(nil)
+		return nil
+	case 1:
+		st.metrics.SetConsumer(mcs[0])
+	default:
+		st.metrics.SetConsumer(processor.NewMetricsCloningFanOutConnector(mcs))
+	}
+	return st.startReader(interval)
+}
+
+// updateExporters creates the OTLP exporters if the export settings changed.
+func (st *selfTelemetry) updateExporters(ctx context.Context, settings configmodels.TelemetryExport, host component.Host) error {
+	if st.tracesExporter != nil && sameEndpoint(st.exportSettings, settings) {
+		return nil
+	}
+	if err := st.shutdownExporters(ctx); err != nil {
+		st.logger.Warn("Failed to shutdown the telemetry exporters", zap.Error(err))
+	}
+	st.exportSettings = settings
+	if settings.Endpoint == "" {
+		return nil
+	}
+
+	factory := otlpexporter.NewFactory()
+	expCfg := factory.CreateDefaultConfig().(*otlpexporter.Config)
+	expCfg.SetName(telemetryExporterName)
+	expCfg.Endpoint = settings.Endpoint
+	expCfg.TLSSetting.Insecure = settings.Insecure
+	expCfg.Headers = settings.Headers
+	params := component.ExporterCreateParams{
+		Logger:               st.logger.With(zap.String("component_kind", "exporter"), zap.String("component_name", telemetryExporterName)),
+		ApplicationStartInfo: st.info,
+	}
+
+	te, err := factory.CreateTracesExporter(ctx, params, expCfg)
+	if err != nil {
+		return fmt.Errorf("cannot create the telemetry traces exporter: %w", err)
+	}
+	me, err := factory.CreateMetricsExporter(ctx, params, expCfg)
+	if err != nil {
+		return fmt.Errorf("cannot create the telemetry metrics exporter: %w", err)
+	}
+	if err = te.Start(ctx, host); err != nil {
+		return fmt.Errorf("cannot start the telemetry traces exporter: %w", err)
+	}
+	if err = me.Start(ctx, host); err != nil {
+		_ = te.Shutdown(ctx)
+		return fmt.Errorf("cannot start the telemetry metrics exporter: %w", err)
+	}
+	st.tracesExporter = te
+	st.metricsExporter = me
+	st.logger.Info("Sending own telemetry", zap.String("endpoint", settings.Endpoint))
+	return nil
+}
+
+func sameEndpoint(a, b configmodels.TelemetryExport) bool {
+	if a.Endpoint != b.Endpoint || a.Insecure != b.Insecure || len(a.Headers) != len(b.Headers) {
+		return false
+	}
+	for k, v := range a.Headers {
+		if bv, ok := b.Headers[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (st *selfTelemetry) startReader(interval time.Duration) error {
+	if st.reader != nil && st.readerInterval == interval {
+		return nil
+	}
+	st.stopReader()
+
+	reader, err := metricexport.NewIntervalReader(metricexport.NewReader(), st.metrics)
+	if err != nil {
+		return fmt.Errorf("cannot read the own metrics: %w", err)
+	}
+	reader.ReportingInterval = interval
+	if err = reader.Start(); err != nil {
+		return fmt.Errorf("cannot read the own metrics: %w", err)
+	}
+	st.reader = reader
+	st.readerInterval = interval
+	return nil
+}
+
+func (st *selfTelemetry) stopReader() {
+	if st.reader != nil {
+		st.reader.Stop()
+		st.reader = nil
+	}
+}
+
+func (st *selfTelemetry) shutdownExporters(ctx context.Context) error {
+	var errs []error
+	if st.tracesExporter != nil {
+		if err := st.tracesExporter.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+		st.tracesExporter = nil
+	}
+	if st.metricsExporter != nil {
+		if err := st.metricsExporter.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+		st.metricsExporter = nil
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// shutdown sends the pending telemetry and stops sending it. It must be called before
+// the pipelines are shut down.
+func (st *selfTelemetry) shutdown(ctx context.Context) error {
+	trace.UnregisterExporter(st.spans)
+	st.stopReader()
+	st.spans.Shutdown()
+	st.spans.SetConsumer(nil)
+	st.metrics.SetConsumer(nil)
+	return st.shutdownExporters(ctx)
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestSelfTelemetry_Pipeline(t *testing.T) {
+	app, _ := newReloadTestApplication(t)
+	cfg := app.config
+	cfg.Service.Telemetry.Traces.SamplingRatio = 1
+	cfg.Service.Telemetry.Export.TracesPipeline = "traces/2"
+
+	st := newSelfTelemetry(zap.NewNop(), app.info, "my-instance", map[string]string{"cluster": "eu-1"})
+	require.NoError(t, st.apply(context.Background(), cfg, app.builtPipelines, app))
+	defer trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(defaultSamplingRatio)})
+	// No metrics pipeline nor endpoint, the metrics are not read.
+	assert.Nil(t, st.reader)
+	assert.Nil(t, st.tracesExporter)
+
+	_, span := trace.StartSpan(context.Background(), "my-operation")
+	span.End()
+	// The pending spans are sent on shutdown.
+	require.NoError(t, st.shutdown(context.Background()))
+
+	var found pdata.Span
+	var resource pdata.Resource
+	for _, td := range tracesExporter(app, cfg, "exampleexporter/2").Traces {
+		rs := td.ResourceSpans().At(0)
+		spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+		for i := 0; i < spans.Len(); i++ {
+			if spans.At(i).Name() == "my-operation" {
+				found = spans.At(i)
+				resource = rs.Resource()
+			}
+		}
+	}
+	require.False(t, found.IsNil())
+	instanceID, ok := resource.Attributes().Get(conventions.AttributeServiceInstance)
+	require.True(t, ok)
+	assert.Equal(t, "my-instance", instanceID.StringVal())
+	cluster, ok := resource.Attributes().Get("cluster")
+	require.True(t, ok)
+	assert.Equal(t, "eu-1", cluster.StringVal())
+}
+
+func TestSelfTelemetry_Endpoint(t *testing.T) {
+	app, _ := newReloadTestApplication(t)
+	cfg := app.config
+	cfg.Service.Telemetry.Export.Endpoint = "localhost:55680"
+	cfg.Service.Telemetry.Export.Insecure = true
+	cfg.Service.Telemetry.Export.MetricsPipeline = "traces"
+
+	st := newSelfTelemetry(zap.NewNop(), app.info, "", nil)
+	require.NoError(t, st.apply(context.Background(), cfg, app.builtPipelines, app))
+	require.NotNil(t, st.tracesExporter)
+	require.NotNil(t, st.metricsExporter)
+	// The metrics are sent to the endpoint, the pipeline is not a metrics pipeline.
+	assert.NotNil(t, st.reader)
+	assert.Equal(t, defaultTelemetryMetricsInterval, st.readerInterval)
+
+	// The exporters are kept while the endpoint does not change.
+	exp := st.tracesExporter
+	require.NoError(t, st.apply(context.Background(), cfg, app.builtPipelines, app))
+	assert.Equal(t, exp, st.tracesExporter)
+
+	cfg.Service.Telemetry.Export.Endpoint = ""
+	require.NoError(t, st.apply(context.Background(), cfg, app.builtPipelines, app))
+	assert.Nil(t, st.tracesExporter)
+	assert.Nil(t, st.reader)
+
+	require.NoError(t, st.shutdown(context.Background()))
+}
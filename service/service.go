@@ -18,29 +18,38 @@ package service
 
 import (
 	"context"
-	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
 	"path"
+	"reflect"
 	"runtime"
 	"sort"
+	"strconv"
+	"sync/atomic"
 	"syscall"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configsource"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/collector/telemetry"
 	"go.opentelemetry.io/collector/internal/version"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/service/builder"
 	"go.opentelemetry.io/collector/service/internal"
 )
@@ -49,6 +58,8 @@ const (
 	servicezPath   = "servicez"
 	pipelinezPath  = "pipelinez"
 	extensionzPath = "extensionz"
+	componentzPath = "componentz"
+	tapzPath       = "tapz"
 )
 
 // State defines Application's state.
@@ -72,14 +83,47 @@ type Application struct {
 	rootCmd         *cobra.Command
 	v               *viper.Viper
 	logger          *zap.Logger
+	loggingOptions  []zap.Option
 	builtExporters  builder.Exporters
 	builtReceivers  builder.Receivers
 	builtPipelines  builder.BuiltPipelines
 	builtExtensions builder.Extensions
 	stateChannel    chan State
 
-	factories component.Factories
-	config    *configmodels.Config
+	// componentPipelines are the pipelines of each component, set when the configuration
+	// is applied and read by ReportStatus from the goroutines of the components.
+	componentPipelines atomic.Value
+	// logsConsumers are the consumers of the running logs pipelines by name, they are
+	// read by GetLogsConsumer from the goroutines of the extensions.
+	logsConsumers atomic.Value
+	// running is the *runningState of the applied configuration, it is read by the
+	// zPages handlers and by GetExporters from other goroutines.
+	running atomic.Value
+
+	factories     component.Factories
+	config        *configmodels.Config
+	configFactory ConfigFactory
+
+	// sources are the config sources available to the default ConfigFactory.
+	sources map[string]configsource.Source
+	// configSources resolved the config source references of the current configuration
+	// and signals when their values change. It is nil if the ConfigFactory does not use
+	// config sources.
+	configSources *configsource.Manager
+	// loadedSources is set by the ConfigFactory to the config sources it used.
+	loadedSources *configsource.Manager
+
+	// pushChannel is used to receive the configurations pushed with ApplyConfig.
+	pushChannel chan configPush
+	// rawConfig is the map[string]interface{} read by the ConfigFactory of the
+	// configuration in effect, it is returned redacted by EffectiveConfig.
+	rawConfig atomic.Value
+
+	// instanceID is the value of the service.instance.id of the collector's own telemetry,
+	// empty if it is not added.
+	instanceID string
+	// selfTelemetry sends the collector's own telemetry, it is nil until the telemetry is set up.
+	selfTelemetry *selfTelemetry
 
 	// stopTestChan is used to terminate the application in end to end tests.
 	stopTestChan chan struct{}
@@ -87,6 +131,9 @@ type Application struct {
 	// signalsChannel is used to receive termination signals from the OS.
 	signalsChannel chan os.Signal
 
+	// reloadChannel is used to receive configuration reload signals from the OS.
+	reloadChannel chan os.Signal
+
 	// asyncErrorChannel is used to signal a fatal error from any component.
 	asyncErrorChannel chan error
 }
@@ -113,18 +160,35 @@ type Parameters struct {
 // ConfigFactory creates config.
 type ConfigFactory func(v *viper.Viper, factories component.Factories) (*configmodels.Config, error)
 
-// FileLoaderConfigFactory implements ConfigFactory and it creates configuration from file.
+// FileLoaderConfigFactory implements ConfigFactory and it creates configuration from the
+// files and directories given as command line flags, merged in order. References to the
+// default config sources are resolved, but the values are not watched for changes.
 func FileLoaderConfigFactory(v *viper.Viper, factories component.Factories) (*configmodels.Config, error) {
-	file := builder.GetConfigFile()
-	if file == "" {
-		return nil, errors.New("config file not specified")
+	sources := configsource.NewManager(configsource.DefaultSources())
+	defer sources.Close()
+	return loadConfigFiles(v, factories, sources)
+}
+
+func loadConfigFiles(v *viper.Viper, factories component.Factories, sources *configsource.Manager) (*configmodels.Config, error) {
+	err := config.ReadConfigFiles(v, builder.GetConfigFiles())
+	if err != nil {
+		return nil, err
 	}
-	v.SetConfigFile(file)
-	err := v.ReadInConfig()
+	return config.LoadWithSources(v, factories, sources)
+}
+
+// watchingFileLoaderConfigFactory is the default ConfigFactory. It works like
+// FileLoaderConfigFactory but keeps watching the values of the config sources, so
+// that the configuration is reloaded when they change.
+func (app *Application) watchingFileLoaderConfigFactory(v *viper.Viper, factories component.Factories) (*configmodels.Config, error) {
+	sources := configsource.NewManager(app.sources)
+	cfg, err := loadConfigFiles(v, factories, sources)
 	if err != nil {
-		return nil, fmt.Errorf("error loading config file %q: %v", file, err)
+		sources.Close()
+		return nil, err
 	}
-	return config.Load(v, factories)
+	app.loadedSources = sources
+	return cfg, nil
 }
 
 // New creates and returns a new instance of Application.
@@ -134,18 +198,25 @@ func New(params Parameters) (*Application, error) {
 		v:            config.NewViper(),
 		factories:    params.Factories,
 		stateChannel: make(chan State, Closed+1),
+		sources:      configsource.DefaultSources(),
+		pushChannel:  make(chan configPush),
 	}
 
 	factory := params.ConfigFactory
 	if factory == nil {
 		// use default factory that loads the configuration file
-		factory = FileLoaderConfigFactory
+		factory = app.watchingFileLoaderConfigFactory
 	}
+	app.configFactory = factory
 
 	rootCmd := &cobra.Command{
 		Use:  params.ApplicationStartInfo.ExeName,
 		Long: params.ApplicationStartInfo.LongName,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if builder.PrintConfig() {
+				return printConfig(cmd.OutOrStdout())
+			}
+
 			err := app.init(params.LoggingOptions)
 			if err != nil {
 				return err
@@ -171,7 +242,9 @@ func New(params Parameters) (*Application, error) {
 	for _, addFlags := range addFlagsFns {
 		addFlags(flagSet)
 	}
-	rootCmd.Flags().AddGoFlagSet(flagSet)
+	// The flags are persistent so that they are also available to the subcommands.
+	rootCmd.PersistentFlags().AddGoFlagSet(flagSet)
+	rootCmd.AddCommand(app.newValidateCommand(), app.newSchemaCommand())
 
 	app.rootCmd = rootCmd
 
@@ -201,6 +274,8 @@ func (app *Application) GetFactory(kind component.Kind, componentType configmode
 		return app.factories.Exporters[componentType]
 	case component.KindExtension:
 		return app.factories.Extensions[componentType]
+	case component.KindConnector:
+		return app.factories.Connectors[componentType]
 	}
 	return nil
 }
@@ -210,13 +285,38 @@ func (app *Application) GetExtensions() map[configmodels.Extension]component.Ser
 }
 
 func (app *Application) GetExporters() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
-	return app.builtExporters.ToMapByDataType()
+	return app.getRunningState().exporters.ToMapByDataType()
+}
+
+// runningState is a snapshot of the applied configuration and of the components
+// built for it. The main loop replaces it as a whole when a configuration is applied.
+type runningState struct {
+	config    *configmodels.Config
+	exporters builder.Exporters
+	pipelines builder.BuiltPipelines
+}
+
+// setRunningState makes the applied configuration and its components available to
+// the other goroutines.
+func (app *Application) setRunningState(cfg *configmodels.Config, exporters builder.Exporters, pipelines builder.BuiltPipelines) {
+	app.running.Store(&runningState{config: cfg, exporters: exporters, pipelines: pipelines})
+}
+
+// getRunningState returns the last snapshot stored by setRunningState, an empty one
+// if no configuration was applied yet.
+func (app *Application) getRunningState() *runningState {
+	if rs, ok := app.running.Load().(*runningState); ok {
+		return rs
+	}
+	return &runningState{}
 }
 
 func (app *Application) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, servicezPath), app.handleServicezRequest)
 	mux.HandleFunc(path.Join(pathPrefix, pipelinezPath), app.handlePipelinezRequest)
 	mux.HandleFunc(path.Join(pathPrefix, extensionzPath), app.handleExtensionzRequest)
+	mux.HandleFunc(path.Join(pathPrefix, componentzPath), app.handleComponentzRequest)
+	mux.HandleFunc(path.Join(pathPrefix, tapzPath), app.handleTapzRequest)
 }
 
 func (app *Application) SignalTestComplete() {
@@ -229,7 +329,8 @@ func (app *Application) SignalTestComplete() {
 }
 
 func (app *Application) init(options []zap.Option) error {
-	l, err := newLogger(options)
+	app.loggingOptions = options
+	l, err := newLogger(options, configmodels.LogsTelemetry{})
 	if err != nil {
 		return fmt.Errorf("failed to get logger: %w", err)
 	}
@@ -237,61 +338,184 @@ func (app *Application) init(options []zap.Option) error {
 	return nil
 }
 
+// setupTelemetry sets up the own telemetry from the command line flags and the
+// service::telemetry configuration, which must be loaded.
 func (app *Application) setupTelemetry(ballastSizeBytes uint64) error {
+	settings := app.config.Service.Telemetry
+	if !reflect.DeepEqual(settings.Logs, configmodels.LogsTelemetry{}) {
+		l, err := newLogger(app.loggingOptions, settings.Logs)
+		if err != nil {
+			return fmt.Errorf("failed to get logger: %w", err)
+		}
+		app.logger = l
+	}
+	if settings.Metrics.Level != "" {
+		level, err := configtelemetry.ParseLevel(settings.Metrics.Level)
+		if err != nil {
+			return fmt.Errorf("failed to initialize telemetry: %w", err)
+		}
+		configtelemetry.SetMetricsLevel(level)
+	}
+
 	app.logger.Info("Setting up own telemetry...")
 
-	err := applicationTelemetry.init(app.asyncErrorChannel, ballastSizeBytes, app.logger)
+	if telemetry.GetAddInstanceID() {
+		instanceUUID, _ := uuid.NewRandom()
+		app.instanceID = instanceUUID.String()
+	}
+
+	err := applicationTelemetry.init(app.asyncErrorChannel, ballastSizeBytes, app.instanceID, settings.Metrics, app.logger)
 	if err != nil {
 		return fmt.Errorf("failed to initialize telemetry: %w", err)
 	}
 
+	app.selfTelemetry = newSelfTelemetry(app.logger, app.info, app.instanceID, settings.Metrics.ResourceLabels)
 	return nil
 }
 
 // runAndWaitForShutdownEvent waits for one of the shutdown events that can happen.
-func (app *Application) runAndWaitForShutdownEvent() {
+// The configuration is reloaded on SIGHUP and when the configuration files change,
+// and replaced when an extension pushes a configuration.
+func (app *Application) runAndWaitForShutdownEvent(ctx context.Context) {
 	app.logger.Info("Everything is ready. Begin running and processing data.")
 
 	// plug SIGTERM signal into a channel.
 	app.signalsChannel = make(chan os.Signal, 1)
 	signal.Notify(app.signalsChannel, os.Interrupt, syscall.SIGTERM)
 
+	// plug SIGHUP signal into a channel.
+	app.reloadChannel = make(chan os.Signal, 1)
+	signal.Notify(app.reloadChannel, syscall.SIGHUP)
+	defer signal.Stop(app.reloadChannel)
+
+	var configChanged <-chan struct{}
+	if paths := builder.GetConfigFiles(); len(paths) != 0 {
+		watcher, err := newConfigWatcher(paths, app.logger)
+		if err != nil {
+			app.logger.Warn("Cannot watch configuration files, changes are applied only on SIGHUP", zap.Error(err))
+		} else {
+			defer watcher.Close()
+			configChanged = watcher.Changed()
+		}
+	}
+
 	// set the channel to stop testing.
 	app.stopTestChan = make(chan struct{})
 	app.stateChannel <- Running
-	select {
-	case err := <-app.asyncErrorChannel:
-		app.logger.Error("Asynchronous error received, terminating process", zap.Error(err))
-	case s := <-app.signalsChannel:
-		app.logger.Info("Received signal from OS", zap.String("signal", s.String()))
-	case <-app.stopTestChan:
-		app.logger.Info("Received stop test request")
+	var sources *configsource.Manager
+	var sourcesChanged <-chan struct{}
+	for running := true; running; {
+		// Watch the config sources of the configuration in effect, they are replaced
+		// when the configuration is reloaded.
+		if app.configSources != sources {
+			sources = app.configSources
+			sourcesChanged = sources.Changed()
+		}
+
+		select {
+		case err := <-app.asyncErrorChannel:
+			app.logger.Error("Asynchronous error received, terminating process", zap.Error(err))
+			running = false
+		case s := <-app.signalsChannel:
+			app.logger.Info("Received signal from OS", zap.String("signal", s.String()))
+			running = false
+		case <-app.stopTestChan:
+			app.logger.Info("Received stop test request")
+			running = false
+		case s := <-app.reloadChannel:
+			app.logger.Info("Received signal from OS", zap.String("signal", s.String()))
+			app.reload(ctx)
+		case <-configChanged:
+			app.logger.Info("Configuration files changed")
+			app.reload(ctx)
+		case <-sourcesChanged:
+			app.logger.Info("Configuration sources changed")
+			// Handle the change only once if the reload fails.
+			sourcesChanged = nil
+			app.reload(ctx)
+		case push := <-app.pushChannel:
+			app.logger.Info("Configuration pushed")
+			err := app.applyPushedConfig(ctx, push.cfg)
+			if err != nil {
+				app.logger.Error("Failed to apply pushed configuration, keeping the previous configuration", zap.Error(err))
+			}
+			push.result <- err
+		}
 	}
 	app.stateChannel <- Closing
 }
 
-func (app *Application) setupConfigurationComponents(ctx context.Context, factory ConfigFactory) error {
-	if err := configcheck.ValidateConfigFromFactories(app.factories); err != nil {
+// printConfig writes the merged configuration files with secrets redacted.
+func printConfig(w io.Writer) error {
+	merged, err := config.MergeConfigFiles(builder.GetConfigFiles())
+	if err != nil {
 		return err
 	}
+	out, err := yaml.Marshal(config.RedactSecrets(merged))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
 
-	app.logger.Info("Loading configuration...")
+// reload reloads the configuration, keeping the current one if that fails.
+func (app *Application) reload(ctx context.Context) {
+	if err := app.reloadConfiguration(ctx); err != nil {
+		app.logger.Error("Failed to reload configuration, keeping the previous configuration", zap.Error(err))
+	}
+}
+
+// loadConfig creates the configuration using the given factory and validates it. It
+// also returns the raw configuration read by the factory and the config sources used
+// by the factory, if any, that the caller must close.
+func (app *Application) loadConfig(factory ConfigFactory) (*configmodels.Config, map[string]interface{}, *configsource.Manager, error) {
+	// Every load starts from an empty viper so that the raw configuration only holds
+	// what the factory read.
+	app.v = config.NewViper()
+	app.loadedSources = nil
 	cfg, err := factory(app.v, app.factories)
+	sources := app.loadedSources
+	app.loadedSources = nil
+	if err == nil {
+		err = config.ValidateConfig(cfg, app.logger)
+	}
 	if err != nil {
-		return fmt.Errorf("cannot load configuration: %w", err)
+		if sources != nil {
+			sources.Close()
+		}
+		return nil, nil, nil, fmt.Errorf("cannot load configuration: %w", err)
 	}
-	err = config.ValidateConfig(cfg, app.logger)
+	return cfg, app.v.AllSettings(), sources, nil
+}
+
+// setupConfiguration loads the configuration the application starts with.
+func (app *Application) setupConfiguration(factory ConfigFactory) error {
+	if err := configcheck.ValidateConfigFromFactories(app.factories); err != nil {
+		return err
+	}
+
+	app.logger.Info("Loading configuration...")
+	cfg, raw, sources, err := app.loadConfig(factory)
 	if err != nil {
-		return fmt.Errorf("cannot load configuration: %w", err)
+		return err
 	}
 
 	app.config = cfg
+	app.configSources = sources
+	app.rawConfig.Store(raw)
+	return nil
+}
+
+// setupConfigurationComponents builds and starts the components of the loaded configuration.
+func (app *Application) setupConfigurationComponents(ctx context.Context) error {
 	app.logger.Info("Applying configuration...")
 
-	err = app.setupExtensions(ctx)
+	err := app.setupExtensions(ctx)
 	if err != nil {
 		return fmt.Errorf("cannot setup extensions: %w", err)
 	}
+	app.setComponentPipelines(app.config)
 
 	err = app.setupPipelines(ctx)
 	if err != nil {
@@ -328,9 +552,11 @@ func (app *Application) setupPipelines(ctx context.Context) error {
 		return fmt.Errorf("cannot start builtExporters: %w", err)
 	}
 
-	// Create pipelines and their processors and plug exporters to the
+	// Create pipelines and their processors and plug exporters and connectors to the
 	// end of the pipelines.
-	app.builtPipelines, err = builder.NewPipelinesBuilder(app.logger, app.info, app.config, app.builtExporters, app.factories.Processors).Build()
+	app.builtPipelines, err = builder.NewPipelinesBuilder(app.logger, app.info, app.config, app.builtExporters, app.factories.Processors).
+		WithConnectors(app.factories.Connectors).
+		Build()
 	if err != nil {
 		return fmt.Errorf("cannot build pipelines: %w", err)
 	}
@@ -341,6 +567,15 @@ func (app *Application) setupPipelines(ctx context.Context) error {
 		return fmt.Errorf("cannot start processors: %w", err)
 	}
 
+	// Send the own telemetry to the pipelines before the receivers record any.
+	if app.selfTelemetry != nil {
+		if err = app.selfTelemetry.apply(ctx, app.config, app.builtPipelines, app); err != nil {
+			return fmt.Errorf("cannot setup own telemetry: %w", err)
+		}
+	}
+	app.setLogsConsumers(app.builtPipelines)
+	app.setRunningState(app.config, app.builtExporters, app.builtPipelines)
+
 	// Create receivers and plug them into the start of the pipelines.
 	app.builtReceivers, err = builder.NewReceiversBuilder(app.logger, app.info, app.config, app.builtPipelines, app.factories.Receivers).Build()
 	if err != nil {
@@ -358,10 +593,13 @@ func (app *Application) setupPipelines(ctx context.Context) error {
 
 func (app *Application) shutdownPipelines(ctx context.Context) error {
 	// Shutdown order is the reverse of building: first receivers, then flushing pipelines
-	// giving senders a chance to send all their data. This may take time, the allowed
-	// time should be part of configuration.
+	// giving senders a chance to send all their data. The data that is not sent before
+	// the drain deadline is dropped.
+	ctx, cancel := context.WithTimeout(ctx, builder.ShutdownDrainTimeout())
+	defer cancel()
 
 	var errs []error
+	app.setLogsConsumers(nil)
 
 	app.logger.Info("Stopping receivers...")
 	err := app.builtReceivers.ShutdownAll(ctx)
@@ -381,9 +619,29 @@ func (app *Application) shutdownPipelines(ctx context.Context) error {
 		errs = append(errs, fmt.Errorf("failed to shutdown exporters: %w", err))
 	}
 
+	app.logDrainedItems(app.builtExporters, ctx.Err() != nil)
 	return componenterror.CombineErrors(errs)
 }
 
+// logDrainedItems logs the number of items flushed and dropped by the sending queues
+// of the exporters when they were shut down.
+func (app *Application) logDrainedItems(exporters builder.Exporters, deadlineExceeded bool) {
+	var flushed, dropped int64
+	for _, exps := range exporters.ToMapByDataType() {
+		for _, exp := range exps {
+			if reporter, ok := exp.(exporterhelper.QueueStatusReporter); ok {
+				status := reporter.QueueStatus()
+				flushed += status.FlushedItems
+				dropped += status.DroppedItems
+			}
+		}
+	}
+	app.logger.Info("Pipelines drained.",
+		zap.Int64("flushed_items", flushed),
+		zap.Int64("dropped_items", dropped),
+		zap.Bool("deadline_exceeded", deadlineExceeded))
+}
+
 func (app *Application) shutdownExtensions(ctx context.Context) error {
 	app.logger.Info("Stopping extensions...")
 	err := app.builtExtensions.ShutdownAll(ctx)
@@ -406,13 +664,19 @@ func (app *Application) execute(ctx context.Context, factory ConfigFactory) erro
 
 	app.asyncErrorChannel = make(chan error)
 
-	// Setup everything.
-	err := app.setupTelemetry(ballastSizeBytes)
+	// Setup everything. The configuration is loaded first since it can override the
+	// telemetry flags, the components are set up with the final telemetry settings.
+	err := app.setupConfiguration(factory)
+	if err != nil {
+		return err
+	}
+
+	err = app.setupTelemetry(ballastSizeBytes)
 	if err != nil {
 		return err
 	}
 
-	err = app.setupConfigurationComponents(ctx, factory)
+	err = app.setupConfigurationComponents(ctx)
 	if err != nil {
 		return err
 	}
@@ -423,7 +687,7 @@ func (app *Application) execute(ctx context.Context, factory ConfigFactory) erro
 	}
 
 	// Everything is ready, now run until an event requiring shutdown happens.
-	app.runAndWaitForShutdownEvent()
+	app.runAndWaitForShutdownEvent(ctx)
 
 	// Accumulate errors and proceed with shutting down remaining components.
 	var errs []error
@@ -437,6 +701,12 @@ func (app *Application) execute(ctx context.Context, factory ConfigFactory) erro
 		errs = append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
 	}
 
+	if app.selfTelemetry != nil {
+		if err = app.selfTelemetry.shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shutdown own telemetry: %w", err))
+		}
+	}
+
 	err = app.shutdownPipelines(ctx)
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
@@ -447,6 +717,12 @@ func (app *Application) execute(ctx context.Context, factory ConfigFactory) erro
 		errs = append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
 	}
 
+	if app.configSources != nil {
+		if err = app.configSources.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close config sources: %w", err))
+		}
+	}
+
 	err = applicationTelemetry.shutdown()
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
@@ -473,6 +749,13 @@ const (
 	zComponentName = "zcomponentname"
 	zComponentKind = "zcomponentkind"
 	zExtensionName = "zextensionname"
+	zStage         = "zstage"
+	zTapAction     = "ztapaction"
+	zTapSize       = "ztapsize"
+	zTapEvery      = "ztapevery"
+
+	defaultTapSize  = 10
+	defaultTapEvery = 1
 )
 
 func (app *Application) handleServicezRequest(w http.ResponseWriter, r *http.Request) {
@@ -489,6 +772,16 @@ func (app *Application) handleServicezRequest(w http.ResponseWriter, r *http.Req
 		ComponentEndpoint: extensionzPath,
 		Link:              true,
 	})
+	internal.WriteHTMLComponentHeader(w, internal.ComponentHeaderData{
+		Name:              "Components",
+		ComponentEndpoint: componentzPath,
+		Link:              true,
+	})
+	internal.WriteHTMLComponentHeader(w, internal.ComponentHeaderData{
+		Name:              "Taps",
+		ComponentEndpoint: tapzPath,
+		Link:              true,
+	})
 	internal.WriteHTMLPropertiesTable(w, internal.PropertiesTableData{Name: "Build And Runtime", Properties: version.InfoVar})
 	internal.WriteHTMLFooter(w)
 }
@@ -502,18 +795,196 @@ func (app *Application) handlePipelinezRequest(w http.ResponseWriter, r *http.Re
 	internal.WriteHTMLHeader(w, internal.HeaderData{Title: "Pipelines"})
 	internal.WriteHTMLPipelinesSummaryTable(w, app.getPipelinesSummaryTableData())
 	if pipelineName != "" && componentName != "" && componentKind != "" {
-		fullName := componentName
-		if componentKind == "processor" {
-			fullName = pipelineName + "/" + componentName
+		for _, kind := range zpagesComponentKinds {
+			if kind.String() != componentKind {
+				continue
+			}
+			fullName := componentName
+			if kind == component.KindProcessor {
+				fullName = pipelineName + "/" + componentName
+			}
+			internal.WriteHTMLComponentHeader(w, internal.ComponentHeaderData{
+				Name: componentKind + ": " + fullName,
+			})
+			internal.WriteHTMLPropertiesTable(w, internal.PropertiesTableData{
+				Name:       "Counters",
+				Properties: getComponentProperties(app.getRunningState().exporters, kind, componentName),
+			})
+		}
+	}
+	internal.WriteHTMLFooter(w)
+}
+
+func (app *Application) handleComponentzRequest(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	internal.WriteHTMLHeader(w, internal.HeaderData{Title: "Components"})
+	if rs := app.getRunningState(); rs.config != nil {
+		for _, kind := range zpagesComponentKinds {
+			for _, name := range getComponentNames(rs.config, kind) {
+				internal.WriteHTMLPropertiesTable(w, internal.PropertiesTableData{
+					Name:       kind.String() + ": " + name,
+					Properties: getComponentProperties(rs.exporters, kind, name),
+				})
+			}
+		}
+	}
+	internal.WriteHTMLFooter(w)
+}
+
+func (app *Application) handleTapzRequest(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	pipelineName := r.Form.Get(zPipelineName)
+	stage := r.Form.Get(zStage)
+	internal.WriteHTMLHeader(w, internal.HeaderData{Title: "Taps"})
+	for _, row := range app.getPipelinesSummaryTableData().Rows {
+		internal.WriteHTMLComponentHeader(w, internal.ComponentHeaderData{
+			Name:              row.FullName,
+			ComponentEndpoint: tapzPath + "?" + zPipelineName + "=" + row.FullName,
+			Link:              true,
+		})
+	}
+	if taps := app.getPipelineTaps(pipelineName); taps != nil {
+		for _, tap := range taps {
+			if tap.Stage != stage {
+				continue
+			}
+			switch r.Form.Get(zTapAction) {
+			case "start":
+				tap.Enable(formInt(r, zTapSize, defaultTapSize), formInt(r, zTapEvery, defaultTapEvery))
+			case "stop":
+				tap.Disable()
+			}
 		}
 		internal.WriteHTMLComponentHeader(w, internal.ComponentHeaderData{
-			Name: componentKind + ": " + fullName,
+			Name: "pipeline: " + pipelineName,
 		})
-		// TODO: Add config + status info.
+		internal.WriteHTMLTapTable(w, getTapTableData(pipelineName, stage, taps))
 	}
 	internal.WriteHTMLFooter(w)
 }
 
+// zpagesComponentKinds are the kinds of the components whose counters are shown by the zPages.
+var zpagesComponentKinds = []component.Kind{component.KindReceiver, component.KindProcessor, component.KindExporter}
+
+// getComponentNames returns the sorted names of the components of the given kind in cfg.
+func getComponentNames(cfg *configmodels.Config, kind component.Kind) []string {
+	var names []string
+	switch kind {
+	case component.KindReceiver:
+		for name := range cfg.Receivers {
+			names = append(names, name)
+		}
+	case component.KindProcessor:
+		for name := range cfg.Processors {
+			names = append(names, name)
+		}
+	case component.KindExporter:
+		for name := range cfg.Exporters {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getComponentProperties returns the live counters of the component and, for the exporters,
+// the state of their sending queue and of their retries for each data type.
+func getComponentProperties(exporters builder.Exporters, kind component.Kind, name string) [][2]string {
+	var counters []obsreport.Counter
+	switch kind {
+	case component.KindReceiver:
+		counters = obsreport.ReceiverCounters(name)
+	case component.KindProcessor:
+		counters = obsreport.ProcessorCounters(name)
+	case component.KindExporter:
+		counters = obsreport.ExporterCounters(name)
+	}
+
+	var props [][2]string
+	for _, c := range counters {
+		props = append(props, [2]string{c.Name, strconv.FormatInt(c.Value, 10)})
+	}
+	if kind != component.KindExporter {
+		return props
+	}
+
+	for _, dataType := range []configmodels.DataType{configmodels.TracesDataType, configmodels.MetricsDataType, configmodels.LogsDataType} {
+		for cfg, exp := range exporters.ToMapByDataType()[dataType] {
+			reporter, ok := exp.(exporterhelper.QueueStatusReporter)
+			if cfg.Name() != name || !ok {
+				continue
+			}
+			props = append(props, queueStatusProperties(string(dataType), reporter.QueueStatus())...)
+		}
+	}
+	return props
+}
+
+func queueStatusProperties(dataType string, qs exporterhelper.QueueStatus) [][2]string {
+	props := [][2]string{{dataType + "/queue_enabled", strconv.FormatBool(qs.QueueEnabled)}}
+	if qs.QueueEnabled {
+		props = append(props,
+			[2]string{dataType + "/queue_size", strconv.Itoa(qs.QueueSize)},
+			[2]string{dataType + "/queue_capacity", strconv.Itoa(qs.QueueCapacity)})
+	}
+	props = append(props, [2]string{dataType + "/retry_enabled", strconv.FormatBool(qs.RetryEnabled)})
+	if qs.RetryEnabled {
+		props = append(props, [2]string{dataType + "/retrying_requests", strconv.FormatInt(qs.RetryingRequests, 10)})
+	}
+	if qs.LastRetryError != nil {
+		props = append(props,
+			[2]string{dataType + "/last_retry_error", qs.LastRetryError.Error()},
+			[2]string{dataType + "/last_retry_time", qs.LastRetryTime.Format(time.RFC3339)})
+	}
+	return props
+}
+
+// getPipelineTaps returns the taps of the pipeline with the given name, nil if it does not exist.
+func (app *Application) getPipelineTaps(pipelineName string) []*builder.Tap {
+	for cfg, bp := range app.getRunningState().pipelines {
+		if cfg.Name == pipelineName {
+			return bp.Taps()
+		}
+	}
+	return nil
+}
+
+func getTapTableData(pipelineName, stage string, taps []*builder.Tap) internal.TapTableData {
+	data := internal.TapTableData{
+		ComponentEndpoint: tapzPath,
+		Pipeline:          pipelineName,
+	}
+	for _, tap := range taps {
+		samples := tap.Samples()
+		data.Rows = append(data.Rows, internal.TapTableRowData{
+			Stage:   tap.Stage,
+			Enabled: tap.Enabled(),
+			Samples: len(samples),
+		})
+		if tap.Stage != stage {
+			continue
+		}
+		for _, s := range samples {
+			data.Samples = append(data.Samples, internal.TapSampleData{
+				Time: s.Time.Format(time.RFC3339Nano),
+				Text: s.Text,
+			})
+		}
+	}
+	return data
+}
+
+// formInt returns the positive integer value of the form field, or def if it is not set or invalid.
+func formInt(r *http.Request, key string, def int) int {
+	v, err := strconv.Atoi(r.Form.Get(key))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return v
+}
+
 func (app *Application) handleExtensionzRequest(w http.ResponseWriter, r *http.Request) {
 	r.ParseForm()
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -534,8 +1005,9 @@ func (app *Application) getPipelinesSummaryTableData() internal.SummaryPipelines
 		ComponentEndpoint: pipelinezPath,
 	}
 
-	data.Rows = make([]internal.SummaryPipelinesTableRowData, 0, len(app.builtExtensions))
-	for c, p := range app.builtPipelines {
+	pipelines := app.getRunningState().pipelines
+	data.Rows = make([]internal.SummaryPipelinesTableRowData, 0, len(pipelines))
+	for c, p := range pipelines {
 		row := internal.SummaryPipelinesTableRowData{
 			FullName:            c.Name,
 			InputType:           string(c.InputType),
@@ -17,15 +17,20 @@ package service
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/prometheus/common/expfmt"
 	"github.com/spf13/viper"
@@ -38,6 +43,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/service/defaultcomponents"
 	"go.opentelemetry.io/collector/testutil"
 )
@@ -95,7 +102,7 @@ func TestApplication_Start(t *testing.T) {
 
 type mockAppTelemetry struct{}
 
-func (tel *mockAppTelemetry) init(chan<- error, uint64, *zap.Logger) error {
+func (tel *mockAppTelemetry) init(chan<- error, uint64, string, configmodels.MetricsTelemetry, *zap.Logger) error {
 	return nil
 }
 
@@ -167,6 +174,181 @@ func TestApplication_StartAsGoRoutine(t *testing.T) {
 	assert.Equal(t, Closed, <-app.GetStateChannel())
 }
 
+func TestApplication_GetLogsConsumer(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	metricsPort := testutil.GetAvailablePort(t)
+	params := Parameters{
+		ApplicationStartInfo: componenttest.TestApplicationStartInfo(),
+		ConfigFactory: func(_ *viper.Viper, factories component.Factories) (*configmodels.Config, error) {
+			v := config.NewViper()
+			v.SetConfigType("yaml")
+			require.NoError(t, v.ReadConfig(strings.NewReader(`
+receivers:
+  examplereceiver:
+exporters:
+  exampleexporter:
+service:
+  pipelines:
+    traces:
+      receivers: [examplereceiver]
+      exporters: [exampleexporter]
+    logs/2:
+      receivers: [examplereceiver]
+      exporters: [exampleexporter]
+`)))
+			return config.Load(v, factories)
+		},
+		Factories: factories,
+	}
+	app, err := New(params)
+	require.NoError(t, err)
+	app.Command().SetArgs([]string{
+		"--metrics-addr=localhost:" + strconv.FormatUint(uint64(metricsPort), 10),
+	})
+	var host component.Host = app
+	_, ok := host.(component.PipelineConsumers)
+	require.True(t, ok)
+	assert.Nil(t, app.GetLogsConsumer("logs/2"))
+
+	appDone := make(chan struct{})
+	go func() {
+		defer close(appDone)
+		assert.NoError(t, app.Run())
+	}()
+
+	assert.Equal(t, Starting, <-app.GetStateChannel())
+	assert.Equal(t, Running, <-app.GetStateChannel())
+	assert.NotNil(t, app.GetLogsConsumer("logs/2"))
+	assert.Nil(t, app.GetLogsConsumer("traces"))
+	assert.Nil(t, app.GetLogsConsumer("logs"))
+
+	app.SignalTestComplete()
+	<-appDone
+	assert.Equal(t, Closing, <-app.GetStateChannel())
+	assert.Equal(t, Closed, <-app.GetStateChannel())
+	assert.Nil(t, app.GetLogsConsumer("logs/2"))
+}
+
+func TestApplication_StartWithTelemetryConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	defer configtelemetry.SetMetricsLevel(configtelemetry.GetMetricsLevelFlagValue())
+	logFile := filepath.Join(t.TempDir(), "otelcol.log")
+	metricsPort := testutil.GetAvailablePort(t)
+	params := Parameters{
+		ApplicationStartInfo: componenttest.TestApplicationStartInfo(),
+		ConfigFactory: func(_ *viper.Viper, factories component.Factories) (*configmodels.Config, error) {
+			v := config.NewViper()
+			v.SetConfigType("yaml")
+			require.NoError(t, v.ReadConfig(strings.NewReader(`
+receivers:
+  examplereceiver:
+exporters:
+  exampleexporter:
+service:
+  pipelines:
+    traces:
+      receivers: [examplereceiver]
+      exporters: [exampleexporter]
+`)))
+			cfg, err := config.Load(v, factories)
+			require.NoError(t, err)
+			cfg.Service.Telemetry.Logs = configmodels.LogsTelemetry{
+				Level:       "warn",
+				Encoding:    "json",
+				OutputPaths: []string{logFile},
+			}
+			cfg.Service.Telemetry.Metrics = configmodels.MetricsTelemetry{
+				Address:        "localhost:" + strconv.FormatUint(uint64(metricsPort), 10),
+				Level:          "detailed",
+				ResourceLabels: map[string]string{"cluster": "eu-1"},
+			}
+			return cfg, nil
+		},
+		Factories: factories,
+	}
+	app, err := New(params)
+	require.NoError(t, err)
+	// The configuration overrides the flags.
+	app.Command().SetArgs([]string{
+		"--log-level=DEBUG",
+		"--metrics-level=NONE",
+	})
+
+	appDone := make(chan struct{})
+	go func() {
+		defer close(appDone)
+		assert.NoError(t, app.Run())
+	}()
+
+	assert.Equal(t, Starting, <-app.GetStateChannel())
+	assert.Equal(t, Running, <-app.GetStateChannel())
+	assert.Equal(t, configtelemetry.LevelDetailed, configtelemetry.GetMetricsLevelFlagValue())
+	assert.False(t, app.GetLogger().Core().Enabled(zapcore.InfoLevel))
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d/metrics", metricsPort))
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	assertMetrics(t, "otelcol", metricsPort, []string{"service_instance_id", "cluster"})
+
+	app.GetLogger().Warn("my warning")
+	contents, err := ioutil.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), `"my warning"`)
+
+	app.SignalTestComplete()
+	<-appDone
+	assert.Equal(t, Closing, <-app.GetStateChannel())
+	assert.Equal(t, Closed, <-app.GetStateChannel())
+}
+
+func TestApplication_PrintConfig(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	app, err := New(Parameters{Factories: factories, ApplicationStartInfo: componenttest.TestApplicationStartInfo()})
+	require.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	app.Command().SetOut(out)
+	app.Command().SetArgs([]string{
+		"--config=testdata/otelcol-config-minimal.yaml",
+		"--config=testdata/otelcol-config-override.yaml",
+		"--print-config",
+	})
+	require.NoError(t, app.Run())
+
+	assert.Equal(t, `exporters:
+  otlp:
+    endpoint: locahost:14250
+    headers:
+      authorization: '[REDACTED]'
+receivers:
+  otlp:
+    protocols:
+      grpc: null
+service:
+  pipelines:
+    metrics:
+      exporters:
+      - otlp
+      receivers:
+      - otlp
+    traces:
+      exporters:
+      - otlp
+      receivers:
+      - otlp
+`, out.String())
+}
+
 // isAppAvailable checks if the healthcheck server at the given endpoint is
 // returning `available`.
 func isAppAvailable(t *testing.T, healthCheckEndPoint string) bool {
@@ -472,6 +654,111 @@ func TestApplication_GetExtensions(t *testing.T) {
 	<-appDone
 }
 
+func TestApplication_ReportStatus(t *testing.T) {
+	app := createExampleApplication(t)
+
+	appDone := make(chan struct{})
+	go func() {
+		defer close(appDone)
+		assert.NoError(t, app.Run())
+	}()
+
+	assert.Equal(t, Starting, <-app.GetStateChannel())
+	assert.Equal(t, Running, <-app.GetStateChannel())
+
+	app.ReportStatus(component.StatusEvent{
+		Kind:   component.KindExporter,
+		Name:   "exampleexporter",
+		Status: component.StatusRecoverableError,
+		Err:    errors.New("my error"),
+	})
+
+	// The event is forwarded to the extension with the pipelines of the exporter.
+	var ext *componenttest.ExampleExtension
+	for _, e := range app.GetExtensions() {
+		ext = e.(*componenttest.ExampleExtension)
+	}
+	require.NotNil(t, ext)
+	events := ext.StatusEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, component.StatusRecoverableError, events[0].Status)
+	assert.Equal(t, []string{"traces"}, events[0].Pipelines)
+	assert.False(t, events[0].Timestamp.IsZero())
+
+	// The extension is notified of the components of the configuration.
+	var exporter *component.StatusEvent
+	for _, c := range ext.Components() {
+		if c.Kind == component.KindExporter && c.Name == "exampleexporter" {
+			c := c
+			exporter = &c
+		}
+	}
+	require.NotNil(t, exporter)
+	assert.Equal(t, component.StatusOK, exporter.Status)
+	assert.Equal(t, []string{"traces"}, exporter.Pipelines)
+
+	// Stop the Application.
+	close(app.stopTestChan)
+	<-appDone
+}
+
+func TestApplication_ZPages(t *testing.T) {
+	app := createExampleApplication(t)
+
+	appDone := make(chan struct{})
+	go func() {
+		defer close(appDone)
+		assert.NoError(t, app.Run())
+	}()
+
+	assert.Equal(t, Starting, <-app.GetStateChannel())
+	assert.Equal(t, Running, <-app.GetStateChannel())
+
+	mux := http.NewServeMux()
+	app.RegisterZPages(mux, "/debug")
+	get := func(url string) string {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest("GET", url, nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		return rr.Body.String()
+	}
+
+	assert.Contains(t, get("/debug/servicez"), "componentz")
+	body := get("/debug/componentz")
+	assert.Contains(t, body, "receiver: examplereceiver")
+	assert.Contains(t, body, "exporter: exampleexporter")
+
+	taps := app.getPipelineTaps("traces")
+	require.Len(t, taps, 1)
+	assert.False(t, taps[0].Enabled())
+	assert.Contains(t, get("/debug/tapz?zpipelinename=traces&zstage=receivers&ztapaction=start"), "stop")
+	assert.True(t, taps[0].Enabled())
+	assert.Contains(t, get("/debug/tapz?zpipelinename=traces&zstage=receivers&ztapaction=stop"), "start")
+	assert.False(t, taps[0].Enabled())
+
+	// Stop the Application.
+	close(app.stopTestChan)
+	<-appDone
+}
+
+func TestQueueStatusProperties(t *testing.T) {
+	props := queueStatusProperties("traces", exporterhelper.QueueStatus{
+		QueueEnabled:     true,
+		QueueSize:        2,
+		QueueCapacity:    10,
+		RetryEnabled:     true,
+		RetryingRequests: 1,
+		LastRetryError:   errors.New("my error"),
+	})
+	assert.Contains(t, props, [2]string{"traces/queue_size", "2"})
+	assert.Contains(t, props, [2]string{"traces/queue_capacity", "10"})
+	assert.Contains(t, props, [2]string{"traces/retrying_requests", "1"})
+	assert.Contains(t, props, [2]string{"traces/last_retry_error", "my error"})
+
+	props = queueStatusProperties("logs", exporterhelper.QueueStatus{})
+	assert.Equal(t, [][2]string{{"logs/queue_enabled", "false"}, {"logs/retry_enabled", "false"}}, props)
+}
+
 func TestApplication_GetExporters(t *testing.T) {
 	app := createExampleApplication(t)
 
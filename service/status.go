@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// statusSource identifies the component of a status event.
+type statusSource struct {
+	kind component.Kind
+	name string
+}
+
+// ReportStatus is used by the components to report a change of their status. The event
+// is forwarded to the extensions implementing component.StatusWatcher.
+func (app *Application) ReportStatus(event component.StatusEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	if event.Pipelines == nil {
+		if pipelines, ok := app.componentPipelines.Load().(map[statusSource][]string); ok {
+			event.Pipelines = pipelines[statusSource{kind: event.Kind, name: event.Name}]
+		}
+	}
+	app.builtExtensions.NotifyComponentStatus(event)
+}
+
+// setComponentPipelines computes the pipelines of each component of cfg, they are added
+// to the status events reported by the components. The extensions are notified of the
+// components, so that they forget the statuses of the ones that were removed.
+func (app *Application) setComponentPipelines(cfg *configmodels.Config) {
+	pipelines := make(map[statusSource][]string)
+	add := func(kind component.Kind, names []string, pipeline string) {
+		for _, name := range names {
+			src := statusSource{kind: kind, name: name}
+			if _, ok := cfg.Connectors[name]; ok {
+				src.kind = component.KindConnector
+			}
+			pipelines[src] = append(pipelines[src], pipeline)
+		}
+	}
+	for _, pipeline := range cfg.Service.Pipelines {
+		add(component.KindReceiver, pipeline.Receivers, pipeline.Name)
+		add(component.KindProcessor, pipeline.Processors, pipeline.Name)
+		add(component.KindExporter, pipeline.Exporters, pipeline.Name)
+	}
+	now := time.Now()
+	components := make([]component.StatusEvent, 0, len(pipelines))
+	for src, names := range pipelines {
+		sort.Strings(names)
+		components = append(components, component.StatusEvent{
+			Kind:      src.kind,
+			Name:      src.name,
+			Status:    component.StatusOK,
+			Timestamp: now,
+			Pipelines: names,
+		})
+	}
+	app.componentPipelines.Store(pipelines)
+	app.builtExtensions.NotifyComponentsChanged(components)
+}
@@ -20,11 +20,12 @@ import (
 	"unicode"
 
 	"contrib.go.opencensus.io/exporter/prometheus"
-	"github.com/google/uuid"
 	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/extension/subprocessextension"
 	"go.opentelemetry.io/collector/internal/collector/telemetry"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/processor"
@@ -40,7 +41,7 @@ import (
 var applicationTelemetry appTelemetryExporter = &appTelemetry{}
 
 type appTelemetryExporter interface {
-	init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, logger *zap.Logger) error
+	init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, instanceID string, cfg configmodels.MetricsTelemetry, logger *zap.Logger) error
 	shutdown() error
 }
 
@@ -49,11 +50,15 @@ type appTelemetry struct {
 	server *http.Server
 }
 
-func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, logger *zap.Logger) error {
+func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, instanceID string, cfg configmodels.MetricsTelemetry, logger *zap.Logger) error {
+	// The level of service::telemetry::metrics is already applied by the service.
 	level := configtelemetry.GetMetricsLevelFlagValue()
 	metricsAddr := telemetry.GetMetricsAddr()
+	if cfg.Address != "" {
+		metricsAddr = cfg.Address
+	}
 
-	if level == configtelemetry.LevelNone || metricsAddr == "" {
+	if level == configtelemetry.LevelNone {
 		return nil
 	}
 
@@ -65,11 +70,13 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	var views []*view.View
 	views = append(views, obsreport.Configure(level)...)
 	views = append(views, processor.MetricViews()...)
+	views = append(views, processor.FanOutMetricViews()...)
 	views = append(views, queuedprocessor.MetricViews()...)
 	views = append(views, batchprocessor.MetricViews()...)
 	views = append(views, kafkareceiver.MetricViews()...)
 	views = append(views, processMetricsViews.Views()...)
 	views = append(views, fluentobserv.MetricViews()...)
+	views = append(views, subprocessextension.MetricViews()...)
 	tel.views = views
 	if err = view.Register(views...); err != nil {
 		return err
@@ -77,17 +84,24 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 
 	processMetricsViews.StartCollection()
 
+	// The views are registered even without Prometheus endpoint, the metrics can also
+	// be sent as configured in service::telemetry::export.
+	if metricsAddr == "" {
+		return nil
+	}
+
 	// Until we can use a generic metrics exporter, default to Prometheus.
 	opts := prometheus.Options{
 		Namespace: telemetry.GetMetricsPrefix(),
 	}
 
-	var instanceID string
-	if telemetry.GetAddInstanceID() {
-		instanceUUID, _ := uuid.NewRandom()
-		instanceID = instanceUUID.String()
-		opts.ConstLabels = map[string]string{
-			sanitizePrometheusKey(conventions.AttributeServiceInstance): instanceID,
+	if instanceID != "" || len(cfg.ResourceLabels) > 0 {
+		opts.ConstLabels = make(map[string]string, len(cfg.ResourceLabels)+1)
+		if instanceID != "" {
+			opts.ConstLabels[sanitizePrometheusKey(conventions.AttributeServiceInstance)] = instanceID
+		}
+		for k, v := range cfg.ResourceLabels {
+			opts.ConstLabels[sanitizePrometheusKey(k)] = v
 		}
 	}
 
@@ -101,7 +115,7 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	logger.Info(
 		"Serving Prometheus metrics",
 		zap.String("address", metricsAddr),
-		zap.Int8("level", int8(level)), // TODO: make it human friendly
+		zap.Stringer("level", &level),
 		zap.String(conventions.AttributeServiceInstance, instanceID),
 	)
 
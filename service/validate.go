@@ -0,0 +1,390 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/service/builder"
+)
+
+// newValidateCommand creates the command that validates the configuration without
+// running the collector.
+func (app *Application) newValidateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validates the configuration without running the collector",
+		Long: "Loads the configuration and creates all the configured components, " +
+			"without starting them, and reports all the errors found.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.validate(context.Background(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+}
+
+// validate checks the configuration created by the ConfigFactory of the application and
+// writes the errors found to errOut. The errors are prefixed by the file and line of the
+// invalid configuration if it is read from the configuration files given on the command
+// line. It returns an error if the configuration is invalid.
+func (app *Application) validate(ctx context.Context, out io.Writer, errOut io.Writer) error {
+	app.loadedSources = nil
+	cfg, errs := config.Check(app.configFactory(config.NewViper(), app.factories))
+	if app.loadedSources != nil {
+		app.loadedSources.Close()
+		app.loadedSources = nil
+	}
+	if cfg != nil {
+		errs = append(errs, createComponents(ctx, cfg, app.factories, app.info)...)
+	}
+
+	if len(errs) == 0 {
+		fmt.Fprintln(out, "Configuration is valid.")
+		return nil
+	}
+
+	locator := newKeyLocator(builder.GetConfigFiles())
+	for _, err := range errs {
+		var keyErr *config.KeyError
+		if errors.As(err, &keyErr) {
+			if location := locator.locate(keyErr.Key); location != "" {
+				fmt.Fprintf(errOut, "%s: %v\n", location, err)
+				continue
+			}
+		}
+		fmt.Fprintln(errOut, err)
+	}
+	return fmt.Errorf("invalid configuration: %d error(s) found", len(errs))
+}
+
+// createComponents creates the components and connectors used by the pipelines of cfg,
+// and all the extensions, without starting them, and returns the errors reported by their
+// factories. The created components are shut down before returning.
+func createComponents(
+	ctx context.Context,
+	cfg *configmodels.Config,
+	factories component.Factories,
+	info component.ApplicationStartInfo,
+) []error {
+	logger := zap.NewNop()
+	var errs []error
+	fail := func(section string, name string, err error) {
+		errs = append(errs, &config.KeyError{Key: []string{section, name}, Err: err})
+	}
+	var created []component.Component
+	add := func(comp component.Component) {
+		if comp == nil {
+			return
+		}
+		// The same component may be returned for several data types.
+		for _, c := range created {
+			if c == comp {
+				return
+			}
+		}
+		created = append(created, comp)
+	}
+	defer func() {
+		// The components were never started, errors shutting them down are not
+		// configuration errors and are ignored.
+		for _, comp := range created {
+			comp.Shutdown(ctx)
+		}
+	}()
+
+	extensions := make([]string, 0, len(cfg.Extensions))
+	for name := range cfg.Extensions {
+		extensions = append(extensions, name)
+	}
+	sort.Strings(extensions)
+	for _, name := range extensions {
+		extCfg := cfg.Extensions[name]
+		params := component.ExtensionCreateParams{Logger: logger, ApplicationStartInfo: info}
+		ext, err := factories.Extensions[extCfg.Type()].CreateExtension(ctx, params, extCfg)
+		add(ext)
+		if err != nil {
+			fail("extensions", name, fmt.Errorf("cannot create extension %s: %v", name, err))
+		}
+	}
+
+	receiverTypes, processorTypes, exporterTypes := pipelineDataTypes(cfg)
+
+	for _, name := range sortedNames(receiverTypes) {
+		rcvCfg := cfg.Receivers[name]
+		factory := factories.Receivers[rcvCfg.Type()]
+		params := component.ReceiverCreateParams{Logger: logger, ApplicationStartInfo: info}
+		for _, dataType := range receiverTypes[name] {
+			var comp component.Component
+			var err error
+			switch dataType {
+			case configmodels.TracesDataType:
+				comp, err = factory.CreateTracesReceiver(ctx, params, rcvCfg, consumertest.NewTracesNop())
+			case configmodels.MetricsDataType:
+				comp, err = factory.CreateMetricsReceiver(ctx, params, rcvCfg, consumertest.NewMetricsNop())
+			case configmodels.LogsDataType:
+				comp, err = factory.CreateLogsReceiver(ctx, params, rcvCfg, consumertest.NewLogsNop())
+			}
+			add(comp)
+			if err != nil {
+				fail("receivers", name, creationError("receiver", name, dataType, err))
+			}
+		}
+	}
+
+	for _, name := range sortedNames(processorTypes) {
+		procCfg := cfg.Processors[name]
+		factory := factories.Processors[procCfg.Type()]
+		params := component.ProcessorCreateParams{Logger: logger, ApplicationStartInfo: info}
+		for _, dataType := range processorTypes[name] {
+			var comp component.Component
+			var err error
+			switch dataType {
+			case configmodels.TracesDataType:
+				comp, err = factory.CreateTracesProcessor(ctx, params, procCfg, consumertest.NewTracesNop())
+			case configmodels.MetricsDataType:
+				comp, err = factory.CreateMetricsProcessor(ctx, params, procCfg, consumertest.NewMetricsNop())
+			case configmodels.LogsDataType:
+				comp, err = factory.CreateLogsProcessor(ctx, params, procCfg, consumertest.NewLogsNop())
+			}
+			add(comp)
+			if err != nil {
+				fail("processors", name, creationError("processor", name, dataType, err))
+			}
+		}
+	}
+
+	for _, name := range sortedNames(exporterTypes) {
+		expCfg := cfg.Exporters[name]
+		factory := factories.Exporters[expCfg.Type()]
+		params := component.ExporterCreateParams{Logger: logger, ApplicationStartInfo: info}
+		for _, dataType := range exporterTypes[name] {
+			var comp component.Component
+			var err error
+			switch dataType {
+			case configmodels.TracesDataType:
+				comp, err = factory.CreateTracesExporter(ctx, params, expCfg)
+			case configmodels.MetricsDataType:
+				comp, err = factory.CreateMetricsExporter(ctx, params, expCfg)
+			case configmodels.LogsDataType:
+				comp, err = factory.CreateLogsExporter(ctx, params, expCfg)
+			}
+			add(comp)
+			if err != nil {
+				fail("exporters", name, creationError("exporter", name, dataType, err))
+			}
+		}
+	}
+
+	connectorInputs, connectorOutputs := connectorDataTypes(cfg)
+	for _, name := range sortedNames(connectorInputs) {
+		connCfg := cfg.Connectors[name]
+		factory := factories.Connectors[connCfg.Type()]
+		params := component.ConnectorCreateParams{Logger: logger, ApplicationStartInfo: info}
+		var nextConsumers component.ConnectorConsumers
+		for _, dataType := range connectorOutputs[name] {
+			switch dataType {
+			case configmodels.TracesDataType:
+				nextConsumers.Traces = consumertest.NewTracesNop()
+			case configmodels.MetricsDataType:
+				nextConsumers.Metrics = consumertest.NewMetricsNop()
+			case configmodels.LogsDataType:
+				nextConsumers.Logs = consumertest.NewLogsNop()
+			}
+		}
+		for _, dataType := range connectorInputs[name] {
+			var comp component.Component
+			var err error
+			switch dataType {
+			case configmodels.TracesDataType:
+				comp, err = factory.CreateTracesConnector(ctx, params, connCfg, nextConsumers)
+			case configmodels.MetricsDataType:
+				comp, err = factory.CreateMetricsConnector(ctx, params, connCfg, nextConsumers)
+			case configmodels.LogsDataType:
+				comp, err = factory.CreateLogsConnector(ctx, params, connCfg, nextConsumers)
+			}
+			add(comp)
+			if err == configerror.ErrDataTypeIsNotSupported {
+				fail("connectors", name, fmt.Errorf("connector %s used in a %s pipeline does not support "+
+					"sending data to the pipelines it is a receiver of", name, dataType))
+			} else if err != nil {
+				fail("connectors", name, creationError("connector", name, dataType, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// connectorDataTypes returns the data types of the pipelines each connector of cfg is an
+// exporter of, the inputs, and is a receiver of, the outputs, sorted.
+func connectorDataTypes(cfg *configmodels.Config) (inputs, outputs map[string][]configmodels.DataType) {
+	inputs = make(map[string][]configmodels.DataType)
+	outputs = make(map[string][]configmodels.DataType)
+	for _, pipeline := range cfg.Service.Pipelines {
+		for _, name := range pipeline.Exporters {
+			if cfg.Connectors[name] != nil {
+				addDataType(inputs, name, pipeline.InputType)
+			}
+		}
+		for _, name := range pipeline.Receivers {
+			if cfg.Connectors[name] != nil {
+				addDataType(outputs, name, pipeline.InputType)
+			}
+		}
+	}
+	return inputs, outputs
+}
+
+// pipelineDataTypes returns the data types of the pipelines using each of the receivers,
+// processors and exporters of cfg, sorted. Components that do not exist are ignored.
+func pipelineDataTypes(cfg *configmodels.Config) (receivers, processors, exporters map[string][]configmodels.DataType) {
+	receivers = make(map[string][]configmodels.DataType)
+	processors = make(map[string][]configmodels.DataType)
+	exporters = make(map[string][]configmodels.DataType)
+	for _, pipeline := range cfg.Service.Pipelines {
+		for _, name := range pipeline.Receivers {
+			if cfg.Receivers[name] != nil {
+				addDataType(receivers, name, pipeline.InputType)
+			}
+		}
+		for _, name := range pipeline.Processors {
+			if cfg.Processors[name] != nil {
+				addDataType(processors, name, pipeline.InputType)
+			}
+		}
+		for _, name := range pipeline.Exporters {
+			if cfg.Exporters[name] != nil {
+				addDataType(exporters, name, pipeline.InputType)
+			}
+		}
+	}
+	return receivers, processors, exporters
+}
+
+// addDataType adds dataType to the sorted data types of the named component, if missing.
+func addDataType(types map[string][]configmodels.DataType, name string, dataType configmodels.DataType) {
+	for _, dt := range types[name] {
+		if dt == dataType {
+			return
+		}
+	}
+	types[name] = append(types[name], dataType)
+	sort.Slice(types[name], func(i, j int) bool { return types[name][i] < types[name][j] })
+}
+
+func creationError(kind string, name string, dataType configmodels.DataType, err error) error {
+	if err == configerror.ErrDataTypeIsNotSupported {
+		return fmt.Errorf("%s %s does not support %s but it is used in a %s pipeline", kind, name, dataType, dataType)
+	}
+	return fmt.Errorf("cannot create %s %s: %v", kind, name, err)
+}
+
+func sortedNames(types map[string][]configmodels.DataType) []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keyLocator finds the position of configuration keys in the configuration files.
+type keyLocator struct {
+	files []string
+	docs  []*yaml.Node
+}
+
+// newKeyLocator parses the configuration files and directories. Files that cannot be
+// parsed are ignored, the keys they contain are not located.
+func newKeyLocator(paths []string) *keyLocator {
+	l := &keyLocator{}
+	files, err := config.ExpandConfigPaths(paths)
+	if err != nil {
+		return l
+	}
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var doc yaml.Node
+		if err = yaml.Unmarshal(content, &doc); err != nil {
+			continue
+		}
+		l.files = append(l.files, file)
+		l.docs = append(l.docs, &doc)
+	}
+	return l
+}
+
+// locate returns the "file:line" position of the key or, if the key is not found, of
+// its closest parent. If several files contain the key the position in the last one is
+// returned, since it takes precedence when the files are merged. It returns an empty
+// string if no part of the key is found.
+func (l *keyLocator) locate(key []string) string {
+	location := ""
+	bestDepth := 0
+	for i := len(l.docs) - 1; i >= 0; i-- {
+		node, depth := findKeyNode(l.docs[i], key)
+		if node != nil && depth > bestDepth {
+			location = fmt.Sprintf("%s:%d", l.files[i], node.Line)
+			bestDepth = depth
+		}
+	}
+	return location
+}
+
+// findKeyNode returns the node of the deepest part of key found in doc and its depth.
+func findKeyNode(doc *yaml.Node, key []string) (*yaml.Node, int) {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, 0
+	}
+	var found *yaml.Node
+	depth := 0
+	node := doc.Content[0]
+	for _, part := range key {
+		if node.Kind != yaml.MappingNode {
+			break
+		}
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			// Keys are case insensitive and surrounding spaces are ignored in component names.
+			if strings.EqualFold(strings.TrimSpace(node.Content[i].Value), part) {
+				found, value = node.Content[i], node.Content[i+1]
+			}
+		}
+		if value == nil {
+			break
+		}
+		depth++
+		node = value
+	}
+	return found, depth
+}
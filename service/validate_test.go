@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/service/defaultcomponents"
+)
+
+func TestApplication_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantOut string
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			args:    []string{"validate", "--config=testdata/otelcol-config-minimal.yaml"},
+			wantOut: "Configuration is valid.\n",
+		},
+		{
+			name: "invalid",
+			args: []string{"validate", "--config=testdata/otelcol-invalid.yaml"},
+			wantErr: `testdata/otelcol-invalid.yaml:6: unknown receivers type "nosuchreceiver" for nosuchreceiver
+testdata/otelcol-invalid.yaml:9: error reading processors configuration for batch: 1 error(s) decoding:
+
+* '' has invalid keys: unknown_setting
+testdata/otelcol-invalid.yaml:21: pipeline "traces" references exporter "nosuchexporter" which does not exist
+testdata/otelcol-invalid.yaml:5: receiver zipkin does not support metrics but it is used in a metrics pipeline
+Error: invalid configuration: 4 error(s) found
+`,
+		},
+		{
+			name: "merged",
+			args: []string{
+				"validate",
+				"--config=testdata/otelcol-invalid.yaml",
+				"--config=testdata/otelcol-invalid-fix.yaml",
+			},
+			wantErr: `testdata/otelcol-invalid.yaml:6: unknown receivers type "nosuchreceiver" for nosuchreceiver
+testdata/otelcol-invalid-fix.yaml:3: pipeline "traces" references exporter "nosuchexporter" which does not exist
+testdata/otelcol-invalid.yaml:5: receiver zipkin does not support metrics but it is used in a metrics pipeline
+Error: invalid configuration: 3 error(s) found
+`,
+		},
+		{
+			name: "connector",
+			args: []string{"validate", "--config=testdata/otelcol-invalid-connector.yaml"},
+			wantErr: `testdata/otelcol-invalid-connector.yaml:10: connector forward used in a traces pipeline does not support sending data to the pipelines it is a receiver of
+Error: invalid configuration: 1 error(s) found
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factories, err := defaultcomponents.Components()
+			require.NoError(t, err)
+			app, err := New(Parameters{Factories: factories, ApplicationStartInfo: componenttest.TestApplicationStartInfo()})
+			require.NoError(t, err)
+
+			out := new(bytes.Buffer)
+			errOut := new(bytes.Buffer)
+			app.Command().SetOut(out)
+			app.Command().SetErr(errOut)
+			app.Command().SetArgs(tt.args)
+			err = app.Run()
+
+			if tt.wantErr == "" {
+				require.NoError(t, err, errOut.String())
+				assert.Equal(t, tt.wantOut, out.String())
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, errOut.String())
+			assert.Empty(t, out.String())
+		})
+	}
+}
+
+func TestApplication_ValidateConfigFactory(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	exporterFactory := &recordingExporterFactory{}
+	factories.Exporters["exampleexporter"] = exporterFactory
+	app, err := New(Parameters{
+		Factories:            factories,
+		ApplicationStartInfo: componenttest.TestApplicationStartInfo(),
+		ConfigFactory: func(*viper.Viper, component.Factories) (*configmodels.Config, error) {
+			return createReloadTestConfig(), nil
+		},
+	})
+	require.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	require.NoError(t, app.validate(context.Background(), out, new(bytes.Buffer)))
+	assert.Equal(t, "Configuration is valid.\n", out.String())
+
+	// The created exporters are shut down.
+	require.Len(t, exporterFactory.created, 2)
+	for _, exp := range exporterFactory.created {
+		assert.False(t, exp.ExporterStarted)
+		assert.True(t, exp.ExporterShutdown)
+	}
+}
+
+// recordingExporterFactory records the traces exporters it creates.
+type recordingExporterFactory struct {
+	componenttest.ExampleExporterFactory
+	created []*componenttest.ExampleExporterConsumer
+}
+
+func (f *recordingExporterFactory) CreateTracesExporter(
+	ctx context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.TracesExporter, error) {
+	exp, err := f.ExampleExporterFactory.CreateTracesExporter(ctx, params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	f.created = append(f.created, exp.(*componenttest.ExampleExporterConsumer))
+	return exp, nil
+}
+
+func TestKeyLocator(t *testing.T) {
+	locator := newKeyLocator([]string{
+		path.Join("testdata", "otelcol-invalid.yaml"),
+		path.Join("testdata", "otelcol-invalid-fix.yaml"),
+	})
+
+	// The last file containing the key takes precedence.
+	assert.Equal(t, "testdata/otelcol-invalid-fix.yaml:6", locator.locate([]string{"processors", "batch"}))
+	assert.Equal(t, "testdata/otelcol-invalid.yaml:2", locator.locate([]string{"receivers", "otlp"}))
+	// Keys are case insensitive.
+	assert.Equal(t, "testdata/otelcol-invalid.yaml:13", locator.locate([]string{"Exporters", "Logging"}))
+	// The closest parent is returned for keys not in the files.
+	assert.Equal(t, "testdata/otelcol-invalid.yaml:12", locator.locate([]string{"exporters", "otlp"}))
+	assert.Equal(t, "", locator.locate([]string{"extensions"}))
+}
@@ -66,6 +66,9 @@ func (mb *DataReceiverBase) ReportFatalError(err error) {
 	log.Printf("Fatal error reported: %v", err)
 }
 
+// ReportStatus is used to report a change of the status of a component.
+func (mb *DataReceiverBase) ReportStatus(_ component.StatusEvent) {}
+
 // GetFactory of the specified kind. Returns the factory for a component type.
 func (mb *DataReceiverBase) GetFactory(_ component.Kind, _ configmodels.Type) component.Factory {
 	return nil
@@ -96,6 +96,9 @@ func (dsb *DataSenderBase) ReportFatalError(err error) {
 	log.Printf("Fatal error reported: %v", err)
 }
 
+// ReportStatus is used to report a change of the status of a component.
+func (dsb *DataSenderBase) ReportStatus(_ component.StatusEvent) {}
+
 // GetFactory of the specified kind. Returns the factory for a component type.
 func (dsb *DataSenderBase) GetFactory(_ component.Kind, _ configmodels.Type) component.Factory {
 	return nil
@@ -28,3 +28,10 @@ const (
 	OCTimeEventMessageEventUSize       = "opencensus.timeevent.messageevent.usize"
 	OCTimeEventMessageEventCSize       = "opencensus.timeevent.messageevent.csize"
 )
+
+// OpenCensus exemplar attachments to map the trace and span IDs of OTLP exemplars. The
+// hex encoded IDs are stored under the label names used by OpenMetrics exemplars.
+const (
+	OCAttachmentExemplarTraceID = "trace_id"
+	OCAttachmentExemplarSpanID  = "span_id"
+)
@@ -22,6 +22,8 @@ import (
 
 	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	metricstranslator "go.opentelemetry.io/collector/translator/metrics"
 )
 
 type labelKeys struct {
@@ -125,6 +127,8 @@ func collectLabelKeys(metric pdata.Metric) *labelKeys {
 		collectLabelKeysDoubleHistogramDataPoints(metric.DoubleHistogram().DataPoints(), keySet)
 	case pdata.MetricDataTypeDoubleSummary:
 		collectLabelKeysDoubleSummaryDataPoints(metric.DoubleSummary().DataPoints(), keySet)
+	case pdata.MetricDataTypeExponentialHistogram:
+		collectLabelKeysExponentialHistogramDataPoints(metric.ExponentialHistogram().DataPoints(), keySet)
 	}
 
 	if len(keySet) == 0 {
@@ -209,6 +213,16 @@ func collectLabelKeysDoubleSummaryDataPoints(dhdp pdata.DoubleSummaryDataPointSl
 	}
 }
 
+func collectLabelKeysExponentialHistogramDataPoints(ehdp pdata.ExponentialHistogramDataPointSlice, keySet map[string]struct{}) {
+	for i := 0; i < ehdp.Len(); i++ {
+		hp := ehdp.At(i)
+		if hp.IsNil() {
+			continue
+		}
+		addLabelKeys(keySet, hp.LabelsMap())
+	}
+}
+
 func addLabelKeys(keySet map[string]struct{}, labels pdata.StringMap) {
 	labels.ForEach(func(k string, v string) {
 		keySet[k] = struct{}{}
@@ -260,6 +274,12 @@ func descriptorTypeToOC(metric pdata.Metric) ocmetrics.MetricDescriptor_Type {
 		return ocmetrics.MetricDescriptor_GAUGE_DISTRIBUTION
 	case pdata.MetricDataTypeDoubleSummary:
 		return ocmetrics.MetricDescriptor_SUMMARY
+	case pdata.MetricDataTypeExponentialHistogram:
+		hd := metric.ExponentialHistogram()
+		if hd.AggregationTemporality() == pdata.AggregationTemporalityCumulative {
+			return ocmetrics.MetricDescriptor_CUMULATIVE_DISTRIBUTION
+		}
+		return ocmetrics.MetricDescriptor_GAUGE_DISTRIBUTION
 	}
 	return ocmetrics.MetricDescriptor_UNSPECIFIED
 }
@@ -280,6 +300,8 @@ func dataPointsToTimeseries(metric pdata.Metric, labelKeys *labelKeys) []*ocmetr
 		return doubleHistogramPointToOC(metric.DoubleHistogram().DataPoints(), labelKeys)
 	case pdata.MetricDataTypeDoubleSummary:
 		return doubleSummaryPointToOC(metric.DoubleSummary().DataPoints(), labelKeys)
+	case pdata.MetricDataTypeExponentialHistogram:
+		return exponentialHistogramPointToOC(metric.ExponentialHistogram().DataPoints(), labelKeys)
 	}
 
 	return nil
@@ -413,6 +435,48 @@ func intHistogramPointToOC(dps pdata.IntHistogramDataPointSlice, labelKeys *labe
 	return timeseries
 }
 
+// exponentialHistogramPointToOC converts the exponential histograms to distributions with
+// explicit bounds, OpenCensus has no exponential buckets so the conversion is lossy.
+func exponentialHistogramPointToOC(dps pdata.ExponentialHistogramDataPointSlice, labelKeys *labelKeys) []*ocmetrics.TimeSeries {
+	if dps.Len() == 0 {
+		return nil
+	}
+	timeseries := make([]*ocmetrics.TimeSeries, 0, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.IsNil() {
+			continue
+		}
+
+		bounds, counts := metricstranslator.ExponentialToExplicitBuckets(dp.Scale(), dp.ZeroCount(),
+			metricstranslator.ExponentialBuckets{Offset: dp.Negative().Offset(), BucketCounts: dp.Negative().BucketCounts()},
+			metricstranslator.ExponentialBuckets{Offset: dp.Positive().Offset(), BucketCounts: dp.Positive().BucketCounts()})
+		buckets := histogramBucketsToOC(counts)
+		doubleExemplarsToOC(bounds, buckets, dp.Exemplars())
+
+		ts := &ocmetrics.TimeSeries{
+			StartTimestamp: pdata.UnixNanoToTimestamp(dp.StartTime()),
+			LabelValues:    labelValuesToOC(dp.LabelsMap(), labelKeys),
+			Points: []*ocmetrics.Point{
+				{
+					Timestamp: pdata.UnixNanoToTimestamp(dp.Timestamp()),
+					Value: &ocmetrics.Point_DistributionValue{
+						DistributionValue: &ocmetrics.DistributionValue{
+							Count:                 int64(dp.Count()),
+							Sum:                   dp.Sum(),
+							SumOfSquaredDeviation: 0,
+							BucketOptions:         histogramExplicitBoundsToOC(bounds),
+							Buckets:               buckets,
+						},
+					},
+				},
+			},
+		}
+		timeseries = append(timeseries, ts)
+	}
+	return timeseries
+}
+
 func histogramExplicitBoundsToOC(bounds []float64) *ocmetrics.DistributionValue_BucketOptions {
 	if len(bounds) == 0 {
 		return nil
@@ -515,7 +579,7 @@ func doubleExemplarsToOC(bounds []float64, ocBuckets []*ocmetrics.DistributionVa
 			}
 			break
 		}
-		ocBuckets[pos].Exemplar = exemplarToOC(exemplar.FilteredLabels(), val, exemplar.Timestamp())
+		ocBuckets[pos].Exemplar = exemplarToOC(exemplar.FilteredLabels(), val, exemplar.Timestamp(), exemplar.TraceID(), exemplar.SpanID())
 	}
 }
 
@@ -537,18 +601,25 @@ func intExemplarsToOC(bounds []float64, ocBuckets []*ocmetrics.DistributionValue
 			}
 			break
 		}
-		ocBuckets[pos].Exemplar = exemplarToOC(exemplar.FilteredLabels(), val, exemplar.Timestamp())
+		ocBuckets[pos].Exemplar = exemplarToOC(exemplar.FilteredLabels(), val, exemplar.Timestamp(), exemplar.TraceID(), exemplar.SpanID())
 	}
 }
 
-func exemplarToOC(filteredLabels pdata.StringMap, value float64, timestamp pdata.TimestampUnixNano) *ocmetrics.DistributionValue_Exemplar {
+func exemplarToOC(filteredLabels pdata.StringMap, value float64, timestamp pdata.TimestampUnixNano, traceID pdata.TraceID, spanID pdata.SpanID) *ocmetrics.DistributionValue_Exemplar {
 	var labels map[string]string
-	if filteredLabels.Len() != 0 {
-		labels = make(map[string]string, filteredLabels.Len())
+	if filteredLabels.Len() != 0 || traceID.IsValid() || spanID.IsValid() {
+		labels = make(map[string]string, filteredLabels.Len()+2)
 		filteredLabels.ForEach(func(k string, v string) {
 			labels[k] = v
 		})
 	}
+	// OpenCensus exemplars have no trace and span IDs, they are stored as attachments.
+	if traceID.IsValid() {
+		labels[conventions.OCAttachmentExemplarTraceID] = traceID.HexString()
+	}
+	if spanID.IsValid() {
+		labels[conventions.OCAttachmentExemplarSpanID] = spanID.HexString()
+	}
 
 	return &ocmetrics.DistributionValue_Exemplar{
 		Value:       value,
@@ -15,6 +15,7 @@
 package internaldata
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -184,6 +185,74 @@ func TestMetricsToOC_InvalidDataType(t *testing.T) {
 	assert.EqualValues(t, want, got)
 }
 
+func TestMetricsToOC_ExponentialHistogram(t *testing.T) {
+	got := MetricsToOC(testdata.GenerateMetricsOneExponentialHistogramMetric())
+	assert.Len(t, got, 1)
+	assert.Len(t, got[0].Metrics, 1)
+	metric := got[0].Metrics[0]
+	assert.EqualValues(t, testdata.TestExponentialHistogramMetricName, metric.MetricDescriptor.Name)
+	assert.EqualValues(t, ocmetrics.MetricDescriptor_CUMULATIVE_DISTRIBUTION, metric.MetricDescriptor.Type)
+	assert.Len(t, metric.Timeseries, 2)
+
+	// Only the zero bucket is populated.
+	zero := metric.Timeseries[0].Points[0].GetDistributionValue()
+	assert.EqualValues(t, 1, zero.Count)
+	assert.EqualValues(t, []float64{0}, zero.BucketOptions.GetExplicit().Bounds)
+	assert.Len(t, zero.Buckets, 2)
+	assert.EqualValues(t, 1, zero.Buckets[0].Count)
+	assert.EqualValues(t, 0, zero.Buckets[1].Count)
+
+	// Scale 1 has a base of sqrt(2), the bounds are the upper boundaries of the buckets.
+	dist := metric.Timeseries[1].Points[0].GetDistributionValue()
+	assert.EqualValues(t, 4, dist.Count)
+	assert.EqualValues(t, 11.5, dist.Sum)
+	assert.InDeltaSlice(t, []float64{-math.Sqrt2 / 2, 0, 2 * math.Sqrt2, 4, 4 * math.Sqrt2}, dist.BucketOptions.GetExplicit().Bounds, 1e-9)
+	counts := make([]int64, 0, len(dist.Buckets))
+	for _, bucket := range dist.Buckets {
+		counts = append(counts, bucket.Count)
+	}
+	assert.EqualValues(t, []int64{1, 0, 1, 0, 2, 0}, counts)
+	// The exemplar with value 5 falls in the (4, 4*sqrt(2)] bucket.
+	assert.NotNil(t, dist.Buckets[4].Exemplar)
+	assert.EqualValues(t, 5, dist.Buckets[4].Exemplar.Value)
+}
+
+func TestMetricsToOC_ExemplarTraceAndSpanID(t *testing.T) {
+	md := testdata.GenerateMetricsOneMetric()
+	metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	metric.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	histogram := metric.DoubleHistogram()
+	histogram.InitEmpty()
+	histogram.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	histogram.DataPoints().Resize(1)
+	dp := histogram.DataPoints().At(0)
+	dp.SetCount(1)
+	dp.SetExplicitBounds([]float64{1})
+	dp.SetBucketCounts([]uint64{1, 0})
+	dp.Exemplars().Resize(1)
+	exemplar := dp.Exemplars().At(0)
+	exemplar.SetValue(0.5)
+	exemplar.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1}))
+	exemplar.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	exemplar.FilteredLabels().Insert("key", "value")
+
+	ocmds := MetricsToOC(md)
+	ocExemplar := ocmds[0].Metrics[0].Timeseries[0].Points[0].GetDistributionValue().Buckets[0].Exemplar
+	assert.EqualValues(t, map[string]string{
+		"key":      "value",
+		"trace_id": "01020304050607080807060504030201",
+		"span_id":  "0102030405060708",
+	}, ocExemplar.Attachments)
+
+	// The IDs are restored when converting back.
+	got := OCToMetrics(ocmds[0])
+	gotExemplar := got.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).
+		DoubleHistogram().DataPoints().At(0).Exemplars().At(0)
+	assert.EqualValues(t, exemplar.TraceID(), gotExemplar.TraceID())
+	assert.EqualValues(t, exemplar.SpanID(), gotExemplar.SpanID())
+	assert.EqualValues(t, map[string]string{"key": "value"}, gotExemplar.FilteredLabels().AsRaw())
+}
+
 func generateOCTestData() consumerdata.MetricsData {
 	ts := timestamppb.New(time.Date(2020, 2, 11, 20, 26, 0, 0, time.UTC))
 
@@ -15,11 +15,14 @@
 package internaldata
 
 import (
+	"encoding/hex"
+
 	occommon "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
 	ocmetrics "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 
 	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
 )
 
 // OCSliceToMetricData converts a slice of OC data format to data.MetricData.
@@ -434,10 +437,34 @@ func exemplarToMetrics(ocExemplar *ocmetrics.DistributionValue_Exemplar, exempla
 	ocAttachments := ocExemplar.GetAttachments()
 	attachments.InitEmptyWithCapacity(len(ocAttachments))
 	for k, v := range ocAttachments {
+		switch k {
+		case conventions.OCAttachmentExemplarTraceID:
+			var traceID [16]byte
+			if hexToID(v, traceID[:]) {
+				exemplar.SetTraceID(pdata.NewTraceID(traceID))
+				continue
+			}
+		case conventions.OCAttachmentExemplarSpanID:
+			var spanID [8]byte
+			if hexToID(v, spanID[:]) {
+				exemplar.SetSpanID(pdata.NewSpanID(spanID))
+				continue
+			}
+		}
 		attachments.Upsert(k, v)
 	}
 }
 
+// hexToID decodes the hex string s to id, it returns false if s is not the hex
+// representation of an ID of the length of id.
+func hexToID(s string, id []byte) bool {
+	if hex.DecodedLen(len(s)) != len(id) {
+		return false
+	}
+	_, err := hex.Decode(id, []byte(s))
+	return err == nil
+}
+
 func getPointsCount(ocMetric *ocmetrics.Metric) int {
 	timeseriesSlice := ocMetric.GetTimeseries()
 	var count int
@@ -241,3 +241,18 @@ func generateOCTestResource() *ocresource.Resource {
 		},
 	}
 }
+
+func TestExemplarToMetrics_InvalidIDs(t *testing.T) {
+	exemplar := pdata.NewDoubleExemplar()
+	exemplar.InitEmpty()
+	exemplarToMetrics(&ocmetrics.DistributionValue_Exemplar{
+		Value: 1,
+		Attachments: map[string]string{
+			"trace_id": "nothex",
+			"span_id":  "01020304",
+		},
+	}, exemplar)
+	assert.False(t, exemplar.TraceID().IsValid())
+	assert.False(t, exemplar.SpanID().IsValid())
+	assert.EqualValues(t, map[string]string{"trace_id": "nothex", "span_id": "01020304"}, exemplar.FilteredLabels().AsRaw())
+}
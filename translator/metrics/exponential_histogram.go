@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metricstranslator contains conversions of metric data that are shared
+// by the exporters of formats that do not support all the OTLP metric types.
+package metricstranslator
+
+import (
+	"math"
+)
+
+// ExponentialBuckets is a range of buckets of an exponential histogram: BucketCounts[i]
+// is the count of the bucket with index Offset+i.
+type ExponentialBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+// ExponentialToExplicitBuckets converts the buckets of an exponential histogram with the
+// given scale to explicit bounds and bucket counts, as used by the histograms of OTLP,
+// OpenCensus and Prometheus: bucketCounts[i] is the count of values in
+// (explicitBounds[i-1], explicitBounds[i]] and the last count is the count of values
+// greater than the last bound.
+//
+// The conversion is lossy: each exponential bucket becomes one explicit bucket bounded by
+// its upper boundary, the values exactly zero are counted in a bucket with the bound 0,
+// and the bounds of the negative buckets are inclusive although the exponential buckets
+// exclude them. Buckets whose boundary does not fit in a float64 are merged into their
+// neighbouring bucket.
+func ExponentialToExplicitBuckets(scale int32, zeroCount uint64, negative, positive ExponentialBuckets) (explicitBounds []float64, bucketCounts []uint64) {
+	size := len(negative.BucketCounts) + len(positive.BucketCounts) + 1
+	explicitBounds = make([]float64, 0, size)
+	bucketCounts = make([]uint64, 0, size+1)
+	add := func(bound float64, count uint64) {
+		// Bounds must be increasing, a bucket whose bound is not greater than the
+		// previous one, because of the precision of float64, is merged into it.
+		if last := len(explicitBounds) - 1; last >= 0 && bound <= explicitBounds[last] {
+			bucketCounts[last] += count
+			return
+		}
+		explicitBounds = append(explicitBounds, bound)
+		bucketCounts = append(bucketCounts, count)
+	}
+
+	// The counts of the buckets whose bound is -Inf are added to the next bucket.
+	var carried uint64
+	// Negative buckets have decreasing values, the bucket with index i covers the values
+	// in [-base^(i+1), -base^i).
+	for i := len(negative.BucketCounts) - 1; i >= 0; i-- {
+		bound := -lowerBoundary(scale, int64(negative.Offset)+int64(i))
+		if math.IsInf(bound, -1) {
+			carried += negative.BucketCounts[i]
+			continue
+		}
+		add(bound, negative.BucketCounts[i]+carried)
+		carried = 0
+	}
+	add(0, zeroCount+carried)
+
+	// Positive buckets, the bucket with index i covers the values in (base^i, base^(i+1)].
+	var overflow uint64
+	for i, count := range positive.BucketCounts {
+		bound := lowerBoundary(scale, int64(positive.Offset)+int64(i)+1)
+		if math.IsInf(bound, 1) {
+			overflow += count
+			continue
+		}
+		add(bound, count)
+	}
+	bucketCounts = append(bucketCounts, overflow)
+	return explicitBounds, bucketCounts
+}
+
+// lowerBoundary returns the lower boundary of the bucket with the given index,
+// base^index where base = 2^(2^-scale).
+func lowerBoundary(scale int32, index int64) float64 {
+	return math.Exp2(math.Ldexp(float64(index), -int(scale)))
+}
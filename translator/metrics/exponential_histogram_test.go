@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstranslator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialToExplicitBuckets(t *testing.T) {
+	tests := []struct {
+		name           string
+		scale          int32
+		zeroCount      uint64
+		negative       ExponentialBuckets
+		positive       ExponentialBuckets
+		explicitBounds []float64
+		bucketCounts   []uint64
+	}{
+		{
+			name:           "empty",
+			explicitBounds: []float64{0},
+			bucketCounts:   []uint64{0, 0},
+		},
+		{
+			name:           "zero",
+			zeroCount:      3,
+			explicitBounds: []float64{0},
+			bucketCounts:   []uint64{3, 0},
+		},
+		{
+			name:           "positive",
+			positive:       ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 2, 3}},
+			explicitBounds: []float64{0, 2, 4, 8},
+			bucketCounts:   []uint64{0, 1, 2, 3, 0},
+		},
+		{
+			name:           "positive negative offset",
+			positive:       ExponentialBuckets{Offset: -2, BucketCounts: []uint64{1, 2}},
+			explicitBounds: []float64{0, 0.5, 1},
+			bucketCounts:   []uint64{0, 1, 2, 0},
+		},
+		{
+			name:           "negative",
+			zeroCount:      1,
+			negative:       ExponentialBuckets{Offset: 1, BucketCounts: []uint64{4, 5}},
+			positive:       ExponentialBuckets{Offset: 1, BucketCounts: []uint64{6}},
+			explicitBounds: []float64{-4, -2, 0, 4},
+			bucketCounts:   []uint64{5, 4, 1, 6, 0},
+		},
+		{
+			name:           "scale 1",
+			scale:          1,
+			positive:       ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 1}},
+			explicitBounds: []float64{0, math.Sqrt2, 2},
+			bucketCounts:   []uint64{0, 1, 1, 0},
+		},
+		{
+			name:           "scale -1",
+			scale:          -1,
+			positive:       ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 1}},
+			explicitBounds: []float64{0, 16, 64},
+			bucketCounts:   []uint64{0, 1, 1, 0},
+		},
+		{
+			name:           "overflow",
+			scale:          -8,
+			negative:       ExponentialBuckets{Offset: 3, BucketCounts: []uint64{1, 2}},
+			positive:       ExponentialBuckets{Offset: 3, BucketCounts: []uint64{3, 4}},
+			explicitBounds: []float64{-math.Exp2(768), 0},
+			bucketCounts:   []uint64{3, 0, 7},
+		},
+		{
+			name:           "underflow",
+			scale:          -8,
+			negative:       ExponentialBuckets{Offset: -5, BucketCounts: []uint64{1}},
+			zeroCount:      2,
+			positive:       ExponentialBuckets{Offset: -6, BucketCounts: []uint64{3, 4}},
+			explicitBounds: []float64{0, math.Exp2(-1024)},
+			bucketCounts:   []uint64{6, 4, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explicitBounds, bucketCounts := ExponentialToExplicitBuckets(tt.scale, tt.zeroCount, tt.negative, tt.positive)
+			assert.InDeltaSlice(t, tt.explicitBounds, explicitBounds, 1e-12)
+			assert.Len(t, explicitBounds, len(tt.explicitBounds))
+			assert.Equal(t, tt.bucketCounts, bucketCounts)
+		})
+	}
+}
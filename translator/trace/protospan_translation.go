@@ -148,26 +148,7 @@ func AttributeValueToString(attr pdata.AttributeValue, jsonLike bool) string {
 
 // AttributeMapToMap converts an OTLP AttributeMap to a standard go map
 func AttributeMapToMap(attrMap pdata.AttributeMap) map[string]interface{} {
-	rawMap := make(map[string]interface{})
-	attrMap.ForEach(func(k string, v pdata.AttributeValue) {
-		switch v.Type() {
-		case pdata.AttributeValueSTRING:
-			rawMap[k] = v.StringVal()
-		case pdata.AttributeValueINT:
-			rawMap[k] = v.IntVal()
-		case pdata.AttributeValueDOUBLE:
-			rawMap[k] = v.DoubleVal()
-		case pdata.AttributeValueBOOL:
-			rawMap[k] = v.BoolVal()
-		case pdata.AttributeValueNULL:
-			rawMap[k] = nil
-		case pdata.AttributeValueMAP:
-			rawMap[k] = AttributeMapToMap(v.MapVal())
-		case pdata.AttributeValueARRAY:
-			rawMap[k] = AttributeArrayToSlice(v.ArrayVal())
-		}
-	})
-	return rawMap
+	return attrMap.AsRaw()
 }
 
 func AttributeArrayToSlice(attrArray pdata.AnyValueArray) []interface{} {